@@ -0,0 +1,151 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: kessel/inventory/v1beta2/check_explain_response.proto
+
+package v1beta2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CheckExplainResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Allowed          Allowed                `protobuf:"varint,1,opt,name=allowed,proto3,enum=kessel.inventory.v1beta2.Allowed" json:"allowed,omitempty"`
+	ConsistencyToken *ConsistencyToken      `protobuf:"bytes,2,opt,name=consistency_token,json=consistencyToken,proto3" json:"consistency_token,omitempty"`
+	// The resolution path that led to the result, rooted at the requested check.
+	Trace         *CheckTrace `protobuf:"bytes,3,opt,name=trace,proto3" json:"trace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckExplainResponse) Reset() {
+	*x = CheckExplainResponse{}
+	mi := &file_kessel_inventory_v1beta2_check_explain_response_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckExplainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckExplainResponse) ProtoMessage() {}
+
+func (x *CheckExplainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kessel_inventory_v1beta2_check_explain_response_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckExplainResponse.ProtoReflect.Descriptor instead.
+func (*CheckExplainResponse) Descriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckExplainResponse) GetAllowed() Allowed {
+	if x != nil {
+		return x.Allowed
+	}
+	return Allowed_ALLOWED_UNSPECIFIED
+}
+
+func (x *CheckExplainResponse) GetConsistencyToken() *ConsistencyToken {
+	if x != nil {
+		return x.ConsistencyToken
+	}
+	return nil
+}
+
+func (x *CheckExplainResponse) GetTrace() *CheckTrace {
+	if x != nil {
+		return x.Trace
+	}
+	return nil
+}
+
+var File_kessel_inventory_v1beta2_check_explain_response_proto protoreflect.FileDescriptor
+
+const file_kessel_inventory_v1beta2_check_explain_response_proto_rawDesc = "" +
+	"\n" +
+	"5kessel/inventory/v1beta2/check_explain_response.proto\x12\x18kessel.inventory.v1beta2\x1a&kessel/inventory/v1beta2/allowed.proto\x1a*kessel/inventory/v1beta2/check_trace.proto\x1a0kessel/inventory/v1beta2/consistency_token.proto\"\xe8\x01\n" +
+	"\x14CheckExplainResponse\x12;\n" +
+	"\aallowed\x18\x01 \x01(\x0e2!.kessel.inventory.v1beta2.AllowedR\aallowed\x12W\n" +
+	"\x11consistency_token\x18\x02 \x01(\v2*.kessel.inventory.v1beta2.ConsistencyTokenR\x10consistencyToken\x12:\n" +
+	"\x05trace\x18\x03 \x01(\v2$.kessel.inventory.v1beta2.CheckTraceR\x05traceBr\n" +
+	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
+
+var (
+	file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescOnce sync.Once
+	file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescData []byte
+)
+
+func file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescGZIP() []byte {
+	file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescOnce.Do(func() {
+		file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_check_explain_response_proto_rawDesc), len(file_kessel_inventory_v1beta2_check_explain_response_proto_rawDesc)))
+	})
+	return file_kessel_inventory_v1beta2_check_explain_response_proto_rawDescData
+}
+
+var file_kessel_inventory_v1beta2_check_explain_response_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kessel_inventory_v1beta2_check_explain_response_proto_goTypes = []any{
+	(*CheckExplainResponse)(nil), // 0: kessel.inventory.v1beta2.CheckExplainResponse
+	(Allowed)(0),                 // 1: kessel.inventory.v1beta2.Allowed
+	(*ConsistencyToken)(nil),     // 2: kessel.inventory.v1beta2.ConsistencyToken
+	(*CheckTrace)(nil),           // 3: kessel.inventory.v1beta2.CheckTrace
+}
+var file_kessel_inventory_v1beta2_check_explain_response_proto_depIdxs = []int32{
+	1, // 0: kessel.inventory.v1beta2.CheckExplainResponse.allowed:type_name -> kessel.inventory.v1beta2.Allowed
+	2, // 1: kessel.inventory.v1beta2.CheckExplainResponse.consistency_token:type_name -> kessel.inventory.v1beta2.ConsistencyToken
+	3, // 2: kessel.inventory.v1beta2.CheckExplainResponse.trace:type_name -> kessel.inventory.v1beta2.CheckTrace
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_kessel_inventory_v1beta2_check_explain_response_proto_init() }
+func file_kessel_inventory_v1beta2_check_explain_response_proto_init() {
+	if File_kessel_inventory_v1beta2_check_explain_response_proto != nil {
+		return
+	}
+	file_kessel_inventory_v1beta2_allowed_proto_init()
+	file_kessel_inventory_v1beta2_check_trace_proto_init()
+	file_kessel_inventory_v1beta2_consistency_token_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_check_explain_response_proto_rawDesc), len(file_kessel_inventory_v1beta2_check_explain_response_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kessel_inventory_v1beta2_check_explain_response_proto_goTypes,
+		DependencyIndexes: file_kessel_inventory_v1beta2_check_explain_response_proto_depIdxs,
+		MessageInfos:      file_kessel_inventory_v1beta2_check_explain_response_proto_msgTypes,
+	}.Build()
+	File_kessel_inventory_v1beta2_check_explain_response_proto = out.File
+	file_kessel_inventory_v1beta2_check_explain_response_proto_goTypes = nil
+	file_kessel_inventory_v1beta2_check_explain_response_proto_depIdxs = nil
+}
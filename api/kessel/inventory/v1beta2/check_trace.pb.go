@@ -0,0 +1,264 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: kessel/inventory/v1beta2/check_trace.proto
+
+package v1beta2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CheckTrace_Kind int32
+
+const (
+	CheckTrace_KIND_UNSPECIFIED CheckTrace_Kind = 0
+	CheckTrace_KIND_RELATION    CheckTrace_Kind = 1
+	CheckTrace_KIND_PERMISSION  CheckTrace_Kind = 2
+)
+
+// Enum value maps for CheckTrace_Kind.
+var (
+	CheckTrace_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_RELATION",
+		2: "KIND_PERMISSION",
+	}
+	CheckTrace_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_RELATION":    1,
+		"KIND_PERMISSION":  2,
+	}
+)
+
+func (x CheckTrace_Kind) Enum() *CheckTrace_Kind {
+	p := new(CheckTrace_Kind)
+	*p = x
+	return p
+}
+
+func (x CheckTrace_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CheckTrace_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_kessel_inventory_v1beta2_check_trace_proto_enumTypes[0].Descriptor()
+}
+
+func (CheckTrace_Kind) Type() protoreflect.EnumType {
+	return &file_kessel_inventory_v1beta2_check_trace_proto_enumTypes[0]
+}
+
+func (x CheckTrace_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CheckTrace_Kind.Descriptor instead.
+func (CheckTrace_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_check_trace_proto_rawDescGZIP(), []int{0, 0}
+}
+
+// One step in resolving a relationship check.
+//
+// A RELATION step that was allowed and has no sub_traces was satisfied directly by a
+// stored tuple `resource#relation@subject`. A PERMISSION step was resolved by evaluating
+// its expression over the steps in sub_traces.
+type CheckTrace struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Resource *ResourceReference     `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Relation string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject  *SubjectReference      `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Kind     CheckTrace_Kind        `protobuf:"varint,4,opt,name=kind,proto3,enum=kessel.inventory.v1beta2.CheckTrace_Kind" json:"kind,omitempty"`
+	Result   Allowed                `protobuf:"varint,5,opt,name=result,proto3,enum=kessel.inventory.v1beta2.Allowed" json:"result,omitempty"`
+	// The permission expression from the schema, e.g. "t_workspace->view_host", when known.
+	Expression string `protobuf:"bytes,6,opt,name=expression,proto3" json:"expression,omitempty"`
+	// True when the result of this step was served from the backend's cache.
+	Cached        bool          `protobuf:"varint,7,opt,name=cached,proto3" json:"cached,omitempty"`
+	SubTraces     []*CheckTrace `protobuf:"bytes,8,rep,name=sub_traces,json=subTraces,proto3" json:"sub_traces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckTrace) Reset() {
+	*x = CheckTrace{}
+	mi := &file_kessel_inventory_v1beta2_check_trace_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckTrace) ProtoMessage() {}
+
+func (x *CheckTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_kessel_inventory_v1beta2_check_trace_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckTrace.ProtoReflect.Descriptor instead.
+func (*CheckTrace) Descriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_check_trace_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckTrace) GetResource() *ResourceReference {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *CheckTrace) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *CheckTrace) GetSubject() *SubjectReference {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *CheckTrace) GetKind() CheckTrace_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return CheckTrace_KIND_UNSPECIFIED
+}
+
+func (x *CheckTrace) GetResult() Allowed {
+	if x != nil {
+		return x.Result
+	}
+	return Allowed_ALLOWED_UNSPECIFIED
+}
+
+func (x *CheckTrace) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *CheckTrace) GetCached() bool {
+	if x != nil {
+		return x.Cached
+	}
+	return false
+}
+
+func (x *CheckTrace) GetSubTraces() []*CheckTrace {
+	if x != nil {
+		return x.SubTraces
+	}
+	return nil
+}
+
+var File_kessel_inventory_v1beta2_check_trace_proto protoreflect.FileDescriptor
+
+const file_kessel_inventory_v1beta2_check_trace_proto_rawDesc = "" +
+	"\n" +
+	"*kessel/inventory/v1beta2/check_trace.proto\x12\x18kessel.inventory.v1beta2\x1a&kessel/inventory/v1beta2/allowed.proto\x1a1kessel/inventory/v1beta2/resource_reference.proto\x1a0kessel/inventory/v1beta2/subject_reference.proto\"\xf4\x03\n" +
+	"\n" +
+	"CheckTrace\x12G\n" +
+	"\bresource\x18\x01 \x01(\v2+.kessel.inventory.v1beta2.ResourceReferenceR\bresource\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12D\n" +
+	"\asubject\x18\x03 \x01(\v2*.kessel.inventory.v1beta2.SubjectReferenceR\asubject\x12=\n" +
+	"\x04kind\x18\x04 \x01(\x0e2).kessel.inventory.v1beta2.CheckTrace.KindR\x04kind\x129\n" +
+	"\x06result\x18\x05 \x01(\x0e2!.kessel.inventory.v1beta2.AllowedR\x06result\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x06 \x01(\tR\n" +
+	"expression\x12\x16\n" +
+	"\x06cached\x18\a \x01(\bR\x06cached\x12C\n" +
+	"\n" +
+	"sub_traces\x18\b \x03(\v2$.kessel.inventory.v1beta2.CheckTraceR\tsubTraces\"D\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rKIND_RELATION\x10\x01\x12\x13\n" +
+	"\x0fKIND_PERMISSION\x10\x02Br\n" +
+	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
+
+var (
+	file_kessel_inventory_v1beta2_check_trace_proto_rawDescOnce sync.Once
+	file_kessel_inventory_v1beta2_check_trace_proto_rawDescData []byte
+)
+
+func file_kessel_inventory_v1beta2_check_trace_proto_rawDescGZIP() []byte {
+	file_kessel_inventory_v1beta2_check_trace_proto_rawDescOnce.Do(func() {
+		file_kessel_inventory_v1beta2_check_trace_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_check_trace_proto_rawDesc), len(file_kessel_inventory_v1beta2_check_trace_proto_rawDesc)))
+	})
+	return file_kessel_inventory_v1beta2_check_trace_proto_rawDescData
+}
+
+var file_kessel_inventory_v1beta2_check_trace_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_kessel_inventory_v1beta2_check_trace_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kessel_inventory_v1beta2_check_trace_proto_goTypes = []any{
+	(CheckTrace_Kind)(0),      // 0: kessel.inventory.v1beta2.CheckTrace.Kind
+	(*CheckTrace)(nil),        // 1: kessel.inventory.v1beta2.CheckTrace
+	(*ResourceReference)(nil), // 2: kessel.inventory.v1beta2.ResourceReference
+	(*SubjectReference)(nil),  // 3: kessel.inventory.v1beta2.SubjectReference
+	(Allowed)(0),              // 4: kessel.inventory.v1beta2.Allowed
+}
+var file_kessel_inventory_v1beta2_check_trace_proto_depIdxs = []int32{
+	2, // 0: kessel.inventory.v1beta2.CheckTrace.resource:type_name -> kessel.inventory.v1beta2.ResourceReference
+	3, // 1: kessel.inventory.v1beta2.CheckTrace.subject:type_name -> kessel.inventory.v1beta2.SubjectReference
+	0, // 2: kessel.inventory.v1beta2.CheckTrace.kind:type_name -> kessel.inventory.v1beta2.CheckTrace.Kind
+	4, // 3: kessel.inventory.v1beta2.CheckTrace.result:type_name -> kessel.inventory.v1beta2.Allowed
+	1, // 4: kessel.inventory.v1beta2.CheckTrace.sub_traces:type_name -> kessel.inventory.v1beta2.CheckTrace
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_kessel_inventory_v1beta2_check_trace_proto_init() }
+func file_kessel_inventory_v1beta2_check_trace_proto_init() {
+	if File_kessel_inventory_v1beta2_check_trace_proto != nil {
+		return
+	}
+	file_kessel_inventory_v1beta2_allowed_proto_init()
+	file_kessel_inventory_v1beta2_resource_reference_proto_init()
+	file_kessel_inventory_v1beta2_subject_reference_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_check_trace_proto_rawDesc), len(file_kessel_inventory_v1beta2_check_trace_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kessel_inventory_v1beta2_check_trace_proto_goTypes,
+		DependencyIndexes: file_kessel_inventory_v1beta2_check_trace_proto_depIdxs,
+		EnumInfos:         file_kessel_inventory_v1beta2_check_trace_proto_enumTypes,
+		MessageInfos:      file_kessel_inventory_v1beta2_check_trace_proto_msgTypes,
+	}.Build()
+	File_kessel_inventory_v1beta2_check_trace_proto = out.File
+	file_kessel_inventory_v1beta2_check_trace_proto_goTypes = nil
+	file_kessel_inventory_v1beta2_check_trace_proto_depIdxs = nil
+}
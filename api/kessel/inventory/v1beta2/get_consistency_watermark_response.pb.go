@@ -0,0 +1,142 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: kessel/inventory/v1beta2/get_consistency_watermark_response.proto
+
+package v1beta2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetConsistencyWatermarkResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Token of the latest replicated write. Unset if nothing has been replicated yet.
+	// Use it with `at_least_as_fresh` to read data at least as recent as every
+	// write acknowledged before this call.
+	ConsistencyToken *ConsistencyToken `protobuf:"bytes,1,opt,name=consistency_token,json=consistencyToken,proto3" json:"consistency_token,omitempty"`
+	// When the write that produced the token was replicated.
+	ReplicatedAt  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=replicated_at,json=replicatedAt,proto3" json:"replicated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsistencyWatermarkResponse) Reset() {
+	*x = GetConsistencyWatermarkResponse{}
+	mi := &file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsistencyWatermarkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsistencyWatermarkResponse) ProtoMessage() {}
+
+func (x *GetConsistencyWatermarkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsistencyWatermarkResponse.ProtoReflect.Descriptor instead.
+func (*GetConsistencyWatermarkResponse) Descriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetConsistencyWatermarkResponse) GetConsistencyToken() *ConsistencyToken {
+	if x != nil {
+		return x.ConsistencyToken
+	}
+	return nil
+}
+
+func (x *GetConsistencyWatermarkResponse) GetReplicatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplicatedAt
+	}
+	return nil
+}
+
+var File_kessel_inventory_v1beta2_get_consistency_watermark_response_proto protoreflect.FileDescriptor
+
+const file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDesc = "" +
+	"\n" +
+	"Akessel/inventory/v1beta2/get_consistency_watermark_response.proto\x12\x18kessel.inventory.v1beta2\x1a\x1fgoogle/protobuf/timestamp.proto\x1a0kessel/inventory/v1beta2/consistency_token.proto\"\xbb\x01\n" +
+	"\x1fGetConsistencyWatermarkResponse\x12W\n" +
+	"\x11consistency_token\x18\x01 \x01(\v2*.kessel.inventory.v1beta2.ConsistencyTokenR\x10consistencyToken\x12?\n" +
+	"\rreplicated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\freplicatedAtBr\n" +
+	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
+
+var (
+	file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescOnce sync.Once
+	file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescData []byte
+)
+
+func file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescGZIP() []byte {
+	file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescOnce.Do(func() {
+		file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDesc), len(file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDesc)))
+	})
+	return file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDescData
+}
+
+var file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_goTypes = []any{
+	(*GetConsistencyWatermarkResponse)(nil), // 0: kessel.inventory.v1beta2.GetConsistencyWatermarkResponse
+	(*ConsistencyToken)(nil),                // 1: kessel.inventory.v1beta2.ConsistencyToken
+	(*timestamppb.Timestamp)(nil),           // 2: google.protobuf.Timestamp
+}
+var file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_depIdxs = []int32{
+	1, // 0: kessel.inventory.v1beta2.GetConsistencyWatermarkResponse.consistency_token:type_name -> kessel.inventory.v1beta2.ConsistencyToken
+	2, // 1: kessel.inventory.v1beta2.GetConsistencyWatermarkResponse.replicated_at:type_name -> google.protobuf.Timestamp
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_init() }
+func file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_init() {
+	if File_kessel_inventory_v1beta2_get_consistency_watermark_response_proto != nil {
+		return
+	}
+	file_kessel_inventory_v1beta2_consistency_token_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDesc), len(file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_goTypes,
+		DependencyIndexes: file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_depIdxs,
+		MessageInfos:      file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_msgTypes,
+	}.Build()
+	File_kessel_inventory_v1beta2_get_consistency_watermark_response_proto = out.File
+	file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_goTypes = nil
+	file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_depIdxs = nil
+}
@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: kessel/inventory/v1beta2/inventory_enrichment.proto
+
+package v1beta2
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// InventoryEnrichment joins lookup results with the resources stored in inventory.
+type InventoryEnrichment struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Dot-separated paths into the common representation to return with each
+	// object. When empty, no common representation data is returned.
+	CommonFields []string `protobuf:"bytes,1,rep,name=common_fields,json=commonFields,proto3" json:"common_fields,omitempty"`
+	// Only objects whose common representation matches every filter are returned.
+	// Objects unknown to inventory never match.
+	Filters       []*RepresentationFilter `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryEnrichment) Reset() {
+	*x = InventoryEnrichment{}
+	mi := &file_kessel_inventory_v1beta2_inventory_enrichment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryEnrichment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryEnrichment) ProtoMessage() {}
+
+func (x *InventoryEnrichment) ProtoReflect() protoreflect.Message {
+	mi := &file_kessel_inventory_v1beta2_inventory_enrichment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryEnrichment.ProtoReflect.Descriptor instead.
+func (*InventoryEnrichment) Descriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InventoryEnrichment) GetCommonFields() []string {
+	if x != nil {
+		return x.CommonFields
+	}
+	return nil
+}
+
+func (x *InventoryEnrichment) GetFilters() []*RepresentationFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+var File_kessel_inventory_v1beta2_inventory_enrichment_proto protoreflect.FileDescriptor
+
+const file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDesc = "" +
+	"\n" +
+	"3kessel/inventory/v1beta2/inventory_enrichment.proto\x12\x18kessel.inventory.v1beta2\x1a\x1bbuf/validate/validate.proto\x1a4kessel/inventory/v1beta2/representation_filter.proto\"\x9e\x01\n" +
+	"\x13InventoryEnrichment\x123\n" +
+	"\rcommon_fields\x18\x01 \x03(\tB\x0e\xbaH\v\x92\x01\b\x102\"\x04r\x02\x10\x01R\fcommonFields\x12R\n" +
+	"\afilters\x18\x02 \x03(\v2..kessel.inventory.v1beta2.RepresentationFilterB\b\xbaH\x05\x92\x01\x02\x10\x14R\afiltersBr\n" +
+	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
+
+var (
+	file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescOnce sync.Once
+	file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescData []byte
+)
+
+func file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescGZIP() []byte {
+	file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescOnce.Do(func() {
+		file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDesc), len(file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDesc)))
+	})
+	return file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDescData
+}
+
+var file_kessel_inventory_v1beta2_inventory_enrichment_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kessel_inventory_v1beta2_inventory_enrichment_proto_goTypes = []any{
+	(*InventoryEnrichment)(nil),  // 0: kessel.inventory.v1beta2.InventoryEnrichment
+	(*RepresentationFilter)(nil), // 1: kessel.inventory.v1beta2.RepresentationFilter
+}
+var file_kessel_inventory_v1beta2_inventory_enrichment_proto_depIdxs = []int32{
+	1, // 0: kessel.inventory.v1beta2.InventoryEnrichment.filters:type_name -> kessel.inventory.v1beta2.RepresentationFilter
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_kessel_inventory_v1beta2_inventory_enrichment_proto_init() }
+func file_kessel_inventory_v1beta2_inventory_enrichment_proto_init() {
+	if File_kessel_inventory_v1beta2_inventory_enrichment_proto != nil {
+		return
+	}
+	file_kessel_inventory_v1beta2_representation_filter_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDesc), len(file_kessel_inventory_v1beta2_inventory_enrichment_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kessel_inventory_v1beta2_inventory_enrichment_proto_goTypes,
+		DependencyIndexes: file_kessel_inventory_v1beta2_inventory_enrichment_proto_depIdxs,
+		MessageInfos:      file_kessel_inventory_v1beta2_inventory_enrichment_proto_msgTypes,
+	}.Build()
+	File_kessel_inventory_v1beta2_inventory_enrichment_proto = out.File
+	file_kessel_inventory_v1beta2_inventory_enrichment_proto_goTypes = nil
+	file_kessel_inventory_v1beta2_inventory_enrichment_proto_depIdxs = nil
+}
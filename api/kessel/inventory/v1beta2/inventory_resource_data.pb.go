@@ -0,0 +1,148 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: kessel/inventory/v1beta2/inventory_resource_data.proto
+
+package v1beta2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// InventoryResourceData is inventory's stored view of a resource returned by a lookup.
+type InventoryResourceData struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ApiHref     string                 `protobuf:"bytes,1,opt,name=api_href,json=apiHref,proto3" json:"api_href,omitempty"`
+	ConsoleHref *string                `protobuf:"bytes,2,opt,name=console_href,json=consoleHref,proto3,oneof" json:"console_href,omitempty"`
+	// The requested fields of the latest common representation, keyed by path.
+	Common        *structpb.Struct `protobuf:"bytes,3,opt,name=common,proto3" json:"common,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InventoryResourceData) Reset() {
+	*x = InventoryResourceData{}
+	mi := &file_kessel_inventory_v1beta2_inventory_resource_data_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InventoryResourceData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InventoryResourceData) ProtoMessage() {}
+
+func (x *InventoryResourceData) ProtoReflect() protoreflect.Message {
+	mi := &file_kessel_inventory_v1beta2_inventory_resource_data_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InventoryResourceData.ProtoReflect.Descriptor instead.
+func (*InventoryResourceData) Descriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *InventoryResourceData) GetApiHref() string {
+	if x != nil {
+		return x.ApiHref
+	}
+	return ""
+}
+
+func (x *InventoryResourceData) GetConsoleHref() string {
+	if x != nil && x.ConsoleHref != nil {
+		return *x.ConsoleHref
+	}
+	return ""
+}
+
+func (x *InventoryResourceData) GetCommon() *structpb.Struct {
+	if x != nil {
+		return x.Common
+	}
+	return nil
+}
+
+var File_kessel_inventory_v1beta2_inventory_resource_data_proto protoreflect.FileDescriptor
+
+const file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDesc = "" +
+	"\n" +
+	"6kessel/inventory/v1beta2/inventory_resource_data.proto\x12\x18kessel.inventory.v1beta2\x1a\x1cgoogle/protobuf/struct.proto\"\x9c\x01\n" +
+	"\x15InventoryResourceData\x12\x19\n" +
+	"\bapi_href\x18\x01 \x01(\tR\aapiHref\x12&\n" +
+	"\fconsole_href\x18\x02 \x01(\tH\x00R\vconsoleHref\x88\x01\x01\x12/\n" +
+	"\x06common\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06commonB\x0f\n" +
+	"\r_console_hrefBr\n" +
+	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
+
+var (
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescOnce sync.Once
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescData []byte
+)
+
+func file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescGZIP() []byte {
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescOnce.Do(func() {
+		file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDesc), len(file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDesc)))
+	})
+	return file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDescData
+}
+
+var file_kessel_inventory_v1beta2_inventory_resource_data_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kessel_inventory_v1beta2_inventory_resource_data_proto_goTypes = []any{
+	(*InventoryResourceData)(nil), // 0: kessel.inventory.v1beta2.InventoryResourceData
+	(*structpb.Struct)(nil),       // 1: google.protobuf.Struct
+}
+var file_kessel_inventory_v1beta2_inventory_resource_data_proto_depIdxs = []int32{
+	1, // 0: kessel.inventory.v1beta2.InventoryResourceData.common:type_name -> google.protobuf.Struct
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_kessel_inventory_v1beta2_inventory_resource_data_proto_init() }
+func file_kessel_inventory_v1beta2_inventory_resource_data_proto_init() {
+	if File_kessel_inventory_v1beta2_inventory_resource_data_proto != nil {
+		return
+	}
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_msgTypes[0].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDesc), len(file_kessel_inventory_v1beta2_inventory_resource_data_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kessel_inventory_v1beta2_inventory_resource_data_proto_goTypes,
+		DependencyIndexes: file_kessel_inventory_v1beta2_inventory_resource_data_proto_depIdxs,
+		MessageInfos:      file_kessel_inventory_v1beta2_inventory_resource_data_proto_msgTypes,
+	}.Build()
+	File_kessel_inventory_v1beta2_inventory_resource_data_proto = out.File
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_goTypes = nil
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_depIdxs = nil
+}
@@ -25,9 +25,10 @@ var File_kessel_inventory_v1beta2_inventory_service_proto protoreflect.FileDescr
 
 const file_kessel_inventory_v1beta2_inventory_service_proto_rawDesc = "" +
 	"\n" +
-	"0kessel/inventory/v1beta2/inventory_service.proto\x12\x18kessel.inventory.v1beta2\x1a\x1cgoogle/api/annotations.proto\x1a,kessel/inventory/v1beta2/check_request.proto\x1a-kessel/inventory/v1beta2/check_response.proto\x1a7kessel/inventory/v1beta2/check_for_update_request.proto\x1a8kessel/inventory/v1beta2/check_for_update_response.proto\x1a6kessel/inventory/v1beta2/report_resource_request.proto\x1a7kessel/inventory/v1beta2/report_resource_response.proto\x1a6kessel/inventory/v1beta2/delete_resource_request.proto\x1a7kessel/inventory/v1beta2/delete_resource_response.proto\x1a<kessel/inventory/v1beta2/streamed_list_objects_request.proto\x1a=kessel/inventory/v1beta2/streamed_list_objects_response.proto\x1a=kessel/inventory/v1beta2/streamed_list_subjects_request.proto\x1a>kessel/inventory/v1beta2/streamed_list_subjects_response.proto\x1a1kessel/inventory/v1beta2/check_bulk_request.proto\x1a2kessel/inventory/v1beta2/check_bulk_response.proto\x1a1kessel/inventory/v1beta2/check_self_request.proto\x1a2kessel/inventory/v1beta2/check_self_response.proto\x1a6kessel/inventory/v1beta2/check_self_bulk_request.proto\x1a7kessel/inventory/v1beta2/check_self_bulk_response.proto\x1a<kessel/inventory/v1beta2/check_for_update_bulk_request.proto\x1a=kessel/inventory/v1beta2/check_for_update_bulk_response.proto2\x86\f\n" +
+	"0kessel/inventory/v1beta2/inventory_service.proto\x12\x18kessel.inventory.v1beta2\x1a\x1cgoogle/api/annotations.proto\x1a,kessel/inventory/v1beta2/check_request.proto\x1a-kessel/inventory/v1beta2/check_response.proto\x1a7kessel/inventory/v1beta2/check_for_update_request.proto\x1a8kessel/inventory/v1beta2/check_for_update_response.proto\x1a6kessel/inventory/v1beta2/report_resource_request.proto\x1a7kessel/inventory/v1beta2/report_resource_response.proto\x1a6kessel/inventory/v1beta2/delete_resource_request.proto\x1a7kessel/inventory/v1beta2/delete_resource_response.proto\x1a<kessel/inventory/v1beta2/streamed_list_objects_request.proto\x1a=kessel/inventory/v1beta2/streamed_list_objects_response.proto\x1a=kessel/inventory/v1beta2/streamed_list_subjects_request.proto\x1a>kessel/inventory/v1beta2/streamed_list_subjects_response.proto\x1a:kessel/inventory/v1beta2/streamed_check_bulk_request.proto\x1a;kessel/inventory/v1beta2/streamed_check_bulk_response.proto\x1a1kessel/inventory/v1beta2/check_bulk_request.proto\x1a2kessel/inventory/v1beta2/check_bulk_response.proto\x1a1kessel/inventory/v1beta2/check_self_request.proto\x1a2kessel/inventory/v1beta2/check_self_response.proto\x1a6kessel/inventory/v1beta2/check_self_bulk_request.proto\x1a7kessel/inventory/v1beta2/check_self_bulk_response.proto\x1a<kessel/inventory/v1beta2/check_for_update_bulk_request.proto\x1a=kessel/inventory/v1beta2/check_for_update_bulk_response.proto\x1a4kessel/inventory/v1beta2/check_explain_request.proto\x1a5kessel/inventory/v1beta2/check_explain_response.proto\x1a@kessel/inventory/v1beta2/get_consistency_watermark_request.proto\x1aAkessel/inventory/v1beta2/get_consistency_watermark_response.proto2\xe9\x0f\n" +
 	"\x16KesselInventoryService\x12~\n" +
-	"\x05Check\x12&.kessel.inventory.v1beta2.CheckRequest\x1a'.kessel.inventory.v1beta2.CheckResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/kessel/v1beta2/check\x12\x8e\x01\n" +
+	"\x05Check\x12&.kessel.inventory.v1beta2.CheckRequest\x1a'.kessel.inventory.v1beta2.CheckResponse\"$\x82\xd3\xe4\x93\x02\x1e:\x01*\"\x19/api/kessel/v1beta2/check\x12\x9a\x01\n" +
+	"\fCheckExplain\x12-.kessel.inventory.v1beta2.CheckExplainRequest\x1a..kessel.inventory.v1beta2.CheckExplainResponse\"+\x82\xd3\xe4\x93\x02%:\x01*\" /api/kessel/v1beta2/checkexplain\x12\x8e\x01\n" +
 	"\tCheckSelf\x12*.kessel.inventory.v1beta2.CheckSelfRequest\x1a+.kessel.inventory.v1beta2.CheckSelfResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/kessel/v1beta2/checkself\x12\xa2\x01\n" +
 	"\x0eCheckForUpdate\x12/.kessel.inventory.v1beta2.CheckForUpdateRequest\x1a0.kessel.inventory.v1beta2.CheckForUpdateResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/api/kessel/v1beta2/checkforupdate\x12\xb2\x01\n" +
 	"\x12CheckForUpdateBulk\x123.kessel.inventory.v1beta2.CheckForUpdateBulkRequest\x1a4.kessel.inventory.v1beta2.CheckForUpdateBulkResponse\"1\x82\xd3\xe4\x93\x02+:\x01*\"&/api/kessel/v1beta2/checkforupdatebulk\x12\x8e\x01\n" +
@@ -36,54 +37,68 @@ const file_kessel_inventory_v1beta2_inventory_service_proto_rawDesc = "" +
 	"\x0eReportResource\x12/.kessel.inventory.v1beta2.ReportResourceRequest\x1a0.kessel.inventory.v1beta2.ReportResourceResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/kessel/v1beta2/resources\x12\x9d\x01\n" +
 	"\x0eDeleteResource\x12/.kessel.inventory.v1beta2.DeleteResourceRequest\x1a0.kessel.inventory.v1beta2.DeleteResourceResponse\"(\x82\xd3\xe4\x93\x02\":\x01**\x1d/api/kessel/v1beta2/resources\x12\x84\x01\n" +
 	"\x13StreamedListObjects\x124.kessel.inventory.v1beta2.StreamedListObjectsRequest\x1a5.kessel.inventory.v1beta2.StreamedListObjectsResponse0\x01\x12\x87\x01\n" +
-	"\x14StreamedListSubjects\x125.kessel.inventory.v1beta2.StreamedListSubjectsRequest\x1a6.kessel.inventory.v1beta2.StreamedListSubjectsResponse0\x01Br\n" +
+	"\x14StreamedListSubjects\x125.kessel.inventory.v1beta2.StreamedListSubjectsRequest\x1a6.kessel.inventory.v1beta2.StreamedListSubjectsResponse0\x01\x12\x80\x01\n" +
+	"\x11StreamedCheckBulk\x122.kessel.inventory.v1beta2.StreamedCheckBulkRequest\x1a3.kessel.inventory.v1beta2.StreamedCheckBulkResponse(\x010\x01\x12\xc0\x01\n" +
+	"\x17GetConsistencyWatermark\x128.kessel.inventory.v1beta2.GetConsistencyWatermarkRequest\x1a9.kessel.inventory.v1beta2.GetConsistencyWatermarkResponse\"0\x82\xd3\xe4\x93\x02*\x12(/api/kessel/v1beta2/consistencywatermarkBr\n" +
 	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
 
 var file_kessel_inventory_v1beta2_inventory_service_proto_goTypes = []any{
-	(*CheckRequest)(nil),                 // 0: kessel.inventory.v1beta2.CheckRequest
-	(*CheckSelfRequest)(nil),             // 1: kessel.inventory.v1beta2.CheckSelfRequest
-	(*CheckForUpdateRequest)(nil),        // 2: kessel.inventory.v1beta2.CheckForUpdateRequest
-	(*CheckForUpdateBulkRequest)(nil),    // 3: kessel.inventory.v1beta2.CheckForUpdateBulkRequest
-	(*CheckBulkRequest)(nil),             // 4: kessel.inventory.v1beta2.CheckBulkRequest
-	(*CheckSelfBulkRequest)(nil),         // 5: kessel.inventory.v1beta2.CheckSelfBulkRequest
-	(*ReportResourceRequest)(nil),        // 6: kessel.inventory.v1beta2.ReportResourceRequest
-	(*DeleteResourceRequest)(nil),        // 7: kessel.inventory.v1beta2.DeleteResourceRequest
-	(*StreamedListObjectsRequest)(nil),   // 8: kessel.inventory.v1beta2.StreamedListObjectsRequest
-	(*StreamedListSubjectsRequest)(nil),  // 9: kessel.inventory.v1beta2.StreamedListSubjectsRequest
-	(*CheckResponse)(nil),                // 10: kessel.inventory.v1beta2.CheckResponse
-	(*CheckSelfResponse)(nil),            // 11: kessel.inventory.v1beta2.CheckSelfResponse
-	(*CheckForUpdateResponse)(nil),       // 12: kessel.inventory.v1beta2.CheckForUpdateResponse
-	(*CheckForUpdateBulkResponse)(nil),   // 13: kessel.inventory.v1beta2.CheckForUpdateBulkResponse
-	(*CheckBulkResponse)(nil),            // 14: kessel.inventory.v1beta2.CheckBulkResponse
-	(*CheckSelfBulkResponse)(nil),        // 15: kessel.inventory.v1beta2.CheckSelfBulkResponse
-	(*ReportResourceResponse)(nil),       // 16: kessel.inventory.v1beta2.ReportResourceResponse
-	(*DeleteResourceResponse)(nil),       // 17: kessel.inventory.v1beta2.DeleteResourceResponse
-	(*StreamedListObjectsResponse)(nil),  // 18: kessel.inventory.v1beta2.StreamedListObjectsResponse
-	(*StreamedListSubjectsResponse)(nil), // 19: kessel.inventory.v1beta2.StreamedListSubjectsResponse
+	(*CheckRequest)(nil),                    // 0: kessel.inventory.v1beta2.CheckRequest
+	(*CheckExplainRequest)(nil),             // 1: kessel.inventory.v1beta2.CheckExplainRequest
+	(*CheckSelfRequest)(nil),                // 2: kessel.inventory.v1beta2.CheckSelfRequest
+	(*CheckForUpdateRequest)(nil),           // 3: kessel.inventory.v1beta2.CheckForUpdateRequest
+	(*CheckForUpdateBulkRequest)(nil),       // 4: kessel.inventory.v1beta2.CheckForUpdateBulkRequest
+	(*CheckBulkRequest)(nil),                // 5: kessel.inventory.v1beta2.CheckBulkRequest
+	(*CheckSelfBulkRequest)(nil),            // 6: kessel.inventory.v1beta2.CheckSelfBulkRequest
+	(*ReportResourceRequest)(nil),           // 7: kessel.inventory.v1beta2.ReportResourceRequest
+	(*DeleteResourceRequest)(nil),           // 8: kessel.inventory.v1beta2.DeleteResourceRequest
+	(*StreamedListObjectsRequest)(nil),      // 9: kessel.inventory.v1beta2.StreamedListObjectsRequest
+	(*StreamedListSubjectsRequest)(nil),     // 10: kessel.inventory.v1beta2.StreamedListSubjectsRequest
+	(*StreamedCheckBulkRequest)(nil),        // 11: kessel.inventory.v1beta2.StreamedCheckBulkRequest
+	(*GetConsistencyWatermarkRequest)(nil),  // 12: kessel.inventory.v1beta2.GetConsistencyWatermarkRequest
+	(*CheckResponse)(nil),                   // 13: kessel.inventory.v1beta2.CheckResponse
+	(*CheckExplainResponse)(nil),            // 14: kessel.inventory.v1beta2.CheckExplainResponse
+	(*CheckSelfResponse)(nil),               // 15: kessel.inventory.v1beta2.CheckSelfResponse
+	(*CheckForUpdateResponse)(nil),          // 16: kessel.inventory.v1beta2.CheckForUpdateResponse
+	(*CheckForUpdateBulkResponse)(nil),      // 17: kessel.inventory.v1beta2.CheckForUpdateBulkResponse
+	(*CheckBulkResponse)(nil),               // 18: kessel.inventory.v1beta2.CheckBulkResponse
+	(*CheckSelfBulkResponse)(nil),           // 19: kessel.inventory.v1beta2.CheckSelfBulkResponse
+	(*ReportResourceResponse)(nil),          // 20: kessel.inventory.v1beta2.ReportResourceResponse
+	(*DeleteResourceResponse)(nil),          // 21: kessel.inventory.v1beta2.DeleteResourceResponse
+	(*StreamedListObjectsResponse)(nil),     // 22: kessel.inventory.v1beta2.StreamedListObjectsResponse
+	(*StreamedListSubjectsResponse)(nil),    // 23: kessel.inventory.v1beta2.StreamedListSubjectsResponse
+	(*StreamedCheckBulkResponse)(nil),       // 24: kessel.inventory.v1beta2.StreamedCheckBulkResponse
+	(*GetConsistencyWatermarkResponse)(nil), // 25: kessel.inventory.v1beta2.GetConsistencyWatermarkResponse
 }
 var file_kessel_inventory_v1beta2_inventory_service_proto_depIdxs = []int32{
 	0,  // 0: kessel.inventory.v1beta2.KesselInventoryService.Check:input_type -> kessel.inventory.v1beta2.CheckRequest
-	1,  // 1: kessel.inventory.v1beta2.KesselInventoryService.CheckSelf:input_type -> kessel.inventory.v1beta2.CheckSelfRequest
-	2,  // 2: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdate:input_type -> kessel.inventory.v1beta2.CheckForUpdateRequest
-	3,  // 3: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdateBulk:input_type -> kessel.inventory.v1beta2.CheckForUpdateBulkRequest
-	4,  // 4: kessel.inventory.v1beta2.KesselInventoryService.CheckBulk:input_type -> kessel.inventory.v1beta2.CheckBulkRequest
-	5,  // 5: kessel.inventory.v1beta2.KesselInventoryService.CheckSelfBulk:input_type -> kessel.inventory.v1beta2.CheckSelfBulkRequest
-	6,  // 6: kessel.inventory.v1beta2.KesselInventoryService.ReportResource:input_type -> kessel.inventory.v1beta2.ReportResourceRequest
-	7,  // 7: kessel.inventory.v1beta2.KesselInventoryService.DeleteResource:input_type -> kessel.inventory.v1beta2.DeleteResourceRequest
-	8,  // 8: kessel.inventory.v1beta2.KesselInventoryService.StreamedListObjects:input_type -> kessel.inventory.v1beta2.StreamedListObjectsRequest
-	9,  // 9: kessel.inventory.v1beta2.KesselInventoryService.StreamedListSubjects:input_type -> kessel.inventory.v1beta2.StreamedListSubjectsRequest
-	10, // 10: kessel.inventory.v1beta2.KesselInventoryService.Check:output_type -> kessel.inventory.v1beta2.CheckResponse
-	11, // 11: kessel.inventory.v1beta2.KesselInventoryService.CheckSelf:output_type -> kessel.inventory.v1beta2.CheckSelfResponse
-	12, // 12: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdate:output_type -> kessel.inventory.v1beta2.CheckForUpdateResponse
-	13, // 13: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdateBulk:output_type -> kessel.inventory.v1beta2.CheckForUpdateBulkResponse
-	14, // 14: kessel.inventory.v1beta2.KesselInventoryService.CheckBulk:output_type -> kessel.inventory.v1beta2.CheckBulkResponse
-	15, // 15: kessel.inventory.v1beta2.KesselInventoryService.CheckSelfBulk:output_type -> kessel.inventory.v1beta2.CheckSelfBulkResponse
-	16, // 16: kessel.inventory.v1beta2.KesselInventoryService.ReportResource:output_type -> kessel.inventory.v1beta2.ReportResourceResponse
-	17, // 17: kessel.inventory.v1beta2.KesselInventoryService.DeleteResource:output_type -> kessel.inventory.v1beta2.DeleteResourceResponse
-	18, // 18: kessel.inventory.v1beta2.KesselInventoryService.StreamedListObjects:output_type -> kessel.inventory.v1beta2.StreamedListObjectsResponse
-	19, // 19: kessel.inventory.v1beta2.KesselInventoryService.StreamedListSubjects:output_type -> kessel.inventory.v1beta2.StreamedListSubjectsResponse
-	10, // [10:20] is the sub-list for method output_type
-	0,  // [0:10] is the sub-list for method input_type
+	1,  // 1: kessel.inventory.v1beta2.KesselInventoryService.CheckExplain:input_type -> kessel.inventory.v1beta2.CheckExplainRequest
+	2,  // 2: kessel.inventory.v1beta2.KesselInventoryService.CheckSelf:input_type -> kessel.inventory.v1beta2.CheckSelfRequest
+	3,  // 3: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdate:input_type -> kessel.inventory.v1beta2.CheckForUpdateRequest
+	4,  // 4: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdateBulk:input_type -> kessel.inventory.v1beta2.CheckForUpdateBulkRequest
+	5,  // 5: kessel.inventory.v1beta2.KesselInventoryService.CheckBulk:input_type -> kessel.inventory.v1beta2.CheckBulkRequest
+	6,  // 6: kessel.inventory.v1beta2.KesselInventoryService.CheckSelfBulk:input_type -> kessel.inventory.v1beta2.CheckSelfBulkRequest
+	7,  // 7: kessel.inventory.v1beta2.KesselInventoryService.ReportResource:input_type -> kessel.inventory.v1beta2.ReportResourceRequest
+	8,  // 8: kessel.inventory.v1beta2.KesselInventoryService.DeleteResource:input_type -> kessel.inventory.v1beta2.DeleteResourceRequest
+	9,  // 9: kessel.inventory.v1beta2.KesselInventoryService.StreamedListObjects:input_type -> kessel.inventory.v1beta2.StreamedListObjectsRequest
+	10, // 10: kessel.inventory.v1beta2.KesselInventoryService.StreamedListSubjects:input_type -> kessel.inventory.v1beta2.StreamedListSubjectsRequest
+	11, // 11: kessel.inventory.v1beta2.KesselInventoryService.StreamedCheckBulk:input_type -> kessel.inventory.v1beta2.StreamedCheckBulkRequest
+	12, // 12: kessel.inventory.v1beta2.KesselInventoryService.GetConsistencyWatermark:input_type -> kessel.inventory.v1beta2.GetConsistencyWatermarkRequest
+	13, // 13: kessel.inventory.v1beta2.KesselInventoryService.Check:output_type -> kessel.inventory.v1beta2.CheckResponse
+	14, // 14: kessel.inventory.v1beta2.KesselInventoryService.CheckExplain:output_type -> kessel.inventory.v1beta2.CheckExplainResponse
+	15, // 15: kessel.inventory.v1beta2.KesselInventoryService.CheckSelf:output_type -> kessel.inventory.v1beta2.CheckSelfResponse
+	16, // 16: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdate:output_type -> kessel.inventory.v1beta2.CheckForUpdateResponse
+	17, // 17: kessel.inventory.v1beta2.KesselInventoryService.CheckForUpdateBulk:output_type -> kessel.inventory.v1beta2.CheckForUpdateBulkResponse
+	18, // 18: kessel.inventory.v1beta2.KesselInventoryService.CheckBulk:output_type -> kessel.inventory.v1beta2.CheckBulkResponse
+	19, // 19: kessel.inventory.v1beta2.KesselInventoryService.CheckSelfBulk:output_type -> kessel.inventory.v1beta2.CheckSelfBulkResponse
+	20, // 20: kessel.inventory.v1beta2.KesselInventoryService.ReportResource:output_type -> kessel.inventory.v1beta2.ReportResourceResponse
+	21, // 21: kessel.inventory.v1beta2.KesselInventoryService.DeleteResource:output_type -> kessel.inventory.v1beta2.DeleteResourceResponse
+	22, // 22: kessel.inventory.v1beta2.KesselInventoryService.StreamedListObjects:output_type -> kessel.inventory.v1beta2.StreamedListObjectsResponse
+	23, // 23: kessel.inventory.v1beta2.KesselInventoryService.StreamedListSubjects:output_type -> kessel.inventory.v1beta2.StreamedListSubjectsResponse
+	24, // 24: kessel.inventory.v1beta2.KesselInventoryService.StreamedCheckBulk:output_type -> kessel.inventory.v1beta2.StreamedCheckBulkResponse
+	25, // 25: kessel.inventory.v1beta2.KesselInventoryService.GetConsistencyWatermark:output_type -> kessel.inventory.v1beta2.GetConsistencyWatermarkResponse
+	13, // [13:26] is the sub-list for method output_type
+	0,  // [0:13] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -106,6 +121,8 @@ func file_kessel_inventory_v1beta2_inventory_service_proto_init() {
 	file_kessel_inventory_v1beta2_streamed_list_objects_response_proto_init()
 	file_kessel_inventory_v1beta2_streamed_list_subjects_request_proto_init()
 	file_kessel_inventory_v1beta2_streamed_list_subjects_response_proto_init()
+	file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_init()
+	file_kessel_inventory_v1beta2_streamed_check_bulk_response_proto_init()
 	file_kessel_inventory_v1beta2_check_bulk_request_proto_init()
 	file_kessel_inventory_v1beta2_check_bulk_response_proto_init()
 	file_kessel_inventory_v1beta2_check_self_request_proto_init()
@@ -114,6 +131,10 @@ func file_kessel_inventory_v1beta2_inventory_service_proto_init() {
 	file_kessel_inventory_v1beta2_check_self_bulk_response_proto_init()
 	file_kessel_inventory_v1beta2_check_for_update_bulk_request_proto_init()
 	file_kessel_inventory_v1beta2_check_for_update_bulk_response_proto_init()
+	file_kessel_inventory_v1beta2_check_explain_request_proto_init()
+	file_kessel_inventory_v1beta2_check_explain_response_proto_init()
+	file_kessel_inventory_v1beta2_get_consistency_watermark_request_proto_init()
+	file_kessel_inventory_v1beta2_get_consistency_watermark_response_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -19,16 +19,19 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	KesselInventoryService_Check_FullMethodName                = "/kessel.inventory.v1beta2.KesselInventoryService/Check"
-	KesselInventoryService_CheckSelf_FullMethodName            = "/kessel.inventory.v1beta2.KesselInventoryService/CheckSelf"
-	KesselInventoryService_CheckForUpdate_FullMethodName       = "/kessel.inventory.v1beta2.KesselInventoryService/CheckForUpdate"
-	KesselInventoryService_CheckForUpdateBulk_FullMethodName   = "/kessel.inventory.v1beta2.KesselInventoryService/CheckForUpdateBulk"
-	KesselInventoryService_CheckBulk_FullMethodName            = "/kessel.inventory.v1beta2.KesselInventoryService/CheckBulk"
-	KesselInventoryService_CheckSelfBulk_FullMethodName        = "/kessel.inventory.v1beta2.KesselInventoryService/CheckSelfBulk"
-	KesselInventoryService_ReportResource_FullMethodName       = "/kessel.inventory.v1beta2.KesselInventoryService/ReportResource"
-	KesselInventoryService_DeleteResource_FullMethodName       = "/kessel.inventory.v1beta2.KesselInventoryService/DeleteResource"
-	KesselInventoryService_StreamedListObjects_FullMethodName  = "/kessel.inventory.v1beta2.KesselInventoryService/StreamedListObjects"
-	KesselInventoryService_StreamedListSubjects_FullMethodName = "/kessel.inventory.v1beta2.KesselInventoryService/StreamedListSubjects"
+	KesselInventoryService_Check_FullMethodName                   = "/kessel.inventory.v1beta2.KesselInventoryService/Check"
+	KesselInventoryService_CheckExplain_FullMethodName            = "/kessel.inventory.v1beta2.KesselInventoryService/CheckExplain"
+	KesselInventoryService_CheckSelf_FullMethodName               = "/kessel.inventory.v1beta2.KesselInventoryService/CheckSelf"
+	KesselInventoryService_CheckForUpdate_FullMethodName          = "/kessel.inventory.v1beta2.KesselInventoryService/CheckForUpdate"
+	KesselInventoryService_CheckForUpdateBulk_FullMethodName      = "/kessel.inventory.v1beta2.KesselInventoryService/CheckForUpdateBulk"
+	KesselInventoryService_CheckBulk_FullMethodName               = "/kessel.inventory.v1beta2.KesselInventoryService/CheckBulk"
+	KesselInventoryService_CheckSelfBulk_FullMethodName           = "/kessel.inventory.v1beta2.KesselInventoryService/CheckSelfBulk"
+	KesselInventoryService_ReportResource_FullMethodName          = "/kessel.inventory.v1beta2.KesselInventoryService/ReportResource"
+	KesselInventoryService_DeleteResource_FullMethodName          = "/kessel.inventory.v1beta2.KesselInventoryService/DeleteResource"
+	KesselInventoryService_StreamedListObjects_FullMethodName     = "/kessel.inventory.v1beta2.KesselInventoryService/StreamedListObjects"
+	KesselInventoryService_StreamedListSubjects_FullMethodName    = "/kessel.inventory.v1beta2.KesselInventoryService/StreamedListSubjects"
+	KesselInventoryService_StreamedCheckBulk_FullMethodName       = "/kessel.inventory.v1beta2.KesselInventoryService/StreamedCheckBulk"
+	KesselInventoryService_GetConsistencyWatermark_FullMethodName = "/kessel.inventory.v1beta2.KesselInventoryService/GetConsistencyWatermark"
 )
 
 // KesselInventoryServiceClient is the client API for KesselInventoryService service.
@@ -48,6 +51,13 @@ type KesselInventoryServiceClient interface {
 	// Common use cases include enforcing read access, conditional UI visibility,
 	// or authorization gating for downstream API calls.
 	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// Performs a relationship check and explains how the result was reached.
+	//
+	// The response carries the same result as `Check` together with the resolution
+	// path: the permission expressions that were evaluated and the tuples that
+	// satisfied (or failed to satisfy) them. It is intended for debugging access
+	// decisions and is authorized separately from `Check`.
+	CheckExplain(ctx context.Context, in *CheckExplainRequest, opts ...grpc.CallOption) (*CheckExplainResponse, error)
 	// Performs a relationship check where the subject is implicitly the caller
 	// (self), as determined by the authentication context, rather than being
 	// provided explicitly in the request.
@@ -163,6 +173,24 @@ type KesselInventoryServiceClient interface {
 	//
 	// Pagination and consistency controls allow fine-tuned performance and data freshness.
 	StreamedListSubjects(ctx context.Context, in *StreamedListSubjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StreamedListSubjectsResponse], error)
+	// Performs bulk permission checks over a bidirectional stream.
+	//
+	// This is the streaming counterpart of CheckBulk for batches too large to fit in
+	// a single message, such as UI tables covering thousands of resources. Clients
+	// send items incrementally; results are streamed back in request order as they
+	// are resolved. Failures of individual items are reported per item rather than
+	// failing the whole stream.
+	//
+	// Consistency is taken from the first request message. Once the client closes
+	// its side of the stream, a final message carrying the consistency token is sent.
+	StreamedCheckBulk(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamedCheckBulkRequest, StreamedCheckBulkResponse], error)
+	// Returns the consistency watermark: the token of the latest write replicated
+	// to the relations backend, globally or for one reporter type.
+	//
+	// Reporters that write many resources can call this once their last write is
+	// acknowledged and use the token with `at_least_as_fresh`, instead of tracking
+	// the consistency token of every write. The watermark never moves backwards.
+	GetConsistencyWatermark(ctx context.Context, in *GetConsistencyWatermarkRequest, opts ...grpc.CallOption) (*GetConsistencyWatermarkResponse, error)
 }
 
 type kesselInventoryServiceClient struct {
@@ -183,6 +211,16 @@ func (c *kesselInventoryServiceClient) Check(ctx context.Context, in *CheckReque
 	return out, nil
 }
 
+func (c *kesselInventoryServiceClient) CheckExplain(ctx context.Context, in *CheckExplainRequest, opts ...grpc.CallOption) (*CheckExplainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckExplainResponse)
+	err := c.cc.Invoke(ctx, KesselInventoryService_CheckExplain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *kesselInventoryServiceClient) CheckSelf(ctx context.Context, in *CheckSelfRequest, opts ...grpc.CallOption) (*CheckSelfResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckSelfResponse)
@@ -291,6 +329,29 @@ func (c *kesselInventoryServiceClient) StreamedListSubjects(ctx context.Context,
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type KesselInventoryService_StreamedListSubjectsClient = grpc.ServerStreamingClient[StreamedListSubjectsResponse]
 
+func (c *kesselInventoryServiceClient) StreamedCheckBulk(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[StreamedCheckBulkRequest, StreamedCheckBulkResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &KesselInventoryService_ServiceDesc.Streams[2], KesselInventoryService_StreamedCheckBulk_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamedCheckBulkRequest, StreamedCheckBulkResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KesselInventoryService_StreamedCheckBulkClient = grpc.BidiStreamingClient[StreamedCheckBulkRequest, StreamedCheckBulkResponse]
+
+func (c *kesselInventoryServiceClient) GetConsistencyWatermark(ctx context.Context, in *GetConsistencyWatermarkRequest, opts ...grpc.CallOption) (*GetConsistencyWatermarkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetConsistencyWatermarkResponse)
+	err := c.cc.Invoke(ctx, KesselInventoryService_GetConsistencyWatermark_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // KesselInventoryServiceServer is the server API for KesselInventoryService service.
 // All implementations must embed UnimplementedKesselInventoryServiceServer
 // for forward compatibility.
@@ -308,6 +369,13 @@ type KesselInventoryServiceServer interface {
 	// Common use cases include enforcing read access, conditional UI visibility,
 	// or authorization gating for downstream API calls.
 	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// Performs a relationship check and explains how the result was reached.
+	//
+	// The response carries the same result as `Check` together with the resolution
+	// path: the permission expressions that were evaluated and the tuples that
+	// satisfied (or failed to satisfy) them. It is intended for debugging access
+	// decisions and is authorized separately from `Check`.
+	CheckExplain(context.Context, *CheckExplainRequest) (*CheckExplainResponse, error)
 	// Performs a relationship check where the subject is implicitly the caller
 	// (self), as determined by the authentication context, rather than being
 	// provided explicitly in the request.
@@ -423,6 +491,24 @@ type KesselInventoryServiceServer interface {
 	//
 	// Pagination and consistency controls allow fine-tuned performance and data freshness.
 	StreamedListSubjects(*StreamedListSubjectsRequest, grpc.ServerStreamingServer[StreamedListSubjectsResponse]) error
+	// Performs bulk permission checks over a bidirectional stream.
+	//
+	// This is the streaming counterpart of CheckBulk for batches too large to fit in
+	// a single message, such as UI tables covering thousands of resources. Clients
+	// send items incrementally; results are streamed back in request order as they
+	// are resolved. Failures of individual items are reported per item rather than
+	// failing the whole stream.
+	//
+	// Consistency is taken from the first request message. Once the client closes
+	// its side of the stream, a final message carrying the consistency token is sent.
+	StreamedCheckBulk(grpc.BidiStreamingServer[StreamedCheckBulkRequest, StreamedCheckBulkResponse]) error
+	// Returns the consistency watermark: the token of the latest write replicated
+	// to the relations backend, globally or for one reporter type.
+	//
+	// Reporters that write many resources can call this once their last write is
+	// acknowledged and use the token with `at_least_as_fresh`, instead of tracking
+	// the consistency token of every write. The watermark never moves backwards.
+	GetConsistencyWatermark(context.Context, *GetConsistencyWatermarkRequest) (*GetConsistencyWatermarkResponse, error)
 	mustEmbedUnimplementedKesselInventoryServiceServer()
 }
 
@@ -436,6 +522,9 @@ type UnimplementedKesselInventoryServiceServer struct{}
 func (UnimplementedKesselInventoryServiceServer) Check(context.Context, *CheckRequest) (*CheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (UnimplementedKesselInventoryServiceServer) CheckExplain(context.Context, *CheckExplainRequest) (*CheckExplainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckExplain not implemented")
+}
 func (UnimplementedKesselInventoryServiceServer) CheckSelf(context.Context, *CheckSelfRequest) (*CheckSelfResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckSelf not implemented")
 }
@@ -463,6 +552,12 @@ func (UnimplementedKesselInventoryServiceServer) StreamedListObjects(*StreamedLi
 func (UnimplementedKesselInventoryServiceServer) StreamedListSubjects(*StreamedListSubjectsRequest, grpc.ServerStreamingServer[StreamedListSubjectsResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamedListSubjects not implemented")
 }
+func (UnimplementedKesselInventoryServiceServer) StreamedCheckBulk(grpc.BidiStreamingServer[StreamedCheckBulkRequest, StreamedCheckBulkResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamedCheckBulk not implemented")
+}
+func (UnimplementedKesselInventoryServiceServer) GetConsistencyWatermark(context.Context, *GetConsistencyWatermarkRequest) (*GetConsistencyWatermarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsistencyWatermark not implemented")
+}
 func (UnimplementedKesselInventoryServiceServer) mustEmbedUnimplementedKesselInventoryServiceServer() {
 }
 func (UnimplementedKesselInventoryServiceServer) testEmbeddedByValue() {}
@@ -503,6 +598,24 @@ func _KesselInventoryService_Check_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KesselInventoryService_CheckExplain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KesselInventoryServiceServer).CheckExplain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KesselInventoryService_CheckExplain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KesselInventoryServiceServer).CheckExplain(ctx, req.(*CheckExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _KesselInventoryService_CheckSelf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckSelfRequest)
 	if err := dec(in); err != nil {
@@ -651,6 +764,31 @@ func _KesselInventoryService_StreamedListSubjects_Handler(srv interface{}, strea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type KesselInventoryService_StreamedListSubjectsServer = grpc.ServerStreamingServer[StreamedListSubjectsResponse]
 
+func _KesselInventoryService_StreamedCheckBulk_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KesselInventoryServiceServer).StreamedCheckBulk(&grpc.GenericServerStream[StreamedCheckBulkRequest, StreamedCheckBulkResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type KesselInventoryService_StreamedCheckBulkServer = grpc.BidiStreamingServer[StreamedCheckBulkRequest, StreamedCheckBulkResponse]
+
+func _KesselInventoryService_GetConsistencyWatermark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsistencyWatermarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KesselInventoryServiceServer).GetConsistencyWatermark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KesselInventoryService_GetConsistencyWatermark_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KesselInventoryServiceServer).GetConsistencyWatermark(ctx, req.(*GetConsistencyWatermarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // KesselInventoryService_ServiceDesc is the grpc.ServiceDesc for KesselInventoryService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -662,6 +800,10 @@ var KesselInventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _KesselInventoryService_Check_Handler,
 		},
+		{
+			MethodName: "CheckExplain",
+			Handler:    _KesselInventoryService_CheckExplain_Handler,
+		},
 		{
 			MethodName: "CheckSelf",
 			Handler:    _KesselInventoryService_CheckSelf_Handler,
@@ -690,6 +832,10 @@ var KesselInventoryService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteResource",
 			Handler:    _KesselInventoryService_DeleteResource_Handler,
 		},
+		{
+			MethodName: "GetConsistencyWatermark",
+			Handler:    _KesselInventoryService_GetConsistencyWatermark_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -702,6 +848,12 @@ var KesselInventoryService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _KesselInventoryService_StreamedListSubjects_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamedCheckBulk",
+			Handler:       _KesselInventoryService_StreamedCheckBulk_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "kessel/inventory/v1beta2/inventory_service.proto",
 }
@@ -21,11 +21,13 @@ const _ = http.SupportPackageIsVersion1
 
 const OperationKesselInventoryServiceCheck = "/kessel.inventory.v1beta2.KesselInventoryService/Check"
 const OperationKesselInventoryServiceCheckBulk = "/kessel.inventory.v1beta2.KesselInventoryService/CheckBulk"
+const OperationKesselInventoryServiceCheckExplain = "/kessel.inventory.v1beta2.KesselInventoryService/CheckExplain"
 const OperationKesselInventoryServiceCheckForUpdate = "/kessel.inventory.v1beta2.KesselInventoryService/CheckForUpdate"
 const OperationKesselInventoryServiceCheckForUpdateBulk = "/kessel.inventory.v1beta2.KesselInventoryService/CheckForUpdateBulk"
 const OperationKesselInventoryServiceCheckSelf = "/kessel.inventory.v1beta2.KesselInventoryService/CheckSelf"
 const OperationKesselInventoryServiceCheckSelfBulk = "/kessel.inventory.v1beta2.KesselInventoryService/CheckSelfBulk"
 const OperationKesselInventoryServiceDeleteResource = "/kessel.inventory.v1beta2.KesselInventoryService/DeleteResource"
+const OperationKesselInventoryServiceGetConsistencyWatermark = "/kessel.inventory.v1beta2.KesselInventoryService/GetConsistencyWatermark"
 const OperationKesselInventoryServiceReportResource = "/kessel.inventory.v1beta2.KesselInventoryService/ReportResource"
 
 type KesselInventoryServiceHTTPServer interface {
@@ -53,6 +55,13 @@ type KesselInventoryServiceHTTPServer interface {
 	//
 	// The response includes a result for each item in the request, maintaining the same order.
 	CheckBulk(context.Context, *CheckBulkRequest) (*CheckBulkResponse, error)
+	// CheckExplain Performs a relationship check and explains how the result was reached.
+	//
+	// The response carries the same result as `Check` together with the resolution
+	// path: the permission expressions that were evaluated and the tuples that
+	// satisfied (or failed to satisfy) them. It is intended for debugging access
+	// decisions and is authorized separately from `Check`.
+	CheckExplain(context.Context, *CheckExplainRequest) (*CheckExplainResponse, error)
 	// CheckForUpdate Performs a strongly consistent relationship check to determine whether a subject
 	// has a specific relation to an object (representing, for example, a permission).
 	//
@@ -103,6 +112,13 @@ type KesselInventoryServiceHTTPServer interface {
 	//
 	// As an example, it can revoke previously granted access across the system.
 	DeleteResource(context.Context, *DeleteResourceRequest) (*DeleteResourceResponse, error)
+	// GetConsistencyWatermark Returns the consistency watermark: the token of the latest write replicated
+	// to the relations backend, globally or for one reporter type.
+	//
+	// Reporters that write many resources can call this once their last write is
+	// acknowledged and use the token with `at_least_as_fresh`, instead of tracking
+	// the consistency token of every write. The watermark never moves backwards.
+	GetConsistencyWatermark(context.Context, *GetConsistencyWatermarkRequest) (*GetConsistencyWatermarkResponse, error)
 	// ReportResource Reports to Kessel Inventory that a Resource has been created or has been updated.
 	//
 	// Reporters can use this API to report facts about their resources in order to
@@ -137,6 +153,7 @@ type KesselInventoryServiceHTTPServer interface {
 func RegisterKesselInventoryServiceHTTPServer(s *http.Server, srv KesselInventoryServiceHTTPServer) {
 	r := s.Route("/")
 	r.POST("/api/kessel/v1beta2/check", _KesselInventoryService_Check0_HTTP_Handler(srv))
+	r.POST("/api/kessel/v1beta2/checkexplain", _KesselInventoryService_CheckExplain0_HTTP_Handler(srv))
 	r.POST("/api/kessel/v1beta2/checkself", _KesselInventoryService_CheckSelf0_HTTP_Handler(srv))
 	r.POST("/api/kessel/v1beta2/checkforupdate", _KesselInventoryService_CheckForUpdate0_HTTP_Handler(srv))
 	r.POST("/api/kessel/v1beta2/checkforupdatebulk", _KesselInventoryService_CheckForUpdateBulk0_HTTP_Handler(srv))
@@ -144,6 +161,7 @@ func RegisterKesselInventoryServiceHTTPServer(s *http.Server, srv KesselInventor
 	r.POST("/api/kessel/v1beta2/checkselfbulk", _KesselInventoryService_CheckSelfBulk0_HTTP_Handler(srv))
 	r.POST("/api/kessel/v1beta2/resources", _KesselInventoryService_ReportResource0_HTTP_Handler(srv))
 	r.DELETE("/api/kessel/v1beta2/resources", _KesselInventoryService_DeleteResource0_HTTP_Handler(srv))
+	r.GET("/api/kessel/v1beta2/consistencywatermark", _KesselInventoryService_GetConsistencyWatermark0_HTTP_Handler(srv))
 }
 
 func _KesselInventoryService_Check0_HTTP_Handler(srv KesselInventoryServiceHTTPServer) func(ctx http.Context) error {
@@ -168,6 +186,28 @@ func _KesselInventoryService_Check0_HTTP_Handler(srv KesselInventoryServiceHTTPS
 	}
 }
 
+func _KesselInventoryService_CheckExplain0_HTTP_Handler(srv KesselInventoryServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in CheckExplainRequest
+		if err := ctx.Bind(&in); err != nil {
+			return err
+		}
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationKesselInventoryServiceCheckExplain)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.CheckExplain(ctx, req.(*CheckExplainRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*CheckExplainResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 func _KesselInventoryService_CheckSelf0_HTTP_Handler(srv KesselInventoryServiceHTTPServer) func(ctx http.Context) error {
 	return func(ctx http.Context) error {
 		var in CheckSelfRequest
@@ -322,14 +362,35 @@ func _KesselInventoryService_DeleteResource0_HTTP_Handler(srv KesselInventorySer
 	}
 }
 
+func _KesselInventoryService_GetConsistencyWatermark0_HTTP_Handler(srv KesselInventoryServiceHTTPServer) func(ctx http.Context) error {
+	return func(ctx http.Context) error {
+		var in GetConsistencyWatermarkRequest
+		if err := ctx.BindQuery(&in); err != nil {
+			return err
+		}
+		http.SetOperation(ctx, OperationKesselInventoryServiceGetConsistencyWatermark)
+		h := ctx.Middleware(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return srv.GetConsistencyWatermark(ctx, req.(*GetConsistencyWatermarkRequest))
+		})
+		out, err := h(ctx, &in)
+		if err != nil {
+			return err
+		}
+		reply := out.(*GetConsistencyWatermarkResponse)
+		return ctx.Result(200, reply)
+	}
+}
+
 type KesselInventoryServiceHTTPClient interface {
 	Check(ctx context.Context, req *CheckRequest, opts ...http.CallOption) (rsp *CheckResponse, err error)
 	CheckBulk(ctx context.Context, req *CheckBulkRequest, opts ...http.CallOption) (rsp *CheckBulkResponse, err error)
+	CheckExplain(ctx context.Context, req *CheckExplainRequest, opts ...http.CallOption) (rsp *CheckExplainResponse, err error)
 	CheckForUpdate(ctx context.Context, req *CheckForUpdateRequest, opts ...http.CallOption) (rsp *CheckForUpdateResponse, err error)
 	CheckForUpdateBulk(ctx context.Context, req *CheckForUpdateBulkRequest, opts ...http.CallOption) (rsp *CheckForUpdateBulkResponse, err error)
 	CheckSelf(ctx context.Context, req *CheckSelfRequest, opts ...http.CallOption) (rsp *CheckSelfResponse, err error)
 	CheckSelfBulk(ctx context.Context, req *CheckSelfBulkRequest, opts ...http.CallOption) (rsp *CheckSelfBulkResponse, err error)
 	DeleteResource(ctx context.Context, req *DeleteResourceRequest, opts ...http.CallOption) (rsp *DeleteResourceResponse, err error)
+	GetConsistencyWatermark(ctx context.Context, req *GetConsistencyWatermarkRequest, opts ...http.CallOption) (rsp *GetConsistencyWatermarkResponse, err error)
 	ReportResource(ctx context.Context, req *ReportResourceRequest, opts ...http.CallOption) (rsp *ReportResourceResponse, err error)
 }
 
@@ -367,6 +428,19 @@ func (c *KesselInventoryServiceHTTPClientImpl) CheckBulk(ctx context.Context, in
 	return &out, nil
 }
 
+func (c *KesselInventoryServiceHTTPClientImpl) CheckExplain(ctx context.Context, in *CheckExplainRequest, opts ...http.CallOption) (*CheckExplainResponse, error) {
+	var out CheckExplainResponse
+	pattern := "/api/kessel/v1beta2/checkexplain"
+	path := binding.EncodeURL(pattern, in, false)
+	opts = append(opts, http.Operation(OperationKesselInventoryServiceCheckExplain))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "POST", path, in, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *KesselInventoryServiceHTTPClientImpl) CheckForUpdate(ctx context.Context, in *CheckForUpdateRequest, opts ...http.CallOption) (*CheckForUpdateResponse, error) {
 	var out CheckForUpdateResponse
 	pattern := "/api/kessel/v1beta2/checkforupdate"
@@ -432,6 +506,19 @@ func (c *KesselInventoryServiceHTTPClientImpl) DeleteResource(ctx context.Contex
 	return &out, nil
 }
 
+func (c *KesselInventoryServiceHTTPClientImpl) GetConsistencyWatermark(ctx context.Context, in *GetConsistencyWatermarkRequest, opts ...http.CallOption) (*GetConsistencyWatermarkResponse, error) {
+	var out GetConsistencyWatermarkResponse
+	pattern := "/api/kessel/v1beta2/consistencywatermark"
+	path := binding.EncodeURL(pattern, in, true)
+	opts = append(opts, http.Operation(OperationKesselInventoryServiceGetConsistencyWatermark))
+	opts = append(opts, http.PathTemplate(pattern))
+	err := c.cc.Invoke(ctx, "GET", path, nil, &out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 func (c *KesselInventoryServiceHTTPClientImpl) ReportResource(ctx context.Context, in *ReportResourceRequest, opts ...http.CallOption) (*ReportResourceResponse, error) {
 	var out ReportResourceResponse
 	pattern := "/api/kessel/v1beta2/resources"
@@ -0,0 +1,261 @@
+// Code generated by `inventory-api schema codegen`. DO NOT EDIT.
+
+package representations
+
+import (
+	v1beta2 "github.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// K8sClusterResourceType is the resource type of K8sClusterCommon and its reporter representations.
+const K8sClusterResourceType = "k8s_cluster"
+
+// K8sClusterCommon is the common representation of a k8s_cluster resource.
+type K8sClusterCommon struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+// ToStruct converts the representation to the Struct sent in ResourceRepresentations.
+func (r K8sClusterCommon) ToStruct() (*structpb.Struct, error) {
+	return toStruct(r)
+}
+
+// K8sClusterAcmReporter is the acm reporter representation of a k8s_cluster resource.
+type K8sClusterAcmReporter struct {
+	CloudPlatform     K8sClusterAcmReporterCloudPlatform `json:"cloud_platform"`
+	ClusterReason     string                             `json:"cluster_reason"`
+	ClusterStatus     K8sClusterAcmReporterClusterStatus `json:"cluster_status"`
+	ExternalClusterID string                             `json:"external_cluster_id"`
+	KubeVendor        K8sClusterAcmReporterKubeVendor    `json:"kube_vendor"`
+	KubeVersion       string                             `json:"kube_version"`
+	Nodes             []K8sClusterAcmReporterNodesItem   `json:"nodes,omitempty"`
+	VendorVersion     string                             `json:"vendor_version"`
+}
+
+// ToStruct converts the representation to the Struct sent in ResourceRepresentations.
+func (r K8sClusterAcmReporter) ToStruct() (*structpb.Struct, error) {
+	return toStruct(r)
+}
+
+// K8sClusterAcmReporterCloudPlatform enumerates the values allowed by the schema.
+type K8sClusterAcmReporterCloudPlatform string
+
+const (
+	K8sClusterAcmReporterCloudPlatformCloudPlatformUnspecified K8sClusterAcmReporterCloudPlatform = "CLOUD_PLATFORM_UNSPECIFIED"
+	K8sClusterAcmReporterCloudPlatformCloudPlatformOther       K8sClusterAcmReporterCloudPlatform = "CLOUD_PLATFORM_OTHER"
+	K8sClusterAcmReporterCloudPlatformNoneUpi                  K8sClusterAcmReporterCloudPlatform = "NONE_UPI"
+	K8sClusterAcmReporterCloudPlatformBaremetalIpi             K8sClusterAcmReporterCloudPlatform = "BAREMETAL_IPI"
+	K8sClusterAcmReporterCloudPlatformBaremetalUpi             K8sClusterAcmReporterCloudPlatform = "BAREMETAL_UPI"
+	K8sClusterAcmReporterCloudPlatformAwsIpi                   K8sClusterAcmReporterCloudPlatform = "AWS_IPI"
+	K8sClusterAcmReporterCloudPlatformAwsUpi                   K8sClusterAcmReporterCloudPlatform = "AWS_UPI"
+	K8sClusterAcmReporterCloudPlatformAzureIpi                 K8sClusterAcmReporterCloudPlatform = "AZURE_IPI"
+	K8sClusterAcmReporterCloudPlatformAzureUpi                 K8sClusterAcmReporterCloudPlatform = "AZURE_UPI"
+	K8sClusterAcmReporterCloudPlatformIbmcloudIpi              K8sClusterAcmReporterCloudPlatform = "IBMCLOUD_IPI"
+	K8sClusterAcmReporterCloudPlatformIbmcloudUpi              K8sClusterAcmReporterCloudPlatform = "IBMCLOUD_UPI"
+	K8sClusterAcmReporterCloudPlatformKubevirtIpi              K8sClusterAcmReporterCloudPlatform = "KUBEVIRT_IPI"
+	K8sClusterAcmReporterCloudPlatformOpenstackIpi             K8sClusterAcmReporterCloudPlatform = "OPENSTACK_IPI"
+	K8sClusterAcmReporterCloudPlatformOpenstackUpi             K8sClusterAcmReporterCloudPlatform = "OPENSTACK_UPI"
+	K8sClusterAcmReporterCloudPlatformGcpIpi                   K8sClusterAcmReporterCloudPlatform = "GCP_IPI"
+	K8sClusterAcmReporterCloudPlatformGcpUpi                   K8sClusterAcmReporterCloudPlatform = "GCP_UPI"
+	K8sClusterAcmReporterCloudPlatformNutanixIpi               K8sClusterAcmReporterCloudPlatform = "NUTANIX_IPI"
+	K8sClusterAcmReporterCloudPlatformNutanixUpi               K8sClusterAcmReporterCloudPlatform = "NUTANIX_UPI"
+	K8sClusterAcmReporterCloudPlatformVsphereIpi               K8sClusterAcmReporterCloudPlatform = "VSPHERE_IPI"
+	K8sClusterAcmReporterCloudPlatformVsphereUpi               K8sClusterAcmReporterCloudPlatform = "VSPHERE_UPI"
+	K8sClusterAcmReporterCloudPlatformOvirtIpi                 K8sClusterAcmReporterCloudPlatform = "OVIRT_IPI"
+)
+
+// K8sClusterAcmReporterClusterStatus enumerates the values allowed by the schema.
+type K8sClusterAcmReporterClusterStatus string
+
+const (
+	K8sClusterAcmReporterClusterStatusClusterStatusUnspecified K8sClusterAcmReporterClusterStatus = "CLUSTER_STATUS_UNSPECIFIED"
+	K8sClusterAcmReporterClusterStatusClusterStatusOther       K8sClusterAcmReporterClusterStatus = "CLUSTER_STATUS_OTHER"
+	K8sClusterAcmReporterClusterStatusReady                    K8sClusterAcmReporterClusterStatus = "READY"
+	K8sClusterAcmReporterClusterStatusFailed                   K8sClusterAcmReporterClusterStatus = "FAILED"
+	K8sClusterAcmReporterClusterStatusOffline                  K8sClusterAcmReporterClusterStatus = "OFFLINE"
+)
+
+// K8sClusterAcmReporterKubeVendor enumerates the values allowed by the schema.
+type K8sClusterAcmReporterKubeVendor string
+
+const (
+	K8sClusterAcmReporterKubeVendorKubeVendorUnspecified K8sClusterAcmReporterKubeVendor = "KUBE_VENDOR_UNSPECIFIED"
+	K8sClusterAcmReporterKubeVendorKubeVendorOther       K8sClusterAcmReporterKubeVendor = "KUBE_VENDOR_OTHER"
+	K8sClusterAcmReporterKubeVendorAks                   K8sClusterAcmReporterKubeVendor = "AKS"
+	K8sClusterAcmReporterKubeVendorEks                   K8sClusterAcmReporterKubeVendor = "EKS"
+	K8sClusterAcmReporterKubeVendorIks                   K8sClusterAcmReporterKubeVendor = "IKS"
+	K8sClusterAcmReporterKubeVendorOpenshift             K8sClusterAcmReporterKubeVendor = "OPENSHIFT"
+	K8sClusterAcmReporterKubeVendorGke                   K8sClusterAcmReporterKubeVendor = "GKE"
+)
+
+// K8sClusterAcmReporterNodesItem is a nested object of a representation.
+type K8sClusterAcmReporterNodesItem struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+	Name   string `json:"name"`
+}
+
+// NewK8sClusterAcmRepresentations builds the representations of a k8s_cluster resource reported by acm.
+func NewK8sClusterAcmRepresentations(metadata *v1beta2.RepresentationMetadata, common K8sClusterCommon, reporter K8sClusterAcmReporter) (*v1beta2.ResourceRepresentations, error) {
+	return newResourceRepresentations(metadata, common, reporter)
+}
+
+// K8sClusterAcsReporter is the acs reporter representation of a k8s_cluster resource.
+type K8sClusterAcsReporter struct {
+	CloudPlatform     K8sClusterAcsReporterCloudPlatform `json:"cloud_platform"`
+	ClusterReason     string                             `json:"cluster_reason"`
+	ClusterStatus     K8sClusterAcsReporterClusterStatus `json:"cluster_status"`
+	ExternalClusterID string                             `json:"external_cluster_id"`
+	KubeVendor        K8sClusterAcsReporterKubeVendor    `json:"kube_vendor"`
+	KubeVersion       string                             `json:"kube_version"`
+	Nodes             []K8sClusterAcsReporterNodesItem   `json:"nodes,omitempty"`
+	VendorVersion     string                             `json:"vendor_version"`
+}
+
+// ToStruct converts the representation to the Struct sent in ResourceRepresentations.
+func (r K8sClusterAcsReporter) ToStruct() (*structpb.Struct, error) {
+	return toStruct(r)
+}
+
+// K8sClusterAcsReporterCloudPlatform enumerates the values allowed by the schema.
+type K8sClusterAcsReporterCloudPlatform string
+
+const (
+	K8sClusterAcsReporterCloudPlatformCloudPlatformUnspecified K8sClusterAcsReporterCloudPlatform = "CLOUD_PLATFORM_UNSPECIFIED"
+	K8sClusterAcsReporterCloudPlatformCloudPlatformOther       K8sClusterAcsReporterCloudPlatform = "CLOUD_PLATFORM_OTHER"
+	K8sClusterAcsReporterCloudPlatformNoneUpi                  K8sClusterAcsReporterCloudPlatform = "NONE_UPI"
+	K8sClusterAcsReporterCloudPlatformBaremetalIpi             K8sClusterAcsReporterCloudPlatform = "BAREMETAL_IPI"
+	K8sClusterAcsReporterCloudPlatformBaremetalUpi             K8sClusterAcsReporterCloudPlatform = "BAREMETAL_UPI"
+	K8sClusterAcsReporterCloudPlatformAwsIpi                   K8sClusterAcsReporterCloudPlatform = "AWS_IPI"
+	K8sClusterAcsReporterCloudPlatformAwsUpi                   K8sClusterAcsReporterCloudPlatform = "AWS_UPI"
+	K8sClusterAcsReporterCloudPlatformAzureIpi                 K8sClusterAcsReporterCloudPlatform = "AZURE_IPI"
+	K8sClusterAcsReporterCloudPlatformAzureUpi                 K8sClusterAcsReporterCloudPlatform = "AZURE_UPI"
+	K8sClusterAcsReporterCloudPlatformIbmcloudIpi              K8sClusterAcsReporterCloudPlatform = "IBMCLOUD_IPI"
+	K8sClusterAcsReporterCloudPlatformIbmcloudUpi              K8sClusterAcsReporterCloudPlatform = "IBMCLOUD_UPI"
+	K8sClusterAcsReporterCloudPlatformKubevirtIpi              K8sClusterAcsReporterCloudPlatform = "KUBEVIRT_IPI"
+	K8sClusterAcsReporterCloudPlatformOpenstackIpi             K8sClusterAcsReporterCloudPlatform = "OPENSTACK_IPI"
+	K8sClusterAcsReporterCloudPlatformOpenstackUpi             K8sClusterAcsReporterCloudPlatform = "OPENSTACK_UPI"
+	K8sClusterAcsReporterCloudPlatformGcpIpi                   K8sClusterAcsReporterCloudPlatform = "GCP_IPI"
+	K8sClusterAcsReporterCloudPlatformGcpUpi                   K8sClusterAcsReporterCloudPlatform = "GCP_UPI"
+	K8sClusterAcsReporterCloudPlatformNutanixIpi               K8sClusterAcsReporterCloudPlatform = "NUTANIX_IPI"
+	K8sClusterAcsReporterCloudPlatformNutanixUpi               K8sClusterAcsReporterCloudPlatform = "NUTANIX_UPI"
+	K8sClusterAcsReporterCloudPlatformVsphereIpi               K8sClusterAcsReporterCloudPlatform = "VSPHERE_IPI"
+	K8sClusterAcsReporterCloudPlatformVsphereUpi               K8sClusterAcsReporterCloudPlatform = "VSPHERE_UPI"
+	K8sClusterAcsReporterCloudPlatformOvirtIpi                 K8sClusterAcsReporterCloudPlatform = "OVIRT_IPI"
+)
+
+// K8sClusterAcsReporterClusterStatus enumerates the values allowed by the schema.
+type K8sClusterAcsReporterClusterStatus string
+
+const (
+	K8sClusterAcsReporterClusterStatusClusterStatusUnspecified K8sClusterAcsReporterClusterStatus = "CLUSTER_STATUS_UNSPECIFIED"
+	K8sClusterAcsReporterClusterStatusClusterStatusOther       K8sClusterAcsReporterClusterStatus = "CLUSTER_STATUS_OTHER"
+	K8sClusterAcsReporterClusterStatusReady                    K8sClusterAcsReporterClusterStatus = "READY"
+	K8sClusterAcsReporterClusterStatusFailed                   K8sClusterAcsReporterClusterStatus = "FAILED"
+	K8sClusterAcsReporterClusterStatusOffline                  K8sClusterAcsReporterClusterStatus = "OFFLINE"
+)
+
+// K8sClusterAcsReporterKubeVendor enumerates the values allowed by the schema.
+type K8sClusterAcsReporterKubeVendor string
+
+const (
+	K8sClusterAcsReporterKubeVendorKubeVendorUnspecified K8sClusterAcsReporterKubeVendor = "KUBE_VENDOR_UNSPECIFIED"
+	K8sClusterAcsReporterKubeVendorKubeVendorOther       K8sClusterAcsReporterKubeVendor = "KUBE_VENDOR_OTHER"
+	K8sClusterAcsReporterKubeVendorAks                   K8sClusterAcsReporterKubeVendor = "AKS"
+	K8sClusterAcsReporterKubeVendorEks                   K8sClusterAcsReporterKubeVendor = "EKS"
+	K8sClusterAcsReporterKubeVendorIks                   K8sClusterAcsReporterKubeVendor = "IKS"
+	K8sClusterAcsReporterKubeVendorOpenshift             K8sClusterAcsReporterKubeVendor = "OPENSHIFT"
+	K8sClusterAcsReporterKubeVendorGke                   K8sClusterAcsReporterKubeVendor = "GKE"
+)
+
+// K8sClusterAcsReporterNodesItem is a nested object of a representation.
+type K8sClusterAcsReporterNodesItem struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+	Name   string `json:"name"`
+}
+
+// NewK8sClusterAcsRepresentations builds the representations of a k8s_cluster resource reported by acs.
+func NewK8sClusterAcsRepresentations(metadata *v1beta2.RepresentationMetadata, common K8sClusterCommon, reporter K8sClusterAcsReporter) (*v1beta2.ResourceRepresentations, error) {
+	return newResourceRepresentations(metadata, common, reporter)
+}
+
+// K8sClusterOcmReporter is the ocm reporter representation of a k8s_cluster resource.
+type K8sClusterOcmReporter struct {
+	CloudPlatform     K8sClusterOcmReporterCloudPlatform `json:"cloud_platform"`
+	ClusterReason     string                             `json:"cluster_reason"`
+	ClusterStatus     K8sClusterOcmReporterClusterStatus `json:"cluster_status"`
+	ExternalClusterID string                             `json:"external_cluster_id"`
+	KubeVendor        K8sClusterOcmReporterKubeVendor    `json:"kube_vendor"`
+	KubeVersion       string                             `json:"kube_version"`
+	Nodes             []K8sClusterOcmReporterNodesItem   `json:"nodes,omitempty"`
+	VendorVersion     string                             `json:"vendor_version"`
+}
+
+// ToStruct converts the representation to the Struct sent in ResourceRepresentations.
+func (r K8sClusterOcmReporter) ToStruct() (*structpb.Struct, error) {
+	return toStruct(r)
+}
+
+// K8sClusterOcmReporterCloudPlatform enumerates the values allowed by the schema.
+type K8sClusterOcmReporterCloudPlatform string
+
+const (
+	K8sClusterOcmReporterCloudPlatformCloudPlatformUnspecified K8sClusterOcmReporterCloudPlatform = "CLOUD_PLATFORM_UNSPECIFIED"
+	K8sClusterOcmReporterCloudPlatformCloudPlatformOther       K8sClusterOcmReporterCloudPlatform = "CLOUD_PLATFORM_OTHER"
+	K8sClusterOcmReporterCloudPlatformNoneUpi                  K8sClusterOcmReporterCloudPlatform = "NONE_UPI"
+	K8sClusterOcmReporterCloudPlatformBaremetalIpi             K8sClusterOcmReporterCloudPlatform = "BAREMETAL_IPI"
+	K8sClusterOcmReporterCloudPlatformBaremetalUpi             K8sClusterOcmReporterCloudPlatform = "BAREMETAL_UPI"
+	K8sClusterOcmReporterCloudPlatformAwsIpi                   K8sClusterOcmReporterCloudPlatform = "AWS_IPI"
+	K8sClusterOcmReporterCloudPlatformAwsUpi                   K8sClusterOcmReporterCloudPlatform = "AWS_UPI"
+	K8sClusterOcmReporterCloudPlatformAzureIpi                 K8sClusterOcmReporterCloudPlatform = "AZURE_IPI"
+	K8sClusterOcmReporterCloudPlatformAzureUpi                 K8sClusterOcmReporterCloudPlatform = "AZURE_UPI"
+	K8sClusterOcmReporterCloudPlatformIbmcloudIpi              K8sClusterOcmReporterCloudPlatform = "IBMCLOUD_IPI"
+	K8sClusterOcmReporterCloudPlatformIbmcloudUpi              K8sClusterOcmReporterCloudPlatform = "IBMCLOUD_UPI"
+	K8sClusterOcmReporterCloudPlatformKubevirtIpi              K8sClusterOcmReporterCloudPlatform = "KUBEVIRT_IPI"
+	K8sClusterOcmReporterCloudPlatformOpenstackIpi             K8sClusterOcmReporterCloudPlatform = "OPENSTACK_IPI"
+	K8sClusterOcmReporterCloudPlatformOpenstackUpi             K8sClusterOcmReporterCloudPlatform = "OPENSTACK_UPI"
+	K8sClusterOcmReporterCloudPlatformGcpIpi                   K8sClusterOcmReporterCloudPlatform = "GCP_IPI"
+	K8sClusterOcmReporterCloudPlatformGcpUpi                   K8sClusterOcmReporterCloudPlatform = "GCP_UPI"
+	K8sClusterOcmReporterCloudPlatformNutanixIpi               K8sClusterOcmReporterCloudPlatform = "NUTANIX_IPI"
+	K8sClusterOcmReporterCloudPlatformNutanixUpi               K8sClusterOcmReporterCloudPlatform = "NUTANIX_UPI"
+	K8sClusterOcmReporterCloudPlatformVsphereIpi               K8sClusterOcmReporterCloudPlatform = "VSPHERE_IPI"
+	K8sClusterOcmReporterCloudPlatformVsphereUpi               K8sClusterOcmReporterCloudPlatform = "VSPHERE_UPI"
+	K8sClusterOcmReporterCloudPlatformOvirtIpi                 K8sClusterOcmReporterCloudPlatform = "OVIRT_IPI"
+)
+
+// K8sClusterOcmReporterClusterStatus enumerates the values allowed by the schema.
+type K8sClusterOcmReporterClusterStatus string
+
+const (
+	K8sClusterOcmReporterClusterStatusClusterStatusUnspecified K8sClusterOcmReporterClusterStatus = "CLUSTER_STATUS_UNSPECIFIED"
+	K8sClusterOcmReporterClusterStatusClusterStatusOther       K8sClusterOcmReporterClusterStatus = "CLUSTER_STATUS_OTHER"
+	K8sClusterOcmReporterClusterStatusReady                    K8sClusterOcmReporterClusterStatus = "READY"
+	K8sClusterOcmReporterClusterStatusFailed                   K8sClusterOcmReporterClusterStatus = "FAILED"
+	K8sClusterOcmReporterClusterStatusOffline                  K8sClusterOcmReporterClusterStatus = "OFFLINE"
+)
+
+// K8sClusterOcmReporterKubeVendor enumerates the values allowed by the schema.
+type K8sClusterOcmReporterKubeVendor string
+
+const (
+	K8sClusterOcmReporterKubeVendorKubeVendorUnspecified K8sClusterOcmReporterKubeVendor = "KUBE_VENDOR_UNSPECIFIED"
+	K8sClusterOcmReporterKubeVendorKubeVendorOther       K8sClusterOcmReporterKubeVendor = "KUBE_VENDOR_OTHER"
+	K8sClusterOcmReporterKubeVendorAks                   K8sClusterOcmReporterKubeVendor = "AKS"
+	K8sClusterOcmReporterKubeVendorEks                   K8sClusterOcmReporterKubeVendor = "EKS"
+	K8sClusterOcmReporterKubeVendorIks                   K8sClusterOcmReporterKubeVendor = "IKS"
+	K8sClusterOcmReporterKubeVendorOpenshift             K8sClusterOcmReporterKubeVendor = "OPENSHIFT"
+	K8sClusterOcmReporterKubeVendorGke                   K8sClusterOcmReporterKubeVendor = "GKE"
+)
+
+// K8sClusterOcmReporterNodesItem is a nested object of a representation.
+type K8sClusterOcmReporterNodesItem struct {
+	CPU    string `json:"cpu"`
+	Memory string `json:"memory"`
+	Name   string `json:"name"`
+}
+
+// NewK8sClusterOcmRepresentations builds the representations of a k8s_cluster resource reported by ocm.
+func NewK8sClusterOcmRepresentations(metadata *v1beta2.RepresentationMetadata, common K8sClusterCommon, reporter K8sClusterOcmReporter) (*v1beta2.ResourceRepresentations, error) {
+	return newResourceRepresentations(metadata, common, reporter)
+}
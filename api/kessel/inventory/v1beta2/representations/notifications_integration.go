@@ -0,0 +1,48 @@
+// Code generated by `inventory-api schema codegen`. DO NOT EDIT.
+
+package representations
+
+import (
+	v1beta2 "github.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// NotificationsIntegrationResourceType is the resource type of NotificationsIntegrationCommon and its reporter representations.
+const NotificationsIntegrationResourceType = "notifications_integration"
+
+// NotificationsIntegrationCommon is the common representation of a notifications_integration resource.
+type NotificationsIntegrationCommon struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+// ToStruct converts the representation to the Struct sent in ResourceRepresentations.
+func (r NotificationsIntegrationCommon) ToStruct() (*structpb.Struct, error) {
+	return toStruct(r)
+}
+
+// NotificationsIntegrationNotificationsReporter is the notifications reporter representation of a notifications_integration resource.
+type NotificationsIntegrationNotificationsReporter struct {
+	// A string representing the local identifier of the resource.
+	LocalResourceID string `json:"local_resource_id"`
+	// A unique identifier for the reporter instance, such as a service account.
+	ReporterInstanceID string `json:"reporter_instance_id"`
+	// The type of reporter, fixed to 'NOTIFICATIONS' for this schema.
+	ReporterType NotificationsIntegrationNotificationsReporterReporterType `json:"reporter_type"`
+}
+
+// ToStruct converts the representation to the Struct sent in ResourceRepresentations.
+func (r NotificationsIntegrationNotificationsReporter) ToStruct() (*structpb.Struct, error) {
+	return toStruct(r)
+}
+
+// NotificationsIntegrationNotificationsReporterReporterType enumerates the values allowed by the schema.
+type NotificationsIntegrationNotificationsReporterReporterType string
+
+const (
+	NotificationsIntegrationNotificationsReporterReporterTypeNotifications NotificationsIntegrationNotificationsReporterReporterType = "NOTIFICATIONS"
+)
+
+// NewNotificationsIntegrationNotificationsRepresentations builds the representations of a notifications_integration resource reported by notifications.
+func NewNotificationsIntegrationNotificationsRepresentations(metadata *v1beta2.RepresentationMetadata, common NotificationsIntegrationCommon, reporter NotificationsIntegrationNotificationsReporter) (*v1beta2.ResourceRepresentations, error) {
+	return newResourceRepresentations(metadata, common, reporter)
+}
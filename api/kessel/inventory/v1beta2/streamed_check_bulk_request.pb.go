@@ -0,0 +1,142 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: kessel/inventory/v1beta2/streamed_check_bulk_request.proto
+
+package v1beta2
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// StreamedCheckBulkRequest carries a batch of permission checks on a
+// StreamedCheckBulk stream. Clients may send any number of these messages;
+// consistency is only read from the first message of the stream.
+type StreamedCheckBulkRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Items         []*CheckBulkRequestItem `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Consistency   *Consistency            `protobuf:"bytes,2,opt,name=consistency,proto3" json:"consistency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamedCheckBulkRequest) Reset() {
+	*x = StreamedCheckBulkRequest{}
+	mi := &file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamedCheckBulkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamedCheckBulkRequest) ProtoMessage() {}
+
+func (x *StreamedCheckBulkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamedCheckBulkRequest.ProtoReflect.Descriptor instead.
+func (*StreamedCheckBulkRequest) Descriptor() ([]byte, []int) {
+	return file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *StreamedCheckBulkRequest) GetItems() []*CheckBulkRequestItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *StreamedCheckBulkRequest) GetConsistency() *Consistency {
+	if x != nil {
+		return x.Consistency
+	}
+	return nil
+}
+
+var File_kessel_inventory_v1beta2_streamed_check_bulk_request_proto protoreflect.FileDescriptor
+
+const file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDesc = "" +
+	"\n" +
+	":kessel/inventory/v1beta2/streamed_check_bulk_request.proto\x12\x18kessel.inventory.v1beta2\x1a\x1bbuf/validate/validate.proto\x1a1kessel/inventory/v1beta2/check_bulk_request.proto\x1a*kessel/inventory/v1beta2/consistency.proto\"\xb6\x01\n" +
+	"\x18StreamedCheckBulkRequest\x12Q\n" +
+	"\x05items\x18\x01 \x03(\v2..kessel.inventory.v1beta2.CheckBulkRequestItemB\v\xbaH\b\x92\x01\x05\b\x01\x10\xe8\aR\x05items\x12G\n" +
+	"\vconsistency\x18\x02 \x01(\v2%.kessel.inventory.v1beta2.ConsistencyR\vconsistencyBr\n" +
+	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
+
+var (
+	file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescOnce sync.Once
+	file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescData []byte
+)
+
+func file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescGZIP() []byte {
+	file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescOnce.Do(func() {
+		file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDesc), len(file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDesc)))
+	})
+	return file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDescData
+}
+
+var file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_goTypes = []any{
+	(*StreamedCheckBulkRequest)(nil), // 0: kessel.inventory.v1beta2.StreamedCheckBulkRequest
+	(*CheckBulkRequestItem)(nil),     // 1: kessel.inventory.v1beta2.CheckBulkRequestItem
+	(*Consistency)(nil),              // 2: kessel.inventory.v1beta2.Consistency
+}
+var file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_depIdxs = []int32{
+	1, // 0: kessel.inventory.v1beta2.StreamedCheckBulkRequest.items:type_name -> kessel.inventory.v1beta2.CheckBulkRequestItem
+	2, // 1: kessel.inventory.v1beta2.StreamedCheckBulkRequest.consistency:type_name -> kessel.inventory.v1beta2.Consistency
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_init() }
+func file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_init() {
+	if File_kessel_inventory_v1beta2_streamed_check_bulk_request_proto != nil {
+		return
+	}
+	file_kessel_inventory_v1beta2_check_bulk_request_proto_init()
+	file_kessel_inventory_v1beta2_consistency_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDesc), len(file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_goTypes,
+		DependencyIndexes: file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_depIdxs,
+		MessageInfos:      file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_msgTypes,
+	}.Build()
+	File_kessel_inventory_v1beta2_streamed_check_bulk_request_proto = out.File
+	file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_goTypes = nil
+	file_kessel_inventory_v1beta2_streamed_check_bulk_request_proto_depIdxs = nil
+}
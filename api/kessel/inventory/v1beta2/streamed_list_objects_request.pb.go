@@ -23,12 +23,16 @@ const (
 )
 
 type StreamedListObjectsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ObjectType    *RepresentationType    `protobuf:"bytes,1,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
-	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
-	Subject       *SubjectReference      `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
-	Pagination    *RequestPagination     `protobuf:"bytes,4,opt,name=pagination,proto3,oneof" json:"pagination,omitempty"`
-	Consistency   *Consistency           `protobuf:"bytes,5,opt,name=consistency,proto3,oneof" json:"consistency,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ObjectType  *RepresentationType    `protobuf:"bytes,1,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
+	Relation    string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject     *SubjectReference      `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Pagination  *RequestPagination     `protobuf:"bytes,4,opt,name=pagination,proto3,oneof" json:"pagination,omitempty"`
+	Consistency *Consistency           `protobuf:"bytes,5,opt,name=consistency,proto3,oneof" json:"consistency,omitempty"`
+	// When set, objects are joined with inventory's stored resources and may be
+	// filtered by their common representation. Filtering happens after the
+	// relations backend pages results, so a page may hold fewer objects than its limit.
+	Inventory     *InventoryEnrichment `protobuf:"bytes,6,opt,name=inventory,proto3,oneof" json:"inventory,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -98,11 +102,18 @@ func (x *StreamedListObjectsRequest) GetConsistency() *Consistency {
 	return nil
 }
 
+func (x *StreamedListObjectsRequest) GetInventory() *InventoryEnrichment {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
 var File_kessel_inventory_v1beta2_streamed_list_objects_request_proto protoreflect.FileDescriptor
 
 const file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_rawDesc = "" +
 	"\n" +
-	"<kessel/inventory/v1beta2/streamed_list_objects_request.proto\x12\x18kessel.inventory.v1beta2\x1a\x1bbuf/validate/validate.proto\x1a1kessel/inventory/v1beta2/request_pagination.proto\x1a0kessel/inventory/v1beta2/subject_reference.proto\x1a*kessel/inventory/v1beta2/consistency.proto\x1a2kessel/inventory/v1beta2/representation_type.proto\"\xa5\x03\n" +
+	"<kessel/inventory/v1beta2/streamed_list_objects_request.proto\x12\x18kessel.inventory.v1beta2\x1a\x1bbuf/validate/validate.proto\x1a1kessel/inventory/v1beta2/request_pagination.proto\x1a0kessel/inventory/v1beta2/subject_reference.proto\x1a*kessel/inventory/v1beta2/consistency.proto\x1a2kessel/inventory/v1beta2/representation_type.proto\x1a3kessel/inventory/v1beta2/inventory_enrichment.proto\"\x85\x04\n" +
 	"\x1aStreamedListObjectsRequest\x12U\n" +
 	"\vobject_type\x18\x01 \x01(\v2,.kessel.inventory.v1beta2.RepresentationTypeB\x06\xbaH\x03\xc8\x01\x01R\n" +
 	"objectType\x12#\n" +
@@ -111,9 +122,12 @@ const file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_rawDesc
 	"\n" +
 	"pagination\x18\x04 \x01(\v2+.kessel.inventory.v1beta2.RequestPaginationH\x00R\n" +
 	"pagination\x88\x01\x01\x12L\n" +
-	"\vconsistency\x18\x05 \x01(\v2%.kessel.inventory.v1beta2.ConsistencyH\x01R\vconsistency\x88\x01\x01B\r\n" +
+	"\vconsistency\x18\x05 \x01(\v2%.kessel.inventory.v1beta2.ConsistencyH\x01R\vconsistency\x88\x01\x01\x12P\n" +
+	"\tinventory\x18\x06 \x01(\v2-.kessel.inventory.v1beta2.InventoryEnrichmentH\x02R\tinventory\x88\x01\x01B\r\n" +
 	"\v_paginationB\x0e\n" +
-	"\f_consistencyBr\n" +
+	"\f_consistencyB\f\n" +
+	"\n" +
+	"_inventoryBr\n" +
 	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
 
 var (
@@ -135,17 +149,19 @@ var file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_goTypes =
 	(*SubjectReference)(nil),           // 2: kessel.inventory.v1beta2.SubjectReference
 	(*RequestPagination)(nil),          // 3: kessel.inventory.v1beta2.RequestPagination
 	(*Consistency)(nil),                // 4: kessel.inventory.v1beta2.Consistency
+	(*InventoryEnrichment)(nil),        // 5: kessel.inventory.v1beta2.InventoryEnrichment
 }
 var file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_depIdxs = []int32{
 	1, // 0: kessel.inventory.v1beta2.StreamedListObjectsRequest.object_type:type_name -> kessel.inventory.v1beta2.RepresentationType
 	2, // 1: kessel.inventory.v1beta2.StreamedListObjectsRequest.subject:type_name -> kessel.inventory.v1beta2.SubjectReference
 	3, // 2: kessel.inventory.v1beta2.StreamedListObjectsRequest.pagination:type_name -> kessel.inventory.v1beta2.RequestPagination
 	4, // 3: kessel.inventory.v1beta2.StreamedListObjectsRequest.consistency:type_name -> kessel.inventory.v1beta2.Consistency
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 4: kessel.inventory.v1beta2.StreamedListObjectsRequest.inventory:type_name -> kessel.inventory.v1beta2.InventoryEnrichment
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_init() }
@@ -157,6 +173,7 @@ func file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_init() {
 	file_kessel_inventory_v1beta2_subject_reference_proto_init()
 	file_kessel_inventory_v1beta2_consistency_proto_init()
 	file_kessel_inventory_v1beta2_representation_type_proto_init()
+	file_kessel_inventory_v1beta2_inventory_enrichment_proto_init()
 	file_kessel_inventory_v1beta2_streamed_list_objects_request_proto_msgTypes[0].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -26,8 +26,10 @@ type StreamedListObjectsResponse struct {
 	Object           *ResourceReference     `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
 	Pagination       *ResponsePagination    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
 	ConsistencyToken *ConsistencyToken      `protobuf:"bytes,3,opt,name=consistency_token,json=consistencyToken,proto3" json:"consistency_token,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// Set when the request asked for inventory enrichment and inventory knows the object.
+	Inventory     *InventoryResourceData `protobuf:"bytes,4,opt,name=inventory,proto3" json:"inventory,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StreamedListObjectsResponse) Reset() {
@@ -81,17 +83,25 @@ func (x *StreamedListObjectsResponse) GetConsistencyToken() *ConsistencyToken {
 	return nil
 }
 
+func (x *StreamedListObjectsResponse) GetInventory() *InventoryResourceData {
+	if x != nil {
+		return x.Inventory
+	}
+	return nil
+}
+
 var File_kessel_inventory_v1beta2_streamed_list_objects_response_proto protoreflect.FileDescriptor
 
 const file_kessel_inventory_v1beta2_streamed_list_objects_response_proto_rawDesc = "" +
 	"\n" +
-	"=kessel/inventory/v1beta2/streamed_list_objects_response.proto\x12\x18kessel.inventory.v1beta2\x1a1kessel/inventory/v1beta2/resource_reference.proto\x1a2kessel/inventory/v1beta2/response_pagination.proto\x1a0kessel/inventory/v1beta2/consistency_token.proto\"\x89\x02\n" +
+	"=kessel/inventory/v1beta2/streamed_list_objects_response.proto\x12\x18kessel.inventory.v1beta2\x1a1kessel/inventory/v1beta2/resource_reference.proto\x1a2kessel/inventory/v1beta2/response_pagination.proto\x1a0kessel/inventory/v1beta2/consistency_token.proto\x1a6kessel/inventory/v1beta2/inventory_resource_data.proto\"\xd8\x02\n" +
 	"\x1bStreamedListObjectsResponse\x12C\n" +
 	"\x06object\x18\x01 \x01(\v2+.kessel.inventory.v1beta2.ResourceReferenceR\x06object\x12L\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2,.kessel.inventory.v1beta2.ResponsePaginationR\n" +
 	"pagination\x12W\n" +
-	"\x11consistency_token\x18\x03 \x01(\v2*.kessel.inventory.v1beta2.ConsistencyTokenR\x10consistencyTokenBr\n" +
+	"\x11consistency_token\x18\x03 \x01(\v2*.kessel.inventory.v1beta2.ConsistencyTokenR\x10consistencyToken\x12M\n" +
+	"\tinventory\x18\x04 \x01(\v2/.kessel.inventory.v1beta2.InventoryResourceDataR\tinventoryBr\n" +
 	"(org.project_kessel.api.inventory.v1beta2P\x01ZDgithub.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2b\x06proto3"
 
 var (
@@ -112,16 +122,18 @@ var file_kessel_inventory_v1beta2_streamed_list_objects_response_proto_goTypes =
 	(*ResourceReference)(nil),           // 1: kessel.inventory.v1beta2.ResourceReference
 	(*ResponsePagination)(nil),          // 2: kessel.inventory.v1beta2.ResponsePagination
 	(*ConsistencyToken)(nil),            // 3: kessel.inventory.v1beta2.ConsistencyToken
+	(*InventoryResourceData)(nil),       // 4: kessel.inventory.v1beta2.InventoryResourceData
 }
 var file_kessel_inventory_v1beta2_streamed_list_objects_response_proto_depIdxs = []int32{
 	1, // 0: kessel.inventory.v1beta2.StreamedListObjectsResponse.object:type_name -> kessel.inventory.v1beta2.ResourceReference
 	2, // 1: kessel.inventory.v1beta2.StreamedListObjectsResponse.pagination:type_name -> kessel.inventory.v1beta2.ResponsePagination
 	3, // 2: kessel.inventory.v1beta2.StreamedListObjectsResponse.consistency_token:type_name -> kessel.inventory.v1beta2.ConsistencyToken
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4, // 3: kessel.inventory.v1beta2.StreamedListObjectsResponse.inventory:type_name -> kessel.inventory.v1beta2.InventoryResourceData
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_kessel_inventory_v1beta2_streamed_list_objects_response_proto_init() }
@@ -132,6 +144,7 @@ func file_kessel_inventory_v1beta2_streamed_list_objects_response_proto_init() {
 	file_kessel_inventory_v1beta2_resource_reference_proto_init()
 	file_kessel_inventory_v1beta2_response_pagination_proto_init()
 	file_kessel_inventory_v1beta2_consistency_token_proto_init()
+	file_kessel_inventory_v1beta2_inventory_resource_data_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
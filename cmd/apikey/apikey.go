@@ -0,0 +1,182 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/spf13/cobra"
+
+	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+	"github.com/project-kessel/inventory-api/internal/data"
+	"github.com/project-kessel/inventory-api/internal/errors"
+	"github.com/project-kessel/inventory-api/internal/storage"
+)
+
+// NewCommand creates the apikey command, which manages the api keys accepted by the api-key
+// authenticator's database store.
+func NewCommand(options *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "Manage api keys",
+		Long:  "Create, revoke and list the api keys accepted by the api-key authenticator with the database store",
+	}
+
+	cmd.AddCommand(
+		newCreateCommand(options, loggerOptions),
+		newRevokeCommand(options, loggerOptions),
+		newListCommand(options, loggerOptions),
+	)
+	return cmd
+}
+
+func newCreateCommand(options *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	var subjectId, clientId, orgId string
+	var configOnly bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create an api key",
+		Long:  "Create an api key and print it. The key is shown only once; only its hash is stored.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if configOnly {
+				return printConfigKey(cmd.OutOrStdout(), subjectId, clientId, orgId)
+			}
+			store, err := openStore(options, loggerOptions)
+			if err != nil {
+				return err
+			}
+			return createKey(cmd.Context(), store, cmd.OutOrStdout(), subjectId, clientId, orgId)
+		},
+	}
+
+	cmd.Flags().StringVar(&subjectId, "subject-id", "", "the subject id the key authenticates as")
+	cmd.Flags().StringVar(&clientId, "client-id", "", "the client id the key authenticates as, e.g. for allowlists")
+	cmd.Flags().StringVar(&orgId, "org-id", "", "the organization id the key authenticates as")
+	cmd.Flags().BoolVar(&configOnly, "config-only", false, "print a keys entry for the config store instead of storing the key in the database")
+	_ = cmd.MarkFlagRequired("subject-id")
+
+	return cmd
+}
+
+func newRevokeCommand(options *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <id>",
+		Short: "Revoke an api key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore(options, loggerOptions)
+			if err != nil {
+				return err
+			}
+			return revokeKey(cmd.Context(), store, cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+func newListCommand(options *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List api keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openStore(options, loggerOptions)
+			if err != nil {
+				return err
+			}
+			return listKeys(cmd.Context(), store, cmd.OutOrStdout())
+		},
+	}
+}
+
+func openStore(options *storage.Options, loggerOptions common.LoggerOptions) (apikey.AdminStore, error) {
+	_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+	logHelper := log.NewHelper(log.With(logger, "group", "storage"))
+
+	if errs := options.Complete(); errs != nil {
+		return nil, errors.NewAggregate(errs)
+	}
+	if errs := options.Validate(); errs != nil {
+		return nil, errors.NewAggregate(errs)
+	}
+
+	db, err := storage.New(storage.NewConfig(options).Complete(), logHelper)
+	if err != nil {
+		return nil, err
+	}
+	return data.NewAPIKeyRepository(db), nil
+}
+
+func newKey(subjectId, clientId, orgId string) (apikey.Key, string, error) {
+	id, rawKey, err := apikey.Generate()
+	if err != nil {
+		return apikey.Key{}, "", err
+	}
+	hash, err := apikey.Hash(rawKey)
+	if err != nil {
+		return apikey.Key{}, "", err
+	}
+	return apikey.Key{
+		Id:        id,
+		Hash:      hash,
+		SubjectId: subjectId,
+		ClientId:  clientId,
+		OrgId:     orgId,
+		CreatedAt: time.Now(),
+	}, rawKey, nil
+}
+
+func createKey(ctx context.Context, store apikey.AdminStore, out io.Writer, subjectId, clientId, orgId string) error {
+	key, rawKey, err := newKey(subjectId, clientId, orgId)
+	if err != nil {
+		return err
+	}
+	if err := store.Create(ctx, key); err != nil {
+		return fmt.Errorf("failed to store api key: %w", err)
+	}
+	_, err = fmt.Fprintf(out, "Created api key %s for subject %s. Store it now, it can't be shown again:\n%s\n", key.Id, key.SubjectId, rawKey)
+	return err
+}
+
+func printConfigKey(out io.Writer, subjectId, clientId, orgId string) error {
+	key, rawKey, err := newKey(subjectId, clientId, orgId)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "api key (store it now, it can't be shown again):\n%s\n\nkeys entry:\n- id: %q\n  hash: %q\n  subject-id: %q\n  client-id: %q\n  org-id: %q\n",
+		rawKey, key.Id, key.Hash, key.SubjectId, key.ClientId, key.OrgId)
+	return err
+}
+
+func revokeKey(ctx context.Context, store apikey.AdminStore, out io.Writer, id string) error {
+	if err := store.Revoke(ctx, id, time.Now()); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "Revoked api key %s\n", id)
+	return err
+}
+
+func listKeys(ctx context.Context, store apikey.AdminStore, out io.Writer) error {
+	keys, err := store.List(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tSUBJECT\tCLIENT\tORG\tCREATED\tLAST USED\tREVOKED")
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			key.Id, key.SubjectId, key.ClientId, key.OrgId,
+			key.CreatedAt.UTC().Format(time.RFC3339), formatTime(key.LastUsedAt), formatTime(key.RevokedAt))
+	}
+	return w.Flush()
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
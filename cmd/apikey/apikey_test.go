@@ -0,0 +1,73 @@
+package apikey
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+	"github.com/project-kessel/inventory-api/internal/data"
+	"github.com/project-kessel/inventory-api/internal/testutil"
+)
+
+func setupStore(t *testing.T) apikey.AdminStore {
+	db := testutil.NewSQLiteTestDB(t, &gorm.Config{TranslateError: true})
+	require.NoError(t, data.Migrate(db, nil))
+	return data.NewAPIKeyRepository(db)
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand(nil, common.LoggerOptions{})
+
+	assert.Equal(t, "apikey", cmd.Use)
+	var names []string
+	for _, c := range cmd.Commands() {
+		names = append(names, c.Name())
+	}
+	assert.ElementsMatch(t, []string{"create", "revoke", "list"}, names)
+}
+
+func TestCreateRevokeList(t *testing.T) {
+	ctx := context.Background()
+	store := setupStore(t)
+
+	var out bytes.Buffer
+	require.NoError(t, createKey(ctx, store, &out, "ci-bot", "ci", "12345"))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	rawKey := lines[len(lines)-1]
+	id, ok := apikey.Parse(rawKey)
+	require.True(t, ok)
+
+	key, err := store.Find(ctx, id)
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.NotContains(t, key.Hash, rawKey)
+	matched, err := apikey.VerifyHash(key.Hash, rawKey)
+	require.NoError(t, err)
+	assert.True(t, matched)
+
+	out.Reset()
+	require.NoError(t, revokeKey(ctx, store, &out, id))
+	assert.Contains(t, out.String(), "Revoked api key "+id)
+	assert.Error(t, revokeKey(ctx, store, &out, id))
+
+	out.Reset()
+	require.NoError(t, listKeys(ctx, store, &out))
+	assert.Contains(t, out.String(), "ID")
+	assert.Contains(t, out.String(), id)
+	assert.Contains(t, out.String(), "ci-bot")
+}
+
+func TestPrintConfigKey(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printConfigKey(&out, "ci-bot", "ci", ""))
+
+	assert.Contains(t, out.String(), "hash: \"$argon2id$")
+	assert.Contains(t, out.String(), "subject-id: \"ci-bot\"")
+}
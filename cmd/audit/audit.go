@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/spf13/cobra"
+
+	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal/audit"
+	"github.com/project-kessel/inventory-api/internal/data"
+	"github.com/project-kessel/inventory-api/internal/errors"
+	"github.com/project-kessel/inventory-api/internal/storage"
+)
+
+// NewCommand creates the audit command, which inspects the entries written by the audit sinks.
+func NewCommand(options *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Inspect the audit log",
+	}
+
+	cmd.AddCommand(newVerifyCommand(options, loggerOptions))
+	return cmd
+}
+
+func newVerifyCommand(options *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	var file, hmacKeyFile string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify the hash chains of the audit log",
+		Long: "Verify that no audit entry was changed, dropped or reordered. With --file, the audit file of the file sink " +
+			"and its rotated files are verified; otherwise the audit_events table of the postgres sink is. Chains are " +
+			"checked against the checkpoints the sink keeps, so that entries dropped from their start or end are detected. " +
+			"--hmac-key-file names the key the server chained the entries with.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var key []byte
+			if hmacKeyFile != "" {
+				var err error
+				if key, err = audit.ReadHMACKey(hmacKeyFile); err != nil {
+					return fmt.Errorf("--hmac-key-file: %w", err)
+				}
+			}
+			if file != "" {
+				return verifyFiles(key, file, cmd.OutOrStdout())
+			}
+			store, err := openStore(options, loggerOptions)
+			if err != nil {
+				return err
+			}
+			return verifyStore(cmd.Context(), key, store, cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", "path of the audit file written by the file sink")
+	cmd.Flags().StringVar(&hmacKeyFile, "hmac-key-file", "", "path of the key of the HMAC chaining the audit entries (audit.hmac-key-file of the server)")
+	return cmd
+}
+
+func openStore(options *storage.Options, loggerOptions common.LoggerOptions) (audit.Store, error) {
+	_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+	logHelper := log.NewHelper(log.With(logger, "group", "storage"))
+
+	if errs := options.Complete(); errs != nil {
+		return nil, errors.NewAggregate(errs)
+	}
+	if errs := options.Validate(); errs != nil {
+		return nil, errors.NewAggregate(errs)
+	}
+
+	db, err := storage.New(storage.NewConfig(options).Complete(), logHelper)
+	if err != nil {
+		return nil, err
+	}
+	return data.NewAuditEventRepository(db), nil
+}
+
+// verifyFiles verifies the rotated files of path, oldest first, and then path itself.
+func verifyFiles(key []byte, path string, out io.Writer) error {
+	files, err := audit.RotatedFiles(path)
+	if err != nil {
+		return err
+	}
+	files = append(files, path)
+	checkpoints, err := audit.ReadFileCheckpoints(path)
+	if err != nil {
+		return err
+	}
+
+	verifier := audit.NewVerifier(key)
+	for _, checkpoint := range checkpoints {
+		verifier.Anchor(checkpoint)
+	}
+	total := 0
+	for _, name := range files {
+		count, err := verifyFile(verifier, name)
+		total += count
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if err := verifier.Finish(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return printVerified(out, total, verifier.Chains())
+}
+
+func verifyFile(verifier *audit.Verifier, name string) (int, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	return verifier.AddJSONLines(f)
+}
+
+func verifyStore(ctx context.Context, key []byte, store audit.Store, out io.Writer) error {
+	verifier := audit.NewVerifier(key)
+	if checkpointer, ok := store.(audit.Checkpointer); ok {
+		checkpoints, err := checkpointer.Checkpoints(ctx)
+		if err != nil {
+			return err
+		}
+		for _, checkpoint := range checkpoints {
+			verifier.Anchor(checkpoint)
+		}
+	}
+	total := 0
+	err := store.Each(ctx, func(entry audit.Entry) error {
+		if err := verifier.Add(entry); err != nil {
+			return err
+		}
+		total++
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := verifier.Finish(); err != nil {
+		return err
+	}
+	return printVerified(out, total, verifier.Chains())
+}
+
+func printVerified(out io.Writer, entries, chains int) error {
+	_, err := fmt.Fprintf(out, "Verified %d audit entries in %d chains\n", entries, chains)
+	return err
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal/audit"
+	"github.com/project-kessel/inventory-api/internal/data"
+	"github.com/project-kessel/inventory-api/internal/testutil"
+)
+
+func recordEvents(t *testing.T, sink audit.Sink, n int) {
+	t.Helper()
+	auditor := audit.NewAuditor([]audit.Sink{sink}, log.DefaultLogger)
+	for i := 0; i < n; i++ {
+		auditor.Record(context.Background(), audit.Event{
+			Message:   "Tuples created",
+			Action:    "CREATE",
+			Principal: "rbac",
+			Resource:  audit.Resource{ResourceType: "tuple", ResourceId: "batch_1_tuples"},
+			Outcome:   audit.OutcomeSuccess,
+		})
+	}
+	require.NoError(t, auditor.Close())
+}
+
+func TestNewCommand(t *testing.T) {
+	cmd := NewCommand(nil, common.LoggerOptions{})
+
+	assert.Equal(t, "audit", cmd.Use)
+	require.Len(t, cmd.Commands(), 1)
+	assert.Equal(t, "verify", cmd.Commands()[0].Name())
+}
+
+func TestVerifyFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := audit.NewFileSink(path, 600, 0) // rotates after every couple of entries
+	require.NoError(t, err)
+	recordEvents(t, sink, 5)
+
+	backups, err := audit.RotatedFiles(path)
+	require.NoError(t, err)
+	require.NotEmpty(t, backups)
+
+	var out bytes.Buffer
+	require.NoError(t, verifyFiles(nil, path, &out))
+	assert.Equal(t, "Verified 5 audit entries in 1 chains\n", out.String())
+
+	// Dropping the newest entries leaves the chain short of its checkpoint.
+	lines, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	err = verifyFiles(nil, path, &out)
+	assert.ErrorIs(t, err, audit.ErrChainBroken)
+	assert.ErrorContains(t, err, "expected last entry 5")
+	require.NoError(t, os.WriteFile(path, lines, 0o600))
+
+	// Dropping the oldest rotated file cuts off the start of the chain.
+	oldest, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(backups[0]))
+	err = verifyFiles(nil, path, &out)
+	assert.ErrorIs(t, err, audit.ErrChainBroken)
+	assert.ErrorContains(t, err, "expected first entry 1")
+	require.NoError(t, os.WriteFile(backups[0], oldest, 0o600))
+
+	// Dropping a rotated file leaves a gap in the chain.
+	require.NoError(t, os.Remove(backups[len(backups)-1]))
+	err = verifyFiles(nil, path, &out)
+	assert.ErrorIs(t, err, audit.ErrChainBroken)
+	assert.True(t, strings.HasPrefix(err.Error(), path+": "), err.Error())
+}
+
+func TestVerifyStore(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &gorm.Config{TranslateError: true})
+	require.NoError(t, data.Migrate(db, nil))
+	store := data.NewAuditEventRepository(db)
+	recordEvents(t, store, 2)
+	recordEvents(t, store, 3)
+
+	var out bytes.Buffer
+	require.NoError(t, verifyStore(context.Background(), nil, store, &out))
+	assert.Equal(t, "Verified 5 audit entries in 2 chains\n", out.String())
+
+	// Deleting the first or last entry of a chain is caught by its checkpoint.
+	require.NoError(t, db.Exec("DELETE FROM audit_events WHERE seq = 3").Error)
+	err := verifyStore(context.Background(), nil, store, &out)
+	assert.ErrorIs(t, err, audit.ErrChainBroken)
+	assert.ErrorContains(t, err, "expected last entry 3, got 2")
+	require.NoError(t, db.Exec("DELETE FROM audit_events WHERE seq = 1").Error)
+	err = verifyStore(context.Background(), nil, store, &out)
+	assert.ErrorIs(t, err, audit.ErrChainBroken)
+	assert.ErrorContains(t, err, "expected first entry 1, got 2")
+}
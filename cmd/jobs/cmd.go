@@ -2,6 +2,7 @@ package jobs
 
 import (
 	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal/config/schema"
 	"github.com/project-kessel/inventory-api/internal/storage"
 	"github.com/spf13/cobra"
 )
@@ -9,7 +10,7 @@ import (
 // To add a job, add a file in this directory named after the job
 // Then create a cobra.Command and function that it will run
 // Finally, add the command to the run-job cobra.Command
-func NewRunJobCommand(storageOptions *storage.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+func NewRunJobCommand(storageOptions *storage.Options, schemaOptions *schema.Options, loggerOptions common.LoggerOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "run-job",
 		Short: "Run an inventory service job",
@@ -19,6 +20,7 @@ func NewRunJobCommand(storageOptions *storage.Options, loggerOptions common.Logg
 	cmds := []*cobra.Command{
 		NewResourceDeleteJobCommand(storageOptions, loggerOptions),
 		NewMetricsCollectJobCommand(storageOptions, loggerOptions),
+		NewSchemaAuditJobCommand(storageOptions, schemaOptions, loggerOptions),
 	}
 
 	for _, c := range cmds {
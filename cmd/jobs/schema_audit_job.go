@@ -0,0 +1,414 @@
+package jobs
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/google/uuid"
+	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal"
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/schema"
+	"github.com/project-kessel/inventory-api/internal/data"
+	"github.com/project-kessel/inventory-api/internal/storage"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultAuditSampleSize = 10
+
+	schemaAuditFormatJSON = "json"
+	schemaAuditFormatCSV  = "csv"
+
+	commonRepresentationKind   = "common"
+	reporterRepresentationKind = "reporter"
+)
+
+// schemaAuditOptions holds the filters and limits of a schema audit run.
+type schemaAuditOptions struct {
+	ResourceType string
+	ReporterType string
+	BatchSize    int
+	SampleSize   int
+}
+
+// SchemaAuditReport is the output of the schema audit job: one entry per representation kind,
+// resource type and reporter type found in storage.
+type SchemaAuditReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Entries     []SchemaAuditEntry `json:"entries"`
+}
+
+// SchemaAuditEntry counts the latest representations of one kind and type that were checked,
+// failed validation, or were skipped because no schema is registered for them.
+type SchemaAuditEntry struct {
+	Representation string              `json:"representation"`
+	ResourceType   string              `json:"resource_type"`
+	ReporterType   string              `json:"reporter_type,omitempty"`
+	Checked        int64               `json:"checked"`
+	Failed         int64               `json:"failed"`
+	Skipped        int64               `json:"skipped"`
+	Samples        []SchemaAuditSample `json:"samples,omitempty"`
+}
+
+// SchemaAuditSample identifies a failing representation by its inventory resource ID, with the
+// validation errors located by JSON pointer.
+type SchemaAuditSample struct {
+	ResourceID string   `json:"resource_id"`
+	Errors     []string `json:"errors"`
+}
+
+type schemaAuditKey struct {
+	representation string
+	resourceType   string
+	reporterType   string
+}
+
+func NewSchemaAuditJobCommand(storageOptions *storage.Options, schemaOptions *schema.Options, loggerOptions common.LoggerOptions) *cobra.Command {
+	var opts schemaAuditOptions
+	var schemaDir string
+	var format string
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "schema-audit-job",
+		Short: "Report stored representations that fail current schema validation",
+		Long: `Streams the latest common and reporter representations from the database in batches,
+		validates them against the current schemas and writes a report with counts per type and
+		sample failing resource IDs with their validation errors.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != schemaAuditFormatJSON && format != schemaAuditFormatCSV {
+				return fmt.Errorf("format must be %q or %q, got %q", schemaAuditFormatJSON, schemaAuditFormatCSV, format)
+			}
+			if opts.BatchSize <= 0 {
+				return fmt.Errorf("batch-size must be positive, got %d", opts.BatchSize)
+			}
+
+			out := cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create report file %q: %w", output, err)
+				}
+				defer f.Close() //nolint:errcheck
+				out = f
+			}
+
+			return auditSchemas(cmd.Context(), storageOptions, schemaOptions, loggerOptions, schemaDir, opts, format, out)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.ResourceType, "resource-type", "", "Only audit representations of this resource type (e.g., 'host')")
+	cmd.Flags().StringVar(&opts.ReporterType, "reporter-type", "", "Only audit reporter representations of this reporter type (e.g., 'hbi'); common representations are skipped")
+	cmd.Flags().IntVar(&opts.BatchSize, "batch-size", DefaultBatchSize, "Number of representations to read per batch")
+	cmd.Flags().IntVar(&opts.SampleSize, "sample-size", DefaultAuditSampleSize, "Maximum number of failing resources to include per type")
+	cmd.Flags().StringVar(&schemaDir, "schema-dir", "", "Validate against the schemas in this directory instead of the configured schema repository")
+	cmd.Flags().StringVar(&format, "format", schemaAuditFormatJSON, "The report format: json or csv")
+	cmd.Flags().StringVar(&output, "output", "", "Write the report to this file instead of stdout")
+
+	return cmd
+}
+
+func auditSchemas(ctx context.Context, storageOptions *storage.Options, schemaOptions *schema.Options, loggerOptions common.LoggerOptions, schemaDir string, opts schemaAuditOptions, format string, out io.Writer) error {
+	_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+	logHelper := log.NewHelper(log.With(logger, "job", "schema_audit"))
+
+	var schemaRepository bizmodel.SchemaRepository
+	var err error
+	if schemaDir != "" {
+		logHelper.Infof("Using schemas from directory %q", schemaDir)
+		schemaRepository, err = data.NewInMemorySchemaRepositoryFromDir(ctx, schemaDir, data.FeaturesAwareSchemaFactory)
+	} else {
+		schemaConfig, errs := schema.NewConfig(schemaOptions).Complete()
+		if errs != nil {
+			return fmt.Errorf("failed to setup schema config: %v", errs)
+		}
+		schemaRepository, err = data.NewSchemaRepository(ctx, schemaConfig, logHelper)
+	}
+	if err != nil {
+		return err
+	}
+
+	storageConfig := storage.NewConfig(storageOptions).Complete()
+	db, err := storage.New(storageConfig, logHelper)
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	defer sqlDB.Close() //nolint:errcheck
+
+	report, err := auditSchemasWithDB(ctx, db, schemaRepository, logHelper, opts)
+	if err != nil {
+		return err
+	}
+
+	if format == schemaAuditFormatCSV {
+		return writeSchemaAuditCSV(out, report)
+	}
+	return writeSchemaAuditJSON(out, report)
+}
+
+func auditSchemasWithDB(ctx context.Context, db *gorm.DB, schemaRepository bizmodel.SchemaRepository, logHelper *log.Helper, opts schemaAuditOptions) (SchemaAuditReport, error) {
+	logHelper.Infof("Starting schema audit job with batch size %d", opts.BatchSize)
+
+	auditor := &schemaAuditor{
+		ctx:              ctx,
+		schemaRepository: schemaRepository,
+		sampleSize:       opts.SampleSize,
+		entries:          map[schemaAuditKey]*SchemaAuditEntry{},
+	}
+
+	if opts.ReporterType == "" {
+		if err := auditCommonRepresentations(db, logHelper, auditor, opts); err != nil {
+			return SchemaAuditReport{}, err
+		}
+	}
+	if err := auditReporterRepresentations(db, logHelper, auditor, opts); err != nil {
+		return SchemaAuditReport{}, err
+	}
+
+	report := auditor.report()
+	var checked, failed int64
+	for _, entry := range report.Entries {
+		checked += entry.Checked
+		failed += entry.Failed
+	}
+	logHelper.Infof("Schema audit job completed: %d representations checked, %d failed validation", checked, failed)
+
+	return report, nil
+}
+
+type auditedRepresentation struct {
+	ID           uuid.UUID
+	ResourceID   uuid.UUID
+	ResourceType string
+	ReporterType string
+	Data         internal.JsonObject
+}
+
+// auditCommonRepresentations checks the highest common representation version of every resource,
+// paging through resources by ID.
+func auditCommonRepresentations(db *gorm.DB, logHelper *log.Helper, auditor *schemaAuditor, opts schemaAuditOptions) error {
+	lastID := uuid.Nil
+	for batch := 1; ; batch++ {
+		var rows []auditedRepresentation
+		query := db.Table("resource AS res").
+			Select("res.id AS id, res.id AS resource_id, res.type AS resource_type, cr.data").
+			Joins(`JOIN common_representations AS cr ON cr.resource_id = res.id
+				AND cr.version = (SELECT MAX(cr2.version) FROM common_representations cr2 WHERE cr2.resource_id = res.id)`).
+			Where("res.id > ?", lastID)
+		if opts.ResourceType != "" {
+			query = query.Where("res.type = ?", opts.ResourceType)
+		}
+		if err := query.Order("res.id").Limit(opts.BatchSize).Scan(&rows).Error; err != nil {
+			logHelper.Errorf("Failed to read common representations batch %d: %v", batch, err)
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			auditor.auditCommon(row)
+		}
+		lastID = rows[len(rows)-1].ID
+		logHelper.Infof("Batch %d: audited %d common representations", batch, len(rows))
+	}
+}
+
+// auditReporterRepresentations checks the current representation of every live reporter resource,
+// paging through reporter resources by ID.
+func auditReporterRepresentations(db *gorm.DB, logHelper *log.Helper, auditor *schemaAuditor, opts schemaAuditOptions) error {
+	lastID := uuid.Nil
+	for batch := 1; ; batch++ {
+		var rows []auditedRepresentation
+		query := db.Table("reporter_resources AS rr").
+			Select("rr.id AS id, rr.resource_id, rr.resource_type, rr.reporter_type, rp.data").
+			Joins(`JOIN reporter_representations AS rp ON rp.reporter_resource_id = rr.id
+				AND rp.version = rr.representation_version AND rp.generation = rr.generation`).
+			Where("rr.tombstone = ?", false).
+			Where("rr.id > ?", lastID)
+		if opts.ResourceType != "" {
+			query = query.Where("rr.resource_type = ?", opts.ResourceType)
+		}
+		if opts.ReporterType != "" {
+			query = query.Where("rr.reporter_type = ?", opts.ReporterType)
+		}
+		if err := query.Order("rr.id").Limit(opts.BatchSize).Scan(&rows).Error; err != nil {
+			logHelper.Errorf("Failed to read reporter representations batch %d: %v", batch, err)
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			auditor.auditReporter(row)
+		}
+		lastID = rows[len(rows)-1].ID
+		logHelper.Infof("Batch %d: audited %d reporter representations", batch, len(rows))
+	}
+}
+
+// schemaAuditor validates representations against the current schemas and accumulates the report.
+type schemaAuditor struct {
+	ctx              context.Context
+	schemaRepository bizmodel.SchemaRepository
+	sampleSize       int
+	entries          map[schemaAuditKey]*SchemaAuditEntry
+}
+
+func (a *schemaAuditor) entry(key schemaAuditKey) *SchemaAuditEntry {
+	entry, ok := a.entries[key]
+	if !ok {
+		entry = &SchemaAuditEntry{
+			Representation: key.representation,
+			ResourceType:   key.resourceType,
+			ReporterType:   key.reporterType,
+		}
+		a.entries[key] = entry
+	}
+	return entry
+}
+
+func (a *schemaAuditor) auditCommon(row auditedRepresentation) {
+	entry := a.entry(schemaAuditKey{representation: commonRepresentationKind, resourceType: row.ResourceType})
+
+	resourceSchema, err := a.schemaRepository.GetResourceSchema(a.ctx, bizmodel.ResourceType(row.ResourceType))
+	if err != nil || resourceSchema.Schema() == nil {
+		entry.Skipped++
+		return
+	}
+	a.validate(entry, resourceSchema.Schema(), row)
+}
+
+func (a *schemaAuditor) auditReporter(row auditedRepresentation) {
+	entry := a.entry(schemaAuditKey{representation: reporterRepresentationKind, resourceType: row.ResourceType, reporterType: row.ReporterType})
+
+	reporterSchema, err := a.schemaRepository.GetReporterSchema(a.ctx, bizmodel.ResourceType(row.ResourceType), bizmodel.ReporterType(row.ReporterType))
+	if err != nil || reporterSchema.Schema() == nil {
+		entry.Skipped++
+		return
+	}
+	a.validate(entry, reporterSchema.Schema(), row)
+}
+
+func (a *schemaAuditor) validate(entry *SchemaAuditEntry, s bizmodel.Schema, row auditedRepresentation) {
+	entry.Checked++
+
+	representation := bizmodel.Representation(row.Data)
+	if len(representation) == 0 {
+		representation = bizmodel.NewEmptyRepresentation()
+	}
+	_, err := s.Validate(representation)
+	if err == nil {
+		return
+	}
+
+	entry.Failed++
+	if len(entry.Samples) < a.sampleSize {
+		entry.Samples = append(entry.Samples, SchemaAuditSample{
+			ResourceID: row.ResourceID.String(),
+			Errors:     validationErrorPointers(err),
+		})
+	}
+}
+
+func (a *schemaAuditor) report() SchemaAuditReport {
+	report := SchemaAuditReport{GeneratedAt: time.Now().UTC()}
+	for _, entry := range a.entries {
+		report.Entries = append(report.Entries, *entry)
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		ei, ej := report.Entries[i], report.Entries[j]
+		if ei.Representation != ej.Representation {
+			return ei.Representation < ej.Representation
+		}
+		if ei.ResourceType != ej.ResourceType {
+			return ei.ResourceType < ej.ResourceType
+		}
+		return ei.ReporterType < ej.ReporterType
+	})
+	return report
+}
+
+// validationErrorPointers splits a JSON schema validation error into one message per failing
+// field, each prefixed with the field's location as a JSON pointer fragment (e.g. "#/nodes/0/name").
+func validationErrorPointers(err error) []string {
+	message := strings.TrimPrefix(err.Error(), "validation failed: ")
+	if message == err.Error() {
+		return []string{err.Error()}
+	}
+
+	var result []string
+	for _, part := range strings.Split(message, "; ") {
+		field, description, ok := strings.Cut(part, ": ")
+		if !ok {
+			result = append(result, part)
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s: %s", fieldToJSONPointer(field), description))
+	}
+	return result
+}
+
+// fieldToJSONPointer converts a gojsonschema field path such as "nodes.0.name" or "(root)".
+func fieldToJSONPointer(field string) string {
+	if field == "(root)" || field == "" {
+		return "#"
+	}
+	var b strings.Builder
+	b.WriteString("#")
+	for _, token := range strings.Split(field, ".") {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func writeSchemaAuditJSON(w io.Writer, report SchemaAuditReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write schema audit report: %w", err)
+	}
+	return nil
+}
+
+// writeSchemaAuditCSV writes one row per type, followed by one row per failing sample of that type.
+func writeSchemaAuditCSV(w io.Writer, report SchemaAuditReport) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"representation", "resource_type", "reporter_type", "checked", "failed", "skipped", "sample_resource_id", "sample_errors"}}
+	for _, entry := range report.Entries {
+		counts := []string{
+			entry.Representation, entry.ResourceType, entry.ReporterType,
+			strconv.FormatInt(entry.Checked, 10), strconv.FormatInt(entry.Failed, 10), strconv.FormatInt(entry.Skipped, 10),
+		}
+		rows = append(rows, append(counts, "", ""))
+		for _, sample := range entry.Samples {
+			rows = append(rows, []string{
+				entry.Representation, entry.ResourceType, entry.ReporterType, "", "", "",
+				sample.ResourceID, strings.Join(sample.Errors, "; "),
+			})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write schema audit report: %w", err)
+	}
+	return nil
+}
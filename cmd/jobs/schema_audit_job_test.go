@@ -0,0 +1,164 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/project-kessel/inventory-api/internal"
+	"github.com/project-kessel/inventory-api/internal/data"
+	"github.com/project-kessel/inventory-api/internal/data/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newAuditSchemaRepository(t *testing.T) *data.InMemorySchemaRepository {
+	t.Helper()
+	repository, err := data.NewInMemorySchemaRepositoryFromDir(context.Background(), filepath.Join("..", "..", "data", "schema", "resources"), data.FeaturesAwareSchemaFactory)
+	require.NoError(t, err)
+	return repository
+}
+
+// createAuditedResource stores a resource with an outdated and a latest version of both its common
+// and reporter representations; only the latest versions should be audited.
+func createAuditedResource(t *testing.T, db *gorm.DB, resourceType, reporterType string, common, reporter internal.JsonObject) uuid.UUID {
+	t.Helper()
+
+	resourceID := uuid.New()
+	commonVersion := uint(1)
+	require.NoError(t, db.Create(&model.Resource{ID: resourceID, Type: resourceType, CommonVersion: &commonVersion}).Error)
+
+	for version, commonData := range []internal.JsonObject{{"outdated": true}, common} {
+		require.NoError(t, db.Create(&model.CommonRepresentation{
+			Representation:             model.Representation{Data: commonData},
+			ResourceId:                 resourceID,
+			Version:                    uint(version),
+			ReportedByReporterType:     reporterType,
+			ReportedByReporterInstance: "instance-123",
+		}).Error)
+	}
+
+	reporterResourceID := uuid.New()
+	require.NoError(t, db.Create(&model.ReporterResource{
+		ID: reporterResourceID,
+		ReporterResourceKey: model.ReporterResourceKey{
+			LocalResourceID:    "local-" + resourceID.String(),
+			ReporterType:       reporterType,
+			ResourceType:       resourceType,
+			ReporterInstanceID: "instance-123",
+		},
+		ResourceID:            resourceID,
+		APIHref:               "https://api.example.com/resource/" + resourceID.String(),
+		RepresentationVersion: 1,
+		Generation:            0,
+	}).Error)
+
+	for version, reporterData := range []internal.JsonObject{{"outdated": true}, reporter} {
+		require.NoError(t, db.Create(&model.ReporterRepresentation{
+			Representation:     model.Representation{Data: reporterData},
+			ReporterResourceID: reporterResourceID,
+			Version:            uint(version),
+			Generation:         0,
+		}).Error)
+	}
+
+	return resourceID
+}
+
+func findAuditEntry(t *testing.T, report SchemaAuditReport, representation, resourceType, reporterType string) SchemaAuditEntry {
+	t.Helper()
+	for _, entry := range report.Entries {
+		if entry.Representation == representation && entry.ResourceType == resourceType && entry.ReporterType == reporterType {
+			return entry
+		}
+	}
+	require.Failf(t, "missing audit entry", "%s %s %s", representation, resourceType, reporterType)
+	return SchemaAuditEntry{}
+}
+
+func TestAuditSchemasWithDB(t *testing.T) {
+	db := setupTestDB(t)
+	validHost := internal.JsonObject{"ansible_host": "host-1"}
+
+	createAuditedResource(t, db, "host", "hbi", internal.JsonObject{"workspace_id": "ws-1"}, validHost)
+	missingWorkspace := createAuditedResource(t, db, "host", "hbi", internal.JsonObject{}, validHost)
+	badReporter := createAuditedResource(t, db, "host", "hbi", internal.JsonObject{"workspace_id": "ws-1"}, internal.JsonObject{"ansible_host": 5})
+	createAuditedResource(t, db, "unknown_type", "hbi", internal.JsonObject{}, internal.JsonObject{})
+
+	report, err := auditSchemasWithDB(context.Background(), db, newAuditSchemaRepository(t), testLogger(),
+		schemaAuditOptions{BatchSize: 2, SampleSize: DefaultAuditSampleSize})
+	require.NoError(t, err)
+
+	common := findAuditEntry(t, report, commonRepresentationKind, "host", "")
+	assert.Equal(t, int64(3), common.Checked)
+	assert.Equal(t, int64(1), common.Failed)
+	require.Len(t, common.Samples, 1)
+	assert.Equal(t, missingWorkspace.String(), common.Samples[0].ResourceID)
+	assert.Equal(t, []string{"#: workspace_id is required"}, common.Samples[0].Errors)
+
+	reporter := findAuditEntry(t, report, reporterRepresentationKind, "host", "hbi")
+	assert.Equal(t, int64(3), reporter.Checked)
+	assert.Equal(t, int64(1), reporter.Failed)
+	require.Len(t, reporter.Samples, 1)
+	assert.Equal(t, badReporter.String(), reporter.Samples[0].ResourceID)
+	assert.Contains(t, reporter.Samples[0].Errors, "#/ansible_host: Invalid type. Expected: string, given: integer")
+
+	unknown := findAuditEntry(t, report, commonRepresentationKind, "unknown_type", "")
+	assert.Equal(t, int64(0), unknown.Checked)
+	assert.Equal(t, int64(1), unknown.Skipped)
+}
+
+func TestAuditSchemasWithDB_Filters(t *testing.T) {
+	db := setupTestDB(t)
+	createAuditedResource(t, db, "host", "hbi", internal.JsonObject{}, internal.JsonObject{"ansible_host": 5})
+	createAuditedResource(t, db, "k8s_cluster", "acm", internal.JsonObject{}, internal.JsonObject{})
+
+	report, err := auditSchemasWithDB(context.Background(), db, newAuditSchemaRepository(t), testLogger(),
+		schemaAuditOptions{ResourceType: "host", ReporterType: "hbi", BatchSize: 10, SampleSize: 0})
+	require.NoError(t, err)
+
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, reporterRepresentationKind, report.Entries[0].Representation)
+	assert.Equal(t, int64(1), report.Entries[0].Failed)
+	assert.Empty(t, report.Entries[0].Samples)
+}
+
+func TestValidationErrorPointers(t *testing.T) {
+	assert.Equal(t,
+		[]string{"#: workspace_id is required", "#/nodes/0/name: Invalid type", "#/a~1b: bad"},
+		validationErrorPointers(errors.New("validation failed: (root): workspace_id is required; nodes.0.name: Invalid type; a/b: bad")))
+	assert.Equal(t, []string{"validation error: boom"}, validationErrorPointers(errors.New("validation error: boom")))
+}
+
+func TestWriteSchemaAuditReport(t *testing.T) {
+	report := SchemaAuditReport{Entries: []SchemaAuditEntry{{
+		Representation: reporterRepresentationKind,
+		ResourceType:   "host",
+		ReporterType:   "hbi",
+		Checked:        2,
+		Failed:         1,
+		Samples:        []SchemaAuditSample{{ResourceID: "id-1", Errors: []string{"#/a: x", "#/b: y"}}},
+	}}}
+
+	var jsonOut bytes.Buffer
+	require.NoError(t, writeSchemaAuditJSON(&jsonOut, report))
+	var decoded SchemaAuditReport
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Equal(t, report.Entries, decoded.Entries)
+
+	var csvOut bytes.Buffer
+	require.NoError(t, writeSchemaAuditCSV(&csvOut, report))
+	rows, err := csv.NewReader(&csvOut).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"representation", "resource_type", "reporter_type", "checked", "failed", "skipped", "sample_resource_id", "sample_errors"},
+		{"reporter", "host", "hbi", "2", "1", "0", "", ""},
+		{"reporter", "host", "hbi", "", "", "", "id-1", "#/a: x; #/b: y"},
+	}, rows)
+}
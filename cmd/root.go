@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/project-kessel/inventory-api/cmd/apikey"
+	"github.com/project-kessel/inventory-api/cmd/audit"
 	"github.com/project-kessel/inventory-api/cmd/common"
 	"github.com/project-kessel/inventory-api/cmd/jobs"
 	"github.com/project-kessel/inventory-api/cmd/migrate"
@@ -99,7 +101,11 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	serveCmd := serve.NewCommand(options.Server, options.Storage, options.Authn, options.Authz, options.Consumer, options.Consistency, options.Service, options.SelfSubjectStrategy, loggerOptions, options.Schema, options.BusinessMetrics, options.MetaAuthorizer)
+	apikeyCmd := apikey.NewCommand(options.Storage, loggerOptions)
+	rootCmd.AddCommand(apikeyCmd)
+	auditCmd := audit.NewCommand(options.Storage, loggerOptions)
+	rootCmd.AddCommand(auditCmd)
+	serveCmd := serve.NewCommand(options.Server, options.Storage, options.Authn, options.Authz, options.Consumer, options.Consistency, options.Service, options.SelfSubjectStrategy, loggerOptions, options.Schema, options.BusinessMetrics, options.MetaAuthorizer, options.Tenancy, options.Audit)
 	rootCmd.AddCommand(serveCmd)
 	err = viper.BindPFlags(serveCmd.Flags())
 	if err != nil {
@@ -111,8 +117,9 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+	rootCmd.AddCommand(schema.NewSchemaCommand(loggerOptions))
 
-	runJobCmd := jobs.NewRunJobCommand(options.Storage, loggerOptions)
+	runJobCmd := jobs.NewRunJobCommand(options.Storage, options.Schema, loggerOptions)
 	rootCmd.AddCommand(runJobCmd)
 	err = viper.BindPFlags(runJobCmd.Flags())
 	if err != nil {
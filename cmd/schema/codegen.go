@@ -0,0 +1,543 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+const (
+	codegenOutDir  = "api/kessel/inventory/v1beta2/representations"
+	codegenPackage = "representations"
+	codegenHeader  = "// Code generated by `inventory-api schema codegen`. DO NOT EDIT.\n\n"
+	codegenAPIPath = "github.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2"
+)
+
+// codegenInitialisms are name parts rendered fully upper-case, following Go naming conventions.
+var codegenInitialisms = map[string]bool{
+	"api": true, "cpu": true, "http": true, "id": true, "ip": true, "url": true, "uuid": true,
+}
+
+type codegenResource struct {
+	ResourceType bizmodel.ResourceType
+	Common       map[string]interface{}
+	Reporters    []codegenReporter
+}
+
+type codegenReporter struct {
+	ReporterType bizmodel.ReporterType
+	Schema       map[string]interface{}
+}
+
+func (r codegenResource) typeName() string {
+	return exportedName(r.ResourceType.String())
+}
+
+func (r codegenResource) commonTypeName() string {
+	return r.typeName() + "Common"
+}
+
+func (r codegenResource) reporterTypeName(reporter codegenReporter) string {
+	return r.typeName() + exportedName(reporter.ReporterType.String()) + "Reporter"
+}
+
+// loadCodegenResources reads every common and reporter representation schema under dir,
+// in a stable order so that generated output does not change between runs.
+func loadCodegenResources(dir string) ([]codegenResource, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema directory %q: %w", dir, err)
+	}
+
+	var result []codegenResource
+	var problems []Problem
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		resourceType, err := bizmodel.NewResourceType(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource type directory %q: %w", entry.Name(), err)
+		}
+		resourcePath := filepath.Join(dir, entry.Name())
+
+		resource := codegenResource{ResourceType: resourceType}
+		document, _, compileProblems := compileJSONSchemaFile(filepath.Join(resourcePath, commonRepresentationFileName))
+		problems = append(problems, compileProblems...)
+		resource.Common = document
+
+		reportersPath := filepath.Join(resourcePath, reportersDirName)
+		reporterEntries, err := os.ReadDir(reportersPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read reporters directory %q: %w", reportersPath, err)
+		}
+		for _, reporterEntry := range reporterEntries {
+			if !reporterEntry.IsDir() {
+				continue
+			}
+			reporterType, err := bizmodel.NewReporterType(reporterEntry.Name())
+			if err != nil {
+				return nil, fmt.Errorf("invalid reporter directory %q: %w", reporterEntry.Name(), err)
+			}
+			schemaPath := filepath.Join(reportersPath, reporterEntry.Name(), fmt.Sprintf("%s.json", entry.Name()))
+			document, _, compileProblems := compileJSONSchemaFile(schemaPath)
+			problems = append(problems, compileProblems...)
+			resource.Reporters = append(resource.Reporters, codegenReporter{ReporterType: reporterType, Schema: document})
+		}
+
+		result = append(result, resource)
+	}
+
+	if len(problems) > 0 {
+		sortProblems(problems)
+		return nil, fmt.Errorf("schemas must be valid before generating code (run `schema validate`):\n%s", formatProblems(problems))
+	}
+	return result, nil
+}
+
+// generateRepresentations renders Go source for the typed representations of every resource
+// under dir, keyed by file name.
+func generateRepresentations(dir string, pkg string) (map[string][]byte, error) {
+	resources, err := loadCodegenResources(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	declared := map[string]bool{}
+	reporterTypes := map[bizmodel.ReporterType]bool{}
+
+	for _, resource := range resources {
+		g := &goGenerator{declared: declared}
+		g.printf("%spackage %s\n\n", codegenHeader, pkg)
+		if len(resource.Reporters) > 0 {
+			g.printf("import (\n\tv1beta2 %q\n\t\"google.golang.org/protobuf/types/known/structpb\"\n)\n\n", codegenAPIPath)
+		} else {
+			g.printf("import \"google.golang.org/protobuf/types/known/structpb\"\n\n")
+		}
+
+		g.printf("// %sResourceType is the resource type of %s and its reporter representations.\n",
+			resource.typeName(), resource.commonTypeName())
+		g.printf("const %sResourceType = %q\n\n", resource.typeName(), resource.ResourceType)
+
+		if err := g.representationType(resource.commonTypeName(),
+			fmt.Sprintf("is the common representation of a %s resource.", resource.ResourceType), resource.Common); err != nil {
+			return nil, err
+		}
+
+		for _, reporter := range resource.Reporters {
+			reporterTypes[reporter.ReporterType] = true
+			name := resource.reporterTypeName(reporter)
+			if err := g.representationType(name,
+				fmt.Sprintf("is the %s reporter representation of a %s resource.", reporter.ReporterType, resource.ResourceType), reporter.Schema); err != nil {
+				return nil, err
+			}
+
+			constructor := fmt.Sprintf("New%s%sRepresentations", resource.typeName(), exportedName(reporter.ReporterType.String()))
+			g.printf("// %s builds the representations of a %s resource reported by %s.\n", constructor, resource.ResourceType, reporter.ReporterType)
+			g.printf("func %s(metadata *v1beta2.RepresentationMetadata, common %s, reporter %s) (*v1beta2.ResourceRepresentations, error) {\n",
+				constructor, resource.commonTypeName(), name)
+			g.printf("\treturn newResourceRepresentations(metadata, common, reporter)\n}\n\n")
+		}
+
+		source, err := g.format()
+		if err != nil {
+			return nil, fmt.Errorf("failed to format generated code for %s: %w", resource.ResourceType, err)
+		}
+		files[resource.ResourceType.String()+".go"] = source
+	}
+
+	source, err := generateRepresentationsSupport(pkg, reporterTypes)
+	if err != nil {
+		return nil, err
+	}
+	files[pkg+".go"] = source
+
+	return files, nil
+}
+
+// generateRepresentationsSupport renders the package documentation, the reporter type constants
+// and the conversion helpers shared by every generated resource file.
+func generateRepresentationsSupport(pkg string, reporterTypes map[bizmodel.ReporterType]bool) ([]byte, error) {
+	sorted := make([]string, 0, len(reporterTypes))
+	for reporterType := range reporterTypes {
+		sorted = append(sorted, reporterType.String())
+	}
+	sort.Strings(sorted)
+
+	g := &goGenerator{}
+	g.printf("%s", codegenHeader)
+	g.printf("// Package %s provides typed common and reporter representations generated from the\n", pkg)
+	g.printf("// resource JSON schemas, with helpers that convert them to the ResourceRepresentations proto.\n")
+	g.printf("package %s\n\n", pkg)
+	g.printf("import (\n\t\"encoding/json\"\n\n\tv1beta2 %q\n\t\"google.golang.org/protobuf/types/known/structpb\"\n)\n\n", codegenAPIPath)
+
+	g.printf("// Reporter types with at least one reporter representation.\nconst (\n")
+	for _, reporterType := range sorted {
+		g.printf("\t%sReporterType = %q\n", exportedName(reporterType), reporterType)
+	}
+	g.printf(")\n\n")
+
+	g.printf(`type structConverter interface {
+	ToStruct() (*structpb.Struct, error)
+}
+
+func newResourceRepresentations(metadata *v1beta2.RepresentationMetadata, common structConverter, reporter structConverter) (*v1beta2.ResourceRepresentations, error) {
+	commonStruct, err := common.ToStruct()
+	if err != nil {
+		return nil, err
+	}
+	reporterStruct, err := reporter.ToStruct()
+	if err != nil {
+		return nil, err
+	}
+	return &v1beta2.ResourceRepresentations{
+		Metadata: metadata,
+		Common:   commonStruct,
+		Reporter: reporterStruct,
+	}, nil
+}
+
+// toStruct converts a representation to a Struct through its JSON encoding, so that the
+// json tags (and omitempty) decide which fields are reported.
+func toStruct(representation interface{}) (*structpb.Struct, error) {
+	data, err := json.Marshal(representation)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(fields)
+}
+`)
+
+	return g.format()
+}
+
+// generateSchemaBundle collects every representation schema into a single JSON Schema document
+// whose definitions use the generated type names. The definitions can also be copied into the
+// components/schemas section of an OpenAPI document.
+func generateSchemaBundle(dir string) ([]byte, error) {
+	resources, err := loadCodegenResources(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := map[string]interface{}{}
+	for _, resource := range resources {
+		definitions[resource.commonTypeName()] = bundleDefinition(resource.Common)
+		for _, reporter := range resource.Reporters {
+			definitions[resource.reporterTypeName(reporter)] = bundleDefinition(reporter.Schema)
+		}
+	}
+
+	bundle, err := json.MarshalIndent(map[string]interface{}{
+		"$schema":     draft07SchemaURI,
+		"definitions": definitions,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema bundle: %w", err)
+	}
+	return append(bundle, '\n'), nil
+}
+
+func bundleDefinition(schema map[string]interface{}) map[string]interface{} {
+	definition := make(map[string]interface{}, len(schema))
+	for k, v := range schema {
+		if k != "$schema" {
+			definition[k] = v
+		}
+	}
+	return definition
+}
+
+// goGenerator accumulates Go declarations, checking that generated type names are unique
+// across the package.
+type goGenerator struct {
+	buf      bytes.Buffer
+	declared map[string]bool
+	pending  []func() error
+}
+
+func (g *goGenerator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *goGenerator) format() ([]byte, error) {
+	return format.Source(g.buf.Bytes())
+}
+
+func (g *goGenerator) declare(name string) error {
+	if g.declared[name] {
+		return fmt.Errorf("generated type name %q is used more than once", name)
+	}
+	g.declared[name] = true
+	return nil
+}
+
+// representationType declares the struct for a top-level representation schema, the types of
+// its nested objects and enums, and its ToStruct method.
+func (g *goGenerator) representationType(name string, doc string, schema map[string]interface{}) error {
+	if err := g.structType(name, fmt.Sprintf("// %s %s", name, doc), schema); err != nil {
+		return err
+	}
+	g.printf("// ToStruct converts the representation to the Struct sent in ResourceRepresentations.\n")
+	g.printf("func (r %s) ToStruct() (*structpb.Struct, error) {\n\treturn toStruct(r)\n}\n\n", name)
+	return g.flush()
+}
+
+// flush emits the nested declarations queued while generating the previous type.
+func (g *goGenerator) flush() error {
+	for len(g.pending) > 0 {
+		next := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *goGenerator) structType(name string, doc string, schema map[string]interface{}) error {
+	if err := g.declare(name); err != nil {
+		return err
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(properties))
+	for property := range properties {
+		names = append(names, property)
+	}
+	sort.Strings(names)
+
+	required := map[string]bool{}
+	if list, ok := schema["required"].([]interface{}); ok {
+		for _, r := range list {
+			if s, ok := r.(string); ok {
+				required[s] = true
+			}
+		}
+	}
+
+	g.printf("%s\ntype %s struct {\n", doc, name)
+	for _, property := range names {
+		propertySchema, _ := properties[property].(map[string]interface{})
+		fieldName := exportedName(property)
+
+		goType, nullable, composite := g.goType(name+fieldName, propertySchema)
+		if (nullable || !required[property]) && !composite {
+			goType = "*" + goType
+		}
+		tag := property
+		if !required[property] {
+			tag += ",omitempty"
+		}
+
+		if description, ok := propertySchema["description"].(string); ok && description != "" {
+			g.printf("\t// %s\n", strings.Join(strings.Fields(description), " "))
+		}
+		g.printf("\t%s %s `json:%q`\n", fieldName, goType, tag)
+	}
+	g.printf("}\n\n")
+	return nil
+}
+
+// goType returns the Go type for a property schema, whether the schema also accepts null, and
+// whether the type is a slice, map or interface that already has a nil value. Named types for
+// nested objects and enums are queued on the generator under name.
+func (g *goGenerator) goType(name string, schema map[string]interface{}) (string, bool, bool) {
+	if alternatives, ok := schemaAlternatives(schema); ok {
+		return alternativesGoType(alternatives)
+	}
+
+	types, nullable := schemaTypes(schema)
+	if len(types) != 1 {
+		return "interface{}", nullable, true
+	}
+
+	switch types[0] {
+	case "string":
+		values := stringEnumValues(schema)
+		if len(values) == 0 {
+			return "string", nullable, false
+		}
+		g.pending = append(g.pending, func() error { return g.enumType(name, values) })
+		return name, nullable, false
+	case "integer":
+		return "int64", nullable, false
+	case "number":
+		return "float64", nullable, false
+	case "boolean":
+		return "bool", nullable, false
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		itemType, itemNullable, itemComposite := g.goType(name+"Item", items)
+		if itemNullable && !itemComposite {
+			itemType = "*" + itemType
+		}
+		return "[]" + itemType, nullable, true
+	case "object":
+		if _, ok := schema["properties"].(map[string]interface{}); !ok {
+			return "map[string]interface{}", nullable, true
+		}
+		g.pending = append(g.pending, func() error {
+			return g.structType(name, fmt.Sprintf("// %s is a nested object of a representation.", name), schema)
+		})
+		return name, nullable, false
+	}
+	return "interface{}", nullable, true
+}
+
+// alternativesGoType maps a oneOf/anyOf to a scalar Go type when every non-null alternative has
+// the same scalar type, which covers the common "value or null" pattern.
+func alternativesGoType(alternatives []map[string]interface{}) (string, bool, bool) {
+	nullable := false
+	scalar := ""
+	for _, alternative := range alternatives {
+		types, alternativeNullable := schemaTypes(alternative)
+		nullable = nullable || alternativeNullable
+		for _, t := range types {
+			if scalar != "" && scalar != t {
+				return "interface{}", nullable, true
+			}
+			scalar = t
+		}
+	}
+
+	switch scalar {
+	case "string":
+		return "string", nullable, false
+	case "integer":
+		return "int64", nullable, false
+	case "number":
+		return "float64", nullable, false
+	case "boolean":
+		return "bool", nullable, false
+	}
+	return "interface{}", nullable, true
+}
+
+func (g *goGenerator) enumType(name string, values []string) error {
+	if err := g.declare(name); err != nil {
+		return err
+	}
+	g.printf("// %s enumerates the values allowed by the schema.\ntype %s string\n\n", name, name)
+	g.printf("const (\n")
+	for _, value := range values {
+		g.printf("\t%s%s %s = %q\n", name, exportedName(strings.ToLower(value)), name, value)
+	}
+	g.printf(")\n\n")
+	return nil
+}
+
+func schemaAlternatives(schema map[string]interface{}) ([]map[string]interface{}, bool) {
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		list, ok := schema[keyword].([]interface{})
+		if !ok {
+			continue
+		}
+		alternatives := make([]map[string]interface{}, 0, len(list))
+		for _, item := range list {
+			if alternative, ok := item.(map[string]interface{}); ok {
+				alternatives = append(alternatives, alternative)
+			}
+		}
+		return alternatives, true
+	}
+	return nil, false
+}
+
+// schemaTypes returns the non-null types named by the "type" keyword and whether null is allowed.
+func schemaTypes(schema map[string]interface{}) ([]string, bool) {
+	var names []string
+	switch t := schema["type"].(type) {
+	case string:
+		names = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	var types []string
+	nullable := false
+	for _, name := range names {
+		if name == "null" {
+			nullable = true
+			continue
+		}
+		types = append(types, name)
+	}
+	return types, nullable
+}
+
+func stringEnumValues(schema map[string]interface{}) []string {
+	list, _ := schema["enum"].([]interface{})
+	var values []string
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// exportedName converts a snake_case (or otherwise delimited) name to an exported Go identifier,
+// e.g. external_cluster_id becomes ExternalClusterID.
+func exportedName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, part := range parts {
+		if codegenInitialisms[strings.ToLower(part)] {
+			b.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+
+	result := b.String()
+	if result == "" || !unicode.IsLetter([]rune(result)[0]) {
+		result = "X" + result
+	}
+	return result
+}
+
+// writeGeneratedFiles writes files into dir, creating it if needed.
+func writeGeneratedFiles(dir string, files map[string][]byte) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory %q: %w", dir, err)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	written := make([]string, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %q: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
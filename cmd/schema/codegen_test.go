@@ -0,0 +1,153 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2"
+	"github.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2/representations"
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+func TestGenerateRepresentations_UpToDate(t *testing.T) {
+	files, err := generateRepresentations(filepath.Join("..", "..", schemaDir), codegenPackage)
+	require.NoError(t, err)
+
+	for name, content := range files {
+		committed, err := os.ReadFile(filepath.Join("..", "..", codegenOutDir, name))
+		require.NoError(t, err, "run `go run main.go schema codegen` to generate %s", name)
+		assert.Equal(t, string(content), string(committed), "%s is out of date, run `go run main.go schema codegen`", name)
+	}
+}
+
+func TestGeneratedRepresentations_PassSchemaValidation(t *testing.T) {
+	reporterVersion := "2.14.0"
+	nodes := []representations.K8sClusterAcmReporterNodesItem{{Name: "node-1", CPU: "4", Memory: "16Gi"}}
+	reps, err := representations.NewK8sClusterAcmRepresentations(
+		&pb.RepresentationMetadata{LocalResourceId: "cluster-1", ApiHref: "https://example.com/cluster-1", ReporterVersion: &reporterVersion},
+		representations.K8sClusterCommon{WorkspaceID: "ws-1"},
+		representations.K8sClusterAcmReporter{
+			ExternalClusterID: "cluster-1",
+			ClusterStatus:     representations.K8sClusterAcmReporterClusterStatusReady,
+			ClusterReason:     "reason",
+			KubeVersion:       "1.31",
+			KubeVendor:        representations.K8sClusterAcmReporterKubeVendorOpenshift,
+			VendorVersion:     "4.18",
+			CloudPlatform:     representations.K8sClusterAcmReporterCloudPlatformAwsIpi,
+			Nodes:             nodes,
+		},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "ws-1", reps.GetCommon().AsMap()["workspace_id"])
+	assert.Equal(t, "READY", reps.GetReporter().AsMap()["cluster_status"])
+
+	common := bizmodel.Representation(reps.GetCommon().AsMap())
+	reporter := bizmodel.Representation(reps.GetReporter().AsMap())
+	err = newTestSchemaService(t).ValidateReportAgainstSchema(context.Background(),
+		representations.K8sClusterResourceType, representations.AcmReporterType, &common, &reporter)
+	assert.NoError(t, err)
+}
+
+func TestGenerateRepresentations_Types(t *testing.T) {
+	files := validHostSchemaFiles()
+	files["host/reporters/hbi/host.json"] = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "insights_id": { "oneOf": [{ "type": "string", "format": "uuid" }, { "type": "null" }] },
+    "status": { "type": "string", "enum": ["READY", "NOT_READY"], "description": "Current status." },
+    "cpu_count": { "type": "integer" },
+    "labels": { "type": "object" },
+    "nodes": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "properties": { "name": { "type": "string" } },
+        "required": ["name"]
+      }
+    }
+  },
+  "required": ["status", "insights_id"]
+}
+`
+	root := writeSchemaFiles(t, files)
+
+	generated, err := generateRepresentations(root, "sdk")
+	require.NoError(t, err)
+	require.Contains(t, generated, "host.go")
+	require.Contains(t, generated, "notifications_integration.go")
+	require.Contains(t, generated, "sdk.go")
+
+	host := string(generated["host.go"])
+	for _, want := range []string{
+		"package sdk",
+		"const HostResourceType = \"host\"",
+		"WorkspaceID *string `json:\"workspace_id,omitempty\"`",
+		"CPUCount *int64 `json:\"cpu_count,omitempty\"`",
+		"InsightsID *string `json:\"insights_id\"`",
+		"Labels map[string]interface{} `json:\"labels,omitempty\"`",
+		"Nodes []HostHbiReporterNodesItem `json:\"nodes,omitempty\"`",
+		"// Current status.\n\tStatus HostHbiReporterStatus `json:\"status\"`",
+		"HostHbiReporterStatusNotReady HostHbiReporterStatus = \"NOT_READY\"",
+		"type HostHbiReporterNodesItem struct {\n\tName string `json:\"name\"`\n}",
+		"func NewHostHbiRepresentations(metadata *v1beta2.RepresentationMetadata, common HostCommon, reporter HostHbiReporter) (*v1beta2.ResourceRepresentations, error)",
+	} {
+		assert.Contains(t, normalizeSpaces(host), normalizeSpaces(want))
+	}
+
+	assert.Contains(t, string(generated["sdk.go"]), "HbiReporterType")
+	assert.Contains(t, string(generated["sdk.go"]), "NotificationsReporterType")
+}
+
+// normalizeSpaces collapses gofmt alignment so expectations do not depend on neighbouring fields.
+func normalizeSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func TestGenerateRepresentations_InvalidSchema(t *testing.T) {
+	files := validHostSchemaFiles()
+	files["host/reporters/hbi/host.json"] = "not json"
+	root := writeSchemaFiles(t, files)
+
+	_, err := generateRepresentations(root, "sdk")
+	assert.ErrorContains(t, err, "schemas must be valid before generating code")
+}
+
+func TestGenerateSchemaBundle(t *testing.T) {
+	root := writeSchemaFiles(t, validHostSchemaFiles())
+
+	content, err := generateSchemaBundle(root)
+	require.NoError(t, err)
+
+	var bundle struct {
+		Schema      string                            `json:"$schema"`
+		Definitions map[string]map[string]interface{} `json:"definitions"`
+	}
+	require.NoError(t, json.Unmarshal(content, &bundle))
+	assert.Equal(t, draft07SchemaURI, bundle.Schema)
+	assert.Contains(t, bundle.Definitions, "HostCommon")
+	assert.Contains(t, bundle.Definitions, "HostHbiReporter")
+	assert.Contains(t, bundle.Definitions, "NotificationsIntegrationNotificationsReporter")
+	assert.NotContains(t, bundle.Definitions["HostCommon"], "$schema")
+}
+
+func TestExportedName(t *testing.T) {
+	tests := map[string]string{
+		"workspace_id":        "WorkspaceID",
+		"k8s_cluster":         "K8sCluster",
+		"api_href":            "APIHref",
+		"CLUSTER_STATUS_IDLE": "CLUSTERSTATUSIDLE",
+		"cluster_status_idle": "ClusterStatusIdle",
+		"3scale":              "X3scale",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, exportedName(input), input)
+	}
+}
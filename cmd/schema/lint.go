@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const draft07SchemaURI = "http://json-schema.org/draft-07/schema#"
+
+// propertyNamePattern is the snake_case style used by representation fields.
+var propertyNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// typeConstraintKeywords are the keywords that pin down what a property may contain.
+var typeConstraintKeywords = []string{"type", "enum", "const", "oneOf", "anyOf", "allOf", "$ref"}
+
+// lintSchemas applies style rules to every JSON schema under dir. Unlike validateSchemas,
+// these rules do not affect whether the server can load the schemas.
+func lintSchemas(dir string) ([]Problem, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".json") {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk schema directory %q: %w", dir, err)
+	}
+
+	var problems []Problem
+	for _, path := range paths {
+		problems = append(problems, lintJSONSchemaFile(path)...)
+	}
+
+	sortProblems(problems)
+	return problems, nil
+}
+
+func lintJSONSchemaFile(path string) []Problem {
+	document, content, problems := compileJSONSchemaFile(path)
+	if document == nil {
+		return problems
+	}
+
+	lines := jsonKeyLines(content)
+	var result []Problem
+
+	if document["$schema"] != draft07SchemaURI {
+		result = append(result, newProblem(path, lines["/$schema"], "\"$schema\" should be %q", draft07SchemaURI))
+	}
+	if document["type"] != "object" {
+		result = append(result, newProblem(path, lines["/type"], "top-level \"type\" should be \"object\""))
+	}
+	if _, ok := document["required"].([]interface{}); !ok {
+		result = append(result, newProblem(path, lines["/required"], "top-level \"required\" should be declared as a list, even when empty"))
+	}
+
+	properties, _ := document["properties"].(map[string]interface{})
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		line := lines["/properties/"+escapeJSONPointer(name)]
+		if !propertyNamePattern.MatchString(name) {
+			result = append(result, newProblem(path, line, "property %q should be snake_case", name))
+		}
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if !hasTypeConstraint(property) {
+			result = append(result, newProblem(path, line,
+				"property %q should declare one of %s", name, strings.Join(typeConstraintKeywords, ", ")))
+		}
+	}
+
+	return result
+}
+
+func hasTypeConstraint(property map[string]interface{}) bool {
+	for _, keyword := range typeConstraintKeywords {
+		if _, ok := property[keyword]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonKeyLines maps the JSON pointer of every object key in content to the line it appears on.
+func jsonKeyLines(content []byte) map[string]int {
+	lines := map[string]int{}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	_ = walkJSONKeys(dec, content, "", lines)
+	return lines
+}
+
+func walkJSONKeys(dec *json.Decoder, content []byte, pointer string, lines map[string]int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			key, _ := keyTok.(string)
+			child := pointer + "/" + escapeJSONPointer(key)
+			lines[child] = lineAtOffset(content, dec.InputOffset())
+			if err := walkJSONKeys(dec, content, child, lines); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := walkJSONKeys(dec, content, fmt.Sprintf("%s/%d", pointer, i), lines); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
+
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintSchemas_RepositorySchemas(t *testing.T) {
+	problems, err := lintSchemas(filepath.Join("..", "..", schemaDir))
+	require.NoError(t, err)
+	assert.Empty(t, problems, formatProblems(problems))
+}
+
+func TestLintSchemas_Problems(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   []string
+	}{
+		{
+			name:   "clean schema",
+			schema: validTestSchema,
+		},
+		{
+			name: "style violations",
+			schema: `{
+  "$schema": "http://json-schema.org/draft-04/schema#",
+  "type": "object",
+  "properties": {
+    "workspaceId": { "type": "string" },
+    "labels": { "description": "free-form" }
+  }
+}
+`,
+			want: []string{
+				"host.json:2: \"$schema\" should be",
+				"host.json:5: property \"workspaceId\" should be snake_case",
+				"host.json:6: property \"labels\" should declare one of",
+				"host.json: top-level \"required\" should be declared as a list",
+			},
+		},
+		{
+			name:   "non-object root",
+			schema: `{"$schema": "http://json-schema.org/draft-07/schema#", "type": "array", "required": []}`,
+			want:   []string{"host.json:1: top-level \"type\" should be \"object\""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := writeSchemaFiles(t, map[string]string{"host/reporters/hbi/host.json": tt.schema})
+
+			problems, err := lintSchemas(root)
+			require.NoError(t, err)
+
+			output := strings.ReplaceAll(formatProblems(problems), filepath.Join(root, "host", "reporters", "hbi")+string(filepath.Separator), "")
+			if len(tt.want) == 0 {
+				assert.Empty(t, problems, output)
+			}
+			for _, want := range tt.want {
+				assert.Contains(t, output, want)
+			}
+		})
+	}
+}
+
+func TestJSONKeyLines(t *testing.T) {
+	content := []byte("{\n  \"a\": {\n    \"b/c\": 1\n  },\n  \"d\": [\n    {\"e\": true}\n  ]\n}\n")
+
+	lines := jsonKeyLines(content)
+	assert.Equal(t, 2, lines["/a"])
+	assert.Equal(t, 3, lines["/a/b~1c"])
+	assert.Equal(t, 5, lines["/d"])
+	assert.Equal(t, 6, lines["/d/0/e"])
+}
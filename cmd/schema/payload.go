@@ -0,0 +1,170 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "github.com/project-kessel/inventory-api/api/kessel/inventory/v1beta2"
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	resourcesvc "github.com/project-kessel/inventory-api/internal/service/resources"
+)
+
+// payloadTestResult is the outcome of running a ReportResourceRequest payload through
+// schema validation and tuple calculation, without touching storage or relations.
+type payloadTestResult struct {
+	ValidationErr  error
+	TuplesToCreate []bizmodel.RelationsTuple
+	TuplesToDelete []bizmodel.RelationsTuple
+}
+
+// readReportResourceCommand parses a ReportResourceRequest JSON file and converts it the same
+// way the ReportResource endpoint does. A non-empty resourceType or reporterType fills in the
+// payload when it omits them and must otherwise agree with it.
+func readReportResourceCommand(path string, resourceType string, reporterType string) (resources.ReportResourceCommand, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return resources.ReportResourceCommand{}, fmt.Errorf("failed to read payload %q: %w", path, err)
+	}
+
+	var request pb.ReportResourceRequest
+	if err := protojson.Unmarshal(content, &request); err != nil {
+		return resources.ReportResourceCommand{}, fmt.Errorf("failed to parse payload %q as ReportResourceRequest: %w", path, err)
+	}
+
+	if err := applyPayloadOverride(&request.Type, resourceType, "resource type", path); err != nil {
+		return resources.ReportResourceCommand{}, err
+	}
+	if err := applyPayloadOverride(&request.ReporterType, reporterType, "reporter type", path); err != nil {
+		return resources.ReportResourceCommand{}, err
+	}
+
+	cmd, err := resourcesvc.ToReportResourceCommand(&request)
+	if err != nil {
+		return resources.ReportResourceCommand{}, fmt.Errorf("invalid payload %q: %w", path, err)
+	}
+	return cmd, nil
+}
+
+func applyPayloadOverride(field *string, value string, name string, path string) error {
+	if value == "" {
+		return nil
+	}
+	if *field == "" {
+		*field = value
+		return nil
+	}
+	if !strings.EqualFold(strings.TrimSpace(*field), strings.TrimSpace(value)) {
+		return fmt.Errorf("payload %q has %s %q, but %q was requested", path, name, *field, value)
+	}
+	return nil
+}
+
+// testPayload validates current against the schemas and computes the tuples the consumer would
+// replicate if previous (which may be nil) were replaced by current.
+func testPayload(ctx context.Context, schemaService *bizmodel.SchemaService, current resources.ReportResourceCommand, previous *resources.ReportResourceCommand) (payloadTestResult, error) {
+	result := payloadTestResult{
+		ValidationErr: schemaService.ValidateReportAgainstSchema(ctx, current.ResourceType, current.ReporterType, current.CommonRepresentation, current.ReporterRepresentation),
+	}
+
+	key, err := bizmodel.NewReporterResourceKey(current.LocalResourceId, current.ResourceType, current.ReporterType, current.ReporterInstanceId)
+	if err != nil {
+		return payloadTestResult{}, fmt.Errorf("failed to create reporter resource key: %w", err)
+	}
+
+	currentRepresentations, err := representationsFromCommand(current, 1)
+	if err != nil {
+		return payloadTestResult{}, fmt.Errorf("invalid current payload: %w", err)
+	}
+
+	var previousRepresentations *bizmodel.Representations
+	if previous != nil {
+		previousKey, err := bizmodel.NewReporterResourceKey(previous.LocalResourceId, previous.ResourceType, previous.ReporterType, previous.ReporterInstanceId)
+		if err != nil {
+			return payloadTestResult{}, fmt.Errorf("failed to create previous reporter resource key: %w", err)
+		}
+		if previousKey != key {
+			return payloadTestResult{}, errors.New("previous payload must report the same resource type, reporter, reporter instance and local resource ID as the current payload")
+		}
+		previousRepresentations, err = representationsFromCommand(*previous, 0)
+		if err != nil {
+			return payloadTestResult{}, fmt.Errorf("invalid previous payload: %w", err)
+		}
+	}
+
+	tuples, err := schemaService.CalculateTuplesForResource(ctx, currentRepresentations, previousRepresentations, key)
+	if err != nil {
+		return payloadTestResult{}, fmt.Errorf("failed to calculate tuples: %w", err)
+	}
+	if tuples.HasTuplesToCreate() {
+		result.TuplesToCreate = *tuples.TuplesToCreate()
+	}
+	if tuples.HasTuplesToDelete() {
+		result.TuplesToDelete = *tuples.TuplesToDelete()
+	}
+
+	return result, nil
+}
+
+// representationsFromCommand returns nil when the command carries no representations at all.
+func representationsFromCommand(cmd resources.ReportResourceCommand, version uint) (*bizmodel.Representations, error) {
+	var commonData, reporterData bizmodel.Representation
+	var commonVersion, reporterVersion *bizmodel.Version
+	v := bizmodel.NewVersion(version)
+
+	if cmd.CommonRepresentation != nil {
+		commonData = *cmd.CommonRepresentation
+		commonVersion = &v
+	}
+	if cmd.ReporterRepresentation != nil {
+		reporterData = *cmd.ReporterRepresentation
+		reporterVersion = &v
+	}
+	if commonData == nil && reporterData == nil {
+		return nil, nil
+	}
+
+	return bizmodel.NewRepresentations(commonData, commonVersion, reporterData, reporterVersion)
+}
+
+func writePayloadTestResult(w io.Writer, result payloadTestResult) {
+	if result.ValidationErr != nil {
+		fmt.Fprintf(w, "Validation: FAILED\n  %v\n", result.ValidationErr)
+	} else {
+		fmt.Fprintln(w, "Validation: OK")
+	}
+
+	writeTuples(w, "Tuples to create", result.TuplesToCreate)
+	writeTuples(w, "Tuples to delete", result.TuplesToDelete)
+}
+
+func writeTuples(w io.Writer, title string, tuples []bizmodel.RelationsTuple) {
+	fmt.Fprintf(w, "%s (%d):\n", title, len(tuples))
+	for _, tuple := range tuples {
+		fmt.Fprintf(w, "  %s\n", formatTuple(tuple))
+	}
+}
+
+// formatTuple renders a tuple in the zed relationship syntax,
+// e.g. hbi/host:123#workspace@rbac/workspace:456.
+func formatTuple(tuple bizmodel.RelationsTuple) string {
+	subject := formatResourceReference(tuple.Subject().Resource())
+	if tuple.Subject().HasRelation() {
+		subject += "#" + tuple.Subject().Relation().String()
+	}
+	return fmt.Sprintf("%s#%s@%s", formatResourceReference(tuple.Object()), tuple.Relation(), subject)
+}
+
+func formatResourceReference(ref bizmodel.ResourceReference) string {
+	objectType := ref.ResourceType().String()
+	if ref.HasReporter() {
+		objectType = ref.Reporter().ReporterType().String() + "/" + objectType
+	}
+	return fmt.Sprintf("%s:%s", objectType, ref.ResourceId())
+}
@@ -0,0 +1,121 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	"github.com/project-kessel/inventory-api/internal/data"
+)
+
+const testHostPayload = `{
+  "type": "host",
+  "reporterType": "hbi",
+  "reporterInstanceId": "3088be62-1c60-4884-b133-9200542d0b3f",
+  "representations": {
+    "metadata": {
+      "localResourceId": "dd1b73b9-3e33-4264-968c-e3ce55b9afec",
+      "apiHref": "https://apiHref.com/",
+      "reporterVersion": "2.7.16"
+    },
+    "common": {
+      "workspace_id": "WORKSPACE"
+    },
+    "reporter": {
+      "ansible_host": "host-1"
+    }
+  }
+}
+`
+
+func writePayload(t *testing.T, workspaceID string, replacements ...string) string {
+	t.Helper()
+	content := strings.ReplaceAll(testHostPayload, "WORKSPACE", workspaceID)
+	content = strings.NewReplacer(replacements...).Replace(content)
+	path := filepath.Join(t.TempDir(), "payload.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func newTestSchemaService(t *testing.T) *bizmodel.SchemaService {
+	t.Helper()
+	repository, err := data.NewInMemorySchemaRepositoryFromDir(context.Background(), filepath.Join("..", "..", schemaDir), data.FeaturesAwareSchemaFactory)
+	require.NoError(t, err)
+	return bizmodel.NewSchemaService(repository, log.NewHelper(log.DefaultLogger))
+}
+
+func runPayloadTest(t *testing.T, current string, previous string) (string, payloadTestResult) {
+	t.Helper()
+	currentCmd, err := readReportResourceCommand(current, "", "")
+	require.NoError(t, err)
+
+	var previousCmd *resources.ReportResourceCommand
+	if previous != "" {
+		p, err := readReportResourceCommand(previous, "", "")
+		require.NoError(t, err)
+		previousCmd = &p
+	}
+
+	result, err := testPayload(context.Background(), newTestSchemaService(t), currentCmd, previousCmd)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	writePayloadTestResult(&out, result)
+	return out.String(), result
+}
+
+func TestTestPayload_Valid(t *testing.T) {
+	output, result := runPayloadTest(t, writePayload(t, "ws-1"), "")
+
+	assert.NoError(t, result.ValidationErr)
+	assert.Equal(t, "Validation: OK\n"+
+		"Tuples to create (1):\n"+
+		"  hbi/host:dd1b73b9-3e33-4264-968c-e3ce55b9afec#workspace@rbac/workspace:ws-1\n"+
+		"Tuples to delete (0):\n", output)
+}
+
+func TestTestPayload_InvalidReporterRepresentation(t *testing.T) {
+	output, result := runPayloadTest(t, writePayload(t, "ws-1", `"ansible_host": "host-1"`, `"ansible_host": 5`), "")
+
+	assert.Error(t, result.ValidationErr)
+	assert.Contains(t, output, "Validation: FAILED\n  ")
+	assert.Contains(t, output, "ansible_host")
+}
+
+func TestTestPayload_PreviousWorkspaceChanged(t *testing.T) {
+	output, result := runPayloadTest(t, writePayload(t, "ws-2"), writePayload(t, "ws-1"))
+
+	assert.NoError(t, result.ValidationErr)
+	assert.Contains(t, output, "Tuples to create (1):\n  hbi/host:dd1b73b9-3e33-4264-968c-e3ce55b9afec#workspace@rbac/workspace:ws-2\n")
+	assert.Contains(t, output, "Tuples to delete (1):\n  hbi/host:dd1b73b9-3e33-4264-968c-e3ce55b9afec#workspace@rbac/workspace:ws-1\n")
+}
+
+func TestTestPayload_PreviousDifferentResource(t *testing.T) {
+	current, err := readReportResourceCommand(writePayload(t, "ws-1"), "", "")
+	require.NoError(t, err)
+	previous, err := readReportResourceCommand(writePayload(t, "ws-1", "dd1b73b9", "00000000"), "", "")
+	require.NoError(t, err)
+
+	_, err = testPayload(context.Background(), newTestSchemaService(t), current, &previous)
+	assert.ErrorContains(t, err, "previous payload must report the same resource")
+}
+
+func TestReadReportResourceCommand_Overrides(t *testing.T) {
+	path := writePayload(t, "ws-1", `"type": "host",`, ``)
+
+	cmd, err := readReportResourceCommand(path, "host", "HBI")
+	require.NoError(t, err)
+	assert.Equal(t, "host", cmd.ResourceType.String())
+
+	_, err = readReportResourceCommand(path, "host", "acm")
+	assert.ErrorContains(t, err, `has reporter type "hbi", but "acm" was requested`)
+}
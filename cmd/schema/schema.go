@@ -1,6 +1,7 @@
 package schema
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,8 @@ import (
 
 	"github.com/project-kessel/inventory-api/cmd/common"
 	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	"github.com/project-kessel/inventory-api/internal/data"
 )
 
 var schemaDir = "data/schema/resources"
@@ -189,3 +192,187 @@ func NewCommand(loggerOptions common.LoggerOptions) *cobra.Command {
 
 	return cmd
 }
+
+// NewSchemaCommand creates the `schema` command group for checking schema directories
+// and payloads without starting the server.
+func NewSchemaCommand(loggerOptions common.LoggerOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Validate, lint, test against and generate code from resource schemas",
+	}
+
+	cmd.AddCommand(
+		newValidateCommand(loggerOptions),
+		newLintCommand(loggerOptions),
+		newTestCommand(loggerOptions),
+		newCodegenCommand(loggerOptions),
+	)
+
+	return cmd
+}
+
+func newValidateCommand(loggerOptions common.LoggerOptions) *cobra.Command {
+	dir := schemaDir
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate resource schemas the same way the server loads them",
+		Long: "Loads the schema directory through the server's schema repository, compiles every JSON schema " +
+			"and checks that config.yaml files agree with the reporter directories.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+			logHelper := log.NewHelper(log.With(logger, "subsystem", "schema"))
+
+			problems, err := validateSchemas(context.Background(), dir)
+			if err != nil {
+				return err
+			}
+			return reportProblems(cmd, logHelper, "validation", dir, problems)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", dir, "The schema resources directory to validate")
+
+	return cmd
+}
+
+func newLintCommand(loggerOptions common.LoggerOptions) *cobra.Command {
+	dir := schemaDir
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Check resource schemas against style rules",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+			logHelper := log.NewHelper(log.With(logger, "subsystem", "schema"))
+
+			problems, err := lintSchemas(dir)
+			if err != nil {
+				return err
+			}
+			return reportProblems(cmd, logHelper, "lint", dir, problems)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", dir, "The schema resources directory to lint")
+
+	return cmd
+}
+
+func newTestCommand(loggerOptions common.LoggerOptions) *cobra.Command {
+	dir := schemaDir
+	var resourceType, reporterType, file, previousFile string
+
+	cmd := &cobra.Command{
+		Use:   "test",
+		Short: "Validate a ReportResourceRequest payload and preview its tuples",
+		Long: "Runs a ReportResourceRequest JSON payload through the same schema validation as the ReportResource " +
+			"endpoint and prints the tuples the consumer would replicate for it. With --previous, tuples are " +
+			"calculated as an update from the previous payload, showing both creates and deletes.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+			logHelper := log.NewHelper(log.With(logger, "subsystem", "schema"))
+			ctx := context.Background()
+
+			schemaRepository, err := data.NewInMemorySchemaRepositoryFromDir(ctx, dir, data.FeaturesAwareSchemaFactory)
+			if err != nil {
+				return err
+			}
+			schemaService := bizmodel.NewSchemaService(schemaRepository, logHelper)
+
+			current, err := readReportResourceCommand(file, resourceType, reporterType)
+			if err != nil {
+				return err
+			}
+			var previous *resources.ReportResourceCommand
+			if previousFile != "" {
+				p, err := readReportResourceCommand(previousFile, resourceType, reporterType)
+				if err != nil {
+					return err
+				}
+				previous = &p
+			}
+
+			result, err := testPayload(ctx, schemaService, current, previous)
+			if err != nil {
+				return err
+			}
+
+			writePayloadTestResult(cmd.OutOrStdout(), result)
+			if result.ValidationErr != nil {
+				return fmt.Errorf("payload %q failed schema validation", file)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", dir, "The schema resources directory to validate against")
+	cmd.Flags().StringVar(&resourceType, "resource-type", "", "The resource type of the payload (e.g., 'host'); defaults to the payload's type")
+	cmd.Flags().StringVar(&reporterType, "reporter-type", "", "The reporter type of the payload (e.g., 'hbi'); defaults to the payload's reporterType")
+	cmd.Flags().StringVar(&file, "file", "", "Path to a ReportResourceRequest JSON payload")
+	cmd.Flags().StringVar(&previousFile, "previous", "", "Path to the previously reported payload, to preview tuple creates and deletes for an update")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func newCodegenCommand(loggerOptions common.LoggerOptions) *cobra.Command {
+	dir := schemaDir
+	out := codegenOutDir
+	pkg := codegenPackage
+	var bundle string
+
+	cmd := &cobra.Command{
+		Use:   "codegen",
+		Short: "Generate typed reporter representation structs from resource schemas",
+		Long: "Generates a Go struct for every common and reporter representation schema, along with helpers " +
+			"that convert them to the ResourceRepresentations proto. With --bundle, also writes a JSON Schema " +
+			"bundle whose definitions use the generated type names and can be reused as OpenAPI components.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, logger := common.InitLogger(common.GetLogLevel(), loggerOptions)
+			logHelper := log.NewHelper(log.With(logger, "subsystem", "schema"))
+
+			files, err := generateRepresentations(dir, pkg)
+			if err != nil {
+				return err
+			}
+			written, err := writeGeneratedFiles(out, files)
+			if err != nil {
+				return err
+			}
+			for _, path := range written {
+				logHelper.Infof("Generated %s", path)
+			}
+
+			if bundle != "" {
+				content, err := generateSchemaBundle(dir)
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(bundle, content, 0644); err != nil {
+					return fmt.Errorf("failed to write schema bundle to %q: %w", bundle, err)
+				}
+				logHelper.Infof("Generated %s", bundle)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", dir, "The schema resources directory to generate code from")
+	cmd.Flags().StringVar(&out, "out", out, "The directory to write the generated Go files to")
+	cmd.Flags().StringVar(&pkg, "package", pkg, "The package name of the generated Go files")
+	cmd.Flags().StringVar(&bundle, "bundle", "", "Optional path to also write a JSON Schema bundle of all representations")
+
+	return cmd
+}
+
+func reportProblems(cmd *cobra.Command, logHelper *log.Helper, check string, dir string, problems []Problem) error {
+	if len(problems) == 0 {
+		logHelper.Infof("Schema %s passed for %s", check, dir)
+		return nil
+	}
+
+	fmt.Fprintln(cmd.OutOrStdout(), formatProblems(problems))
+	return fmt.Errorf("schema %s failed with %d problem(s)", check, len(problems))
+}
@@ -0,0 +1,331 @@
+package schema
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+	"gopkg.in/yaml.v3"
+
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/data"
+)
+
+const (
+	configFileName               = "config.yaml"
+	commonRepresentationFileName = "common_representation.json"
+	reportersDirName             = "reporters"
+)
+
+// namespacePattern matches the namespace names accepted by the relations backends.
+var namespacePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// Problem is a single validation or lint finding, located by file and, when known, line.
+type Problem struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (p Problem) String() string {
+	if p.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Message)
+	}
+	return fmt.Sprintf("%s: %s", p.File, p.Message)
+}
+
+func newProblem(file string, line int, format string, args ...interface{}) Problem {
+	return Problem{File: file, Line: line, Message: fmt.Sprintf(format, args...)}
+}
+
+// validateSchemas checks the schema directory for anything that would make the server
+// reject or silently drop a schema: unparsable files, JSON schemas that do not compile,
+// and config.yaml files that disagree with the directory layout.
+func validateSchemas(ctx context.Context, dir string) ([]Problem, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema directory %q: %w", dir, err)
+	}
+
+	var problems []Problem
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		problems = append(problems, validateResourceDir(dir, entry.Name())...)
+	}
+
+	// Finally, load the directory exactly as `serve` does with the dir repository.
+	if _, err := data.NewInMemorySchemaRepositoryFromDir(ctx, dir, data.FeaturesAwareSchemaFactory); err != nil {
+		problems = append(problems, newProblem(dir, 0, "server failed to load schemas: %v", err))
+	}
+
+	sortProblems(problems)
+	return problems, nil
+}
+
+func validateResourceDir(dir string, name string) []Problem {
+	var problems []Problem
+	resourcePath := filepath.Join(dir, name)
+
+	resourceType, err := bizmodel.NewResourceType(name)
+	if err != nil {
+		return []Problem{newProblem(resourcePath, 0, "invalid resource type directory: %v", err)}
+	}
+	if resourceType.String() != name {
+		problems = append(problems, newProblem(resourcePath, 0,
+			"resource directory must use the normalized resource type %q", resourceType))
+	}
+
+	configPath := filepath.Join(resourcePath, configFileName)
+	config, configProblems := readYAMLMapping(configPath)
+	problems = append(problems, configProblems...)
+
+	var declaredReporters []*yaml.Node
+	if config != nil {
+		problems = append(problems, checkResourceTypeField(configPath, config, resourceType)...)
+		problems = append(problems, checkPolicyField(configPath, config)...)
+
+		reportersNode, ok := config["resource_reporters"]
+		switch {
+		case !ok:
+			problems = append(problems, newProblem(configPath, 0, "missing required field \"resource_reporters\""))
+		case reportersNode.Kind != yaml.SequenceNode:
+			problems = append(problems, newProblem(configPath, reportersNode.Line, "\"resource_reporters\" must be a list"))
+		default:
+			declaredReporters = reportersNode.Content
+		}
+	}
+
+	commonPath := filepath.Join(resourcePath, commonRepresentationFileName)
+	if _, err := os.Stat(commonPath); err != nil {
+		problems = append(problems, newProblem(commonPath, 0, "missing common representation schema"))
+	} else {
+		_, _, compileProblems := compileJSONSchemaFile(commonPath)
+		problems = append(problems, compileProblems...)
+	}
+
+	reporterDirs := map[bizmodel.ReporterType]string{}
+	reportersPath := filepath.Join(resourcePath, reportersDirName)
+	if reporterEntries, err := os.ReadDir(reportersPath); err == nil {
+		for _, reporter := range reporterEntries {
+			if !reporter.IsDir() {
+				continue
+			}
+			reporterType, reporterProblems := validateReporterDir(reportersPath, reporter.Name(), resourceType)
+			problems = append(problems, reporterProblems...)
+			if reporterType != "" {
+				reporterDirs[reporterType] = reporter.Name()
+			}
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		problems = append(problems, newProblem(reportersPath, 0, "failed to read reporters directory: %v", err))
+	}
+
+	if config == nil {
+		return problems
+	}
+
+	declared := map[bizmodel.ReporterType]bool{}
+	for _, node := range declaredReporters {
+		reporterType, err := bizmodel.NewReporterType(node.Value)
+		if err != nil {
+			problems = append(problems, newProblem(configPath, node.Line, "invalid reporter %q in \"resource_reporters\": %v", node.Value, err))
+			continue
+		}
+		if declared[reporterType] {
+			problems = append(problems, newProblem(configPath, node.Line, "reporter %q is listed more than once in \"resource_reporters\"", node.Value))
+			continue
+		}
+		declared[reporterType] = true
+		if _, ok := reporterDirs[reporterType]; !ok {
+			problems = append(problems, newProblem(configPath, node.Line,
+				"reporter %q is listed in \"resource_reporters\" but %s does not exist", node.Value, filepath.Join(reportersPath, reporterType.String())))
+		}
+	}
+	for reporterType, dirName := range reporterDirs {
+		if !declared[reporterType] {
+			problems = append(problems, newProblem(filepath.Join(reportersPath, dirName), 0,
+				"reporter directory is not listed in %s \"resource_reporters\"", configPath))
+		}
+	}
+
+	return problems
+}
+
+// validateReporterDir returns the normalized reporter type of the directory, or "" if it is unusable.
+func validateReporterDir(reportersPath string, name string, resourceType bizmodel.ResourceType) (bizmodel.ReporterType, []Problem) {
+	var problems []Problem
+	reporterPath := filepath.Join(reportersPath, name)
+
+	reporterType, err := bizmodel.NewReporterType(name)
+	if err != nil {
+		return "", []Problem{newProblem(reporterPath, 0, "invalid reporter directory: %v", err)}
+	}
+	if reporterType.String() != name {
+		problems = append(problems, newProblem(reporterPath, 0,
+			"reporter directory must use the normalized reporter type %q", reporterType))
+	}
+
+	schemaPath := filepath.Join(reporterPath, fmt.Sprintf("%s.json", resourceType))
+	if _, err := os.Stat(schemaPath); err != nil {
+		problems = append(problems, newProblem(schemaPath, 0, "missing reporter representation schema"))
+	} else {
+		_, _, compileProblems := compileJSONSchemaFile(schemaPath)
+		problems = append(problems, compileProblems...)
+	}
+
+	// The reporter config.yaml is optional, but must agree with its location when present.
+	configPath := filepath.Join(reporterPath, configFileName)
+	if _, err := os.Stat(configPath); err != nil {
+		return reporterType, problems
+	}
+	config, configProblems := readYAMLMapping(configPath)
+	problems = append(problems, configProblems...)
+	if config == nil {
+		return reporterType, problems
+	}
+
+	problems = append(problems, checkResourceTypeField(configPath, config, resourceType)...)
+	problems = append(problems, checkPolicyField(configPath, config)...)
+
+	if node, ok := config["reporter_name"]; ok {
+		if got, err := bizmodel.NewReporterType(node.Value); err != nil || got != reporterType {
+			problems = append(problems, newProblem(configPath, node.Line,
+				"\"reporter_name\" %q does not match reporter directory %q", node.Value, name))
+		}
+	}
+
+	if node, ok := config["namespace"]; ok {
+		switch {
+		case !namespacePattern.MatchString(node.Value):
+			problems = append(problems, newProblem(configPath, node.Line,
+				"\"namespace\" %q must match %s", node.Value, namespacePattern))
+		case node.Value != reporterType.String():
+			problems = append(problems, newProblem(configPath, node.Line,
+				"\"namespace\" %q must equal the reporter type %q", node.Value, reporterType))
+		}
+	}
+
+	return reporterType, problems
+}
+
+func checkResourceTypeField(configPath string, config map[string]*yaml.Node, resourceType bizmodel.ResourceType) []Problem {
+	node, ok := config["resource_type"]
+	if !ok {
+		return []Problem{newProblem(configPath, 0, "missing required field \"resource_type\"")}
+	}
+	got, err := bizmodel.NewResourceType(node.Value)
+	if err != nil {
+		return []Problem{newProblem(configPath, node.Line, "invalid \"resource_type\": %v", err)}
+	}
+	if got != resourceType {
+		return []Problem{newProblem(configPath, node.Line,
+			"\"resource_type\" %q normalizes to %q, which does not match resource directory %q", node.Value, got, resourceType)}
+	}
+	return nil
+}
+
+// checkPolicyField validates the optional schema policy block the server enforces on reports.
+func checkPolicyField(configPath string, config map[string]*yaml.Node) []Problem {
+	node, ok := config["policy"]
+	if !ok {
+		return nil
+	}
+	content, err := yaml.Marshal(map[string]*yaml.Node{"policy": node})
+	if err != nil {
+		return []Problem{newProblem(configPath, node.Line, "invalid \"policy\": %v", err)}
+	}
+	if _, err := data.ParseSchemaPolicyConfig(content); err != nil {
+		return []Problem{newProblem(configPath, node.Line, "invalid \"policy\": %v", err)}
+	}
+	return nil
+}
+
+// readYAMLMapping parses a YAML file whose top level is a mapping, returning the value node
+// of each key so callers can report line numbers. A nil map is returned if the file is unusable.
+func readYAMLMapping(path string) (map[string]*yaml.Node, []Problem) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, []Problem{newProblem(path, 0, "missing %s", configFileName)}
+		}
+		return nil, []Problem{newProblem(path, 0, "failed to read file: %v", err)}
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, []Problem{newProblem(path, 0, "invalid YAML: %v", err)}
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, []Problem{newProblem(path, 1, "expected a YAML mapping at the top level")}
+	}
+
+	root := doc.Content[0]
+	fields := make(map[string]*yaml.Node, len(root.Content)/2)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		fields[root.Content[i].Value] = root.Content[i+1]
+	}
+	return fields, nil
+}
+
+// compileJSONSchemaFile parses and compiles a JSON schema file. On success it also returns the
+// decoded document and its raw bytes for further (lint) checks.
+func compileJSONSchemaFile(path string) (map[string]interface{}, []byte, []Problem) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, []Problem{newProblem(path, 0, "failed to read file: %v", err)}
+	}
+
+	var document map[string]interface{}
+	if err := json.Unmarshal(content, &document); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			return nil, nil, []Problem{newProblem(path, lineAtOffset(content, syntaxErr.Offset), "invalid JSON: %v", err)}
+		case errors.As(err, &typeErr):
+			return nil, nil, []Problem{newProblem(path, lineAtOffset(content, typeErr.Offset), "JSON schema must be an object")}
+		default:
+			return nil, nil, []Problem{newProblem(path, 0, "invalid JSON: %v", err)}
+		}
+	}
+
+	if _, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(content)); err != nil {
+		return nil, nil, []Problem{newProblem(path, 0, "JSON schema does not compile: %v", err)}
+	}
+
+	return document, content, nil
+}
+
+func lineAtOffset(content []byte, offset int64) int {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	return bytes.Count(content[:offset], []byte("\n")) + 1
+}
+
+func sortProblems(problems []Problem) {
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return problems[i].File < problems[j].File
+		}
+		return problems[i].Line < problems[j].Line
+	})
+}
+
+func formatProblems(problems []Problem) string {
+	lines := make([]string, len(problems))
+	for i, p := range problems {
+		lines[i] = p.String()
+	}
+	return strings.Join(lines, "\n")
+}
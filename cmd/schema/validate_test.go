@@ -0,0 +1,177 @@
+package schema
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validTestSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "type": "object",
+  "properties": {
+    "workspace_id": { "type": "string" }
+  },
+  "required": []
+}
+`
+
+// writeSchemaFiles creates files under a temporary schema root, keyed by relative path.
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	root := t.TempDir()
+	for rel, content := range files {
+		path := filepath.Join(root, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func validHostSchemaFiles() map[string]string {
+	return map[string]string{
+		"host/config.yaml":                                     "resource_type: host\nresource_reporters:\n  - HBI\n",
+		"host/common_representation.json":                      validTestSchema,
+		"host/reporters/hbi/config.yaml":                       "resource_type: host\nreporter_name: hbi\nnamespace: hbi\n",
+		"host/reporters/hbi/host.json":                         validTestSchema,
+		"notifications_integration/config.yaml":                "resource_type: notifications/integration\nresource_reporters:\n  - notifications\n",
+		"notifications_integration/common_representation.json": validTestSchema,
+		"notifications_integration/reporters/notifications/notifications_integration.json": validTestSchema,
+	}
+}
+
+func TestValidateSchemas_RepositorySchemas(t *testing.T) {
+	problems, err := validateSchemas(context.Background(), filepath.Join("..", "..", schemaDir))
+	require.NoError(t, err)
+	assert.Empty(t, problems, formatProblems(problems))
+}
+
+func TestValidateSchemas_Valid(t *testing.T) {
+	root := writeSchemaFiles(t, validHostSchemaFiles())
+
+	problems, err := validateSchemas(context.Background(), root)
+	require.NoError(t, err)
+	assert.Empty(t, problems, formatProblems(problems))
+}
+
+func TestValidateSchemas_MissingDirectory(t *testing.T) {
+	_, err := validateSchemas(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to read schema directory")
+}
+
+func TestValidateSchemas_Problems(t *testing.T) {
+	tests := []struct {
+		name     string
+		override map[string]string
+		want     string
+	}{
+		{
+			name:     "invalid JSON reports line",
+			override: map[string]string{"host/reporters/hbi/host.json": "{\n  \"type\": \"object\",\n  \"properties\": {,\n}\n"},
+			want:     "host/reporters/hbi/host.json:3: invalid JSON",
+		},
+		{
+			name:     "schema that does not compile",
+			override: map[string]string{"host/common_representation.json": `{"type": "object", "properties": {"a": {"type": 5}}}`},
+			want:     "host/common_representation.json: JSON schema does not compile",
+		},
+		{
+			name:     "resource type does not match directory",
+			override: map[string]string{"host/config.yaml": "resource_type: k8s_cluster\nresource_reporters:\n  - hbi\n"},
+			want:     "host/config.yaml:1: \"resource_type\" \"k8s_cluster\" normalizes to \"k8s_cluster\", which does not match resource directory \"host\"",
+		},
+		{
+			name:     "declared reporter without directory",
+			override: map[string]string{"host/config.yaml": "resource_type: host\nresource_reporters:\n  - hbi\n  - satellite\n"},
+			want:     "host/config.yaml:4: reporter \"satellite\" is listed in \"resource_reporters\"",
+		},
+		{
+			name:     "duplicate reporter",
+			override: map[string]string{"host/config.yaml": "resource_type: host\nresource_reporters:\n  - hbi\n  - HBI\n"},
+			want:     "host/config.yaml:4: reporter \"HBI\" is listed more than once",
+		},
+		{
+			name: "reporter directory not declared",
+			override: map[string]string{
+				"host/reporters/satellite/host.json": validTestSchema,
+			},
+			want: "host/reporters/satellite: reporter directory is not listed in",
+		},
+		{
+			name:     "namespace does not match reporter",
+			override: map[string]string{"host/reporters/hbi/config.yaml": "resource_type: host\nreporter_name: hbi\nnamespace: inventory\n"},
+			want:     "host/reporters/hbi/config.yaml:3: \"namespace\" \"inventory\" must equal the reporter type \"hbi\"",
+		},
+		{
+			name:     "namespace is not normalized",
+			override: map[string]string{"host/reporters/hbi/config.yaml": "resource_type: host\nreporter_name: hbi\nnamespace: HBI\n"},
+			want:     "host/reporters/hbi/config.yaml:3: \"namespace\" \"HBI\" must match",
+		},
+		{
+			name:     "reporter name does not match directory",
+			override: map[string]string{"host/reporters/hbi/config.yaml": "resource_type: host\nreporter_name: acm\nnamespace: hbi\n"},
+			want:     "host/reporters/hbi/config.yaml:2: \"reporter_name\" \"acm\" does not match reporter directory \"hbi\"",
+		},
+		{
+			name:     "unknown policy setting",
+			override: map[string]string{"host/config.yaml": "resource_type: host\nresource_reporters:\n  - hbi\npolicy:\n  reject_unknown_property: true\n"},
+			want:     "host/config.yaml:5: invalid \"policy\": unknown policy settings: reject_unknown_property",
+		},
+		{
+			name:     "invalid reporter policy value",
+			override: map[string]string{"host/reporters/hbi/config.yaml": "resource_type: host\nreporter_name: hbi\npolicy:\n  max_representation_bytes: -1\n"},
+			want:     "host/reporters/hbi/config.yaml:4: invalid \"policy\": invalid policy: max_representation_bytes must not be negative",
+		},
+		{
+			name:     "invalid YAML",
+			override: map[string]string{"host/config.yaml": "resource_type: [host\n"},
+			want:     "host/config.yaml: invalid YAML",
+		},
+		{
+			name: "non-normalized resource directory",
+			override: map[string]string{
+				"Host/config.yaml":                "resource_type: host\nresource_reporters: []\n",
+				"Host/common_representation.json": validTestSchema,
+			},
+			want: "Host: resource directory must use the normalized resource type \"host\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := validHostSchemaFiles()
+			for k, v := range tt.override {
+				files[k] = v
+			}
+			root := writeSchemaFiles(t, files)
+
+			problems, err := validateSchemas(context.Background(), root)
+			require.NoError(t, err)
+
+			output := strings.ReplaceAll(formatProblems(problems), root+string(filepath.Separator), "")
+			assert.Contains(t, output, tt.want)
+		})
+	}
+}
+
+func TestValidateSchemas_ReportsEveryProblem(t *testing.T) {
+	files := validHostSchemaFiles()
+	files["host/reporters/hbi/host.json"] = "not json"
+	files["host/reporters/hbi/config.yaml"] = "resource_type: host\nreporter_name: hbi\nnamespace: other\n"
+	delete(files, "host/common_representation.json")
+	root := writeSchemaFiles(t, files)
+
+	problems, err := validateSchemas(context.Background(), root)
+	require.NoError(t, err)
+
+	output := formatProblems(problems)
+	assert.Contains(t, output, "missing common representation schema")
+	assert.Contains(t, output, "invalid JSON")
+	assert.Contains(t, output, "must equal the reporter type")
+	assert.Contains(t, output, "server failed to load schemas")
+}
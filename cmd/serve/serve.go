@@ -18,12 +18,11 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/project-kessel/inventory-api/cmd/common"
-	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
 	resourcesctl "github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/tenancy"
 	tuplesctl "github.com/project-kessel/inventory-api/internal/biz/usecase/tuples"
 	"github.com/project-kessel/inventory-api/internal/config/schema"
-	inmemoryConfig "github.com/project-kessel/inventory-api/internal/config/schema/inmemory"
 	"github.com/project-kessel/inventory-api/internal/consistency"
 	"github.com/project-kessel/inventory-api/internal/consumer"
 	"github.com/project-kessel/inventory-api/internal/data"
@@ -33,7 +32,9 @@ import (
 	resourcesvc "github.com/project-kessel/inventory-api/internal/service/resources"
 	tuplesvc "github.com/project-kessel/inventory-api/internal/service/tuples"
 
+	"github.com/project-kessel/inventory-api/internal/audit"
 	"github.com/project-kessel/inventory-api/internal/authn"
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
 	"github.com/project-kessel/inventory-api/internal/config/relations"
 	"github.com/project-kessel/inventory-api/internal/errors"
 	"github.com/project-kessel/inventory-api/internal/middleware"
@@ -65,6 +66,8 @@ func NewCommand(
 	schemaOptions *schema.Options,
 	businessMetricsOptions *metricscollector.Options,
 	metaAuthorizerOptions *metaauthorizer.Options,
+	tenancyOptions *tenancy.Options,
+	auditOptions *audit.Options,
 ) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "serve",
@@ -168,6 +171,30 @@ func NewCommand(
 				return errors.NewAggregate(errs)
 			}
 
+			// configure tenancy
+			if errs := tenancyOptions.Complete(); errs != nil {
+				return errors.NewAggregate(errs)
+			}
+			if errs := tenancyOptions.Validate(); errs != nil {
+				return errors.NewAggregate(errs)
+			}
+			tenancyConfig, errs := tenancy.NewConfig(tenancyOptions).Complete()
+			if errs != nil {
+				return errors.NewAggregate(errs)
+			}
+
+			// configure audit
+			if errs := auditOptions.Complete(); errs != nil {
+				return errors.NewAggregate(errs)
+			}
+			if errs := auditOptions.Validate(); errs != nil {
+				return errors.NewAggregate(errs)
+			}
+			auditConfig, errs := audit.NewConfig(auditOptions).Complete()
+			if errs != nil {
+				return errors.NewAggregate(errs)
+			}
+
 			// configure the server
 			if errs := serverOptions.Complete(); errs != nil {
 				return errors.NewAggregate(errs)
@@ -186,6 +213,12 @@ func NewCommand(
 				return err
 			}
 
+			// construct the auditor; the postgres sink writes through storage
+			auditor, err := audit.New(auditConfig, data.NewAuditEventRepository(db), log.With(logger, "subsystem", "audit"))
+			if err != nil {
+				return err
+			}
+
 			// setup metrics collector for consumer and custom metrics
 			mc := &metricscollector.MetricsCollector{}
 			meter := otel.Meter("github.com/project-kessel/inventory-api/blob/main/internal/server/otel")
@@ -227,20 +260,39 @@ func NewCommand(
 			}
 			// STOP: construct pubsub
 
-			// construct authn
+			// construct authn; api keys kept in the database are looked up through storage
+			authnConfig.SetAPIKeyStore(data.NewAPIKeyRepository(db))
 			authenticator, err := authn.New(authnConfig, log.NewHelper(log.With(logger, "subsystem", "authn")))
 			if err != nil {
 				return err
 			}
+			defer authnapi.Stop(authenticator)
+
+			// Create transaction manager for all repositories
+			transactionManager := data.NewGormTransactionManager(mc, storageConfig.Options.MaxSerializationRetries)
 
 			// construct relations repository
-			relationsRepo, err := data.NewRelationsRepository(ctx, authzConfig, log.With(logger, "subsystem", "relations"))
+			relationsRepo, err := data.NewRelationsRepository(ctx, authzConfig, db, transactionManager, mc, log.With(logger, "subsystem", "relations"))
 			if err != nil {
 				return err
 			}
+			if shadowRelationsRepo, ok := relationsRepo.(*data.ShadowRelationsRepository); ok {
+				defer shadowRelationsRepo.Close()
+			}
+			// The cache sits inside resilience so that fail-open answers never reach it.
+			if authzConfig.Cache.Enabled {
+				relationsRepo = data.NewCachingRelationsRepository(relationsRepo, authzConfig.Cache, mc, log.NewHelper(log.With(logger, "subsystem", "relations_cache")))
+			}
+			// Meta-authorization must not be granted because the backend is down, so it checks
+			// through a fail-closed view and falls back to its own allowlist instead.
+			metaRelationsRepo := relationsRepo
+			if authzConfig.Resilience.Enabled {
+				resilientRelationsRepo := data.NewResilientRelationsRepository(relationsRepo, authzConfig.Resilience, mc, log.NewHelper(log.With(logger, "subsystem", "relations_resilience")))
+				relationsRepo, metaRelationsRepo = resilientRelationsRepo, resilientRelationsRepo.FailClosed()
+			}
 
 			// constructs schema repository
-			schemaRepository, err := newSchemaRepository(ctx, schemaConfig, log.NewHelper(log.With(logger, "subsystem", "schemaRepository")))
+			schemaRepository, err := data.NewSchemaRepository(ctx, schemaConfig, log.NewHelper(log.With(logger, "subsystem", "schemaRepository")))
 			if err != nil {
 				return err
 			}
@@ -286,13 +338,52 @@ func NewCommand(
 				},
 			})
 
-			// Create transaction manager for all repositories
-			transactionManager := data.NewGormTransactionManager(mc, storageConfig.Options.MaxSerializationRetries)
-
 			//v1beta2
 			// wire together inventory service handling
 			resourceRepo := data.NewResourceRepository(db, transactionManager, data.SetOutboxPublisher(storageConfig.Options.OutboxMode))
-			inventory_controller := resourcesctl.New(resourceRepo, schemaRepository, relationsRepo, "notifications", log.With(logger, "subsystem", "notificationsintegrations_controller"), listenManager, waitForNotifCircuitBreaker, usecaseConfig, mc, metaauthorizer.NewSimpleMetaAuthorizer(), selfSubjectStrategy)
+			// A policy file or the relations backend replaces the built-in rules and allowlists for
+			// every operation. An audit-only policy replaces nothing: it is evaluated next to the
+			// authorizers that would otherwise be used, and only its differing decisions are logged.
+			var configuredMetaAuthorizer metaauthorizer.MetaAuthorizer
+			var policyMetaAuthorizer *metaauthorizer.PolicyMetaAuthorizer
+			if metaAuthorizerConfig.PolicyFile != "" {
+				policyMetaAuthorizer, err = metaauthorizer.NewPolicyMetaAuthorizer(metaAuthorizerConfig)
+				if err != nil {
+					return err
+				}
+				defer policyMetaAuthorizer.Stop()
+			}
+			if metaAuthorizerConfig.KesselEnabled {
+				configuredMetaAuthorizer = metaauthorizer.NewKesselMetaAuthorizer(metaRelationsRepo, metaAuthorizerConfig)
+			} else if policyMetaAuthorizer != nil && !metaAuthorizerConfig.PolicyAuditOnly {
+				configuredMetaAuthorizer = policyMetaAuthorizer
+			}
+			auditPolicy := func(enforced metaauthorizer.MetaAuthorizer) metaauthorizer.MetaAuthorizer {
+				if policyMetaAuthorizer == nil || !metaAuthorizerConfig.PolicyAuditOnly {
+					return enforced
+				}
+				return policyMetaAuthorizer.AuditOnly(enforced)
+			}
+
+			var resourceMetaAuthorizer metaauthorizer.MetaAuthorizer = metaauthorizer.NewSimpleMetaAuthorizer()
+			if configuredMetaAuthorizer != nil {
+				resourceMetaAuthorizer = configuredMetaAuthorizer
+			}
+			resourceMetaAuthorizer = auditPolicy(resourceMetaAuthorizer)
+			inventory_controller := resourcesctl.New(resourceRepo, schemaRepository, relationsRepo, "notifications", log.With(logger, "subsystem", "notificationsintegrations_controller"), listenManager, waitForNotifCircuitBreaker, usecaseConfig, mc, resourceMetaAuthorizer, selfSubjectStrategy)
+
+			if configuredMetaAuthorizer != nil {
+				inventory_controller.ExplainMetaAuthorizer = configuredMetaAuthorizer
+			} else if authnOptions.AllowUnauthenticated != nil && *authnOptions.AllowUnauthenticated {
+				inventory_controller.ExplainMetaAuthorizer = metaauthorizer.NewSimpleMetaAuthorizer()
+			} else {
+				inventory_controller.ExplainMetaAuthorizer = metaauthorizer.NewWhitelistMetaAuthorizer(metaAuthorizerConfig.CheckExplainAllowlist)
+			}
+			inventory_controller.ExplainMetaAuthorizer = auditPolicy(inventory_controller.ExplainMetaAuthorizer)
+			inventory_controller.ReporterBindings = metaAuthorizerConfig.ReporterBindings
+			inventory_controller.Tenancy = tenancy.New(tenancyConfig)
+			inventory_controller.Audit = auditor
+			inventory_controller.ConsistencyWatermarks = data.NewConsistencyWatermarkRepository(db)
 
 			inventory_service := resourcesvc.NewKesselInventoryServiceV1beta2(inventory_controller)
 			pbv1beta2.RegisterKesselInventoryServiceServer(server.GrpcServer, inventory_service)
@@ -300,16 +391,20 @@ func NewCommand(
 
 			// DEPRECATED: Legacy tuple service for RBAC-only backward compatibility
 			var tupleMetaAuthorizer metaauthorizer.MetaAuthorizer
-			if authnOptions.AllowUnauthenticated != nil && *authnOptions.AllowUnauthenticated {
+			if configuredMetaAuthorizer != nil {
+				tupleMetaAuthorizer = configuredMetaAuthorizer
+			} else if authnOptions.AllowUnauthenticated != nil && *authnOptions.AllowUnauthenticated {
 				tupleMetaAuthorizer = metaauthorizer.NewSimpleMetaAuthorizer()
 			} else {
 				tupleMetaAuthorizer = metaauthorizer.NewWhitelistMetaAuthorizer(metaAuthorizerConfig.TupleCrudAllowlist)
 			}
+			tupleMetaAuthorizer = auditPolicy(tupleMetaAuthorizer)
 			tuple_crud_usecase := tuplesctl.New(
 				relationsRepo,
 				tupleMetaAuthorizer,
 				log.With(logger, "subsystem", "tuple_crud_controller"),
 			)
+			tuple_crud_usecase.Audit = auditor
 			tuple_service := tuplesvc.New(tuple_crud_usecase)
 			pbv1beta2.RegisterKesselTupleServiceServer(server.GrpcServer, tuple_service)
 
@@ -329,6 +424,8 @@ func NewCommand(
 				"read_after_write_enabled", consistencyOptions.ReadAfterWriteEnabled,
 				"database", storageConfig.Options.Database,
 				"read_only_mode", serverOptions.ReadOnlyMode,
+				"tenancy_enabled", tenancyOptions.Enabled,
+				"audit_sinks", auditOptions.Sinks,
 				// DO NOT LOG: DB passwords, Kafka credentials, OIDC client secrets
 			)
 
@@ -344,7 +441,7 @@ func NewCommand(
 				}()
 			}
 
-			shutdown := shutdown(db, server, pprofServer, &inventoryConsumer, log.NewHelper(logger))
+			shutdown := shutdown(db, server, pprofServer, &inventoryConsumer, auditor, log.NewHelper(logger))
 
 			if consumerOptions.Enabled {
 				go func() {
@@ -405,13 +502,15 @@ func NewCommand(
 	selfSubjectOptions.AddFlags(cmd.Flags(), "selfsubjectstrategy")
 	businessMetricsOptions.AddFlags(cmd.Flags(), "business-metrics")
 	metaAuthorizerOptions.AddFlags(cmd.Flags(), "metaauthorizer")
+	tenancyOptions.AddFlags(cmd.Flags(), "tenancy")
+	auditOptions.AddFlags(cmd.Flags(), "audit")
 
 	return cmd
 }
 
 // shutdown returns a shutdown function that gracefully closes all server components
-// including the HTTP server, pprof server, consumer, and database connections.
-func shutdown(db *gorm.DB, srv *server.Server, pprofSrv *pprof.Server, cm *consumer.InventoryConsumer, logger *log.Helper) func(reason interface{}) {
+// including the HTTP server, pprof server, consumer, audit sinks, and database connections.
+func shutdown(db *gorm.DB, srv *server.Server, pprofSrv *pprof.Server, cm *consumer.InventoryConsumer, auditor *audit.Auditor, logger *log.Helper) func(reason interface{}) {
 	return func(reason interface{}) {
 		log.Info(fmt.Sprintf("Server Shutdown: %s", reason))
 
@@ -436,6 +535,11 @@ func shutdown(db *gorm.DB, srv *server.Server, pprofSrv *pprof.Server, cm *consu
 			}
 		}
 
+		// Audit entries of the last requests are flushed before storage is closed.
+		if err := auditor.Close(); err != nil {
+			logger.Error(fmt.Sprintf("Error Closing Audit Sinks: %v", err))
+		}
+
 		if cm != nil {
 			defer func() {
 				err := cm.Shutdown()
@@ -460,24 +564,3 @@ func shutdown(db *gorm.DB, srv *server.Server, pprofSrv *pprof.Server, cm *consu
 		}
 	}
 }
-
-func newSchemaRepository(ctx context.Context, c schema.CompletedConfig, logger *log.Helper) (bizmodel.SchemaRepository, error) {
-	switch c.Repository {
-	case schema.InMemoryRepository:
-		switch c.InMemory.Type {
-		case inmemoryConfig.EmptyRepository:
-			logger.Infof("Using empty in-memory schema repository")
-			return data.NewInMemorySchemaRepository(), nil
-		case inmemoryConfig.JSONRepository:
-			logger.Infof("Using json in-memory schema repository from path %q", c.InMemory.Path)
-			return data.NewInMemorySchemaRepositoryFromJsonFile(ctx, c.InMemory.Path, data.FeaturesAwareSchemaFactory)
-		case inmemoryConfig.DirRepository:
-			logger.Infof("Using dir in-memory schema repository from path %q", c.InMemory.Path)
-			return data.NewInMemorySchemaRepositoryFromDir(ctx, c.InMemory.Path, data.FeaturesAwareSchemaFactory)
-		default:
-			return nil, fmt.Errorf("invalid repository type: %s/%s", c.Repository, c.InMemory.Type)
-		}
-	}
-
-	return nil, fmt.Errorf("invalid repository type: %s", c.Repository)
-}
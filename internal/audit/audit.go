@@ -0,0 +1,261 @@
+// Package audit records security-relevant operations (SEC-MON-REQ-1) as typed, hash-chained
+// entries and writes them to one or more sinks.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/middleware/tracing"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/google/uuid"
+)
+
+// RequestIdHeader is the request header a caller's request ID is taken from. Requests without it
+// are identified by their trace ID.
+const RequestIdHeader = "x-request-id"
+
+// Outcome is the result of an audited operation.
+type Outcome string
+
+const (
+	OutcomeSuccess Outcome = "success"
+	OutcomeFailure Outcome = "failure"
+	// OutcomeDenied is an operation refused by authorization (EOI-8 authorization_failure).
+	OutcomeDenied Outcome = "denied"
+)
+
+// Resource identifies what an audited operation acted on. Fields that don't apply are empty.
+type Resource struct {
+	ResourceType       string `json:"resource_type,omitempty"`
+	ResourceId         string `json:"resource_id,omitempty"`
+	ReporterType       string `json:"reporter_type,omitempty"`
+	ReporterInstanceId string `json:"reporter_instance_id,omitempty"`
+	OrgId              string `json:"org_id,omitempty"`
+}
+
+// Event is an audited operation as reported by a usecase.
+type Event struct {
+	// Message is a human-readable summary, e.g. "Resource deleted".
+	Message   string   `json:"message"`
+	Action    string   `json:"action"`
+	Principal string   `json:"principal"`
+	Resource  Resource `json:"resource"`
+	// Relation is the relation or permission of check operations.
+	Relation string  `json:"relation,omitempty"`
+	Outcome  Outcome `json:"outcome"`
+	Reason   string  `json:"reason,omitempty"`
+}
+
+// Entry is an event as written to sinks. Entries of an auditor form a chain: each entry's Hash
+// covers the entry and the Hash of the one before it, so that changing, dropping or reordering
+// entries breaks the chain (see Verify). With a key, Hash is an HMAC, so that only holders of the
+// key can rewrite the chain.
+type Entry struct {
+	// ChainId identifies the chain; each auditor starts a new one.
+	ChainId  string    `json:"chain_id"`
+	Sequence uint64    `json:"seq"`
+	Time     time.Time `json:"time"`
+	// RequestId is the caller's x-request-id header, or the trace ID of the request.
+	RequestId string `json:"request_id,omitempty"`
+	Event
+	PrevHash string `json:"prev_hash"`
+	Hash     string `json:"hash"`
+}
+
+// Sink writes audit entries, in chain order.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+	Close() error
+}
+
+// Store is a sink whose entries can be read back, in chain order, for verification.
+type Store interface {
+	Sink
+	Each(ctx context.Context, fn func(Entry) error) error
+}
+
+// Defaults of the queue of entries waiting to be written to the sinks.
+const (
+	DefaultQueueSize    = 10000
+	DefaultWriteTimeout = 5 * time.Second
+)
+
+// Auditor chains events into entries and writes them to its sinks. Entries are queued and written
+// in chain order by a background writer, so that a slow sink doesn't hold up audited requests.
+type Auditor struct {
+	sinks        []Sink
+	log          *log.Helper
+	now          func() time.Time
+	key          []byte
+	queueSize    int
+	writeTimeout time.Duration
+
+	// mu guards the chain, the sequence and hash of the last entry queued, and closed. Entries
+	// are queued under mu, so that they are queued in chain order.
+	mu       sync.Mutex
+	chainId  string
+	sequence uint64
+	prevHash string
+	closed   bool
+
+	queue       chan Entry
+	startWriter sync.Once
+	// written is closed once the writer has written every queued entry.
+	written chan struct{}
+}
+
+// AuditorOption configures an Auditor.
+type AuditorOption func(*Auditor)
+
+// WithKey hashes entries with an HMAC keyed by key instead of a plain SHA-256.
+func WithKey(key []byte) AuditorOption {
+	return func(a *Auditor) { a.key = key }
+}
+
+// WithQueueSize bounds the entries waiting to be written. Events recorded while the queue is full
+// are logged but left out of the chain.
+func WithQueueSize(size int) AuditorOption {
+	return func(a *Auditor) { a.queueSize = size }
+}
+
+// WithWriteTimeout bounds each write of an entry to a sink.
+func WithWriteTimeout(timeout time.Duration) AuditorOption {
+	return func(a *Auditor) { a.writeTimeout = timeout }
+}
+
+// NewAuditor returns an auditor that starts a new chain.
+func NewAuditor(sinks []Sink, logger log.Logger, opts ...AuditorOption) *Auditor {
+	a := &Auditor{
+		sinks:        sinks,
+		log:          log.NewHelper(logger),
+		now:          time.Now,
+		queueSize:    DefaultQueueSize,
+		writeTimeout: DefaultWriteTimeout,
+		chainId:      uuid.NewString(),
+		written:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.queue = make(chan Entry, a.queueSize)
+	return a
+}
+
+// NewLogAuditor returns an auditor that writes entries to the log only.
+func NewLogAuditor(logger log.Logger) *Auditor {
+	return NewAuditor([]Sink{NewLogSink(logger)}, logger)
+}
+
+// Record appends an event to the chain and queues it for the sinks. Auditing never fails or holds
+// up the audited operation: sink errors are logged, and when the queue is full the event is logged
+// instead of chained, so that the chain stays unbroken. Entries are written even if ctx is
+// canceled.
+func (a *Auditor) Record(ctx context.Context, event Event) {
+	a.startWriter.Do(func() { go a.write() })
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		a.logUnchained("Audit entry recorded after the auditor was closed", event)
+		return
+	}
+
+	entry := Entry{
+		ChainId:   a.chainId,
+		Sequence:  a.sequence + 1,
+		Time:      a.now().UTC().Truncate(time.Microsecond), // as stored by postgres
+		RequestId: requestId(ctx),
+		Event:     event,
+		PrevHash:  a.prevHash,
+	}
+	hash, err := entry.computeHash(a.key)
+	if err != nil {
+		a.log.Errorf("failed to hash audit entry: %v", err)
+		return
+	}
+	entry.Hash = hash
+
+	select {
+	case a.queue <- entry:
+		a.sequence = entry.Sequence
+		a.prevHash = hash
+	default:
+		a.logUnchained("Audit queue full, entry not written to the audit sinks", event)
+	}
+}
+
+// write writes the queued entries to every sink, in chain order, until the queue is closed.
+func (a *Auditor) write() {
+	defer close(a.written)
+	for entry := range a.queue {
+		for _, sink := range a.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), a.writeTimeout)
+			err := sink.Write(ctx, entry)
+			cancel()
+			if err != nil {
+				a.log.Errorw("msg", "Failed to write audit entry",
+					"audit_seq", entry.Sequence,
+					"action", entry.Action,
+					"error", err,
+				)
+			}
+		}
+	}
+}
+
+// logUnchained logs an event that couldn't be added to the chain, so that it isn't lost entirely.
+func (a *Auditor) logUnchained(msg string, event Event) {
+	a.log.Errorw("msg", msg,
+		"event", "audit_entry_dropped",
+		"action", event.Action,
+		"principal", event.Principal,
+		"resource_type", event.Resource.ResourceType,
+		"resource_id", event.Resource.ResourceId,
+		"reporter_type", event.Resource.ReporterType,
+		"outcome", string(event.Outcome),
+	)
+}
+
+// Close waits for the queued entries to be written, then closes every sink, flushing buffered
+// entries. Events recorded after Close are logged only.
+func (a *Auditor) Close() error {
+	a.startWriter.Do(func() { go a.write() })
+
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.queue)
+	a.mu.Unlock()
+	<-a.written
+
+	var errs []error
+	for _, sink := range a.sinks {
+		if err := sink.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to close audit sinks: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+func requestId(ctx context.Context) string {
+	if tr, ok := transport.FromServerContext(ctx); ok {
+		if id := tr.RequestHeader().Get(RequestIdHeader); id != "" {
+			return id
+		}
+	}
+	if traceId, ok := tracing.TraceID()(ctx).(string); ok {
+		return traceId
+	}
+	return ""
+}
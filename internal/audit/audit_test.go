@@ -0,0 +1,418 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	entries []Entry
+	err     error
+	closed  bool
+}
+
+func (s *recordingSink) Write(_ context.Context, entry Entry) error {
+	s.entries = append(s.entries, entry)
+	return s.err
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return s.err
+}
+
+// capturingLogger records the key-values of each log line.
+type capturingLogger struct {
+	mu    sync.Mutex
+	lines []map[string]interface{}
+	level []log.Level
+}
+
+func (l *capturingLogger) Log(level log.Level, keyvals ...interface{}) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	line := map[string]interface{}{}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		line[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+	}
+	l.lines = append(l.lines, line)
+	l.level = append(l.level, level)
+	return nil
+}
+
+// mockTransporter is a test helper that implements transport.Transporter
+type mockTransporter struct {
+	headers map[string]string
+}
+
+func (m *mockTransporter) Kind() transport.Kind            { return transport.KindGRPC }
+func (m *mockTransporter) Endpoint() string                { return "/test" }
+func (m *mockTransporter) Operation() string               { return "test" }
+func (m *mockTransporter) RequestHeader() transport.Header { return &mockHeader{headers: m.headers} }
+func (m *mockTransporter) ReplyHeader() transport.Header {
+	return &mockHeader{headers: make(map[string]string)}
+}
+
+type mockHeader struct {
+	headers map[string]string
+}
+
+func (m *mockHeader) Get(key string) string      { return m.headers[key] }
+func (m *mockHeader) Set(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Add(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Keys() []string             { return nil }
+func (m *mockHeader) Values(key string) []string { return nil }
+
+func deleteEvent(resourceId string) Event {
+	return Event{
+		Message:   "Resource deleted",
+		Action:    "DELETE",
+		Principal: "hbi",
+		Resource:  Resource{ResourceType: "host", ResourceId: resourceId, ReporterType: "hbi", ReporterInstanceId: "instance-1"},
+		Outcome:   OutcomeSuccess,
+	}
+}
+
+func recordEntries(t *testing.T, n int, opts ...AuditorOption) []Entry {
+	t.Helper()
+	sink := &recordingSink{}
+	auditor := NewAuditor([]Sink{sink}, log.DefaultLogger, opts...)
+	for i := 0; i < n; i++ {
+		auditor.Record(context.Background(), deleteEvent(fmt.Sprintf("host-%d", i)))
+	}
+	require.NoError(t, auditor.Close())
+	return sink.entries
+}
+
+func TestAuditor_ChainsEntries(t *testing.T) {
+	first, second := &recordingSink{}, &recordingSink{}
+	auditor := NewAuditor([]Sink{first, second}, log.DefaultLogger)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 123456789, time.FixedZone("CEST", 2*60*60))
+	auditor.now = func() time.Time { return now }
+
+	auditor.Record(context.Background(), deleteEvent("host-1"))
+	auditor.Record(context.Background(), deleteEvent("host-2"))
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, first.entries, 2)
+	assert.Equal(t, first.entries, second.entries, "every sink sees the same entries")
+	entry1, entry2 := first.entries[0], first.entries[1]
+	assert.NotEmpty(t, entry1.ChainId)
+	assert.Equal(t, entry1.ChainId, entry2.ChainId)
+	assert.Equal(t, uint64(1), entry1.Sequence)
+	assert.Equal(t, uint64(2), entry2.Sequence)
+	assert.Empty(t, entry1.PrevHash)
+	assert.Equal(t, entry1.Hash, entry2.PrevHash)
+	assert.Len(t, entry1.Hash, 64)
+	assert.Equal(t, time.Date(2026, 10, 18, 10, 0, 0, 123456000, time.UTC), entry1.Time, "times are UTC with microsecond precision")
+	assert.Equal(t, "host-1", entry1.Resource.ResourceId)
+
+	assert.NoError(t, Verify(nil, first.entries))
+}
+
+func TestAuditor_NewChainPerAuditor(t *testing.T) {
+	a := recordEntries(t, 1)
+	b := recordEntries(t, 1)
+	assert.NotEqual(t, a[0].ChainId, b[0].ChainId)
+	assert.NoError(t, Verify(nil, append(a, b...)), "chains are verified independently")
+}
+
+func TestAuditor_SinkErrorsDontStopOtherSinks(t *testing.T) {
+	failing := &recordingSink{err: errors.New("disk full")}
+	working := &recordingSink{}
+	auditor := NewAuditor([]Sink{failing, working}, log.DefaultLogger)
+
+	auditor.Record(context.Background(), deleteEvent("host-1"))
+
+	err := auditor.Close()
+	assert.Len(t, working.entries, 1)
+	assert.ErrorContains(t, err, "disk full")
+	assert.True(t, failing.closed)
+	assert.True(t, working.closed)
+}
+
+func TestAuditor_RequestId(t *testing.T) {
+	sink := &recordingSink{}
+	auditor := NewAuditor([]Sink{sink}, log.DefaultLogger)
+
+	ctx := transport.NewServerContext(context.Background(), &mockTransporter{headers: map[string]string{RequestIdHeader: "req-42"}})
+	auditor.Record(ctx, deleteEvent("host-1"))
+	auditor.Record(context.Background(), deleteEvent("host-2"))
+	require.NoError(t, auditor.Close())
+
+	assert.Equal(t, "req-42", sink.entries[0].RequestId)
+	assert.Empty(t, sink.entries[1].RequestId)
+}
+
+func TestAuditor_ConcurrentRecordsFormOneChain(t *testing.T) {
+	sink := &recordingSink{}
+	auditor := NewAuditor([]Sink{sink}, log.DefaultLogger)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			auditor.Record(context.Background(), deleteEvent(fmt.Sprintf("host-%d", i)))
+		}(i)
+	}
+	wg.Wait()
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, sink.entries, 50)
+	assert.NoError(t, Verify(nil, sink.entries))
+}
+
+// blockingSink holds its first write until released or until the write's context is done, and
+// records whether writes saw a done context.
+type blockingSink struct {
+	recordingSink
+	started  chan struct{}
+	release  chan struct{}
+	canceled []bool
+}
+
+func newBlockingSink() *blockingSink {
+	return &blockingSink{started: make(chan struct{}), release: make(chan struct{})}
+}
+
+func (s *blockingSink) Write(ctx context.Context, entry Entry) error {
+	if len(s.canceled) == 0 {
+		close(s.started)
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+		}
+	}
+	s.canceled = append(s.canceled, ctx.Err() != nil)
+	return s.recordingSink.Write(ctx, entry)
+}
+
+func TestAuditor_HungSinkDoesntHoldUpRecord(t *testing.T) {
+	sink := newBlockingSink()
+	auditor := NewAuditor([]Sink{sink}, log.DefaultLogger, WithWriteTimeout(20*time.Millisecond))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	auditor.Record(context.Background(), deleteEvent("host-1"))
+	<-sink.started
+	recorded := make(chan struct{})
+	go func() {
+		defer close(recorded)
+		auditor.Record(canceled, deleteEvent("host-2"))
+	}()
+	select {
+	case <-recorded:
+	case <-time.After(time.Second):
+		t.Fatal("Record waited for a pending sink write")
+	}
+
+	require.NoError(t, auditor.Close())
+	require.Len(t, sink.entries, 2)
+	assert.Equal(t, []bool{true, false}, sink.canceled, "the hung write times out, and canceling the request doesn't drop its entry")
+	assert.NoError(t, Verify(nil, sink.entries), "sinks see entries in chain order")
+}
+
+func TestAuditor_FullQueueLeavesEventsOutOfChain(t *testing.T) {
+	logger := &capturingLogger{}
+	sink := newBlockingSink()
+	auditor := NewAuditor([]Sink{sink}, logger, WithQueueSize(1))
+
+	auditor.Record(context.Background(), deleteEvent("host-1"))
+	<-sink.started
+	auditor.Record(context.Background(), deleteEvent("host-2")) // waits in the queue
+	auditor.Record(context.Background(), deleteEvent("host-3")) // finds the queue full
+	close(sink.release)
+	require.NoError(t, auditor.Close())
+	auditor.Record(context.Background(), deleteEvent("host-4"))
+
+	require.Len(t, sink.entries, 2)
+	assert.Equal(t, "host-2", sink.entries[1].Resource.ResourceId)
+	assert.NoError(t, Verify(nil, sink.entries), "dropped events don't break the chain")
+
+	var dropped []interface{}
+	for i, line := range logger.lines {
+		if line["event"] == "audit_entry_dropped" {
+			assert.Equal(t, log.LevelError, logger.level[i])
+			dropped = append(dropped, line["resource_id"])
+		}
+	}
+	assert.Equal(t, []interface{}{"host-3", "host-4"}, dropped, "events left out of the chain are logged")
+}
+
+func TestVerify_Key(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+	entries := recordEntries(t, 3, WithKey(key))
+
+	assert.NoError(t, Verify(key, entries))
+	assert.ErrorIs(t, Verify([]byte(strings.Repeat("x", 32)), entries), ErrChainBroken, "another key doesn't verify the chain")
+	assert.ErrorIs(t, Verify(nil, entries), ErrChainBroken)
+
+	// Without the key, a changed entry can't be rehashed into the chain.
+	entries[2].Outcome = OutcomeFailure
+	entries[2].Hash, _ = entries[2].computeHash(nil)
+	err := Verify(key, entries)
+	require.ErrorIs(t, err, ErrChainBroken)
+	assert.ErrorContains(t, err, "entry 3: hash mismatch")
+}
+
+func TestVerify_DetectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func([]Entry) []Entry
+		want   string
+	}{
+		{
+			name: "changed entry",
+			tamper: func(entries []Entry) []Entry {
+				entries[1].Principal = "someone-else"
+				return entries
+			},
+			want: "entry 2: hash mismatch",
+		},
+		{
+			name: "changed and rehashed entry",
+			tamper: func(entries []Entry) []Entry {
+				entries[1].Outcome = OutcomeFailure
+				entries[1].Hash, _ = entries[1].computeHash(nil)
+				return entries
+			},
+			want: "entry 3: previous hash mismatch",
+		},
+		{
+			name: "dropped entry",
+			tamper: func(entries []Entry) []Entry {
+				return append(entries[:1], entries[2:]...)
+			},
+			want: "entry 3: expected entry 2",
+		},
+		{
+			name: "reordered entries",
+			tamper: func(entries []Entry) []Entry {
+				entries[1], entries[2] = entries[2], entries[1]
+				return entries
+			},
+			want: "entry 3: expected entry 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries := tt.tamper(recordEntries(t, 3))
+			err := Verify(nil, entries)
+			require.ErrorIs(t, err, ErrChainBroken)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestVerify_ChainMayStartAfterRotation(t *testing.T) {
+	entries := recordEntries(t, 3)
+	assert.NoError(t, Verify(nil, entries[1:]), "older entries may have been rotated away")
+}
+
+func TestVerifier_AnchorDetectsDroppedEnds(t *testing.T) {
+	entries := recordEntries(t, 5)
+	checkpoint := Checkpoint{ChainId: entries[0].ChainId, First: 1, Last: 4, Hash: entries[3].Hash}
+	rehashed := entries[3]
+	rehashed.Outcome = OutcomeFailure
+	rehashed.Hash, _ = rehashed.computeHash(nil)
+
+	tests := []struct {
+		name    string
+		entries []Entry
+		want    string
+	}{
+		{name: "whole chain", entries: entries[:4]},
+		{name: "entries after the checkpoint", entries: entries},
+		{name: "dropped head", entries: entries[1:4], want: "expected first entry 1, got 2"},
+		{name: "dropped tail", entries: entries[:3], want: "expected last entry 4, got 3"},
+		{name: "rewritten tail", entries: append(slices.Clone(entries[:3]), rehashed), want: "entry 4: checkpoint hash mismatch"},
+		{name: "dropped chain", entries: nil, want: "entries 1 to 4 missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewVerifier(nil)
+			v.Anchor(checkpoint)
+			for _, entry := range tt.entries {
+				require.NoError(t, v.Add(entry))
+			}
+			err := v.Finish()
+			if tt.want == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrChainBroken)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestVerifier_AddJSONLines(t *testing.T) {
+	path := t.TempDir() + "/audit.log"
+	sink, err := NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	for _, entry := range recordEntries(t, 3) {
+		require.NoError(t, sink.Write(context.Background(), entry))
+	}
+	require.NoError(t, sink.Close())
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 3)
+
+	count, err := NewVerifier(nil).AddJSONLines(strings.NewReader(strings.Join(lines, "\n")))
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	tampered := strings.Replace(lines[1], `"principal":"hbi"`, `"principal":"acs"`, 1)
+	count, err = NewVerifier(nil).AddJSONLines(strings.NewReader(strings.Join([]string{lines[0], tampered, lines[2]}, "\n")))
+	assert.ErrorIs(t, err, ErrChainBroken)
+	assert.Equal(t, 1, count)
+
+	_, err = NewVerifier(nil).AddJSONLines(strings.NewReader(lines[0] + "\nnot json\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestLogSink(t *testing.T) {
+	logger := &capturingLogger{}
+	auditor := NewAuditor([]Sink{NewLogSink(logger)}, logger)
+
+	auditor.Record(context.Background(), deleteEvent("host-1"))
+	auditor.Record(context.Background(), Event{
+		Message:   "Permission denied",
+		Action:    "CHECK",
+		Principal: "hbi",
+		Resource:  Resource{ResourceType: "host", ResourceId: "host-1"},
+		Relation:  "view",
+		Outcome:   OutcomeDenied,
+	})
+	require.NoError(t, auditor.Close())
+
+	require.Len(t, logger.lines, 2)
+	assert.Equal(t, log.LevelInfo, logger.level[0])
+	assert.Equal(t, "Resource deleted", logger.lines[0]["msg"])
+	assert.Equal(t, "DELETE", logger.lines[0]["action"])
+	assert.Equal(t, "host-1", logger.lines[0]["resource_id"])
+	assert.Equal(t, "instance-1", logger.lines[0]["reporter_instance_id"])
+	assert.Equal(t, "success", logger.lines[0]["outcome"])
+	assert.Equal(t, uint64(1), logger.lines[0]["audit_seq"])
+	assert.NotContains(t, logger.lines[0], "event")
+	assert.NotContains(t, logger.lines[0], "relation")
+
+	assert.Equal(t, log.LevelWarn, logger.level[1])
+	assert.Equal(t, "authorization_failure", logger.lines[1]["event"])
+	assert.Equal(t, "view", logger.lines[1]["relation"])
+	assert.Equal(t, "failure", logger.lines[1]["outcome"], "denials keep the SEC-MON-REQ-1 outcome")
+}
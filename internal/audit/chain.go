@@ -0,0 +1,164 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// ErrChainBroken indicates audit entries were changed, dropped or reordered.
+var ErrChainBroken = errors.New("audit chain broken")
+
+// maxEntrySize bounds the size of a single JSON-lines entry read by AddJSONLines.
+const maxEntrySize = 1024 * 1024
+
+// computeHash returns the hex HMAC-SHA256 with key of the entry's JSON encoding without its Hash,
+// or its plain SHA-256 without a key. PrevHash is part of the encoding, which links the entry to
+// the one before it.
+func (e Entry) computeHash(key []byte) (string, error) {
+	e.Hash = ""
+	data, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:]), nil
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Checkpoint records the first and last entries of a chain that a sink holds, so that entries
+// dropped from either end of the chain are detected (see Verifier.Anchor).
+type Checkpoint struct {
+	ChainId string `json:"chain_id"`
+	First   uint64 `json:"first_seq"`
+	Last    uint64 `json:"last_seq"`
+	// Hash is the hash of the last entry.
+	Hash string `json:"hash"`
+}
+
+// Checkpointer is a store that keeps a checkpoint of each of its chains.
+type Checkpointer interface {
+	Checkpoints(ctx context.Context) ([]Checkpoint, error)
+}
+
+// Verifier checks that entries, fed in chain order, form unbroken chains. Entries of several
+// chains may be interleaved. A chain may start at any sequence, since older entries may have
+// been rotated away, but from then on every entry must follow the one before it. Chains with a
+// checkpoint must also start and end where it says (see Anchor).
+type Verifier struct {
+	key     []byte
+	first   map[string]uint64
+	last    map[string]Entry
+	anchors map[string]Checkpoint
+}
+
+// NewVerifier returns a verifier of entries hashed with key, or with a plain SHA-256 when key is
+// nil.
+func NewVerifier(key []byte) *Verifier {
+	return &Verifier{key: key, first: map[string]uint64{}, last: map[string]Entry{}, anchors: map[string]Checkpoint{}}
+}
+
+// Anchor makes Finish check that the chain of the checkpoint runs from its first to at least its
+// last entry.
+func (v *Verifier) Anchor(checkpoint Checkpoint) {
+	v.anchors[checkpoint.ChainId] = checkpoint
+}
+
+// Finish checks the ends of the anchored chains, once all entries have been added. Entries may
+// follow a checkpoint's last one, as a sink may have stopped before updating its checkpoint.
+func (v *Verifier) Finish() error {
+	chainIds := slices.Sorted(maps.Keys(v.anchors))
+	for _, chainId := range chainIds {
+		checkpoint := v.anchors[chainId]
+		first, ok := v.first[chainId]
+		if !ok {
+			return fmt.Errorf("%w: chain %s: entries %d to %d missing", ErrChainBroken, chainId, checkpoint.First, checkpoint.Last)
+		}
+		if first != checkpoint.First {
+			return fmt.Errorf("%w: chain %s: expected first entry %d, got %d", ErrChainBroken, chainId, checkpoint.First, first)
+		}
+		last := v.last[chainId]
+		if last.Sequence < checkpoint.Last {
+			return fmt.Errorf("%w: chain %s: expected last entry %d, got %d", ErrChainBroken, chainId, checkpoint.Last, last.Sequence)
+		}
+		if last.Sequence == checkpoint.Last && last.Hash != checkpoint.Hash {
+			return fmt.Errorf("%w: chain %s entry %d: checkpoint hash mismatch", ErrChainBroken, chainId, last.Sequence)
+		}
+	}
+	return nil
+}
+
+// Add verifies the next entry of its chain.
+func (v *Verifier) Add(entry Entry) error {
+	hash, err := entry.computeHash(v.key)
+	if err != nil {
+		return err
+	}
+	if hash != entry.Hash {
+		return fmt.Errorf("%w: chain %s entry %d: hash mismatch", ErrChainBroken, entry.ChainId, entry.Sequence)
+	}
+	if last, ok := v.last[entry.ChainId]; ok {
+		if entry.Sequence != last.Sequence+1 {
+			return fmt.Errorf("%w: chain %s entry %d: expected entry %d", ErrChainBroken, entry.ChainId, entry.Sequence, last.Sequence+1)
+		}
+		if entry.PrevHash != last.Hash {
+			return fmt.Errorf("%w: chain %s entry %d: previous hash mismatch", ErrChainBroken, entry.ChainId, entry.Sequence)
+		}
+	}
+	if _, ok := v.first[entry.ChainId]; !ok {
+		v.first[entry.ChainId] = entry.Sequence
+	}
+	v.last[entry.ChainId] = entry
+	return nil
+}
+
+// Chains returns the number of chains seen.
+func (v *Verifier) Chains() int {
+	return len(v.last)
+}
+
+// Verify checks that entries hashed with key, in chain order, form unbroken chains.
+func Verify(key []byte, entries []Entry) error {
+	v := NewVerifier(key)
+	for _, entry := range entries {
+		if err := v.Add(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddJSONLines verifies entries written one per line, as by the file sink, and returns the
+// number of entries read.
+func (v *Verifier) AddJSONLines(r io.Reader) (int, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+	count, line := 0, 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return count, fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := v.Add(entry); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, scanner.Err()
+}
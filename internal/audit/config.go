@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const kafkaClientID = "inventory-audit"
+
+type Config struct {
+	*Options
+}
+
+type completedConfig struct {
+	*Options
+	Sinks       []string
+	HMACKey     []byte
+	File        FileOptions
+	KafkaTopic  string
+	KafkaConfig *kafka.ConfigMap
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+func (c *Config) Complete() (CompletedConfig, []error) {
+	var errs []error
+	var hmacKey []byte
+	if c.HMACKeyFile != "" {
+		key, err := ReadHMACKey(c.HMACKeyFile)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("audit.hmac-key-file: %w", err))
+		}
+		hmacKey = key
+	}
+	var kafkaConfig *kafka.ConfigMap
+	if c.hasSink(SinkKafka) {
+		kafkaConfig = &kafka.ConfigMap{}
+		// Idempotence keeps the entries of a chain in order and free of duplicates across retries.
+		settings := map[string]string{
+			"client.id":          kafkaClientID,
+			"bootstrap.servers":  strings.Join(c.Kafka.BootstrapServers, ","),
+			"acks":               "all",
+			"enable.idempotence": "true",
+		}
+		if auth := c.Kafka.AuthOptions; auth != nil && auth.Enabled {
+			settings["security.protocol"] = auth.SecurityProtocol
+			settings["sasl.mechanism"] = auth.SASLMechanism
+			settings["sasl.username"] = auth.SASLUsername
+			settings["sasl.password"] = auth.SASLPassword
+			settings["ssl.ca.location"] = auth.CACertLocation
+		}
+		for key, value := range settings {
+			if value == "" {
+				continue
+			}
+			if err := kafkaConfig.SetKey(key, value); err != nil {
+				errs = append(errs, fmt.Errorf("cannot set %s value: %w", key, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return CompletedConfig{}, errs
+	}
+
+	return CompletedConfig{&completedConfig{
+		Options:     c.Options,
+		Sinks:       c.Options.Sinks,
+		HMACKey:     hmacKey,
+		File:        *c.File,
+		KafkaTopic:  c.Kafka.Topic,
+		KafkaConfig: kafkaConfig,
+	}}, nil
+}
+
+// New returns an auditor writing to the configured sinks. store backs the postgres sink.
+func New(c CompletedConfig, store Sink, logger log.Logger) (*Auditor, error) {
+	var sinks []Sink
+	closeSinks := func() {
+		for _, sink := range sinks {
+			_ = sink.Close()
+		}
+	}
+	for _, name := range c.Sinks {
+		var sink Sink
+		var err error
+		switch name {
+		case SinkLog:
+			sink = NewLogSink(logger)
+		case SinkFile:
+			sink, err = NewFileSink(c.File.Path, int64(c.File.MaxSizeMB)*1024*1024, c.File.MaxBackups)
+		case SinkKafka:
+			sink, err = NewKafkaSink(c.KafkaConfig, c.KafkaTopic, logger)
+		case SinkPostgres:
+			if store == nil {
+				err = errors.New("the postgres sink requires a database")
+			}
+			sink = store
+		default:
+			err = fmt.Errorf("unknown sink %q", name)
+		}
+		if err != nil {
+			closeSinks()
+			return nil, fmt.Errorf("failed to create audit %s sink: %w", name, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return NewAuditor(sinks, logger,
+		WithKey(c.HMACKey),
+		WithQueueSize(c.QueueSize),
+		WithWriteTimeout(c.WriteTimeout),
+	), nil
+}
+
+// minHMACKeySize is the size of the SHA-256 output; shorter keys weaken the HMAC.
+const minHMACKeySize = 32
+
+// ReadHMACKey reads the key of the audit chain HMAC from path. Surrounding whitespace, such as the
+// trailing newline of a mounted secret, is not part of the key.
+func ReadHMACKey(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	key := bytes.TrimSpace(data)
+	if len(key) < minHMACKeySize {
+		return nil, fmt.Errorf("key must be at least %d bytes, got %d", minHMACKeySize, len(key))
+	}
+	return key, nil
+}
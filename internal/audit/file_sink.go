@@ -0,0 +1,253 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rotatedTimeFormat names rotated files so that they sort in rotation order.
+const rotatedTimeFormat = "20060102T150405.000000000Z"
+
+// fileSink appends entries to a file as JSON lines. When the file would grow past maxSize, it is
+// renamed to <path>.<rotation time> and a new file is started; only the newest maxBackups
+// rotated files are kept. The checkpoints of the chains in the files are kept in
+// <path>.chains, rewritten after every entry.
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+	now        func() time.Time
+
+	mu          sync.Mutex
+	file        *os.File
+	size        int64
+	checkpoints map[string]Checkpoint
+}
+
+// NewFileSink opens, or creates, the audit file at path.
+func NewFileSink(path string, maxSize int64, maxBackups int) (Sink, error) {
+	s := &fileSink{path: path, maxSize: maxSize, maxBackups: maxBackups, now: time.Now, checkpoints: map[string]Checkpoint{}}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	checkpoints, err := ReadFileCheckpoints(path)
+	if err != nil {
+		_ = s.file.Close()
+		return nil, err
+	}
+	for _, checkpoint := range checkpoints {
+		s.checkpoints[checkpoint.ChainId] = checkpoint
+	}
+	return s, nil
+}
+
+// FileCheckpointsPath returns the path of the checkpoints of the audit file at path.
+func FileCheckpointsPath(path string) string {
+	return path + ".chains"
+}
+
+// ReadFileCheckpoints returns the checkpoints of the audit file at path, or none when it has
+// none, as files written before checkpoints were kept don't.
+func ReadFileCheckpoints(path string) ([]Checkpoint, error) {
+	data, err := os.ReadFile(FileCheckpointsPath(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit checkpoints: %w", err)
+	}
+	var checkpoints []Checkpoint
+	if err := json.Unmarshal(data, &checkpoints); err != nil {
+		return nil, fmt.Errorf("failed to read audit checkpoints: %w", err)
+	}
+	return checkpoints, nil
+}
+
+func (s *fileSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return fmt.Errorf("audit file %s is closed", s.path)
+	}
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return err
+	}
+
+	checkpoint, ok := s.checkpoints[entry.ChainId]
+	if !ok {
+		checkpoint = Checkpoint{ChainId: entry.ChainId, First: entry.Sequence}
+	}
+	checkpoint.Last, checkpoint.Hash = entry.Sequence, entry.Hash
+	s.checkpoints[entry.ChainId] = checkpoint
+	return s.writeCheckpoints()
+}
+
+// writeCheckpoints replaces the checkpoints file, through a rename so that it is never partly
+// written.
+func (s *fileSink) writeCheckpoints() error {
+	checkpoints := slices.SortedFunc(maps.Values(s.checkpoints), func(a, b Checkpoint) int {
+		return strings.Compare(a.ChainId, b.ChainId)
+	})
+	data, err := json.Marshal(checkpoints)
+	if err != nil {
+		return err
+	}
+	path := FileCheckpointsPath(s.path)
+	if err := os.WriteFile(path+".tmp", data, 0o600); err != nil {
+		return fmt.Errorf("failed to write audit checkpoints: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write audit checkpoints: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+func (s *fileSink) open() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to stat audit file: %w", err)
+	}
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit file: %w", err)
+	}
+	s.file = nil
+	rotated := s.path + "." + s.now().UTC().Format(rotatedTimeFormat)
+	if err := os.Rename(s.path, rotated); err != nil {
+		return fmt.Errorf("failed to rotate audit file: %w", err)
+	}
+	if err := s.open(); err != nil {
+		return err
+	}
+	return s.removeOldBackups()
+}
+
+func (s *fileSink) removeOldBackups() error {
+	if s.maxBackups <= 0 {
+		return nil
+	}
+	backups, err := RotatedFiles(s.path)
+	if err != nil {
+		return err
+	}
+	if len(backups) <= s.maxBackups {
+		return nil
+	}
+	for len(backups) > s.maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return fmt.Errorf("failed to remove rotated audit file: %w", err)
+		}
+		backups = backups[1:]
+	}
+
+	// The chains now start at their first entries in the files that are left.
+	first, err := firstSequences(append(backups, s.path))
+	if err != nil {
+		return err
+	}
+	for chainId, checkpoint := range s.checkpoints {
+		seq, ok := first[chainId]
+		if !ok {
+			delete(s.checkpoints, chainId)
+			continue
+		}
+		checkpoint.First = seq
+		s.checkpoints[chainId] = checkpoint
+	}
+	return s.writeCheckpoints()
+}
+
+// firstSequences returns the sequence of the first entry of each chain in files.
+func firstSequences(files []string) (map[string]uint64, error) {
+	first := map[string]uint64{}
+	for _, name := range files {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit file: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxEntrySize)
+		for scanner.Scan() {
+			var entry struct {
+				ChainId  string `json:"chain_id"`
+				Sequence uint64 `json:"seq"`
+			}
+			if len(scanner.Bytes()) == 0 || json.Unmarshal(scanner.Bytes(), &entry) != nil {
+				continue
+			}
+			if _, ok := first[entry.ChainId]; !ok {
+				first[entry.ChainId] = entry.Sequence
+			}
+		}
+		err = scanner.Err()
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read audit file: %w", err)
+		}
+	}
+	return first, nil
+}
+
+// RotatedFiles returns the rotated files of the audit file at path, oldest first.
+func RotatedFiles(path string) ([]string, error) {
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, err
+	}
+	prefix := path + "."
+	backups := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if _, err := time.Parse(rotatedTimeFormat, strings.TrimPrefix(match, prefix)); err == nil {
+			backups = append(backups, match)
+		}
+	}
+	slices.Sort(backups)
+	return backups, nil
+}
@@ -0,0 +1,137 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer func() { _ = f.Close() }()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
+
+func TestFileSink_AppendsToExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit", "audit.log")
+	entries := recordEntries(t, 2)
+
+	sink, err := NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), entries[0]))
+	require.NoError(t, sink.Close())
+
+	sink, err = NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), entries[1]))
+	require.NoError(t, sink.Close())
+
+	assert.Len(t, readLines(t, path), 2)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	assert.Error(t, sink.Write(context.Background(), entries[1]), "closed sinks refuse writes")
+}
+
+func TestFileSink_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	entries := recordEntries(t, 10)
+
+	s, err := NewFileSink(path, 1, 3) // every entry starts a new file
+	require.NoError(t, err)
+	sink := s.(*fileSink)
+	rotation := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	sink.now = func() time.Time {
+		rotation = rotation.Add(time.Second)
+		return rotation
+	}
+	for _, entry := range entries {
+		require.NoError(t, sink.Write(context.Background(), entry))
+	}
+	require.NoError(t, sink.Close())
+
+	backups, err := RotatedFiles(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 3, "only max-backups rotated files are kept")
+	assert.Equal(t, path+".20261018T120009.000000000Z", backups[2])
+
+	// The checkpoint follows the chain to the entries that are kept.
+	checkpoints, err := ReadFileCheckpoints(path)
+	require.NoError(t, err)
+	assert.Equal(t, []Checkpoint{{ChainId: entries[0].ChainId, First: 7, Last: 10, Hash: entries[9].Hash}}, checkpoints)
+
+	// The kept files hold the newest entries, which still verify as a chain.
+	v := NewVerifier(nil)
+	v.Anchor(checkpoints[0])
+	for i, name := range append(backups, path) {
+		lines := readLines(t, name)
+		require.Len(t, lines, 1)
+		assert.Contains(t, lines[0], fmt.Sprintf(`"seq":%d`, 7+i))
+
+		f, err := os.Open(name)
+		require.NoError(t, err)
+		_, err = v.AddJSONLines(f)
+		_ = f.Close()
+		require.NoError(t, err)
+	}
+	assert.NoError(t, v.Finish())
+}
+
+func TestFileSink_KeepsCheckpoints(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	checkpoints, err := ReadFileCheckpoints(path)
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints, "files without checkpoints have none")
+
+	a, b := recordEntries(t, 2), recordEntries(t, 1)
+	sink, err := NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), a[0]))
+	require.NoError(t, sink.Close())
+
+	// Checkpoints survive reopening the file.
+	sink, err = NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Write(context.Background(), b[0]))
+	require.NoError(t, sink.Write(context.Background(), a[1]))
+	require.NoError(t, sink.Close())
+
+	checkpoints, err = ReadFileCheckpoints(path)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []Checkpoint{
+		{ChainId: a[0].ChainId, First: 1, Last: 2, Hash: a[1].Hash},
+		{ChainId: b[0].ChainId, First: 1, Last: 1, Hash: b[0].Hash},
+	}, checkpoints)
+}
+
+func TestRotatedFiles_IgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "audit.log")
+	for _, name := range []string{"audit.log", "audit.log.20261018T120002.000000000Z", "audit.log.20261018T120001.000000000Z", "audit.log.bak", "other.log.20261018T120001.000000000Z"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o600))
+	}
+
+	backups, err := RotatedFiles(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		path + ".20261018T120001.000000000Z",
+		path + ".20261018T120002.000000000Z",
+	}, backups)
+}
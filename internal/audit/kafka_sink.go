@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// flushTimeoutMs bounds how long Close waits for undelivered entries.
+const flushTimeoutMs = 10000
+
+// kafkaSink produces entries to a Kafka topic. Entries are keyed by chain ID, so the entries of
+// a chain land on one partition in chain order.
+type kafkaSink struct {
+	producer *kafka.Producer
+	topic    string
+	log      *log.Helper
+	done     chan struct{}
+
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// NewKafkaSink creates a producer for the topic. Delivery failures are logged.
+func NewKafkaSink(config *kafka.ConfigMap, topic string, logger log.Logger) (Sink, error) {
+	producer, err := kafka.NewProducer(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audit producer: %w", err)
+	}
+	s := &kafkaSink{
+		producer: producer,
+		topic:    topic,
+		log:      log.NewHelper(logger),
+		done:     make(chan struct{}),
+	}
+	go s.handleEvents()
+	return s, nil
+}
+
+func (s *kafkaSink) Write(_ context.Context, entry Entry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &s.topic, Partition: kafka.PartitionAny},
+		Key:            []byte(entry.ChainId),
+		Value:          value,
+	}, nil)
+}
+
+func (s *kafkaSink) Close() error {
+	s.closeOnce.Do(func() {
+		remaining := s.producer.Flush(flushTimeoutMs)
+		s.producer.Close()
+		<-s.done
+		if remaining > 0 {
+			s.closeErr = fmt.Errorf("%d audit entries were not delivered to %s", remaining, s.topic)
+		}
+	})
+	return s.closeErr
+}
+
+func (s *kafkaSink) handleEvents() {
+	defer close(s.done)
+	for event := range s.producer.Events() {
+		switch e := event.(type) {
+		case *kafka.Message:
+			if e.TopicPartition.Error != nil {
+				s.log.Errorf("failed to deliver audit entry to %s: %v", s.topic, e.TopicPartition.Error)
+			}
+		case kafka.Error:
+			s.log.Errorf("audit producer error: %v", e)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// logSink writes entries as SEC-MON-REQ-1 log lines: successes at info level, failures and
+// denials at warn level.
+type logSink struct {
+	log *log.Helper
+}
+
+func NewLogSink(logger log.Logger) Sink {
+	return &logSink{log: log.NewHelper(logger)}
+}
+
+func (s *logSink) Write(_ context.Context, entry Entry) error {
+	keyvals := []interface{}{"msg", entry.Message}
+	if entry.Outcome == OutcomeDenied {
+		// Auth failure - SEC-MON-REQ-1 compliance (EOI-8 authorization_failure)
+		keyvals = append(keyvals, "event", "authorization_failure")
+	}
+	keyvals = append(keyvals, "action", entry.Action)
+	keyvals = appendIfSet(keyvals, "resource_type", entry.Resource.ResourceType)
+	keyvals = appendIfSet(keyvals, "resource_id", entry.Resource.ResourceId)
+	keyvals = appendIfSet(keyvals, "reporter_type", entry.Resource.ReporterType)
+	keyvals = appendIfSet(keyvals, "reporter_instance_id", entry.Resource.ReporterInstanceId)
+	keyvals = appendIfSet(keyvals, "org_id", entry.Resource.OrgId)
+	keyvals = appendIfSet(keyvals, "relation", entry.Relation)
+	keyvals = append(keyvals, "principal", entry.Principal)
+
+	switch entry.Outcome {
+	case OutcomeSuccess:
+		keyvals = append(keyvals, "outcome", "success")
+	default:
+		keyvals = append(keyvals, "outcome", "failure")
+	}
+	keyvals = appendIfSet(keyvals, "reason", entry.Reason)
+	keyvals = appendIfSet(keyvals, "request_id", entry.RequestId)
+	keyvals = append(keyvals, "audit_seq", entry.Sequence, "audit_hash", entry.Hash)
+
+	if entry.Outcome == OutcomeSuccess {
+		s.log.Infow(keyvals...)
+	} else {
+		s.log.Warnw(keyvals...)
+	}
+	return nil
+}
+
+func (s *logSink) Close() error {
+	return nil
+}
+
+func appendIfSet(keyvals []interface{}, key, value string) []interface{} {
+	if value == "" {
+		return keyvals
+	}
+	return append(keyvals, key, value)
+}
@@ -0,0 +1,125 @@
+package audit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/project-kessel/inventory-api/internal/consumer/auth"
+)
+
+// Sink names accepted in Options.Sinks.
+const (
+	SinkLog      = "log"
+	SinkFile     = "file"
+	SinkKafka    = "kafka"
+	SinkPostgres = "postgres"
+)
+
+var sinkNames = []string{SinkLog, SinkFile, SinkKafka, SinkPostgres}
+
+type Options struct {
+	Sinks        []string      `mapstructure:"sinks"`
+	HMACKeyFile  string        `mapstructure:"hmac-key-file"`
+	QueueSize    int           `mapstructure:"queue-size"`
+	WriteTimeout time.Duration `mapstructure:"write-timeout"`
+	File         *FileOptions  `mapstructure:"file"`
+	Kafka        *KafkaOptions `mapstructure:"kafka"`
+}
+
+type FileOptions struct {
+	Path       string `mapstructure:"path"`
+	MaxSizeMB  int    `mapstructure:"max-size-mb"`
+	MaxBackups int    `mapstructure:"max-backups"`
+}
+
+type KafkaOptions struct {
+	BootstrapServers []string      `mapstructure:"bootstrap-servers"`
+	Topic            string        `mapstructure:"topic"`
+	AuthOptions      *auth.Options `mapstructure:"auth"`
+}
+
+func NewOptions() *Options {
+	kafkaAuth := auth.NewOptions()
+	kafkaAuth.Enabled = false
+	return &Options{
+		Sinks:        []string{SinkLog},
+		QueueSize:    DefaultQueueSize,
+		WriteTimeout: DefaultWriteTimeout,
+		File: &FileOptions{
+			MaxSizeMB:  100,
+			MaxBackups: 10,
+		},
+		Kafka: &KafkaOptions{
+			Topic:       "kessel.inventory.audit",
+			AuthOptions: kafkaAuth,
+		},
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.StringSliceVar(&o.Sinks, prefix+"sinks", o.Sinks, fmt.Sprintf("Sinks audit entries are written to, any of %s. Empty disables auditing.", strings.Join(sinkNames, ", ")))
+	fs.StringVar(&o.HMACKeyFile, prefix+"hmac-key-file", o.HMACKeyFile, "File holding the key of the HMAC chaining audit entries, required by the file, kafka and postgres sinks. Keep it outside the sinks.")
+	fs.IntVar(&o.QueueSize, prefix+"queue-size", o.QueueSize, "Number of audit entries waiting to be written to the sinks; events recorded while it is full are logged instead")
+	fs.DurationVar(&o.WriteTimeout, prefix+"write-timeout", o.WriteTimeout, "Timeout of writing an audit entry to a sink")
+	fs.StringVar(&o.File.Path, prefix+"file.path", o.File.Path, "Path of the JSON-lines audit file of the file sink")
+	fs.IntVar(&o.File.MaxSizeMB, prefix+"file.max-size-mb", o.File.MaxSizeMB, "Size in megabytes at which the audit file is rotated (0 never rotates)")
+	fs.IntVar(&o.File.MaxBackups, prefix+"file.max-backups", o.File.MaxBackups, "Number of rotated audit files to keep (0 keeps all)")
+	fs.StringSliceVar(&o.Kafka.BootstrapServers, prefix+"kafka.bootstrap-servers", o.Kafka.BootstrapServers, "Kafka bootstrap servers of the kafka sink")
+	fs.StringVar(&o.Kafka.Topic, prefix+"kafka.topic", o.Kafka.Topic, "Kafka topic audit entries are produced to")
+	o.Kafka.AuthOptions.AddFlags(fs, prefix+"kafka.auth")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	for i, sink := range o.Sinks {
+		if !slices.Contains(sinkNames, sink) {
+			errs = append(errs, fmt.Errorf("audit.sinks[%d]: unknown sink %q, must be one of %s", i, sink, strings.Join(sinkNames, ", ")))
+		}
+	}
+	if o.QueueSize <= 0 {
+		errs = append(errs, fmt.Errorf("audit.queue-size must be positive"))
+	}
+	if o.WriteTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("audit.write-timeout must be positive"))
+	}
+	// Only the log sink may do without a key: entries of the other sinks outlive the process, and
+	// an unkeyed chain can be recomputed by whoever rewrites them.
+	if strings.TrimSpace(o.HMACKeyFile) == "" && slices.ContainsFunc(o.Sinks, func(sink string) bool { return sink != SinkLog }) {
+		errs = append(errs, fmt.Errorf("audit.hmac-key-file is required when the file, kafka or postgres sink is enabled"))
+	}
+	if o.hasSink(SinkFile) {
+		if strings.TrimSpace(o.File.Path) == "" {
+			errs = append(errs, fmt.Errorf("audit.file.path is required when the file sink is enabled"))
+		}
+		if o.File.MaxSizeMB < 0 {
+			errs = append(errs, fmt.Errorf("audit.file.max-size-mb must not be negative"))
+		}
+		if o.File.MaxBackups < 0 {
+			errs = append(errs, fmt.Errorf("audit.file.max-backups must not be negative"))
+		}
+	}
+	if o.hasSink(SinkKafka) {
+		if len(o.Kafka.BootstrapServers) == 0 {
+			errs = append(errs, fmt.Errorf("audit.kafka.bootstrap-servers is required when the kafka sink is enabled"))
+		}
+		if strings.TrimSpace(o.Kafka.Topic) == "" {
+			errs = append(errs, fmt.Errorf("audit.kafka.topic is required when the kafka sink is enabled"))
+		}
+	}
+	return errs
+}
+
+func (o *Options) Complete() []error {
+	return nil
+}
+
+func (o *Options) hasSink(name string) bool {
+	return slices.Contains(o.Sinks, name)
+}
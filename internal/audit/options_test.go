@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	assert.Empty(t, NewOptions().Validate(), "the log sink needs no settings")
+
+	opts := NewOptions()
+	opts.Sinks = []string{SinkLog, "syslog", SinkFile, SinkKafka}
+	opts.File.MaxBackups = -1
+	opts.Kafka.Topic = ""
+	opts.QueueSize = 0
+	opts.WriteTimeout = 0
+	errs := opts.Validate()
+	require.Len(t, errs, 8)
+	assert.EqualError(t, errs[0], `audit.sinks[1]: unknown sink "syslog", must be one of log, file, kafka, postgres`)
+	assert.EqualError(t, errs[1], "audit.queue-size must be positive")
+	assert.EqualError(t, errs[2], "audit.write-timeout must be positive")
+	assert.EqualError(t, errs[3], "audit.hmac-key-file is required when the file, kafka or postgres sink is enabled")
+	assert.EqualError(t, errs[4], "audit.file.path is required when the file sink is enabled")
+	assert.EqualError(t, errs[5], "audit.file.max-backups must not be negative")
+	assert.EqualError(t, errs[6], "audit.kafka.bootstrap-servers is required when the kafka sink is enabled")
+	assert.EqualError(t, errs[7], "audit.kafka.topic is required when the kafka sink is enabled")
+}
+
+// writeKey writes an HMAC key file of size bytes.
+func writeKey(t *testing.T, size int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "audit-hmac-key")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Repeat("k", size)+"\n"), 0o600))
+	return path
+}
+
+func TestConfig_CompleteHMACKey(t *testing.T) {
+	opts := NewOptions()
+	opts.Sinks = []string{SinkPostgres}
+	opts.HMACKeyFile = writeKey(t, 32)
+	require.Empty(t, opts.Validate())
+	c, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+	assert.Equal(t, []byte(strings.Repeat("k", 32)), c.HMACKey, "the trailing newline isn't part of the key")
+
+	opts.HMACKeyFile = writeKey(t, 31)
+	_, errs = NewConfig(opts).Complete()
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "audit.hmac-key-file: key must be at least 32 bytes, got 31")
+
+	opts.HMACKeyFile = filepath.Join(t.TempDir(), "missing")
+	_, errs = NewConfig(opts).Complete()
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], os.ErrNotExist)
+}
+
+func TestConfig_CompleteKafka(t *testing.T) {
+	opts := NewOptions()
+	opts.Sinks = []string{SinkKafka}
+	opts.Kafka.BootstrapServers = []string{"kafka-1:9092", "kafka-2:9092"}
+	opts.Kafka.AuthOptions.Enabled = true
+	opts.Kafka.AuthOptions.SecurityProtocol = "SASL_SSL"
+	opts.Kafka.AuthOptions.SASLMechanism = "SCRAM-SHA-512"
+	opts.HMACKeyFile = writeKey(t, 32)
+	require.Empty(t, opts.Validate())
+
+	c, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+	assert.Equal(t, "kessel.inventory.audit", c.KafkaTopic)
+	for key, want := range map[string]string{
+		"bootstrap.servers":  "kafka-1:9092,kafka-2:9092",
+		"enable.idempotence": "true",
+		"security.protocol":  "SASL_SSL",
+		"sasl.mechanism":     "SCRAM-SHA-512",
+	} {
+		value, err := c.KafkaConfig.Get(key, nil)
+		require.NoError(t, err)
+		assert.Equal(t, want, value, key)
+	}
+	value, err := c.KafkaConfig.Get("sasl.username", nil)
+	require.NoError(t, err)
+	assert.Nil(t, value, "empty auth settings are left unset")
+}
+
+func TestNew(t *testing.T) {
+	opts := NewOptions()
+	opts.Sinks = []string{SinkLog, SinkFile, SinkPostgres}
+	opts.File.Path = filepath.Join(t.TempDir(), "audit.log")
+	opts.HMACKeyFile = writeKey(t, 32)
+	require.Empty(t, opts.Validate())
+	c, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+
+	store := &recordingSink{}
+	auditor, err := New(c, store, log.DefaultLogger)
+	require.NoError(t, err)
+	require.Len(t, auditor.sinks, 3)
+	assert.Same(t, store, auditor.sinks[2])
+	assert.Equal(t, c.HMACKey, auditor.key)
+	assert.NoError(t, auditor.Close())
+
+	_, err = New(c, nil, log.DefaultLogger)
+	assert.EqualError(t, err, "failed to create audit postgres sink: the postgres sink requires a database")
+}
+
+func TestNew_NoSinks(t *testing.T) {
+	opts := NewOptions()
+	opts.Sinks = nil
+	c, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+
+	auditor, err := New(c, nil, log.DefaultLogger)
+	require.NoError(t, err)
+	assert.Empty(t, auditor.sinks)
+}
@@ -18,4 +18,8 @@ type StrategyType string
 const (
 	// FirstMatch returns the first non-Ignore decision
 	FirstMatch StrategyType = "first_match"
+	// AllMustPass allows only if every authenticator allows
+	AllMustPass StrategyType = "all_must_pass"
+	// ClaimsMerge combines the claims of every authenticator that allows
+	ClaimsMerge StrategyType = "claims_merge"
 )
@@ -0,0 +1,79 @@
+package aggregator
+
+import (
+	"context"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// AllMustPassAuthenticator implements an "all must pass" aggregation strategy.
+// It allows the request only if every authenticator returns Allow, e.g. to require both a
+// client certificate and an OIDC token from privileged reporters.
+// The claims are merged as in ClaimsMergeAuthenticator, so the first authenticator in the
+// chain provides the subject.
+type AllMustPassAuthenticator struct {
+	Authenticators []api.Authenticator
+	logger         *log.Helper
+}
+
+// NewAllMustPass creates a new AllMustPassAuthenticator with an empty chain.
+func NewAllMustPass() *AllMustPassAuthenticator {
+	return &AllMustPassAuthenticator{
+		Authenticators: []api.Authenticator{},
+	}
+}
+
+// SetLogger sets the logger for this authenticator (optional, for debugging)
+func (a *AllMustPassAuthenticator) SetLogger(logger *log.Helper) {
+	a.logger = logger
+}
+
+// Add appends an authenticator to the chain.
+func (a *AllMustPassAuthenticator) Add(authenticator api.Authenticator) {
+	a.Authenticators = append(a.Authenticators, authenticator)
+}
+
+// Stop ends the background work of the authenticators in the chain.
+func (a *AllMustPassAuthenticator) Stop() {
+	for _, a := range a.Authenticators {
+		api.Stop(a)
+	}
+}
+
+// Authenticate checks the authenticators in chain order and stops at the first that doesn't allow.
+// Returns Deny if any authenticator returns Deny or Ignore (a required credential is missing).
+// Returns Allow with the merged claims if every authenticator returns Allow and their subjects and
+// organizations agree.
+func (a *AllMustPassAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
+	if len(a.Authenticators) == 0 {
+		return nil, api.Deny
+	}
+
+	allowed := make([]*api.Claims, 0, len(a.Authenticators))
+	for i, authenticator := range a.Authenticators {
+		claims, decision := authenticator.Authenticate(ctx, t)
+		if decision != api.Allow {
+			if a.logger != nil {
+				a.logger.Debugf("Authentication not allowed by authenticator at chain index %d (decision: %s)", i, decision)
+			}
+			return nil, api.Deny
+		}
+		allowed = append(allowed, claims)
+	}
+
+	if err := checkSameIdentity(allowed); err != nil {
+		if a.logger != nil {
+			a.logger.Warnf("Authentication denied: %v", err)
+		}
+		return nil, api.Deny
+	}
+	merged := mergeClaims(allowed)
+	if a.logger != nil {
+		a.logger.Debugf("Authentication allowed by all %d authenticators (authType: %s, subject: %s)",
+			len(allowed), merged.AuthType, merged.SubjectId)
+	}
+	return merged, api.Allow
+}
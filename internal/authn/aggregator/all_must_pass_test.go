@@ -0,0 +1,114 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// countingAuthenticator counts how often it is asked to authenticate
+type countingAuthenticator struct {
+	mockAuthenticator
+	calls int
+}
+
+func (c *countingAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
+	c.calls++
+	return c.mockAuthenticator.Authenticate(ctx, t)
+}
+
+func TestNewAllMustPass(t *testing.T) {
+	auth := NewAllMustPass()
+	assert.NotNil(t, auth)
+	assert.Empty(t, auth.Authenticators)
+}
+
+func TestAllMustPassAuthenticator_AllAllow(t *testing.T) {
+	auth := NewAllMustPass()
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: &api.Claims{
+		SubjectId: "service-account-hbi",
+		ClientID:  "hbi",
+		AuthType:  api.AuthTypeMTLS,
+	}})
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: &api.Claims{
+		SubjectId: "service-account-hbi",
+		Issuer:    "https://sso.example.com",
+		ClientID:  "hbi-client",
+		AuthType:  api.AuthTypeOIDC,
+	}})
+
+	claims, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+
+	assert.Equal(t, api.Allow, decision)
+	assert.Equal(t, &api.Claims{
+		SubjectId: "service-account-hbi",
+		Issuer:    "https://sso.example.com",
+		ClientID:  "hbi",
+		AuthType:  api.AuthTypeMTLS,
+	}, claims)
+}
+
+func TestAllMustPassAuthenticator_DeniesConflictingIdentities(t *testing.T) {
+	auth := NewAllMustPass()
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: &api.Claims{
+		SubjectId: "spiffe://kessel.example/ns/hbi/sa/reporter",
+		ClientID:  "hbi",
+		AuthType:  api.AuthTypeMTLS,
+	}})
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: &api.Claims{
+		SubjectId: "user-123",
+		Issuer:    "https://sso.example.com",
+		AuthType:  api.AuthTypeOIDC,
+	}})
+
+	claims, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+
+	assert.Equal(t, api.Deny, decision)
+	assert.Nil(t, claims)
+}
+
+func TestAllMustPassAuthenticator_Decisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		decisions []api.Decision
+		want      api.Decision
+	}{
+		{name: "empty chain", decisions: nil, want: api.Deny},
+		{name: "all allow", decisions: []api.Decision{api.Allow, api.Allow}, want: api.Allow},
+		{name: "allow and ignore", decisions: []api.Decision{api.Allow, api.Ignore}, want: api.Deny},
+		{name: "allow and deny", decisions: []api.Decision{api.Allow, api.Deny}, want: api.Deny},
+		{name: "all ignore", decisions: []api.Decision{api.Ignore, api.Ignore}, want: api.Deny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewAllMustPass()
+			for _, d := range tt.decisions {
+				auth.Add(&mockAuthenticator{decision: d, claims: &api.Claims{SubjectId: "user", AuthType: api.AuthTypeOIDC}})
+			}
+			claims, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+			assert.Equal(t, tt.want, decision)
+			if tt.want != api.Allow {
+				assert.Nil(t, claims)
+			}
+		})
+	}
+}
+
+func TestAllMustPassAuthenticator_StopsAtFirstFailure(t *testing.T) {
+	auth := NewAllMustPass()
+	first := &countingAuthenticator{mockAuthenticator: mockAuthenticator{decision: api.Ignore}}
+	second := &countingAuthenticator{mockAuthenticator: mockAuthenticator{decision: api.Allow}}
+	auth.Add(first)
+	auth.Add(second)
+
+	_, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+
+	assert.Equal(t, api.Deny, decision)
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 0, second.calls)
+}
@@ -0,0 +1,141 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// ClaimsMergeAuthenticator implements a "claims merge" aggregation strategy.
+// It asks every authenticator and combines the claims of those that allow, so that e.g. the
+// org can come from x-rh-identity and the client ID from OIDC.
+// It denies if any authenticator denies, since a credential was presented and rejected, and if
+// the allowed credentials name different subjects or organizations.
+type ClaimsMergeAuthenticator struct {
+	Authenticators []api.Authenticator
+	logger         *log.Helper
+}
+
+// NewClaimsMerge creates a new ClaimsMergeAuthenticator with an empty chain.
+func NewClaimsMerge() *ClaimsMergeAuthenticator {
+	return &ClaimsMergeAuthenticator{
+		Authenticators: []api.Authenticator{},
+	}
+}
+
+// SetLogger sets the logger for this authenticator (optional, for debugging)
+func (c *ClaimsMergeAuthenticator) SetLogger(logger *log.Helper) {
+	c.logger = logger
+}
+
+// Add appends an authenticator to the chain.
+func (c *ClaimsMergeAuthenticator) Add(a api.Authenticator) {
+	c.Authenticators = append(c.Authenticators, a)
+}
+
+// Stop ends the background work of the authenticators in the chain.
+func (c *ClaimsMergeAuthenticator) Stop() {
+	for _, a := range c.Authenticators {
+		api.Stop(a)
+	}
+}
+
+// Authenticate checks all authenticators in the chain.
+// Returns Deny if any authenticator returns Deny.
+// Returns Allow with the merged claims if at least one authenticator returns Allow.
+// Returns Ignore only if all authenticators return Ignore (none can handle the request).
+func (c *ClaimsMergeAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
+	if len(c.Authenticators) == 0 {
+		return nil, api.Deny
+	}
+
+	var allowed []*api.Claims
+	for i, a := range c.Authenticators {
+		claims, decision := a.Authenticate(ctx, t)
+		switch decision {
+		case api.Allow:
+			allowed = append(allowed, claims)
+		case api.Deny:
+			if c.logger != nil {
+				c.logger.Debugf("Authentication denied by authenticator at chain index %d", i)
+			}
+			return nil, api.Deny
+		}
+	}
+
+	if len(allowed) == 0 {
+		return nil, api.Ignore
+	}
+	if err := checkSameIdentity(allowed); err != nil {
+		if c.logger != nil {
+			c.logger.Warnf("Authentication denied: %v", err)
+		}
+		return nil, api.Deny
+	}
+	merged := mergeClaims(allowed)
+	if c.logger != nil {
+		c.logger.Debugf("Authentication allowed by %d authenticators (authType: %s, subject: %s)",
+			len(allowed), merged.AuthType, merged.SubjectId)
+	}
+	return merged, api.Allow
+}
+
+// checkSameIdentity returns an error if the authenticated claims name different subjects or
+// organizations, since claims of different identities can't be combined into one.
+func checkSameIdentity(all []*api.Claims) error {
+	var subjectId api.SubjectId
+	var organizationId api.OrganizationId
+	for _, claims := range all {
+		if claims == nil || !claims.IsAuthenticated() {
+			continue
+		}
+		if subjectId != "" && claims.SubjectId != "" && claims.SubjectId != subjectId {
+			return fmt.Errorf("credentials name different subjects %q and %q", subjectId, claims.SubjectId)
+		}
+		if organizationId != "" && claims.OrganizationId != "" && claims.OrganizationId != organizationId {
+			return fmt.Errorf("credentials name different organizations %q and %q", organizationId, claims.OrganizationId)
+		}
+		if subjectId == "" {
+			subjectId = claims.SubjectId
+		}
+		if organizationId == "" {
+			organizationId = claims.OrganizationId
+		}
+	}
+	return nil
+}
+
+// mergeClaims combines claims in chain order: each field takes the first non-empty value, so
+// earlier authenticators take precedence. Unauthenticated claims only count if nothing else
+// allowed, so they can't mark an authenticated request as unauthenticated.
+func mergeClaims(all []*api.Claims) *api.Claims {
+	merged := &api.Claims{}
+	for _, claims := range all {
+		if claims == nil || !claims.IsAuthenticated() {
+			continue
+		}
+		if merged.SubjectId == "" {
+			merged.SubjectId = claims.SubjectId
+		}
+		if merged.OrganizationId == "" {
+			merged.OrganizationId = claims.OrganizationId
+		}
+		if merged.Issuer == "" {
+			merged.Issuer = claims.Issuer
+		}
+		if merged.ClientID == "" {
+			merged.ClientID = claims.ClientID
+		}
+		if merged.AuthType == "" {
+			merged.AuthType = claims.AuthType
+		}
+	}
+	if merged.AuthType == "" {
+		return api.UnauthenticatedClaims()
+	}
+	return merged
+}
@@ -0,0 +1,122 @@
+package aggregator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+func TestNewClaimsMerge(t *testing.T) {
+	auth := NewClaimsMerge()
+	assert.NotNil(t, auth)
+	assert.Empty(t, auth.Authenticators)
+}
+
+func TestClaimsMergeAuthenticator_MergesAllowedClaims(t *testing.T) {
+	auth := NewClaimsMerge()
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: api.UnauthenticatedClaims()})
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: &api.Claims{
+		SubjectId:      "user-123",
+		OrganizationId: "org-1",
+		AuthType:       api.AuthTypeXRhIdentity,
+	}})
+	auth.Add(&mockAuthenticator{decision: api.Ignore})
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: &api.Claims{
+		SubjectId: "user-123",
+		Issuer:    "https://sso.example.com",
+		ClientID:  "rbac",
+		AuthType:  api.AuthTypeOIDC,
+	}})
+
+	claims, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+
+	assert.Equal(t, api.Allow, decision)
+	assert.Equal(t, &api.Claims{
+		SubjectId:      "user-123",
+		OrganizationId: "org-1",
+		Issuer:         "https://sso.example.com",
+		ClientID:       "rbac",
+		AuthType:       api.AuthTypeXRhIdentity,
+	}, claims)
+}
+
+func TestClaimsMergeAuthenticator_Decisions(t *testing.T) {
+	tests := []struct {
+		name      string
+		decisions []api.Decision
+		want      api.Decision
+	}{
+		{name: "empty chain", decisions: nil, want: api.Deny},
+		{name: "all ignore", decisions: []api.Decision{api.Ignore, api.Ignore}, want: api.Ignore},
+		{name: "allow and ignore", decisions: []api.Decision{api.Ignore, api.Allow}, want: api.Allow},
+		{name: "allow and deny", decisions: []api.Decision{api.Allow, api.Deny}, want: api.Deny},
+		{name: "deny and ignore", decisions: []api.Decision{api.Deny, api.Ignore}, want: api.Deny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewClaimsMerge()
+			for _, d := range tt.decisions {
+				auth.Add(&mockAuthenticator{decision: d, claims: &api.Claims{SubjectId: "user", AuthType: api.AuthTypeOIDC}})
+			}
+			_, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+			assert.Equal(t, tt.want, decision)
+		})
+	}
+}
+
+func TestClaimsMergeAuthenticator_OnlyUnauthenticated(t *testing.T) {
+	auth := NewClaimsMerge()
+	auth.Add(&mockAuthenticator{decision: api.Ignore})
+	auth.Add(&mockAuthenticator{decision: api.Allow, claims: api.UnauthenticatedClaims()})
+
+	claims, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+
+	assert.Equal(t, api.Allow, decision)
+	assert.False(t, claims.IsAuthenticated())
+}
+
+func TestClaimsMergeAuthenticator_DeniesConflictingIdentities(t *testing.T) {
+	tests := []struct {
+		name  string
+		first *api.Claims
+		other *api.Claims
+		want  api.Decision
+	}{
+		{
+			name:  "different subjects",
+			first: &api.Claims{SubjectId: "user-123", AuthType: api.AuthTypeXRhIdentity},
+			other: &api.Claims{SubjectId: "service-account-abc", AuthType: api.AuthTypeOIDC},
+			want:  api.Deny,
+		},
+		{
+			name:  "different organizations",
+			first: &api.Claims{OrganizationId: "org-1", AuthType: api.AuthTypeXRhIdentity},
+			other: &api.Claims{SubjectId: "user-123", OrganizationId: "org-2", AuthType: api.AuthTypeOIDC},
+			want:  api.Deny,
+		},
+		{
+			name:  "only one names an organization",
+			first: &api.Claims{SubjectId: "user-123", OrganizationId: "org-1", AuthType: api.AuthTypeXRhIdentity},
+			other: &api.Claims{SubjectId: "user-123", AuthType: api.AuthTypeOIDC},
+			want:  api.Allow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth := NewClaimsMerge()
+			auth.Add(&mockAuthenticator{decision: api.Allow, claims: tt.first})
+			auth.Add(&mockAuthenticator{decision: api.Allow, claims: tt.other})
+
+			claims, decision := auth.Authenticate(context.Background(), &mockTransporter{})
+			assert.Equal(t, tt.want, decision)
+			if tt.want == api.Deny {
+				assert.Nil(t, claims)
+			}
+		})
+	}
+}
@@ -35,6 +35,13 @@ func (f *FirstMatchAuthenticator) Add(a api.Authenticator) {
 	f.Authenticators = append(f.Authenticators, a)
 }
 
+// Stop ends the background work of the authenticators in the chain.
+func (f *FirstMatchAuthenticator) Stop() {
+	for _, a := range f.Authenticators {
+		api.Stop(a)
+	}
+}
+
 // Authenticate checks all authenticators in the chain.
 // Returns Allow immediately if any authenticator returns Allow.
 // Returns Deny if all authenticators return Deny, or if there's a mix of Deny and Ignore (stricter policy).
@@ -206,3 +206,21 @@ func (t *trackingAuthenticator) Authenticate(ctx context.Context, transporter tr
 	*t.callCount++
 	return t.authenticator.Authenticate(ctx, transporter)
 }
+
+type stoppingAuthenticator struct {
+	mockAuthenticator
+	stopped bool
+}
+
+func (s *stoppingAuthenticator) Stop() { s.stopped = true }
+
+func TestAggregators_StopChainedAuthenticators(t *testing.T) {
+	for _, aggregator := range []AggregatingAuthenticator{NewFirstMatch(), NewAllMustPass(), NewClaimsMerge()} {
+		stopping := &stoppingAuthenticator{}
+		aggregator.Add(&mockAuthenticator{})
+		aggregator.Add(stopping)
+
+		api.Stop(aggregator)
+		assert.True(t, stopping.stopped, "%T stops the authenticators it chains", aggregator)
+	}
+}
@@ -26,3 +26,16 @@ type Authenticator interface {
 	// Authenticate examines the transport context and returns claims and authentication decision.
 	Authenticate(context.Context, transport.Transporter) (*Claims, Decision)
 }
+
+// Stopper is implemented by authenticators that run in the background, such as to reload keys.
+type Stopper interface {
+	// Stop ends the authenticator's background work.
+	Stop()
+}
+
+// Stop ends the background work of authenticator, if it has any.
+func Stop(authenticator Authenticator) {
+	if stopper, ok := authenticator.(Stopper); ok {
+		stopper.Stop()
+	}
+}
@@ -13,6 +13,9 @@ type AuthType string
 const (
 	AuthTypeOIDC                 AuthType = "oidc"
 	AuthTypeXRhIdentity          AuthType = "x-rh-identity"
+	AuthTypeMTLS                 AuthType = "mtls"
+	AuthTypeJWT                  AuthType = "jwt"
+	AuthTypeAPIKey               AuthType = "api-key"
 	AuthTypeAllowUnauthenticated AuthType = "allow-unauthenticated"
 )
 
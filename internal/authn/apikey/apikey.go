@@ -0,0 +1,149 @@
+// Package apikey provides an Authenticator based on long-lived, pre-shared api keys, for scripts and
+// CI jobs that can't obtain tokens from an authorization server.
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/patrickmn/go-cache"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/authn/util"
+)
+
+// lastUsedResolution limits how often the last-used time of a key is written.
+const lastUsedResolution = time.Minute
+
+type APIKeyAuthenticator struct {
+	CompletedConfig
+
+	// verified maps the SHA-256 of recently verified keys to the hash they were verified against,
+	// so the slow hash isn't recomputed on every request. Keys are still read on every request, so
+	// that revoking a key takes effect at once.
+	verified *cache.Cache
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+func New(c CompletedConfig) (*APIKeyAuthenticator, error) {
+	if c.Store == nil {
+		return nil, fmt.Errorf("api-key authenticator with store %q has no key store", c.StoreType)
+	}
+	a := &APIKeyAuthenticator{CompletedConfig: c, lastUsed: map[string]time.Time{}}
+	if c.CacheTTL > 0 {
+		a.verified = cache.New(c.CacheTTL, 2*c.CacheTTL)
+	}
+	return a, nil
+}
+
+// Authenticate returns Ignore when the request carries no api key, and Deny when it carries one
+// that is unknown, revoked or doesn't match.
+func (a *APIKeyAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
+	rawKey := a.keyFromRequest(t)
+	if rawKey == "" {
+		return nil, api.Ignore
+	}
+
+	id, ok := Parse(rawKey)
+	if !ok {
+		util.LogAuthenticationFailure("api-key", "api key is malformed", "malformed_key")
+		return nil, api.Deny
+	}
+
+	key, err := a.Store.Find(ctx, id)
+	if err != nil {
+		log.NewHelper(log.DefaultLogger).Errorw("msg", "failed to look up api key",
+			"auth_method", "api-key",
+			"key_id", id,
+			"error", err,
+		)
+		return nil, api.Deny
+	}
+	if key == nil {
+		util.LogAuthenticationFailure("api-key", "api key is unknown", "unknown_key", "key_id", id)
+		return nil, api.Deny
+	}
+	if key.Revoked() {
+		util.LogAuthenticationFailure("api-key", "api key is revoked", "revoked_key", "key_id", id)
+		return nil, api.Deny
+	}
+	if !a.matches(key, rawKey) {
+		util.LogAuthenticationFailure("api-key", "api key does not match", "invalid_key", "key_id", id)
+		return nil, api.Deny
+	}
+	return a.allow(ctx, key)
+}
+
+// matches reports whether rawKey matches the hash of key, trusting a recent verification against
+// the same hash.
+func (a *APIKeyAuthenticator) matches(key *Key, rawKey string) bool {
+	digest := sha256.Sum256([]byte(rawKey))
+	cacheKey := hex.EncodeToString(digest[:])
+	if a.verified != nil {
+		if hash, found := a.verified.Get(cacheKey); found && hash.(string) == key.Hash {
+			return true
+		}
+	}
+
+	matched, err := VerifyHash(key.Hash, rawKey)
+	if err != nil || !matched {
+		return false
+	}
+	if a.verified != nil {
+		a.verified.SetDefault(cacheKey, key.Hash)
+	}
+	return true
+}
+
+func (a *APIKeyAuthenticator) allow(ctx context.Context, key *Key) (*api.Claims, api.Decision) {
+	a.markUsed(ctx, key.Id)
+	return &api.Claims{
+		SubjectId:      api.SubjectId(key.SubjectId),
+		OrganizationId: api.OrganizationId(key.OrgId),
+		ClientID:       api.ClientID(key.ClientId),
+		AuthType:       api.AuthTypeAPIKey,
+	}, api.Allow
+}
+
+// markUsed records the last-used time at most once per lastUsedResolution per key. Failures are
+// logged rather than failing the request.
+func (a *APIKeyAuthenticator) markUsed(ctx context.Context, id string) {
+	now := time.Now().UTC()
+	a.mu.Lock()
+	if last, ok := a.lastUsed[id]; ok && now.Sub(last) < lastUsedResolution {
+		a.mu.Unlock()
+		return
+	}
+	a.lastUsed[id] = now
+	a.mu.Unlock()
+
+	if err := a.Store.MarkUsed(ctx, id, now); err != nil {
+		log.NewHelper(log.DefaultLogger).Warnw("msg", "failed to record api key last-used time",
+			"auth_method", "api-key",
+			"key_id", id,
+			"error", err,
+		)
+	}
+}
+
+func (a *APIKeyAuthenticator) keyFromRequest(t transport.Transporter) string {
+	value := strings.TrimSpace(t.RequestHeader().Get(a.Header))
+	if !strings.EqualFold(a.Header, DefaultHeader) {
+		return value
+	}
+	scheme, key, found := strings.Cut(value, " ")
+	if !found || !strings.EqualFold(scheme, AuthorizationScheme) {
+		// e.g. a Bearer token for another authenticator
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
@@ -0,0 +1,295 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// mockTransporter is a test helper that implements transport.Transporter
+type mockTransporter struct {
+	headers map[string]string
+}
+
+func (m *mockTransporter) Kind() transport.Kind            { return transport.KindHTTP }
+func (m *mockTransporter) Endpoint() string                { return "/test" }
+func (m *mockTransporter) Operation() string               { return "test" }
+func (m *mockTransporter) RequestHeader() transport.Header { return &mockHeader{headers: m.headers} }
+func (m *mockTransporter) ReplyHeader() transport.Header {
+	return &mockHeader{headers: make(map[string]string)}
+}
+
+type mockHeader struct {
+	headers map[string]string
+}
+
+func (m *mockHeader) Get(key string) string      { return m.headers[key] }
+func (m *mockHeader) Set(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Add(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Keys() []string             { return nil }
+func (m *mockHeader) Values(key string) []string { return nil }
+
+func withHeader(name, value string) *mockTransporter {
+	return &mockTransporter{headers: map[string]string{name: value}}
+}
+
+// countingStore wraps a store, counting lookups and recording last-used times.
+type countingStore struct {
+	keys     map[string]*Key
+	finds    int
+	lastUsed map[string]time.Time
+	err      error
+}
+
+func (s *countingStore) Find(_ context.Context, id string) (*Key, error) {
+	s.finds++
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.keys[id], nil
+}
+
+func (s *countingStore) MarkUsed(_ context.Context, id string, at time.Time) error {
+	s.lastUsed[id] = at
+	return nil
+}
+
+func newKey(t *testing.T) (*Key, string) {
+	t.Helper()
+	id, rawKey, err := Generate()
+	require.NoError(t, err)
+	hash, err := Hash(rawKey)
+	require.NoError(t, err)
+	return &Key{Id: id, Hash: hash, SubjectId: "ci-bot", ClientId: "ci", OrgId: "12345"}, rawKey
+}
+
+func newAuthenticator(t *testing.T, store Store, header string, cacheTTL time.Duration) *APIKeyAuthenticator {
+	t.Helper()
+	completed, err := NewConfig(&Options{Header: header, Store: StoreDatabase, CacheTTL: cacheTTL}).Complete()
+	require.NoError(t, err)
+	completed.SetStore(store)
+	auth, err := New(completed)
+	require.NoError(t, err)
+	return auth
+}
+
+func TestGenerateAndParse(t *testing.T) {
+	id, rawKey, err := Generate()
+	require.NoError(t, err)
+	assert.Len(t, id, 2*idBytes)
+
+	parsedId, ok := Parse(rawKey)
+	assert.True(t, ok)
+	assert.Equal(t, id, parsedId)
+
+	for _, malformed := range []string{"", "no-separator", ".secret", "id."} {
+		_, ok := Parse(malformed)
+		assert.False(t, ok, malformed)
+	}
+}
+
+func TestVerifyHash(t *testing.T) {
+	argonHash, err := Hash("1a2b.secret")
+	require.NoError(t, err)
+	require.NoError(t, ValidateHash(argonHash))
+
+	matched, err := VerifyHash(argonHash, "1a2b.secret")
+	require.NoError(t, err)
+	assert.True(t, matched)
+	matched, err = VerifyHash(argonHash, "1a2b.other")
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("1a2b.secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	require.NoError(t, ValidateHash(string(bcryptHash)))
+
+	matched, err = VerifyHash(string(bcryptHash), "1a2b.secret")
+	require.NoError(t, err)
+	assert.True(t, matched)
+	matched, err = VerifyHash(string(bcryptHash), "1a2b.other")
+	require.NoError(t, err)
+	assert.False(t, matched)
+
+	assert.Error(t, ValidateHash("plaintext"))
+	assert.Error(t, ValidateHash("$argon2id$v=19$m=1,t=1$salt"))
+}
+
+func TestAuthenticate_ConfigStore(t *testing.T) {
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("0011.secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+	completed, err := NewConfig(&Options{
+		Header: DefaultHeader,
+		Store:  StoreConfig,
+		Keys:   []KeyOptions{{Id: "0011", Hash: string(bcryptHash), SubjectId: "ci-bot", ClientId: "ci", OrgId: "12345"}},
+	}).Complete()
+	require.NoError(t, err)
+	auth, err := New(completed)
+	require.NoError(t, err)
+
+	claims, decision := auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey 0011.secret"))
+
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("ci-bot"), claims.SubjectId)
+	assert.Equal(t, api.ClientID("ci"), claims.ClientID)
+	assert.Equal(t, api.OrganizationId("12345"), claims.OrganizationId)
+	assert.Equal(t, api.AuthTypeAPIKey, claims.AuthType)
+
+	key, err := completed.Store.Find(context.Background(), "0011")
+	require.NoError(t, err)
+	assert.NotNil(t, key.LastUsedAt)
+}
+
+func TestAuthenticate_CustomHeader(t *testing.T) {
+	key, rawKey := newKey(t)
+	store := &countingStore{keys: map[string]*Key{key.Id: key}, lastUsed: map[string]time.Time{}}
+	auth := newAuthenticator(t, store, "X-Api-Key", 0)
+
+	claims, decision := auth.Authenticate(context.Background(), withHeader("X-Api-Key", rawKey))
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("ci-bot"), claims.SubjectId)
+
+	_, decision = auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey "+rawKey))
+	assert.Equal(t, api.Ignore, decision)
+}
+
+func TestAuthenticate_Decisions(t *testing.T) {
+	key, rawKey := newKey(t)
+	revoked, revokedRawKey := newKey(t)
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+	store := &countingStore{keys: map[string]*Key{key.Id: key, revoked.Id: revoked}, lastUsed: map[string]time.Time{}}
+	auth := newAuthenticator(t, store, DefaultHeader, 0)
+
+	tests := []struct {
+		name   string
+		header string
+		want   api.Decision
+	}{
+		{name: "no header", header: "", want: api.Ignore},
+		{name: "bearer token", header: "Bearer eyJhbGciOi", want: api.Ignore},
+		{name: "valid key", header: "ApiKey " + rawKey, want: api.Allow},
+		{name: "scheme is case-insensitive", header: "apikey " + rawKey, want: api.Allow},
+		{name: "malformed key", header: "ApiKey secret", want: api.Deny},
+		{name: "unknown key", header: "ApiKey ffff." + rawKey[len(key.Id)+1:], want: api.Deny},
+		{name: "wrong secret", header: "ApiKey " + key.Id + ".wrong", want: api.Deny},
+		{name: "revoked key", header: "ApiKey " + revokedRawKey, want: api.Deny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, decision := auth.Authenticate(context.Background(), withHeader("Authorization", tt.header))
+			assert.Equal(t, tt.want, decision)
+		})
+	}
+}
+
+func TestAuthenticate_StoreError(t *testing.T) {
+	_, rawKey := newKey(t)
+	store := &countingStore{err: errors.New("connection refused"), lastUsed: map[string]time.Time{}}
+	auth := newAuthenticator(t, store, DefaultHeader, 0)
+
+	claims, decision := auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey "+rawKey))
+	assert.Nil(t, claims)
+	assert.Equal(t, api.Deny, decision)
+}
+
+func TestAuthenticate_CachesVerifiedKeysAndThrottlesLastUsed(t *testing.T) {
+	key, rawKey := newKey(t)
+	store := &countingStore{keys: map[string]*Key{key.Id: key}, lastUsed: map[string]time.Time{}}
+	auth := newAuthenticator(t, store, DefaultHeader, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		_, decision := auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey "+rawKey))
+		assert.Equal(t, api.Allow, decision)
+	}
+	assert.Equal(t, 3, store.finds, "keys are read on every request")
+	assert.Equal(t, 1, auth.verified.ItemCount())
+	first := store.lastUsed[key.Id]
+	assert.False(t, first.IsZero())
+
+	// A different secret for the same id is not served from the cache.
+	_, decision := auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey "+key.Id+".wrong"))
+	assert.Equal(t, api.Deny, decision)
+	assert.Equal(t, first, store.lastUsed[key.Id])
+}
+
+func TestAuthenticate_RevocationBypassesCache(t *testing.T) {
+	key, rawKey := newKey(t)
+	store := &countingStore{keys: map[string]*Key{key.Id: key}, lastUsed: map[string]time.Time{}}
+	auth := newAuthenticator(t, store, DefaultHeader, time.Hour)
+
+	_, decision := auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey "+rawKey))
+	require.Equal(t, api.Allow, decision)
+
+	revoked := *key
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+	store.keys[key.Id] = &revoked
+	_, decision = auth.Authenticate(context.Background(), withHeader("Authorization", "ApiKey "+rawKey))
+	assert.Equal(t, api.Deny, decision, "a cached key stops working as soon as it is revoked")
+}
+
+func TestNew_DatabaseStoreNotSet(t *testing.T) {
+	completed, err := NewConfig(&Options{Header: DefaultHeader, Store: StoreDatabase}).Complete()
+	require.NoError(t, err)
+
+	_, err = New(completed)
+	assert.ErrorContains(t, err, `api-key authenticator with store "database" has no key store`)
+}
+
+func TestConfigComplete(t *testing.T) {
+	hash, err := Hash("0011.secret")
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		opts    *Options
+		wantErr string
+	}{
+		{name: "no header", opts: &Options{Store: StoreDatabase}, wantErr: "header is required"},
+		{name: "invalid store", opts: &Options{Header: DefaultHeader, Store: "vault"}, wantErr: `invalid store "vault"`},
+		{name: "config store without keys", opts: &Options{Header: DefaultHeader, Store: StoreConfig}, wantErr: "at least one key is required"},
+		{
+			name:    "missing id",
+			opts:    &Options{Header: DefaultHeader, Store: StoreConfig, Keys: []KeyOptions{{Hash: hash, SubjectId: "s"}}},
+			wantErr: "keys[0]: id is required",
+		},
+		{
+			name:    "duplicate id",
+			opts:    &Options{Header: DefaultHeader, Store: StoreConfig, Keys: []KeyOptions{{Id: "a", Hash: hash, SubjectId: "s"}, {Id: "a", Hash: hash, SubjectId: "s"}}},
+			wantErr: `keys[1]: duplicate id "a"`,
+		},
+		{
+			name:    "plaintext hash",
+			opts:    &Options{Header: DefaultHeader, Store: StoreConfig, Keys: []KeyOptions{{Id: "a", Hash: "secret", SubjectId: "s"}}},
+			wantErr: "keys[0]: invalid hash",
+		},
+		{
+			name:    "missing subject",
+			opts:    &Options{Header: DefaultHeader, Store: StoreConfig, Keys: []KeyOptions{{Id: "a", Hash: hash}}},
+			wantErr: "keys[0]: subject-id is required",
+		},
+		{
+			name:    "keys with database store",
+			opts:    &Options{Header: DefaultHeader, Store: StoreDatabase, Keys: []KeyOptions{{Id: "a", Hash: hash, SubjectId: "s"}}},
+			wantErr: `keys can only be configured for store "config"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(tt.opts).Complete()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package apikey
+
+import (
+	"fmt"
+	"time"
+)
+
+type Config struct {
+	*Options
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+type completedConfig struct {
+	Header    string
+	StoreType string
+	CacheTTL  time.Duration
+
+	// Store is built from the keys option for StoreConfig. For StoreDatabase it is set with
+	// SetStore once the database is available.
+	Store Store
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+// SetStore sets the store keys are looked up in.
+func (c CompletedConfig) SetStore(store Store) {
+	c.Store = store
+}
+
+func (c *Config) Complete() (CompletedConfig, error) {
+	if c.Header == "" {
+		return CompletedConfig{}, fmt.Errorf("header is required")
+	}
+	if c.CacheTTL < 0 {
+		return CompletedConfig{}, fmt.Errorf("cache-ttl must not be negative")
+	}
+
+	completed := &completedConfig{Header: c.Header, StoreType: c.Store, CacheTTL: c.CacheTTL}
+	switch c.Store {
+	case StoreConfig:
+		if len(c.Keys) == 0 {
+			return CompletedConfig{}, fmt.Errorf("at least one key is required for store %q", StoreConfig)
+		}
+		keys := make(map[string]*Key, len(c.Keys))
+		for i, k := range c.Keys {
+			if k.Id == "" {
+				return CompletedConfig{}, fmt.Errorf("keys[%d]: id is required", i)
+			}
+			if _, exists := keys[k.Id]; exists {
+				return CompletedConfig{}, fmt.Errorf("keys[%d]: duplicate id %q", i, k.Id)
+			}
+			if err := ValidateHash(k.Hash); err != nil {
+				return CompletedConfig{}, fmt.Errorf("keys[%d]: invalid hash: %w", i, err)
+			}
+			if k.SubjectId == "" {
+				return CompletedConfig{}, fmt.Errorf("keys[%d]: subject-id is required", i)
+			}
+			keys[k.Id] = &Key{Id: k.Id, Hash: k.Hash, SubjectId: k.SubjectId, ClientId: k.ClientId, OrgId: k.OrgId}
+		}
+		completed.Store = newConfigStore(keys)
+	case StoreDatabase:
+		if len(c.Keys) > 0 {
+			return CompletedConfig{}, fmt.Errorf("keys can only be configured for store %q", StoreConfig)
+		}
+	default:
+		return CompletedConfig{}, fmt.Errorf("invalid store %q, expected %q or %q", c.Store, StoreConfig, StoreDatabase)
+	}
+
+	return CompletedConfig{completed}, nil
+}
@@ -0,0 +1,156 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Key is an issued api key. Only a hash of the key is stored.
+type Key struct {
+	Id         string
+	Hash       string
+	SubjectId  string
+	ClientId   string
+	OrgId      string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func (k *Key) Revoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Store looks up keys for the authenticator. Find returns nil without an error for unknown ids.
+type Store interface {
+	Find(ctx context.Context, id string) (*Key, error)
+	MarkUsed(ctx context.Context, id string, at time.Time) error
+}
+
+// AdminStore additionally manages keys, for the apikey command.
+type AdminStore interface {
+	Store
+	Create(ctx context.Context, key Key) error
+	Revoke(ctx context.Context, id string, at time.Time) error
+	List(ctx context.Context) ([]Key, error)
+}
+
+const (
+	idBytes     = 8
+	secretBytes = 32
+)
+
+// Generate returns a new key of the form "<id>.<secret>". The id is not secret; it lets the key be
+// looked up without scanning every hash.
+func Generate() (id string, key string, err error) {
+	idRaw := make([]byte, idBytes)
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(idRaw); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	id = hex.EncodeToString(idRaw)
+	return id, id + "." + base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// Parse returns the id of a key, or false if it isn't of the form "<id>.<secret>".
+func Parse(key string) (string, bool) {
+	id, secret, found := strings.Cut(key, ".")
+	if !found || id == "" || secret == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// argon2id parameters for new hashes, per the OWASP password storage recommendations.
+const (
+	argon2Time    = 2
+	argon2Memory  = 19 * 1024
+	argon2Threads = 1
+	argon2KeyLen  = 32
+	argon2SaltLen = 16
+)
+
+// Hash returns an argon2id hash of key in the PHC string format.
+func Hash(key string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	sum := argon2.IDKey([]byte(key), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(sum)), nil
+}
+
+// ValidateHash reports whether hash is a bcrypt or argon2id hash this package can verify.
+func ValidateHash(hash string) error {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		_, _, _, err := parseArgon2id(hash)
+		return err
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		_, err := bcrypt.Cost([]byte(hash))
+		return err
+	default:
+		return fmt.Errorf("unsupported hash, expected bcrypt or argon2id")
+	}
+}
+
+// VerifyHash reports whether key matches a bcrypt or argon2id hash.
+func VerifyHash(hash string, key string) (bool, error) {
+	if !strings.HasPrefix(hash, "$argon2id$") {
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(key))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	params, salt, sum, err := parseArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	actual := argon2.IDKey([]byte(key), salt, params.time, params.memory, params.threads, uint32(len(sum)))
+	return subtle.ConstantTimeCompare(actual, sum) == 1, nil
+}
+
+type argon2Params struct {
+	memory  uint32
+	time    uint32
+	threads uint8
+}
+
+func parseArgon2id(hash string) (argon2Params, []byte, []byte, error) {
+	var params argon2Params
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("unsupported argon2id version")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id parameters: %w", err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("invalid argon2id salt: %w", err)
+	}
+	sum, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(sum) == 0 {
+		return params, nil, nil, fmt.Errorf("invalid argon2id hash value")
+	}
+	return params, salt, sum, nil
+}
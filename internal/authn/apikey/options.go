@@ -0,0 +1,48 @@
+package apikey
+
+import "time"
+
+// Where keys are looked up.
+const (
+	// StoreConfig reads keys from the keys option.
+	StoreConfig = "config"
+	// StoreDatabase reads keys from the api_keys table, managed with the apikey command.
+	StoreDatabase = "database"
+)
+
+const (
+	DefaultHeader = "Authorization"
+	// AuthorizationScheme prefixes keys sent in the Authorization header, e.g. "ApiKey 1a2b3c.secret".
+	AuthorizationScheme = "ApiKey"
+
+	DefaultCacheTTL = time.Minute
+)
+
+// KeyOptions is an api key configured inline. Hash is the bcrypt or argon2id hash of the key, as
+// printed by "inventory-api apikey create --config-only".
+type KeyOptions struct {
+	Id        string `mapstructure:"id"`
+	Hash      string `mapstructure:"hash"`
+	SubjectId string `mapstructure:"subject-id"`
+	ClientId  string `mapstructure:"client-id"`
+	OrgId     string `mapstructure:"org-id"`
+}
+
+type Options struct {
+	// Header carries the key. For Authorization the value must use the ApiKey scheme; any other
+	// header carries the bare key.
+	Header string       `mapstructure:"header"`
+	Store  string       `mapstructure:"store"`
+	Keys   []KeyOptions `mapstructure:"keys"`
+	// CacheTTL is how long a verified key is trusted without re-hashing it; zero disables caching.
+	// Keys are read on every request, so revocation takes effect at once either way.
+	CacheTTL time.Duration `mapstructure:"cache-ttl"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Header:   DefaultHeader,
+		Store:    StoreConfig,
+		CacheTTL: DefaultCacheTTL,
+	}
+}
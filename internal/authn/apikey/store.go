@@ -0,0 +1,40 @@
+package apikey
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// configStore serves keys from configuration. Last-used times are kept in memory only.
+type configStore struct {
+	keys map[string]*Key
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time
+}
+
+func newConfigStore(keys map[string]*Key) *configStore {
+	return &configStore{keys: keys, lastUsed: map[string]time.Time{}}
+}
+
+func (s *configStore) Find(_ context.Context, id string) (*Key, error) {
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *key
+	s.mu.Lock()
+	if at, ok := s.lastUsed[id]; ok {
+		found.LastUsedAt = &at
+	}
+	s.mu.Unlock()
+	return &found, nil
+}
+
+func (s *configStore) MarkUsed(_ context.Context, id string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastUsed[id] = at
+	return nil
+}
@@ -41,6 +41,12 @@ type protocolRoutingAuthenticator struct {
 	grpc api.Authenticator
 }
 
+// Stop ends the background work of the authenticators of both protocols.
+func (a *protocolRoutingAuthenticator) Stop() {
+	api.Stop(a.http)
+	api.Stop(a.grpc)
+}
+
 func (a *protocolRoutingAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
 	switch t.Kind() {
 	case transport.KindHTTP:
@@ -65,6 +71,14 @@ func NewForProtocol(config CompletedConfig, protocol Protocol, logger *log.Helpe
 		// Set logger for debugging which authenticator allowed
 		firstMatch.SetLogger(logger)
 		aggregatingAuth = firstMatch
+	case aggregator.AllMustPass:
+		allMustPass := aggregator.NewAllMustPass()
+		allMustPass.SetLogger(logger)
+		aggregatingAuth = allMustPass
+	case aggregator.ClaimsMerge:
+		claimsMerge := aggregator.NewClaimsMerge()
+		claimsMerge.SetLogger(logger)
+		aggregatingAuth = claimsMerge
 	default:
 		return nil, fmt.Errorf("unknown authenticator strategy type: %s", config.Authenticator.Type)
 	}
@@ -122,6 +136,36 @@ func NewForProtocol(config CompletedConfig, protocol Protocol, logger *log.Helpe
 				return nil, fmt.Errorf("failed to create x-rh-identity authenticator: %w", err)
 			}
 
+		case string(factory.TypeMTLS):
+			if chainConfig.MTLSConfig == nil {
+				return nil, fmt.Errorf("mtls authenticator requires config at chain index %d", i)
+			}
+			logger.Infof("Will check for verified client certificates using %d rules", len(chainConfig.MTLSConfig.Rules))
+			auth, err = factory.CreateAuthenticator(factory.TypeMTLS, chainConfig.MTLSConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create mtls authenticator: %w", err)
+			}
+
+		case string(factory.TypeJWT):
+			if chainConfig.JWTConfig == nil {
+				return nil, fmt.Errorf("jwt authenticator requires config at chain index %d", i)
+			}
+			logger.Infof("Will verify JWTs offline for %d issuers", len(chainConfig.JWTConfig.Issuers))
+			auth, err = factory.CreateAuthenticator(factory.TypeJWT, chainConfig.JWTConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create jwt authenticator: %w", err)
+			}
+
+		case string(factory.TypeAPIKey):
+			if chainConfig.APIKeyConfig == nil {
+				return nil, fmt.Errorf("api-key authenticator requires config at chain index %d", i)
+			}
+			logger.Infof("Will check for api keys in the %s header using the %s store", chainConfig.APIKeyConfig.Header, chainConfig.APIKeyConfig.StoreType)
+			auth, err = factory.CreateAuthenticator(factory.TypeAPIKey, chainConfig.APIKeyConfig)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create api-key authenticator: %w", err)
+			}
+
 		default:
 			return nil, fmt.Errorf("unknown authenticator type in chain at index %d: %s", i, chainConfig.Type)
 		}
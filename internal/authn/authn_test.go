@@ -353,6 +353,58 @@ func TestNew_EnableHTTP_EnableGRPC_PerAuthenticatorType(t *testing.T) {
 	})
 }
 
+func TestNew_AggregationStrategies(t *testing.T) {
+	// A User identity with org_id 123456 and user_id user-123
+	const xrh = "eyJpZGVudGl0eSI6eyJhY2NvdW50X251bWJlciI6IjEyMzQ1NiIsIm9yZ19pZCI6IjEyMzQ1NiIsInVzZXIiOnsidXNlcm5hbWUiOiJ0ZXN0dXNlciIsImVtYWlsIjoidGVzdHVzZXJAZXhhbXBsZS5jb20iLCJ1c2VyX2lkIjoidXNlci0xMjMifSwiaW50ZXJuYWwiOnt9LCJ0eXBlIjoiVXNlciJ9fQ=="
+	logger := log.NewHelper(log.DefaultLogger)
+	newConfig := func(strategy aggregator.StrategyType) CompletedConfig {
+		return CompletedConfig{
+			&completedConfig{
+				Authenticator: &AuthenticatorCompletedConfig{
+					Type: strategy,
+					ChainConfigs: []ChainCompletedConfig{
+						{Type: "x-rh-identity", EnabledHTTP: true, EnabledGRPC: false},
+						{Type: "allow-unauthenticated", EnabledHTTP: true, EnabledGRPC: true},
+					},
+				},
+			},
+		}
+	}
+	withXRH := &mockTransporter{kind: transport.KindHTTP, headers: map[string]string{"x-rh-identity": xrh}}
+	withoutXRH := &mockTransporter{kind: transport.KindHTTP, headers: map[string]string{}}
+	grpcT := &mockTransporter{kind: transport.KindGRPC, headers: map[string]string{}}
+
+	t.Run("all_must_pass", func(t *testing.T) {
+		auth, err := New(newConfig(aggregator.AllMustPass), logger)
+		assert.NoError(t, err)
+
+		claims, decision := auth.Authenticate(context.Background(), withXRH)
+		assert.Equal(t, api.Allow, decision)
+		assert.Equal(t, api.AuthTypeXRhIdentity, claims.AuthType)
+
+		_, decision = auth.Authenticate(context.Background(), withoutXRH)
+		assert.Equal(t, api.Deny, decision)
+
+		// x-rh-identity is disabled for gRPC, so only allow-unauthenticated must pass there.
+		_, decision = auth.Authenticate(context.Background(), grpcT)
+		assert.Equal(t, api.Allow, decision)
+	})
+
+	t.Run("claims_merge", func(t *testing.T) {
+		auth, err := New(newConfig(aggregator.ClaimsMerge), logger)
+		assert.NoError(t, err)
+
+		claims, decision := auth.Authenticate(context.Background(), withXRH)
+		assert.Equal(t, api.Allow, decision)
+		assert.Equal(t, api.AuthTypeXRhIdentity, claims.AuthType)
+		assert.Equal(t, api.OrganizationId("123456"), claims.OrganizationId)
+
+		claims, decision = auth.Authenticate(context.Background(), withoutXRH)
+		assert.Equal(t, api.Allow, decision)
+		assert.False(t, claims.IsAuthenticated())
+	})
+}
+
 func TestNew_UnknownAuthenticatorType(t *testing.T) {
 	logger := log.NewHelper(log.DefaultLogger)
 	config := CompletedConfig{
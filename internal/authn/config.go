@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	"github.com/project-kessel/inventory-api/internal/authn/aggregator"
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+	"github.com/project-kessel/inventory-api/internal/authn/jwt"
+	"github.com/project-kessel/inventory-api/internal/authn/mtls"
 	"github.com/project-kessel/inventory-api/internal/authn/oidc"
 )
 
@@ -137,6 +140,18 @@ func (c *CompletedConfig) GetOIDCConfig() *oidc.CompletedConfig {
 	return nil
 }
 
+// SetAPIKeyStore sets the key store of api-key authenticators configured with the database store.
+func (c *CompletedConfig) SetAPIKeyStore(store apikey.Store) {
+	if c.Authenticator == nil {
+		return
+	}
+	for _, chainConfig := range c.Authenticator.ChainConfigs {
+		if chainConfig.APIKeyConfig != nil && chainConfig.APIKeyConfig.StoreType == apikey.StoreDatabase {
+			chainConfig.APIKeyConfig.SetStore(store)
+		}
+	}
+}
+
 // AuthenticatorCompletedConfig represents the completed authenticator configuration
 type AuthenticatorCompletedConfig struct {
 	Type         aggregator.StrategyType
@@ -145,10 +160,13 @@ type AuthenticatorCompletedConfig struct {
 
 // ChainCompletedConfig represents a completed chain entry configuration
 type ChainCompletedConfig struct {
-	Type        string
-	EnabledHTTP bool // true if enabled for HTTP, false if disabled
-	EnabledGRPC bool // true if enabled for gRPC, false if disabled
-	OIDCConfig  *oidc.CompletedConfig
+	Type         string
+	EnabledHTTP  bool // true if enabled for HTTP, false if disabled
+	EnabledGRPC  bool // true if enabled for gRPC, false if disabled
+	OIDCConfig   *oidc.CompletedConfig
+	MTLSConfig   *mtls.CompletedConfig
+	JWTConfig    *jwt.CompletedConfig
+	APIKeyConfig *apikey.CompletedConfig
 }
 
 func (c *Config) Complete() (CompletedConfig, []error) {
@@ -162,7 +180,9 @@ func (c *Config) Complete() (CompletedConfig, []error) {
 
 	// Validate strategy type
 	strategyType := aggregator.StrategyType(c.Authenticator.Type)
-	if strategyType != aggregator.FirstMatch {
+	switch strategyType {
+	case aggregator.FirstMatch, aggregator.AllMustPass, aggregator.ClaimsMerge:
+	default:
 		errs = append(errs, &ConfigError{
 			Message: "invalid authenticator strategy type",
 			Value:   c.Authenticator.Type,
@@ -332,6 +352,87 @@ func (c *Config) completeChainEntry(entry ChainEntry, index int) (ChainCompleted
 		}
 		chainConfig.OIDCConfig = &completedOIDC
 
+	case "mtls":
+		// If mTLS is disabled for both protocols, skip config completion/validation.
+		if !enableHTTP && !enableGRPC {
+			return chainConfig, nil
+		}
+		if entry.Config == nil {
+			errs = append(errs, &ConfigError{
+				Message: fmt.Sprintf("mtls authenticator requires config at chain index %d", index),
+				Type:    entry.Type,
+			})
+			return chainConfig, errs
+		}
+		mtlsOpts, err := mtlsOptionsFromConfig(entry.Config)
+		if err == nil {
+			var completedMTLS mtls.CompletedConfig
+			completedMTLS, err = mtls.NewConfig(mtlsOpts).Complete()
+			chainConfig.MTLSConfig = &completedMTLS
+		}
+		if err != nil {
+			errs = append(errs, &ConfigError{
+				Message: fmt.Sprintf("failed to complete mtls config at chain index %d", index),
+				Type:    entry.Type,
+				Err:     err,
+			})
+			return chainConfig, errs
+		}
+
+	case "jwt":
+		// If jwt is disabled for both protocols, skip config completion/validation.
+		if !enableHTTP && !enableGRPC {
+			return chainConfig, nil
+		}
+		if entry.Config == nil {
+			errs = append(errs, &ConfigError{
+				Message: fmt.Sprintf("jwt authenticator requires config at chain index %d", index),
+				Type:    entry.Type,
+			})
+			return chainConfig, errs
+		}
+		jwtOpts, err := jwtOptionsFromConfig(entry.Config)
+		if err == nil {
+			var completedJWT jwt.CompletedConfig
+			completedJWT, err = jwt.NewConfig(jwtOpts).Complete()
+			chainConfig.JWTConfig = &completedJWT
+		}
+		if err != nil {
+			errs = append(errs, &ConfigError{
+				Message: fmt.Sprintf("failed to complete jwt config at chain index %d", index),
+				Type:    entry.Type,
+				Err:     err,
+			})
+			return chainConfig, errs
+		}
+
+	case "api-key":
+		// If api-key is disabled for both protocols, skip config completion/validation.
+		if !enableHTTP && !enableGRPC {
+			return chainConfig, nil
+		}
+		if entry.Config == nil {
+			errs = append(errs, &ConfigError{
+				Message: fmt.Sprintf("api-key authenticator requires config at chain index %d", index),
+				Type:    entry.Type,
+			})
+			return chainConfig, errs
+		}
+		apiKeyOpts, err := apiKeyOptionsFromConfig(entry.Config)
+		if err == nil {
+			var completedAPIKey apikey.CompletedConfig
+			completedAPIKey, err = apikey.NewConfig(apiKeyOpts).Complete()
+			chainConfig.APIKeyConfig = &completedAPIKey
+		}
+		if err != nil {
+			errs = append(errs, &ConfigError{
+				Message: fmt.Sprintf("failed to complete api-key config at chain index %d", index),
+				Type:    entry.Type,
+				Err:     err,
+			})
+			return chainConfig, errs
+		}
+
 	default:
 		errs = append(errs, &ConfigError{
 			Message: fmt.Sprintf("unknown authenticator type at chain index %d", index),
@@ -343,6 +444,94 @@ func (c *Config) completeChainEntry(entry ChainEntry, index int) (ChainCompleted
 	return chainConfig, nil
 }
 
+// mtlsOptionsFromConfig reads mtls options from a chain entry config map.
+func mtlsOptionsFromConfig(config map[string]interface{}) (*mtls.Options, error) {
+	opts := mtls.NewOptions()
+	err := configMaps(config, "rules", func(_ int, rule map[string]interface{}) error {
+		var ruleOpts mtls.RuleOptions
+		for key, target := range map[string]*string{
+			"source":     &ruleOpts.Source,
+			"pattern":    &ruleOpts.Pattern,
+			"subject-id": &ruleOpts.SubjectId,
+			"client-id":  &ruleOpts.ClientId,
+		} {
+			if err := configString(rule, key, target); err != nil {
+				return err
+			}
+		}
+		opts.Rules = append(opts.Rules, ruleOpts)
+		return nil
+	})
+	return opts, err
+}
+
+// jwtOptionsFromConfig reads jwt options from a chain entry config map.
+func jwtOptionsFromConfig(config map[string]interface{}) (*jwt.Options, error) {
+	opts := jwt.NewOptions()
+	for _, key := range []string{"jwks-file", "keys"} {
+		if _, ok := config[key]; ok {
+			return nil, fmt.Errorf("%s must be set on each issuer", key)
+		}
+	}
+	if err := configDuration(config, "reload-interval", &opts.ReloadInterval); err != nil {
+		return nil, err
+	}
+	err := configMaps(config, "issuers", func(_ int, issuer map[string]interface{}) error {
+		var issuerOpts jwt.IssuerOptions
+		for key, target := range map[string]*string{
+			"issuer":          &issuerOpts.Issuer,
+			"jwks-file":       &issuerOpts.JWKSFile,
+			"subject-claim":   &issuerOpts.SubjectClaim,
+			"org-id-claim":    &issuerOpts.OrgIdClaim,
+			"client-id-claim": &issuerOpts.ClientIdClaim,
+		} {
+			if err := configString(issuer, key, target); err != nil {
+				return err
+			}
+		}
+		if err := configStringSlice(issuer, "keys", &issuerOpts.Keys); err != nil {
+			return err
+		}
+		if err := configStringSlice(issuer, "audiences", &issuerOpts.Audiences); err != nil {
+			return err
+		}
+		opts.Issuers = append(opts.Issuers, issuerOpts)
+		return nil
+	})
+	return opts, err
+}
+
+// apiKeyOptionsFromConfig reads api-key options from a chain entry config map.
+func apiKeyOptionsFromConfig(config map[string]interface{}) (*apikey.Options, error) {
+	opts := apikey.NewOptions()
+	if err := configString(config, "header", &opts.Header); err != nil {
+		return nil, err
+	}
+	if err := configString(config, "store", &opts.Store); err != nil {
+		return nil, err
+	}
+	if err := configDuration(config, "cache-ttl", &opts.CacheTTL); err != nil {
+		return nil, err
+	}
+	err := configMaps(config, "keys", func(_ int, key map[string]interface{}) error {
+		var keyOpts apikey.KeyOptions
+		for name, target := range map[string]*string{
+			"id":         &keyOpts.Id,
+			"hash":       &keyOpts.Hash,
+			"subject-id": &keyOpts.SubjectId,
+			"client-id":  &keyOpts.ClientId,
+			"org-id":     &keyOpts.OrgId,
+		} {
+			if err := configString(key, name, target); err != nil {
+				return err
+			}
+		}
+		opts.Keys = append(opts.Keys, keyOpts)
+		return nil
+	})
+	return opts, err
+}
+
 // ConfigError represents a configuration error
 type ConfigError struct {
 	Message string
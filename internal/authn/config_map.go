@@ -0,0 +1,78 @@
+package authn
+
+import (
+	"fmt"
+	"time"
+)
+
+// Helpers for reading chain entry config maps, which hold what YAML parsing via mapstructure
+// produces: strings, []interface{} and map[string]interface{}. Missing keys leave the target unchanged.
+
+func configString(config map[string]interface{}, key string, target *string) error {
+	value, exists := config[key]
+	if !exists {
+		return nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%s must be a string", key)
+	}
+	*target = str
+	return nil
+}
+
+func configStringSlice(config map[string]interface{}, key string, target *[]string) error {
+	value, exists := config[key]
+	if !exists {
+		return nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be an array", key)
+	}
+	strs := make([]string, len(items))
+	for i, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return fmt.Errorf("%s[%d] must be a string", key, i)
+		}
+		strs[i] = str
+	}
+	*target = strs
+	return nil
+}
+
+func configDuration(config map[string]interface{}, key string, target *time.Duration) error {
+	var str string
+	if err := configString(config, key, &str); err != nil || str == "" {
+		return err
+	}
+	d, err := time.ParseDuration(str)
+	if err != nil {
+		return fmt.Errorf("%s must be a duration: %w", key, err)
+	}
+	*target = d
+	return nil
+}
+
+// configMaps calls fn for each map in the array at key, passing the element's index.
+func configMaps(config map[string]interface{}, key string, fn func(i int, m map[string]interface{}) error) error {
+	value, exists := config[key]
+	if !exists {
+		return nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("%s must be an array", key)
+	}
+	for i, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s[%d] must be a map", key, i)
+		}
+		if err := fn(i, m); err != nil {
+			return fmt.Errorf("%s[%d].%w", key, i, err)
+		}
+	}
+	return nil
+}
@@ -1,10 +1,15 @@
 package authn
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
 )
 
 func TestConfigComplete_EnableHTTP_EnableGRPC_OIDCConfigRequiredOnlyWhenEnabledForAnyProtocol(t *testing.T) {
@@ -210,3 +215,277 @@ func TestConfigComplete_WithGrpcEndpoints(t *testing.T) {
 }
 
 func boolPtr(v bool) *bool { return &v }
+
+func TestConfigComplete_WithMTLSRules(t *testing.T) {
+	t.Run("rules converted from config map to mtls options", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{
+						Type:      "mtls",
+						Transport: &Transport{HTTP: boolPtr(true), GRPC: boolPtr(true)},
+						Config: map[string]interface{}{
+							"rules": []interface{}{
+								map[string]interface{}{
+									"source":    "spiffe-id",
+									"pattern":   "spiffe://kessel.example/ns/([^/]+)/sa/.+",
+									"client-id": "$1",
+								},
+							},
+						},
+					},
+					{Type: "allow-unauthenticated", Transport: &Transport{HTTP: boolPtr(true), GRPC: boolPtr(true)}},
+				},
+			},
+		}
+
+		completed, errs := c.Complete()
+		assert.Empty(t, errs)
+		mtlsChainConfig := completed.Authenticator.ChainConfigs[0]
+		assert.NotNil(t, mtlsChainConfig.MTLSConfig)
+		assert.Len(t, mtlsChainConfig.MTLSConfig.Rules, 1)
+		assert.Equal(t, "$1", mtlsChainConfig.MTLSConfig.Rules[0].ClientId)
+		assert.Equal(t, "$0", mtlsChainConfig.MTLSConfig.Rules[0].SubjectId)
+	})
+
+	t.Run("rules rejects non-string field", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{
+						Type: "mtls",
+						Config: map[string]interface{}{
+							"rules": []interface{}{
+								map[string]interface{}{"source": "dns-san", "pattern": 123},
+							},
+						},
+					},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		_, errs := c.Complete()
+		assert.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), "rules[0].pattern must be a string")
+	})
+
+	t.Run("mtls requires at least one rule when enabled", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{Type: "mtls", Config: map[string]interface{}{}},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		_, errs := c.Complete()
+		assert.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), "at least one rule is required")
+	})
+}
+
+func TestConfigComplete_WithJWTIssuers(t *testing.T) {
+	t.Run("issuers converted from config map to jwt options", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{
+						Type: "jwt",
+						Config: map[string]interface{}{
+							"reload-interval": "30s",
+							"issuers": []interface{}{
+								map[string]interface{}{
+									"issuer":       "https://sso.example.com",
+									"jwks-file":    "/etc/kessel/jwks.json",
+									"audiences":    []interface{}{"inventory"},
+									"org-id-claim": "identity.org_id",
+								},
+							},
+						},
+					},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		completed, errs := c.Complete()
+		assert.Empty(t, errs)
+		jwtChainConfig := completed.Authenticator.ChainConfigs[0]
+		assert.NotNil(t, jwtChainConfig.JWTConfig)
+		assert.Equal(t, 30*time.Second, jwtChainConfig.JWTConfig.ReloadInterval)
+		issuer := jwtChainConfig.JWTConfig.Issuers["https://sso.example.com"]
+		assert.Equal(t, "/etc/kessel/jwks.json", issuer.JWKSFile)
+		assert.Equal(t, []string{"inventory"}, issuer.Audiences)
+		assert.Equal(t, "identity.org_id", issuer.OrgIdClaim)
+		assert.Equal(t, "sub", issuer.SubjectClaim)
+	})
+
+	t.Run("invalid reload-interval", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{Type: "jwt", Config: map[string]interface{}{"reload-interval": "soon"}},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		_, errs := c.Complete()
+		assert.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), "reload-interval must be a duration")
+	})
+
+	t.Run("audiences rejects non-string element", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{
+						Type: "jwt",
+						Config: map[string]interface{}{
+							"issuers": []interface{}{
+								map[string]interface{}{"issuer": "https://sso.example.com", "jwks-file": "jwks.json", "audiences": []interface{}{1}},
+							},
+						},
+					},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		_, errs := c.Complete()
+		assert.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), "issuers[0].audiences[0] must be a string")
+	})
+
+	t.Run("keys outside an issuer", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{Type: "jwt", Config: map[string]interface{}{"jwks-file": "jwks.json"}},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		_, errs := c.Complete()
+		assert.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), "jwks-file must be set on each issuer")
+	})
+}
+
+func TestConfigComplete_WithAPIKeys(t *testing.T) {
+	t.Run("keys converted from config map to api-key options", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{
+						Type: "api-key",
+						Config: map[string]interface{}{
+							"header":    "X-Api-Key",
+							"cache-ttl": "10s",
+							"keys": []interface{}{
+								map[string]interface{}{
+									"id":         "0011",
+									"hash":       "$2b$10$3euPcmQFCiblsZeEu5s7p.9OVHgeHWFDk9nhMqZ0m/3pd/lhwZgES",
+									"subject-id": "ci-bot",
+								},
+							},
+						},
+					},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		completed, errs := c.Complete()
+		assert.Empty(t, errs)
+		apiKeyChainConfig := completed.Authenticator.ChainConfigs[0]
+		assert.NotNil(t, apiKeyChainConfig.APIKeyConfig)
+		assert.Equal(t, "X-Api-Key", apiKeyChainConfig.APIKeyConfig.Header)
+		assert.Equal(t, "config", apiKeyChainConfig.APIKeyConfig.StoreType)
+		assert.Equal(t, 10*time.Second, apiKeyChainConfig.APIKeyConfig.CacheTTL)
+		assert.NotNil(t, apiKeyChainConfig.APIKeyConfig.Store)
+	})
+
+	t.Run("database store is set by SetAPIKeyStore", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{Type: "api-key", Config: map[string]interface{}{"store": "database"}},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		completed, errs := c.Complete()
+		assert.Empty(t, errs)
+		assert.Nil(t, completed.Authenticator.ChainConfigs[0].APIKeyConfig.Store)
+
+		_, err := NewForProtocol(completed, ProtocolHTTP, log.NewHelper(log.DefaultLogger))
+		assert.ErrorContains(t, err, "has no key store")
+
+		completed.SetAPIKeyStore(&stubAPIKeyStore{})
+		_, err = NewForProtocol(completed, ProtocolHTTP, log.NewHelper(log.DefaultLogger))
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid store", func(t *testing.T) {
+		c := &Config{
+			Authenticator: &AuthenticatorConfig{
+				Type: "first_match",
+				Chain: []ChainEntry{
+					{Type: "api-key", Config: map[string]interface{}{"store": "vault"}},
+					{Type: "allow-unauthenticated"},
+				},
+			},
+		}
+
+		_, errs := c.Complete()
+		assert.NotEmpty(t, errs)
+		assert.Contains(t, errs[0].Error(), `invalid store "vault"`)
+	})
+}
+
+type stubAPIKeyStore struct{}
+
+func (s *stubAPIKeyStore) Find(context.Context, string) (*apikey.Key, error) { return nil, nil }
+
+func (s *stubAPIKeyStore) MarkUsed(context.Context, string, time.Time) error { return nil }
+
+func TestConfigComplete_StrategyTypes(t *testing.T) {
+	for _, strategy := range []string{"first_match", "all_must_pass", "claims_merge"} {
+		t.Run(strategy, func(t *testing.T) {
+			c := &Config{
+				Authenticator: &AuthenticatorConfig{
+					Type:  strategy,
+					Chain: []ChainEntry{{Type: "allow-unauthenticated"}},
+				},
+			}
+
+			completed, errs := c.Complete()
+			assert.Empty(t, errs)
+			assert.Equal(t, strategy, string(completed.Authenticator.Type))
+		})
+	}
+
+	c := &Config{
+		Authenticator: &AuthenticatorConfig{
+			Type:  "any_of",
+			Chain: []ChainEntry{{Type: "allow-unauthenticated"}},
+		},
+	}
+	_, errs := c.Complete()
+	assert.NotEmpty(t, errs)
+	assert.Contains(t, errs[0].Error(), "invalid authenticator strategy type")
+}
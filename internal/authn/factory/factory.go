@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	"github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+	"github.com/project-kessel/inventory-api/internal/authn/jwt"
+	"github.com/project-kessel/inventory-api/internal/authn/mtls"
 	"github.com/project-kessel/inventory-api/internal/authn/oidc"
 	"github.com/project-kessel/inventory-api/internal/authn/unauthenticated"
 	"github.com/project-kessel/inventory-api/internal/authn/xrhidentity"
@@ -19,6 +22,9 @@ const (
 	// TypeAllowUnauthenticated is the preferred name for the unauthenticated (allow-all) authenticator.
 	TypeAllowUnauthenticated AuthenticatorType = "allow-unauthenticated"
 	TypeXRhIdentity          AuthenticatorType = "x-rh-identity"
+	TypeMTLS                 AuthenticatorType = "mtls"
+	TypeJWT                  AuthenticatorType = "jwt"
+	TypeAPIKey               AuthenticatorType = "api-key"
 )
 
 // CreateAuthenticator creates an authenticator of the specified type with the given config.
@@ -26,6 +32,9 @@ const (
 // - oidc: *oidc.CompletedConfig
 // - guest: nil (no config needed)
 // - x-rh-identity: nil (no config needed)
+// - mtls: *mtls.CompletedConfig
+// - jwt: *jwt.CompletedConfig
+// - api-key: *apikey.CompletedConfig
 func CreateAuthenticator(authType AuthenticatorType, config interface{}) (api.Authenticator, error) {
 	switch authType {
 	case TypeOIDC:
@@ -50,6 +59,36 @@ func CreateAuthenticator(authType AuthenticatorType, config interface{}) (api.Au
 		}
 		return xrhidentity.New(), nil
 
+	case TypeMTLS:
+		mtlsConfig, ok := config.(*mtls.CompletedConfig)
+		if !ok {
+			return nil, fmt.Errorf("mtls authenticator requires *mtls.CompletedConfig, got %T", config)
+		}
+		if mtlsConfig == nil {
+			return nil, fmt.Errorf("mtls authenticator requires non-nil config")
+		}
+		return mtls.New(*mtlsConfig), nil
+
+	case TypeJWT:
+		jwtConfig, ok := config.(*jwt.CompletedConfig)
+		if !ok {
+			return nil, fmt.Errorf("jwt authenticator requires *jwt.CompletedConfig, got %T", config)
+		}
+		if jwtConfig == nil {
+			return nil, fmt.Errorf("jwt authenticator requires non-nil config")
+		}
+		return jwt.New(*jwtConfig)
+
+	case TypeAPIKey:
+		apiKeyConfig, ok := config.(*apikey.CompletedConfig)
+		if !ok {
+			return nil, fmt.Errorf("api-key authenticator requires *apikey.CompletedConfig, got %T", config)
+		}
+		if apiKeyConfig == nil {
+			return nil, fmt.Errorf("api-key authenticator requires non-nil config")
+		}
+		return apikey.New(*apiKeyConfig)
+
 	default:
 		return nil, fmt.Errorf("unknown authenticator type: %s", authType)
 	}
@@ -1,9 +1,14 @@
 package factory
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+	"github.com/project-kessel/inventory-api/internal/authn/jwt"
+	"github.com/project-kessel/inventory-api/internal/authn/mtls"
 )
 
 func TestCreateAuthenticator_OIDC(t *testing.T) {
@@ -126,6 +131,73 @@ func TestCreateAuthenticator_XRhIdentity(t *testing.T) {
 	}
 }
 
+func TestCreateAuthenticator_MTLS(t *testing.T) {
+	completed, err := mtls.NewConfig(&mtls.Options{
+		Rules: []mtls.RuleOptions{{Source: mtls.SourceSPIFFEID, Pattern: "spiffe://.*"}},
+	}).Complete()
+	assert.NoError(t, err)
+
+	auth, err := CreateAuthenticator(TypeMTLS, &completed)
+	assert.NoError(t, err)
+	assert.NotNil(t, auth)
+
+	auth, err = CreateAuthenticator(TypeMTLS, (*mtls.CompletedConfig)(nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mtls authenticator requires non-nil config")
+	assert.Nil(t, auth)
+
+	auth, err = CreateAuthenticator(TypeMTLS, "invalid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "mtls authenticator requires *mtls.CompletedConfig")
+	assert.Nil(t, auth)
+}
+
+func TestCreateAuthenticator_JWT(t *testing.T) {
+	completed, err := jwt.NewConfig(&jwt.Options{
+		Issuers: []jwt.IssuerOptions{{
+			Issuer:    "https://sso.example.com",
+			JWKSFile:  filepath.Join(t.TempDir(), "missing.json"),
+			Audiences: []string{"inventory"},
+		}},
+	}).Complete()
+	assert.NoError(t, err)
+
+	auth, err := CreateAuthenticator(TypeJWT, &completed)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read JWKS file")
+	assert.Nil(t, auth)
+
+	auth, err = CreateAuthenticator(TypeJWT, (*jwt.CompletedConfig)(nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "jwt authenticator requires non-nil config")
+	assert.Nil(t, auth)
+
+	auth, err = CreateAuthenticator(TypeJWT, "invalid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "jwt authenticator requires *jwt.CompletedConfig")
+	assert.Nil(t, auth)
+}
+
+func TestCreateAuthenticator_APIKey(t *testing.T) {
+	completed, err := apikey.NewConfig(&apikey.Options{Header: apikey.DefaultHeader, Store: apikey.StoreDatabase}).Complete()
+	assert.NoError(t, err)
+
+	auth, err := CreateAuthenticator(TypeAPIKey, &completed)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "has no key store")
+	assert.Nil(t, auth)
+
+	auth, err = CreateAuthenticator(TypeAPIKey, (*apikey.CompletedConfig)(nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "api-key authenticator requires non-nil config")
+	assert.Nil(t, auth)
+
+	auth, err = CreateAuthenticator(TypeAPIKey, "invalid")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "api-key authenticator requires *apikey.CompletedConfig")
+	assert.Nil(t, auth)
+}
+
 func TestCreateAuthenticator_UnknownType(t *testing.T) {
 	auth, err := CreateAuthenticator("unknown-type", nil)
 	assert.Error(t, err)
@@ -0,0 +1,93 @@
+package jwt
+
+import (
+	"crypto"
+	"fmt"
+	"time"
+)
+
+type Config struct {
+	*Options
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+// Issuer is an IssuerOptions with its keys parsed and its claim paths defaulted.
+type Issuer struct {
+	Issuer        string
+	JWKSFile      string
+	InlineKeys    []crypto.PublicKey
+	Audiences     []string
+	SubjectClaim  string
+	OrgIdClaim    string
+	ClientIdClaim string
+}
+
+type completedConfig struct {
+	ReloadInterval time.Duration
+	Issuers        map[string]Issuer
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func (c *Config) Complete() (CompletedConfig, error) {
+	if c.ReloadInterval < 0 {
+		return CompletedConfig{}, fmt.Errorf("reload-interval must not be negative")
+	}
+	if len(c.Issuers) == 0 {
+		return CompletedConfig{}, fmt.Errorf("at least one issuer is required")
+	}
+
+	issuers := make(map[string]Issuer, len(c.Issuers))
+	for i, o := range c.Issuers {
+		if o.Issuer == "" {
+			return CompletedConfig{}, fmt.Errorf("issuers[%d]: issuer is required", i)
+		}
+		if _, exists := issuers[o.Issuer]; exists {
+			return CompletedConfig{}, fmt.Errorf("issuers[%d]: duplicate issuer %q", i, o.Issuer)
+		}
+		// Keys belong to a single issuer, so that a key of one issuer can't sign tokens for another.
+		if o.JWKSFile == "" && len(o.Keys) == 0 {
+			return CompletedConfig{}, fmt.Errorf("issuers[%d]: jwks-file or keys is required", i)
+		}
+		if len(o.Audiences) == 0 {
+			return CompletedConfig{}, fmt.Errorf("issuers[%d]: audiences is required", i)
+		}
+		inlineKeys := make([]crypto.PublicKey, 0, len(o.Keys))
+		for j, k := range o.Keys {
+			key, err := parsePEMPublicKey([]byte(k))
+			if err != nil {
+				return CompletedConfig{}, fmt.Errorf("issuers[%d]: keys[%d]: %w", i, j, err)
+			}
+			inlineKeys = append(inlineKeys, key)
+		}
+		issuer := Issuer{
+			Issuer:        o.Issuer,
+			JWKSFile:      o.JWKSFile,
+			InlineKeys:    inlineKeys,
+			Audiences:     o.Audiences,
+			SubjectClaim:  o.SubjectClaim,
+			OrgIdClaim:    o.OrgIdClaim,
+			ClientIdClaim: o.ClientIdClaim,
+		}
+		if issuer.SubjectClaim == "" {
+			issuer.SubjectClaim = DefaultSubjectClaim
+		}
+		if issuer.OrgIdClaim == "" {
+			issuer.OrgIdClaim = DefaultOrgIdClaim
+		}
+		if issuer.ClientIdClaim == "" {
+			issuer.ClientIdClaim = DefaultClientIdClaim
+		}
+		issuers[o.Issuer] = issuer
+	}
+
+	return CompletedConfig{&completedConfig{
+		ReloadInterval: c.ReloadInterval,
+		Issuers:        issuers,
+	}}, nil
+}
@@ -0,0 +1,155 @@
+// Package jwt provides an Authenticator that verifies JWTs offline against locally configured
+// keys, for installs that can't reach an OIDC discovery endpoint.
+package jwt
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	coreosoidc "github.com/coreos/go-oidc/v3/oidc"
+	"github.com/go-kratos/kratos/v2/transport"
+	gojwt "github.com/golang-jwt/jwt/v5"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/authn/util"
+)
+
+// supportedSigningAlgs are the asymmetric algorithms tokens may be signed with.
+var supportedSigningAlgs = []string{
+	coreosoidc.RS256, coreosoidc.RS384, coreosoidc.RS512,
+	coreosoidc.ES256, coreosoidc.ES384, coreosoidc.ES512,
+	coreosoidc.PS256, coreosoidc.PS384, coreosoidc.PS512,
+	coreosoidc.EdDSA,
+}
+
+// issuerVerifier verifies the tokens of an issuer with the issuer's own keys.
+type issuerVerifier struct {
+	Issuer
+	keys     *keySet
+	verifier *coreosoidc.IDTokenVerifier
+}
+
+type JWTAuthenticator struct {
+	CompletedConfig
+
+	issuers map[string]issuerVerifier
+}
+
+func New(c CompletedConfig) (*JWTAuthenticator, error) {
+	a := &JWTAuthenticator{
+		CompletedConfig: c,
+		issuers:         make(map[string]issuerVerifier, len(c.Issuers)),
+	}
+	for name, issuer := range c.Issuers {
+		keys, err := newKeySet(issuer.JWKSFile, issuer.InlineKeys, c.ReloadInterval)
+		if err != nil {
+			a.Stop()
+			return nil, fmt.Errorf("issuer %q: %w", name, err)
+		}
+		a.issuers[name] = issuerVerifier{
+			Issuer: issuer,
+			keys:   keys,
+			// Audiences are checked separately since an issuer may accept several.
+			verifier: coreosoidc.NewVerifier(name, keys, &coreosoidc.Config{
+				SkipClientIDCheck:    true,
+				SupportedSigningAlgs: supportedSigningAlgs,
+			}),
+		}
+	}
+	return a, nil
+}
+
+// Stop ends periodic reloading of the JWKS files.
+func (a *JWTAuthenticator) Stop() {
+	for _, issuer := range a.issuers {
+		issuer.keys.Stop()
+	}
+}
+
+// Authenticate returns Ignore when there is no bearer token or the token's issuer isn't configured,
+// so that e.g. an oidc authenticator later in the chain can handle it. Tokens from a configured
+// issuer that fail verification are denied.
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
+	rawToken := util.GetBearerToken(t)
+	if rawToken == "" {
+		return nil, api.Ignore
+	}
+
+	// The issuer is read before verification only to select the verifier, which checks it again.
+	unverified, _, err := gojwt.NewParser().ParseUnverified(rawToken, gojwt.MapClaims{})
+	if err != nil {
+		util.LogAuthenticationFailure("jwt", "JWT could not be parsed", "malformed_token")
+		return nil, api.Deny
+	}
+	issuerName, err := unverified.Claims.GetIssuer()
+	if err != nil {
+		util.LogAuthenticationFailure("jwt", "JWT issuer could not be read", "invalid_claims_payload")
+		return nil, api.Deny
+	}
+	issuer, ok := a.issuers[issuerName]
+	if !ok {
+		return nil, api.Ignore
+	}
+
+	tok, err := issuer.verifier.Verify(ctx, rawToken)
+	if err != nil {
+		util.LogAuthenticationFailure("jwt", "JWT verification failed", "invalid_token", "issuer", issuerName)
+		return nil, api.Deny
+	}
+
+	if !slices.ContainsFunc(tok.Audience, func(aud string) bool {
+		return slices.Contains(issuer.Audiences, aud)
+	}) {
+		util.LogAuthenticationFailure("jwt", "JWT audience mismatch", "audience_mismatch",
+			"issuer", issuerName,
+			"actual_audience", strings.Join(tok.Audience, ","),
+		)
+		return nil, api.Deny
+	}
+
+	var claims map[string]interface{}
+	if err := tok.Claims(&claims); err != nil {
+		util.LogAuthenticationFailure("jwt", "JWT claims extraction failed", "invalid_claims_payload", "issuer", issuerName)
+		return nil, api.Deny
+	}
+
+	subject := claimString(claims, issuer.SubjectClaim)
+	if subject == "" {
+		util.LogAuthenticationFailure("jwt", "JWT subject claim missing", "missing_subject",
+			"issuer", issuerName,
+			"subject_claim", issuer.SubjectClaim,
+		)
+		return nil, api.Deny
+	}
+
+	return &api.Claims{
+		SubjectId:      api.SubjectId(subject),
+		OrganizationId: api.OrganizationId(claimString(claims, issuer.OrgIdClaim)),
+		Issuer:         api.Issuer(tok.Issuer),
+		ClientID:       api.ClientID(claimString(claims, issuer.ClientIdClaim)),
+		AuthType:       api.AuthTypeJWT,
+	}, api.Allow
+}
+
+// claimString returns the string or number at a dot-separated path in the token claims, or "".
+func claimString(claims map[string]interface{}, path string) string {
+	var value interface{} = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = object[key]
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
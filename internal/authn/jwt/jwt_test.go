@@ -0,0 +1,317 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jose "github.com/go-jose/go-jose/v4"
+	"github.com/go-kratos/kratos/v2/transport"
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// mockTransporter is a test helper that implements transport.Transporter
+type mockTransporter struct {
+	headers map[string]string
+}
+
+func (m *mockTransporter) Kind() transport.Kind            { return transport.KindGRPC }
+func (m *mockTransporter) Endpoint() string                { return "/test" }
+func (m *mockTransporter) Operation() string               { return "test" }
+func (m *mockTransporter) RequestHeader() transport.Header { return &mockHeader{headers: m.headers} }
+func (m *mockTransporter) ReplyHeader() transport.Header {
+	return &mockHeader{headers: make(map[string]string)}
+}
+
+type mockHeader struct {
+	headers map[string]string
+}
+
+func (m *mockHeader) Get(key string) string      { return m.headers[key] }
+func (m *mockHeader) Set(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Add(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Keys() []string             { return nil }
+func (m *mockHeader) Values(key string) []string { return nil }
+
+func bearer(token string) *mockTransporter {
+	return &mockTransporter{headers: map[string]string{"Authorization": "Bearer " + token}}
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func writeJWKS(t *testing.T, path string, keys ...*ecdsa.PrivateKey) {
+	t.Helper()
+	set := jose.JSONWebKeySet{}
+	for i, key := range keys {
+		set.Keys = append(set.Keys, jose.JSONWebKey{Key: &key.PublicKey, KeyID: string(rune('a' + i)), Algorithm: "ES256", Use: "sig"})
+	}
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func pemPublicKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, claims gojwt.MapClaims) string {
+	t.Helper()
+	if _, ok := claims["exp"]; !ok {
+		claims["exp"] = time.Now().Add(time.Hour).Unix()
+	}
+	token, err := gojwt.NewWithClaims(gojwt.SigningMethodES256, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func newAuthenticator(t *testing.T, opts *Options) *JWTAuthenticator {
+	t.Helper()
+	completed, err := NewConfig(opts).Complete()
+	require.NoError(t, err)
+	auth, err := New(completed)
+	require.NoError(t, err)
+	t.Cleanup(auth.Stop)
+	return auth
+}
+
+func TestAuthenticate_JWKSFile(t *testing.T) {
+	key := newKey(t)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, key)
+
+	auth := newAuthenticator(t, &Options{
+		Issuers: []IssuerOptions{{Issuer: "https://sso.example.com", JWKSFile: jwksFile, Audiences: []string{"inventory"}}},
+	})
+
+	claims, decision := auth.Authenticate(context.Background(), bearer(sign(t, key, gojwt.MapClaims{
+		"iss":       "https://sso.example.com",
+		"aud":       []string{"other", "inventory"},
+		"sub":       "user-1",
+		"org_id":    "12345",
+		"client_id": "rbac",
+	})))
+
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("user-1"), claims.SubjectId)
+	assert.Equal(t, api.OrganizationId("12345"), claims.OrganizationId)
+	assert.Equal(t, api.ClientID("rbac"), claims.ClientID)
+	assert.Equal(t, api.Issuer("https://sso.example.com"), claims.Issuer)
+	assert.Equal(t, api.AuthTypeJWT, claims.AuthType)
+}
+
+func TestAuthenticate_MultipleIssuersWithClaimMappings(t *testing.T) {
+	key := newKey(t)
+	auth := newAuthenticator(t, &Options{
+		Issuers: []IssuerOptions{
+			{Issuer: "https://sso.example.com", Keys: []string{pemPublicKey(t, newKey(t))}, Audiences: []string{"inventory"}},
+			{
+				Issuer:        "https://internal.example.com",
+				Keys:          []string{pemPublicKey(t, key)},
+				Audiences:     []string{"inventory"},
+				SubjectClaim:  "identity.user_id",
+				OrgIdClaim:    "identity.org.id",
+				ClientIdClaim: "azp",
+			},
+		},
+	})
+
+	claims, decision := auth.Authenticate(context.Background(), bearer(sign(t, key, gojwt.MapClaims{
+		"iss": "https://internal.example.com",
+		"aud": "inventory",
+		"sub": "ignored",
+		"azp": "acm",
+		"identity": map[string]interface{}{
+			"user_id": "svc-acm",
+			"org":     map[string]interface{}{"id": 987654},
+		},
+	})))
+
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("svc-acm"), claims.SubjectId)
+	assert.Equal(t, api.OrganizationId("987654"), claims.OrganizationId)
+	assert.Equal(t, api.ClientID("acm"), claims.ClientID)
+}
+
+func TestAuthenticate_Decisions(t *testing.T) {
+	key := newKey(t)
+	otherKey := newKey(t)
+	otherIssuerKey := newKey(t)
+	auth := newAuthenticator(t, &Options{
+		Issuers: []IssuerOptions{
+			{Issuer: "https://sso.example.com", Keys: []string{pemPublicKey(t, key)}, Audiences: []string{"inventory"}},
+			{Issuer: "https://other-sso.example.com", Keys: []string{pemPublicKey(t, otherIssuerKey)}, Audiences: []string{"inventory"}},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		transporter *mockTransporter
+		want        api.Decision
+	}{
+		{
+			name:        "no token",
+			transporter: &mockTransporter{headers: map[string]string{}},
+			want:        api.Ignore,
+		},
+		{
+			name:        "unknown issuer",
+			transporter: bearer(sign(t, key, gojwt.MapClaims{"iss": "https://other.example.com", "aud": "inventory", "sub": "u"})),
+			want:        api.Ignore,
+		},
+		{
+			name:        "malformed token",
+			transporter: bearer("not-a-jwt"),
+			want:        api.Deny,
+		},
+		{
+			name:        "wrong key",
+			transporter: bearer(sign(t, otherKey, gojwt.MapClaims{"iss": "https://sso.example.com", "aud": "inventory", "sub": "u"})),
+			want:        api.Deny,
+		},
+		{
+			name:        "key of another issuer",
+			transporter: bearer(sign(t, otherIssuerKey, gojwt.MapClaims{"iss": "https://sso.example.com", "aud": "inventory", "sub": "u"})),
+			want:        api.Deny,
+		},
+		{
+			name:        "no audience",
+			transporter: bearer(sign(t, key, gojwt.MapClaims{"iss": "https://sso.example.com", "sub": "u"})),
+			want:        api.Deny,
+		},
+		{
+			name: "expired",
+			transporter: bearer(sign(t, key, gojwt.MapClaims{
+				"iss": "https://sso.example.com", "aud": "inventory", "sub": "u", "exp": time.Now().Add(-time.Hour).Unix(),
+			})),
+			want: api.Deny,
+		},
+		{
+			name:        "audience mismatch",
+			transporter: bearer(sign(t, key, gojwt.MapClaims{"iss": "https://sso.example.com", "aud": "other", "sub": "u"})),
+			want:        api.Deny,
+		},
+		{
+			name:        "missing subject",
+			transporter: bearer(sign(t, key, gojwt.MapClaims{"iss": "https://sso.example.com", "aud": "inventory"})),
+			want:        api.Deny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, decision := auth.Authenticate(context.Background(), tt.transporter)
+			assert.Nil(t, claims)
+			assert.Equal(t, tt.want, decision)
+		})
+	}
+}
+
+func TestAuthenticate_ReloadsJWKSFile(t *testing.T) {
+	oldKey := newKey(t)
+	rotatedKey := newKey(t)
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, jwksFile, oldKey)
+
+	auth := newAuthenticator(t, &Options{
+		ReloadInterval: 10 * time.Millisecond,
+		Issuers:        []IssuerOptions{{Issuer: "https://sso.example.com", JWKSFile: jwksFile, Audiences: []string{"inventory"}}},
+	})
+	token := sign(t, rotatedKey, gojwt.MapClaims{"iss": "https://sso.example.com", "aud": "inventory", "sub": "user-1"})
+
+	_, decision := auth.Authenticate(context.Background(), bearer(token))
+	assert.Equal(t, api.Deny, decision)
+
+	writeJWKS(t, jwksFile, rotatedKey)
+	assert.Eventually(t, func() bool {
+		_, decision := auth.Authenticate(context.Background(), bearer(token))
+		return decision == api.Allow
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// An unreadable file keeps the previous keys.
+	require.NoError(t, os.WriteFile(jwksFile, []byte("{"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	_, decision = auth.Authenticate(context.Background(), bearer(token))
+	assert.Equal(t, api.Allow, decision)
+}
+
+func TestNew_InvalidJWKSFile(t *testing.T) {
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	completed, err := NewConfig(&Options{
+		Issuers: []IssuerOptions{{Issuer: "https://sso.example.com", JWKSFile: jwksFile, Audiences: []string{"inventory"}}},
+	}).Complete()
+	require.NoError(t, err)
+
+	_, err = New(completed)
+	assert.ErrorContains(t, err, "failed to read JWKS file")
+
+	require.NoError(t, os.WriteFile(jwksFile, []byte(`{"keys":[]}`), 0o600))
+	_, err = New(completed)
+	assert.ErrorContains(t, err, "no signing keys found")
+}
+
+func TestConfigComplete(t *testing.T) {
+	key := pemPublicKey(t, newKey(t))
+	aud := []string{"inventory"}
+	tests := []struct {
+		name    string
+		opts    *Options
+		wantErr string
+	}{
+		{name: "no keys", opts: &Options{Issuers: []IssuerOptions{{Issuer: "i", Audiences: aud}}}, wantErr: "issuers[0]: jwks-file or keys is required"},
+		{name: "no audiences", opts: &Options{Issuers: []IssuerOptions{{Issuer: "i", Keys: []string{key}}}}, wantErr: "issuers[0]: audiences is required"},
+		{name: "no issuers", opts: &Options{}, wantErr: "at least one issuer is required"},
+		{name: "invalid key", opts: &Options{Issuers: []IssuerOptions{{Issuer: "i", Keys: []string{"nope"}, Audiences: aud}}}, wantErr: "issuers[0]: keys[0]: no PEM data found"},
+		{name: "empty issuer", opts: &Options{Issuers: []IssuerOptions{{Keys: []string{key}, Audiences: aud}}}, wantErr: "issuers[0]: issuer is required"},
+		{
+			name:    "duplicate issuer",
+			opts:    &Options{Issuers: []IssuerOptions{{Issuer: "i", Keys: []string{key}, Audiences: aud}, {Issuer: "i", Keys: []string{key}, Audiences: aud}}},
+			wantErr: `issuers[1]: duplicate issuer "i"`,
+		},
+		{
+			name:    "negative reload interval",
+			opts:    &Options{ReloadInterval: -time.Second, Issuers: []IssuerOptions{{Issuer: "i", JWKSFile: "jwks.json", Audiences: aud}}},
+			wantErr: "reload-interval must not be negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewConfig(tt.opts).Complete()
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	completed, err := NewConfig(&Options{Issuers: []IssuerOptions{{Issuer: "i", Keys: []string{key}, Audiences: aud}}}).Complete()
+	require.NoError(t, err)
+	require.Len(t, completed.Issuers["i"].InlineKeys, 1)
+	assert.Equal(t, Issuer{
+		Issuer:        "i",
+		InlineKeys:    completed.Issuers["i"].InlineKeys,
+		Audiences:     aud,
+		SubjectClaim:  DefaultSubjectClaim,
+		OrgIdClaim:    DefaultOrgIdClaim,
+		ClientIdClaim: DefaultClientIdClaim,
+	}, completed.Issuers["i"])
+}
@@ -0,0 +1,101 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"slices"
+	"time"
+
+	coreosoidc "github.com/coreos/go-oidc/v3/oidc"
+	jose "github.com/go-jose/go-jose/v4"
+
+	"github.com/project-kessel/inventory-api/internal/filereload"
+)
+
+// keySet is a coreosoidc.KeySet over the inline keys and the keys of a JWKS file, which it
+// re-reads periodically so keys can be rotated without a restart.
+type keySet struct {
+	inlineKeys *coreosoidc.StaticKeySet
+	// file is nil without a JWKS file.
+	file *filereload.Reloader[coreosoidc.StaticKeySet]
+}
+
+func newKeySet(file string, inlineKeys []crypto.PublicKey, reloadInterval time.Duration) (*keySet, error) {
+	s := &keySet{inlineKeys: &coreosoidc.StaticKeySet{PublicKeys: inlineKeys}}
+	if file == "" {
+		return s, nil
+	}
+	reloader, err := filereload.New("JWKS file", file, reloadInterval, func(data []byte) (*coreosoidc.StaticKeySet, error) {
+		fileKeys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		return &coreosoidc.StaticKeySet{PublicKeys: append(slices.Clone(inlineKeys), fileKeys...)}, nil
+	}, "auth_method", "jwt")
+	if err != nil {
+		return nil, err
+	}
+	s.file = reloader
+	return s, nil
+}
+
+func (s *keySet) VerifySignature(ctx context.Context, jwt string) ([]byte, error) {
+	if s.file == nil {
+		return s.inlineKeys.VerifySignature(ctx, jwt)
+	}
+	return s.file.Load().VerifySignature(ctx, jwt)
+}
+
+// Stop ends periodic reloading.
+func (s *keySet) Stop() {
+	if s.file != nil {
+		s.file.Stop()
+	}
+}
+
+// parseJWKS returns the public signing keys of a JSON Web Key Set.
+func parseJWKS(data []byte) ([]crypto.PublicKey, error) {
+	var set jose.JSONWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	keys := make([]crypto.PublicKey, 0, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		public := k.Public()
+		if !public.Valid() {
+			return nil, fmt.Errorf("key %q is not a valid public key", k.KeyID)
+		}
+		keys = append(keys, public.Key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys found")
+	}
+	return keys, nil
+}
+
+// parsePEMPublicKey parses a PEM-encoded PKIX public key or certificate.
+func parsePEMPublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
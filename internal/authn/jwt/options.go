@@ -0,0 +1,41 @@
+package jwt
+
+import "time"
+
+// Claim paths used when an issuer doesn't configure its own.
+const (
+	DefaultSubjectClaim  = "sub"
+	DefaultOrgIdClaim    = "org_id"
+	DefaultClientIdClaim = "client_id"
+
+	DefaultReloadInterval = time.Minute
+)
+
+// IssuerOptions configures a trusted token issuer and the keys its tokens are signed with. Claim
+// paths are dot-separated, e.g. "identity.org_id", to reach claims nested in JSON objects.
+type IssuerOptions struct {
+	Issuer string `mapstructure:"issuer"`
+	// JWKSFile is the path to a JSON Web Key Set, re-read every ReloadInterval.
+	JWKSFile string `mapstructure:"jwks-file"`
+	// Keys are PEM-encoded public keys or certificates.
+	Keys []string `mapstructure:"keys"`
+	// Audiences lists accepted aud values.
+	Audiences     []string `mapstructure:"audiences"`
+	SubjectClaim  string   `mapstructure:"subject-claim"`
+	OrgIdClaim    string   `mapstructure:"org-id-claim"`
+	ClientIdClaim string   `mapstructure:"client-id-claim"`
+}
+
+// Options configures the jwt authenticator, which verifies tokens offline against locally
+// configured keys instead of discovering them from an authorization server.
+type Options struct {
+	// ReloadInterval is how often the issuers' JWKS files are re-read; zero disables reloading.
+	ReloadInterval time.Duration   `mapstructure:"reload-interval"`
+	Issuers        []IssuerOptions `mapstructure:"issuers"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		ReloadInterval: DefaultReloadInterval,
+	}
+}
@@ -0,0 +1,67 @@
+package mtls
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// defaultTemplate expands to the whole matched value.
+const defaultTemplate = "$0"
+
+type Config struct {
+	*Options
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+// Rule is a RuleOptions with its pattern compiled and its templates defaulted.
+type Rule struct {
+	Source    string
+	Pattern   *regexp.Regexp
+	SubjectId string
+	ClientId  string
+}
+
+type completedConfig struct {
+	Rules []Rule
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func (c *Config) Complete() (CompletedConfig, error) {
+	if len(c.Rules) == 0 {
+		return CompletedConfig{}, fmt.Errorf("at least one rule is required")
+	}
+
+	rules := make([]Rule, 0, len(c.Rules))
+	for i, r := range c.Rules {
+		switch r.Source {
+		case SourceSPIFFEID, SourceURISAN, SourceDNSSAN, SourceEmailSAN, SourceSubjectCN, SourceSubject:
+		default:
+			return CompletedConfig{}, fmt.Errorf("rules[%d]: invalid source %q", i, r.Source)
+		}
+		if r.Pattern == "" {
+			return CompletedConfig{}, fmt.Errorf("rules[%d]: pattern is required", i)
+		}
+		// Patterns must match the whole value, so that e.g. "hbi" doesn't match "not-hbi".
+		pattern, err := regexp.Compile("^(?:" + r.Pattern + ")$")
+		if err != nil {
+			return CompletedConfig{}, fmt.Errorf("rules[%d]: invalid pattern: %w", i, err)
+		}
+
+		rule := Rule{Source: r.Source, Pattern: pattern, SubjectId: r.SubjectId, ClientId: r.ClientId}
+		if rule.SubjectId == "" {
+			rule.SubjectId = defaultTemplate
+		}
+		if rule.ClientId == "" {
+			rule.ClientId = defaultTemplate
+		}
+		rules = append(rules, rule)
+	}
+
+	return CompletedConfig{&completedConfig{Rules: rules}}, nil
+}
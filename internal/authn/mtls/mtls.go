@@ -0,0 +1,113 @@
+// Package mtls provides an Authenticator based on verified TLS client certificates.
+package mtls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
+	khttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// ClientCertAuthenticator authenticates requests by the client certificate presented during the
+// TLS handshake. Only certificates the server verified against its client CA (client-ca-file with
+// a certopt that verifies) are considered; the first rule matching the certificate provides the
+// subject and client IDs.
+type ClientCertAuthenticator struct {
+	CompletedConfig
+}
+
+func New(c CompletedConfig) *ClientCertAuthenticator {
+	return &ClientCertAuthenticator{CompletedConfig: c}
+}
+
+// Authenticate returns Ignore when no verified client certificate was presented or no rule matches
+// it, so that other authenticators in the chain can still authenticate the request.
+func (a *ClientCertAuthenticator) Authenticate(ctx context.Context, t transport.Transporter) (*api.Claims, api.Decision) {
+	cert := verifiedClientCertificate(ctx, t)
+	if cert == nil {
+		return nil, api.Ignore
+	}
+
+	for _, rule := range a.Rules {
+		for _, value := range certificateValues(cert, rule.Source) {
+			match := rule.Pattern.FindStringSubmatchIndex(value)
+			if match == nil {
+				continue
+			}
+			subjectId := string(rule.Pattern.ExpandString(nil, rule.SubjectId, value, match))
+			if subjectId == "" {
+				continue
+			}
+			return &api.Claims{
+				SubjectId: api.SubjectId(subjectId),
+				ClientID:  api.ClientID(rule.Pattern.ExpandString(nil, rule.ClientId, value, match)),
+				Issuer:    api.Issuer(cert.Issuer.String()),
+				AuthType:  api.AuthTypeMTLS,
+			}, api.Allow
+		}
+	}
+
+	log.NewHelper(log.DefaultLogger).Debugw("msg", "mtls client certificate matched no rule",
+		"auth_method", "mtls",
+		"subject", cert.Subject.String(),
+	)
+	return nil, api.Ignore
+}
+
+// verifiedClientCertificate returns the leaf of the first verified client certificate chain, or nil.
+func verifiedClientCertificate(ctx context.Context, t transport.Transporter) *x509.Certificate {
+	var state *tls.ConnectionState
+	switch t.Kind() {
+	case transport.KindGRPC:
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+				state = &info.State
+			}
+		}
+	case transport.KindHTTP:
+		if ht, ok := t.(khttp.Transporter); ok && ht.Request() != nil {
+			state = ht.Request().TLS
+		}
+	}
+
+	// Unverified certificates (certopt request or require-any) must never authenticate a request.
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return state.VerifiedChains[0][0]
+}
+
+func certificateValues(cert *x509.Certificate, source string) []string {
+	switch source {
+	case SourceSPIFFEID, SourceURISAN:
+		var values []string
+		for _, uri := range cert.URIs {
+			if source == SourceSPIFFEID && !strings.EqualFold(uri.Scheme, "spiffe") {
+				continue
+			}
+			values = append(values, uri.String())
+		}
+		return values
+	case SourceDNSSAN:
+		return cert.DNSNames
+	case SourceEmailSAN:
+		return cert.EmailAddresses
+	case SourceSubjectCN:
+		if cert.Subject.CommonName == "" {
+			return nil
+		}
+		return []string{cert.Subject.CommonName}
+	case SourceSubject:
+		return []string{cert.Subject.String()}
+	default:
+		return nil
+	}
+}
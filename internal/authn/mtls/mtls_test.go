@@ -0,0 +1,197 @@
+package mtls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+
+	"github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// mockTransporter is a test helper that implements the kratos http Transporter
+type mockTransporter struct {
+	kind    transport.Kind
+	request *http.Request
+}
+
+func (m *mockTransporter) Kind() transport.Kind            { return m.kind }
+func (m *mockTransporter) Endpoint() string                { return "/test" }
+func (m *mockTransporter) Operation() string               { return "test" }
+func (m *mockTransporter) RequestHeader() transport.Header { return nil }
+func (m *mockTransporter) ReplyHeader() transport.Header   { return nil }
+func (m *mockTransporter) Request() *http.Request          { return m.request }
+func (m *mockTransporter) PathTemplate() string            { return "/test" }
+
+func newCertificate(t *testing.T, cn string, dnsNames []string, uris ...string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: cn, Organization: []string{"Kessel"}},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	for _, u := range uris {
+		parsed, err := url.Parse(u)
+		require.NoError(t, err)
+		template.URIs = append(template.URIs, parsed)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func verifiedState(cert *x509.Certificate) tls.ConnectionState {
+	return tls.ConnectionState{
+		PeerCertificates: []*x509.Certificate{cert},
+		VerifiedChains:   [][]*x509.Certificate{{cert}},
+	}
+}
+
+func grpcContext(state tls.ConnectionState) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func newAuthenticator(t *testing.T, rules ...RuleOptions) *ClientCertAuthenticator {
+	t.Helper()
+	completed, err := NewConfig(&Options{Rules: rules}).Complete()
+	require.NoError(t, err)
+	return New(completed)
+}
+
+func TestAuthenticate_SPIFFEID_GRPC(t *testing.T) {
+	auth := newAuthenticator(t, RuleOptions{
+		Source:   SourceSPIFFEID,
+		Pattern:  `spiffe://kessel\.example/ns/([^/]+)/sa/([^/]+)`,
+		ClientId: "$1",
+	})
+	cert := newCertificate(t, "hbi", nil, "https://kessel.example/hbi", "spiffe://kessel.example/ns/hbi/sa/reporter")
+
+	claims, decision := auth.Authenticate(grpcContext(verifiedState(cert)), &mockTransporter{kind: transport.KindGRPC})
+
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("spiffe://kessel.example/ns/hbi/sa/reporter"), claims.SubjectId)
+	assert.Equal(t, api.ClientID("hbi"), claims.ClientID)
+	assert.Equal(t, api.AuthTypeMTLS, claims.AuthType)
+	assert.Equal(t, api.Issuer(cert.Issuer.String()), claims.Issuer)
+}
+
+func TestAuthenticate_DNSSAN_HTTP(t *testing.T) {
+	auth := newAuthenticator(t, RuleOptions{
+		Source:    SourceDNSSAN,
+		Pattern:   `(?P<name>[a-z-]+)\.reporters\.svc`,
+		SubjectId: "service-${name}",
+		ClientId:  "${name}",
+	})
+	cert := newCertificate(t, "ignored", []string{"localhost", "acm.reporters.svc"})
+	state := verifiedState(cert)
+	req := &http.Request{TLS: &state}
+
+	claims, decision := auth.Authenticate(context.Background(), &mockTransporter{kind: transport.KindHTTP, request: req})
+
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("service-acm"), claims.SubjectId)
+	assert.Equal(t, api.ClientID("acm"), claims.ClientID)
+}
+
+func TestAuthenticate_FirstMatchingRuleWins(t *testing.T) {
+	auth := newAuthenticator(t,
+		RuleOptions{Source: SourceSPIFFEID, Pattern: `spiffe://other\.example/.*`},
+		RuleOptions{Source: SourceSubjectCN, Pattern: `hbi|acm`, ClientId: "reporter-$0"},
+		RuleOptions{Source: SourceSubject, Pattern: `.*`, ClientId: "fallback"},
+	)
+	cert := newCertificate(t, "hbi", nil)
+
+	claims, decision := auth.Authenticate(grpcContext(verifiedState(cert)), &mockTransporter{kind: transport.KindGRPC})
+
+	assert.Equal(t, api.Allow, decision)
+	require.NotNil(t, claims)
+	assert.Equal(t, api.SubjectId("hbi"), claims.SubjectId)
+	assert.Equal(t, api.ClientID("reporter-hbi"), claims.ClientID)
+}
+
+func TestAuthenticate_PatternMatchesWholeValue(t *testing.T) {
+	auth := newAuthenticator(t, RuleOptions{Source: SourceSubjectCN, Pattern: `hbi`})
+	cert := newCertificate(t, "not-hbi", nil)
+
+	claims, decision := auth.Authenticate(grpcContext(verifiedState(cert)), &mockTransporter{kind: transport.KindGRPC})
+
+	assert.Nil(t, claims)
+	assert.Equal(t, api.Ignore, decision)
+}
+
+func TestAuthenticate_UnverifiedCertificateIgnored(t *testing.T) {
+	auth := newAuthenticator(t, RuleOptions{Source: SourceSubjectCN, Pattern: `.*`})
+	cert := newCertificate(t, "hbi", nil)
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	claims, decision := auth.Authenticate(grpcContext(state), &mockTransporter{kind: transport.KindGRPC})
+
+	assert.Nil(t, claims)
+	assert.Equal(t, api.Ignore, decision)
+}
+
+func TestAuthenticate_NoCertificateIgnored(t *testing.T) {
+	auth := newAuthenticator(t, RuleOptions{Source: SourceSubjectCN, Pattern: `.*`})
+
+	claims, decision := auth.Authenticate(context.Background(), &mockTransporter{kind: transport.KindGRPC})
+	assert.Nil(t, claims)
+	assert.Equal(t, api.Ignore, decision)
+
+	claims, decision = auth.Authenticate(context.Background(), &mockTransporter{kind: transport.KindHTTP, request: &http.Request{}})
+	assert.Nil(t, claims)
+	assert.Equal(t, api.Ignore, decision)
+}
+
+func TestConfigComplete(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []RuleOptions
+		wantErr string
+	}{
+		{name: "no rules", rules: nil, wantErr: "at least one rule is required"},
+		{name: "invalid source", rules: []RuleOptions{{Source: "issuer", Pattern: ".*"}}, wantErr: `rules[0]: invalid source "issuer"`},
+		{name: "missing pattern", rules: []RuleOptions{{Source: SourceDNSSAN}}, wantErr: "rules[0]: pattern is required"},
+		{name: "invalid pattern", rules: []RuleOptions{{Source: SourceDNSSAN, Pattern: "("}}, wantErr: "rules[0]: invalid pattern"},
+		{name: "valid", rules: []RuleOptions{{Source: SourceSPIFFEID, Pattern: "spiffe://.*"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completed, err := NewConfig(&Options{Rules: tt.rules}).Complete()
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, completed.Rules, 1)
+			assert.Equal(t, defaultTemplate, completed.Rules[0].SubjectId)
+			assert.Equal(t, defaultTemplate, completed.Rules[0].ClientId)
+		})
+	}
+}
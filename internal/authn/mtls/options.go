@@ -0,0 +1,33 @@
+package mtls
+
+// Certificate fields a rule can match against.
+const (
+	// SourceSPIFFEID matches URI SANs with the spiffe scheme, e.g. spiffe://example.org/ns/hbi/sa/reporter.
+	SourceSPIFFEID = "spiffe-id"
+	SourceURISAN   = "uri-san"
+	SourceDNSSAN   = "dns-san"
+	SourceEmailSAN = "email-san"
+	// SourceSubjectCN matches the common name of the certificate subject.
+	SourceSubjectCN = "subject-cn"
+	// SourceSubject matches the certificate subject as an RFC 2253 distinguished name, e.g. CN=hbi,O=Example.
+	SourceSubject = "subject"
+)
+
+// RuleOptions maps a verified client certificate to claims. The pattern is matched against every
+// value of the source field; the first match wins. SubjectId and ClientId are templates expanded
+// with the pattern's submatches, e.g. "$1" or "${name}"; both default to the whole matched value.
+type RuleOptions struct {
+	Source    string `mapstructure:"source"`
+	Pattern   string `mapstructure:"pattern"`
+	SubjectId string `mapstructure:"subject-id"`
+	ClientId  string `mapstructure:"client-id"`
+}
+
+// Options configures the mtls authenticator. Rules are tried in order.
+type Options struct {
+	Rules []RuleOptions `mapstructure:"rules"`
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
@@ -61,6 +61,9 @@ func (o *Options) Validate() []error {
 			"guest":                 true,
 			"allow-unauthenticated": true,
 			"x-rh-identity":         true,
+			"mtls":                  true,
+			"jwt":                   true,
+			"api-key":               true,
 		}
 
 		for _, entry := range o.Authenticator.Chain {
@@ -0,0 +1,17 @@
+package util
+
+import (
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// LogAuthenticationFailure logs a denied credential of authMethod in the same shape as the oidc
+// authenticator - SEC-MON-REQ-1 compliance (EOI-7 invalid_login).
+func LogAuthenticationFailure(authMethod, msg, reason string, keyvals ...interface{}) {
+	log.NewHelper(log.DefaultLogger).Warnw(append([]interface{}{
+		"msg", msg,
+		"event", "authentication_failure",
+		"auth_method", authMethod,
+		"outcome", "failure",
+		"reason", reason,
+	}, keyvals...)...)
+}
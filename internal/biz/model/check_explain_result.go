@@ -0,0 +1,73 @@
+package model
+
+// CheckTraceKind distinguishes stored relations from computed permissions in a check trace.
+type CheckTraceKind int
+
+const (
+	CheckTraceKindUnspecified CheckTraceKind = iota
+	CheckTraceKindRelation
+	CheckTraceKindPermission
+)
+
+// CheckTrace is one step in resolving a permission check. A relation step that is allowed
+// and has no children was satisfied directly by a stored tuple; a permission step was
+// resolved by evaluating its expression over its children.
+type CheckTrace struct {
+	relationship Relationship
+	kind         CheckTraceKind
+	allowed      bool
+	expression   string
+	cached       bool
+	children     []CheckTrace
+}
+
+func NewCheckTrace(relationship Relationship, kind CheckTraceKind, allowed bool, expression string, cached bool, children []CheckTrace) CheckTrace {
+	return CheckTrace{
+		relationship: relationship,
+		kind:         kind,
+		allowed:      allowed,
+		expression:   expression,
+		cached:       cached,
+		children:     children,
+	}
+}
+
+func (t CheckTrace) Relationship() Relationship { return t.relationship }
+func (t CheckTrace) Kind() CheckTraceKind       { return t.kind }
+func (t CheckTrace) Allowed() bool              { return t.allowed }
+func (t CheckTrace) Expression() string         { return t.expression }
+func (t CheckTrace) Cached() bool               { return t.cached }
+func (t CheckTrace) Children() []CheckTrace     { return t.children }
+
+// MatchedTuples returns the relationships of the allowed relation steps that were satisfied
+// directly by stored tuples, in depth-first order.
+func (t CheckTrace) MatchedTuples() []Relationship {
+	var matched []Relationship
+	var walk func(CheckTrace)
+	walk = func(step CheckTrace) {
+		if step.kind == CheckTraceKindRelation && step.allowed && len(step.children) == 0 {
+			matched = append(matched, step.relationship)
+		}
+		for _, child := range step.children {
+			walk(child)
+		}
+	}
+	walk(t)
+	return matched
+}
+
+// CheckExplainResult contains the outcome of a permission check together with its resolution path.
+type CheckExplainResult struct {
+	result CheckResult
+	trace  CheckTrace
+}
+
+func NewCheckExplainResult(result CheckResult, trace CheckTrace) CheckExplainResult {
+	return CheckExplainResult{result: result, trace: trace}
+}
+
+func (r CheckExplainResult) Allowed() bool { return r.result.Allowed() }
+
+func (r CheckExplainResult) ConsistencyToken() ConsistencyToken { return r.result.ConsistencyToken() }
+
+func (r CheckExplainResult) Trace() CheckTrace { return r.trace }
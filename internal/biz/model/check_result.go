@@ -4,12 +4,23 @@ package model
 type CheckResult struct {
 	allowed          bool
 	consistencyToken ConsistencyToken
+	failOpen         bool
 }
 
 func NewCheckResult(allowed bool, consistencyToken ConsistencyToken) CheckResult {
 	return CheckResult{allowed: allowed, consistencyToken: consistencyToken}
 }
 
+// NewFailOpenCheckResult answers a check as allowed because the relations backend failed and checks
+// are configured to fail open. It is not a decision of the backend, so it must not be cached or
+// used to grant access that has to fail closed.
+func NewFailOpenCheckResult() CheckResult {
+	return CheckResult{allowed: true, consistencyToken: MinimizeLatencyToken, failOpen: true}
+}
+
 func (r CheckResult) Allowed() bool { return r.allowed }
 
 func (r CheckResult) ConsistencyToken() ConsistencyToken { return r.consistencyToken }
+
+// FailOpen reports whether the result was made up because the backend failed.
+func (r CheckResult) FailOpen() bool { return r.failOpen }
@@ -91,6 +91,10 @@ func DeserializeReporterInstanceId(value string) ReporterInstanceId {
 	return Deserialize[ReporterInstanceId](value)
 }
 
+func DeserializeOrgId(value string) OrgId {
+	return Deserialize[OrgId](value)
+}
+
 func DeserializeTransactionId(value string) TransactionId {
 	return Deserialize[TransactionId](value)
 }
@@ -294,6 +298,24 @@ func (ri ReporterInstanceId) String() string {
 
 func (ri ReporterInstanceId) Serialize() string { return SerializeString(ri) }
 
+// OrgId is the organization that owns a resource when tenancy is enabled. It is empty for
+// resources reported without tenancy.
+type OrgId string
+
+func NewOrgId(orgId string) (OrgId, error) {
+	orgId = strings.TrimSpace(orgId)
+	if orgId == "" {
+		return OrgId(""), fmt.Errorf("%w: OrgId", ErrEmpty)
+	}
+	return OrgId(orgId), nil
+}
+
+func (o OrgId) String() string {
+	return string(o)
+}
+
+func (o OrgId) Serialize() string { return SerializeString(o) }
+
 type ConsistencyToken string
 
 func NewConsistencyToken(token string) (ConsistencyToken, error) {
@@ -442,6 +464,21 @@ func (r Representation) Serialize() internal.JsonObject {
 	return internal.JsonObject(r)
 }
 
+// Lookup returns the value at a dot-separated path of object keys, e.g. "labels.env".
+func (r Representation) Lookup(path string) (interface{}, bool) {
+	var current interface{} = map[string]interface{}(r)
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = obj[key]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
 func DeserializeRepresentation(data internal.JsonObject) Representation {
 	return Representation(data)
 }
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/google/uuid"
@@ -1081,3 +1082,40 @@ func TestSerializationRoundtrip(t *testing.T) {
 		}
 	})
 }
+
+func TestRepresentation_Lookup(t *testing.T) {
+	t.Parallel()
+
+	rep := Representation{
+		"workspace_id": "ws-1",
+		"labels":       map[string]interface{}{"env": "prod"},
+		"count":        float64(3),
+	}
+
+	testCases := []struct {
+		path      string
+		wantValue interface{}
+		wantFound bool
+	}{
+		{"workspace_id", "ws-1", true},
+		{"labels.env", "prod", true},
+		{"count", float64(3), true},
+		{"labels", map[string]interface{}{"env": "prod"}, true},
+		{"labels.missing", nil, false},
+		{"workspace_id.nested", nil, false},
+		{"missing", nil, false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			t.Parallel()
+
+			value, found := rep.Lookup(tc.path)
+			if found != tc.wantFound {
+				t.Fatalf("Lookup(%q) found = %v, want %v", tc.path, found, tc.wantFound)
+			}
+			if found && fmt.Sprint(value) != fmt.Sprint(tc.wantValue) {
+				t.Errorf("Lookup(%q) = %v, want %v", tc.path, value, tc.wantValue)
+			}
+		})
+	}
+}
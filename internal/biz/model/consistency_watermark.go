@@ -0,0 +1,64 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// WatermarkScope identifies a set of replicated relationships that share a consistency watermark.
+type WatermarkScope string
+
+// GlobalWatermarkScope covers every replicated relationship.
+const GlobalWatermarkScope WatermarkScope = "global"
+
+// NewSubjectWatermarkScope returns the scope for relationships whose subject is the given
+// resource. Subject relations are ignored, so a subject set shares the scope of its resource.
+// Workspaces are subjects of the relationships that place resources in them, so this is also
+// the per-workspace scope.
+func NewSubjectWatermarkScope(subject SubjectReference) WatermarkScope {
+	resource := subject.Resource()
+	if resource.HasReporter() {
+		return WatermarkScope(fmt.Sprintf("subject:%s/%s:%s",
+			resource.Reporter().ReporterType(), resource.ResourceType(), resource.ResourceId()))
+	}
+	return WatermarkScope(fmt.Sprintf("subject:%s:%s", resource.ResourceType(), resource.ResourceId()))
+}
+
+// NewReporterTypeWatermarkScope returns the scope for relationships of resources reported by
+// the given reporter type.
+func NewReporterTypeWatermarkScope(reporterType ReporterType) WatermarkScope {
+	return WatermarkScope(fmt.Sprintf("reporter_type:%s", reporterType))
+}
+
+func (s WatermarkScope) String() string {
+	return string(s)
+}
+
+// ConsistencyWatermark is the consistency token of the latest replicated write in a scope.
+// The zero value means nothing has been replicated in the scope yet.
+type ConsistencyWatermark struct {
+	token        ConsistencyToken
+	replicatedAt time.Time
+}
+
+func NewConsistencyWatermark(token ConsistencyToken, replicatedAt time.Time) ConsistencyWatermark {
+	return ConsistencyWatermark{token: token, replicatedAt: replicatedAt}
+}
+
+func (w ConsistencyWatermark) Token() ConsistencyToken { return w.token }
+func (w ConsistencyWatermark) ReplicatedAt() time.Time { return w.replicatedAt }
+func (w ConsistencyWatermark) IsEmpty() bool           { return w.token == MinimizeLatencyToken }
+
+// ConsistencyWatermarkRepository stores the consistency token of the latest replicated write
+// for each scope. The consumer advances watermarks after writing relationships, and reads that
+// can't be tied to a single resource resolve at_least_as_acknowledged from them.
+type ConsistencyWatermarkRepository interface {
+	// Advance records token, replicated at replicatedAt, as the watermark for each of the scopes.
+	// Watermarks never move backwards: a scope whose watermark was replicated after replicatedAt
+	// keeps it.
+	Advance(tx *gorm.DB, scopes []WatermarkScope, token ConsistencyToken, replicatedAt time.Time) error
+	// Find returns the watermark for the scope, or an empty watermark if none has been recorded.
+	Find(tx *gorm.DB, scope WatermarkScope) (ConsistencyWatermark, error)
+}
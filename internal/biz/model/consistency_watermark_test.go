@@ -0,0 +1,19 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSubjectWatermarkScope(t *testing.T) {
+	rbac := NewReporterReference(DeserializeReporterType("rbac"), nil)
+	principal := NewResourceReference(DeserializeResourceType("principal"), DeserializeLocalResourceId("alice"), &rbac)
+	assert.Equal(t, WatermarkScope("subject:rbac/principal:alice"),
+		NewSubjectWatermarkScope(NewSubjectReferenceWithoutRelation(principal)))
+
+	workspace := NewResourceReference(DeserializeResourceType("workspace"), DeserializeLocalResourceId("ws-1"), nil)
+	member := DeserializeRelation("member")
+	assert.Equal(t, WatermarkScope("subject:workspace:ws-1"),
+		NewSubjectWatermarkScope(NewSubjectReference(workspace, &member)))
+}
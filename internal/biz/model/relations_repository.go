@@ -24,6 +24,10 @@ type RelationsRepository interface {
 	CheckForUpdateBulk(ctx context.Context, rels []Relationship,
 	) (CheckBulkResult, error)
 
+	// CheckExplain performs a Check and reports how the result was resolved.
+	CheckExplain(ctx context.Context, rel Relationship, consistency Consistency,
+	) (CheckExplainResult, error)
+
 	// --- Lookup APIs: use RepresentationType for type patterns ---
 
 	LookupObjects(ctx context.Context,
@@ -55,3 +59,17 @@ type RelationsRepository interface {
 
 	AcquireLock(ctx context.Context, lockId LockId) (AcquireLockResult, error)
 }
+
+// TupleWriter is implemented by relations repositories that store the tuples written to them.
+// Decorators report the capability of the repository they wrap.
+type TupleWriter interface {
+	WritesTuples() bool
+}
+
+// WritesTuples reports whether repository stores the tuples written to it, which is when the
+// consumer replicates resource relationships to it. Repositories that don't implement
+// TupleWriter are treated as not storing tuples.
+func WritesTuples(repository RelationsRepository) bool {
+	writer, ok := repository.(TupleWriter)
+	return ok && writer.WritesTuples()
+}
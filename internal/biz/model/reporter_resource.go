@@ -29,6 +29,8 @@ type ReporterResourceKey struct {
 	localResourceID LocalResourceId
 	resourceType    ResourceType
 	reporter        ReporterId
+	// orgId scopes the key to an organization when tenancy is enabled.
+	orgId OrgId
 }
 
 // Factory methods
@@ -130,6 +132,16 @@ func (rrk ReporterResourceKey) ReporterInstanceId() ReporterInstanceId {
 	return rrk.reporter.reporterInstanceId
 }
 
+func (rrk ReporterResourceKey) OrgId() OrgId {
+	return rrk.orgId
+}
+
+// WithOrgId returns the key scoped to an organization.
+func (rrk ReporterResourceKey) WithOrgId(orgId OrgId) ReporterResourceKey {
+	rrk.orgId = orgId
+	return rrk
+}
+
 func (rr ReporterResource) LocalResourceId() string {
 	return rr.localResourceID.String()
 }
@@ -164,6 +176,7 @@ func (rr ReporterResource) Serialize() ReporterResourceSnapshot {
 		ReporterType:       rr.reporter.reporterType.Serialize(),
 		ResourceType:       rr.resourceType.Serialize(),
 		ReporterInstanceID: rr.reporter.reporterInstanceId.Serialize(),
+		OrgID:              rr.orgId.Serialize(),
 	}
 
 	var consoleHref *string
@@ -191,7 +204,7 @@ func DeserializeReporterResource(snapshot ReporterResourceSnapshot) ReporterReso
 
 	return ReporterResource{
 		id:                    DeserializeReporterResourceId(snapshot.ID),
-		ReporterResourceKey:   DeserializeReporterResourceKey(snapshot.ReporterResourceKey.LocalResourceID, snapshot.ReporterResourceKey.ResourceType, snapshot.ReporterResourceKey.ReporterType, snapshot.ReporterResourceKey.ReporterInstanceID),
+		ReporterResourceKey:   DeserializeReporterResourceKey(snapshot.ReporterResourceKey.LocalResourceID, snapshot.ReporterResourceKey.ResourceType, snapshot.ReporterResourceKey.ReporterType, snapshot.ReporterResourceKey.ReporterInstanceID).WithOrgId(DeserializeOrgId(snapshot.ReporterResourceKey.OrgID)),
 		resourceID:            DeserializeResourceId(snapshot.ResourceID),
 		apiHref:               DeserializeApiHref(snapshot.APIHref),
 		consoleHref:           DeserializeConsoleHref(snapshot.ConsoleHref),
@@ -227,6 +240,7 @@ func (rrk ReporterResourceKey) MarshalJSON() ([]byte, error) {
 		LocalResourceID string       `json:"local_resource_id"`
 		ResourceType    string       `json:"resource_type"`
 		Reporter        reporterJSON `json:"reporter"`
+		OrgID           string       `json:"org_id,omitempty"`
 	}
 
 	reporterType, reporterInstanceID := rrk.reporter.Serialize()
@@ -238,6 +252,7 @@ func (rrk ReporterResourceKey) MarshalJSON() ([]byte, error) {
 			ReporterType:       reporterType,
 			ReporterInstanceID: reporterInstanceID,
 		},
+		OrgID: rrk.orgId.Serialize(),
 	})
 }
 
@@ -252,6 +267,7 @@ func (rrk *ReporterResourceKey) UnmarshalJSON(data []byte) error {
 		LocalResourceID string       `json:"local_resource_id"`
 		ResourceType    string       `json:"resource_type"`
 		Reporter        reporterJSON `json:"reporter"`
+		OrgID           string       `json:"org_id,omitempty"`
 	}
 
 	var temp reporterResourceKeyJSON
@@ -262,6 +278,7 @@ func (rrk *ReporterResourceKey) UnmarshalJSON(data []byte) error {
 	rrk.localResourceID = DeserializeLocalResourceId(temp.LocalResourceID)
 	rrk.resourceType = DeserializeResourceType(temp.ResourceType)
 	rrk.reporter = DeserializeReporterId(temp.Reporter.ReporterType, temp.Reporter.ReporterInstanceID)
+	rrk.orgId = DeserializeOrgId(temp.OrgID)
 
 	return nil
 }
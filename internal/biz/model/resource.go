@@ -160,11 +160,38 @@ func (r *Resource) Update(
 		log.Debugf("resource %s has zero created_at timestamp", r.id)
 	}
 	resourceEvent.SetTimestamps(existingCreatedAt, now)
+	resourceEvent.orgId = reporterResource.orgId
 
 	r.resourceReportEvents = []ResourceReportEvent{resourceEvent}
 	return nil
 }
 
+// AssignOrgId stamps a newly created resource with the organization that owns it, so that later
+// reads and writes of it are scoped to that organization.
+func (r *Resource) AssignOrgId(orgId OrgId) {
+	for i := range r.reporterResources {
+		r.reporterResources[i].orgId = orgId
+	}
+	for i := range r.resourceReportEvents {
+		r.resourceReportEvents[i].orgId = orgId
+	}
+}
+
+// ClaimOrgId assigns the organization of key to the reporter resource with that key when it has no
+// organization, as resources reported before tenancy was enabled don't. It reports whether the
+// reporter resource belongs to the organization of key. Only admin clients naming the organization
+// may claim resources (see tenancy.Tenancy.ClaimsUnowned).
+func (r *Resource) ClaimOrgId(key ReporterResourceKey) bool {
+	reporterResource, err := r.findReporterResourceToUpdateByKey(key.WithOrgId(""))
+	if err != nil {
+		return false
+	}
+	if reporterResource.orgId == "" {
+		reporterResource.orgId = key.orgId
+	}
+	return reporterResource.orgId == key.orgId
+}
+
 func (r *Resource) Delete(key ReporterResourceKey) error {
 	reporterResource, err := r.findReporterResourceToUpdateByKey(key)
 	if err != nil {
@@ -192,6 +219,7 @@ func (r *Resource) Delete(key ReporterResourceKey) error {
 	if err != nil {
 		return fmt.Errorf("failed to create ResourceDeleteEvent: %w", err)
 	}
+	resourceDeleteEvent.orgId = reporterResource.orgId
 
 	r.resourceDeleteEvents = []ResourceDeleteEvent{resourceDeleteEvent}
 	return nil
@@ -319,6 +347,9 @@ func (r *Resource) findReporterResourceToUpdateByKey(key ReporterResourceKey) (*
 			searchReporterInstanceId := key.ReporterInstanceId().Serialize()
 			storedReporterInstanceId := storedKey.ReporterInstanceId().Serialize()
 
+			if key.orgId != "" && storedKey.orgId != key.orgId {
+				continue
+			}
 			if searchReporterInstanceId == "" || strings.EqualFold(storedReporterInstanceId, searchReporterInstanceId) {
 				return reporter, nil
 			}
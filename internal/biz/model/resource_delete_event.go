@@ -11,6 +11,7 @@ type ResourceDeleteEvent struct {
 	resourceType           ResourceType
 	reporterId             ReporterId
 	localResourceId        LocalResourceId
+	orgId                  OrgId
 	reporterRepresentation ReporterDeleteRepresentation
 	createdAt              time.Time
 	updatedAt              time.Time
@@ -88,5 +89,6 @@ func (re ResourceDeleteEvent) ReporterResourceKey() ReporterResourceKey {
 		localResourceID: re.localResourceId,
 		resourceType:    re.resourceType,
 		reporter:        re.reporterId,
+		orgId:           re.orgId,
 	}
 }
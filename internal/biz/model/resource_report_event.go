@@ -13,6 +13,7 @@ type ResourceReportEvent struct {
 	resourceType           ResourceType
 	reporterId             ReporterId
 	localResourceId        LocalResourceId
+	orgId                  OrgId
 	apiHref                ApiHref
 	consoleHref            *ConsoleHref
 	reporterRepresentation *ReporterDataRepresentation
@@ -140,6 +141,7 @@ func (re ResourceReportEvent) ReporterResourceKey() ReporterResourceKey {
 		localResourceID: re.localResourceId,
 		resourceType:    re.resourceType,
 		reporter:        re.reporterId,
+		orgId:           re.orgId,
 	}
 }
 
@@ -11,6 +11,8 @@ type ResourceRepository interface {
 	FindResourceByKeys(tx *gorm.DB, key ReporterResourceKey) (*Resource, error)
 	FindCurrentAndPreviousVersionedRepresentations(tx *gorm.DB, key ReporterResourceKey, currentVersion *Version, operationType EventOperationType) (*Representations, *Representations, error)
 	FindLatestRepresentations(tx *gorm.DB, key ReporterResourceKey) (*Representations, error)
+	FindResourceSummaries(tx *gorm.DB, orgId OrgId, reporterType ReporterType, resourceType ResourceType, localResourceIds []LocalResourceId) ([]ResourceSummary, error)
+	FindResourceOrgIds(tx *gorm.DB, reporterType ReporterType, resourceType ResourceType, localResourceIds []LocalResourceId) (map[LocalResourceId][]OrgId, error)
 	GetDB() *gorm.DB
 	GetTransactionManager() TransactionManager
 	HasTransactionIdBeenProcessed(tx *gorm.DB, transactionId TransactionId) (bool, error)
@@ -0,0 +1,24 @@
+package model
+
+// ResourceSummary is inventory's latest stored view of a reported resource: where it can be
+// found and its current common representation. It is used to enrich relation lookups.
+type ResourceSummary struct {
+	localResourceId LocalResourceId
+	apiHref         ApiHref
+	consoleHref     *ConsoleHref
+	common          Representation
+}
+
+func NewResourceSummary(localResourceId LocalResourceId, apiHref ApiHref, consoleHref *ConsoleHref, common Representation) ResourceSummary {
+	return ResourceSummary{
+		localResourceId: localResourceId,
+		apiHref:         apiHref,
+		consoleHref:     consoleHref,
+		common:          common,
+	}
+}
+
+func (s ResourceSummary) LocalResourceId() LocalResourceId { return s.localResourceId }
+func (s ResourceSummary) ApiHref() ApiHref                 { return s.apiHref }
+func (s ResourceSummary) ConsoleHref() *ConsoleHref        { return s.consoleHref }
+func (s ResourceSummary) Common() Representation           { return s.common }
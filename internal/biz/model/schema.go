@@ -75,11 +75,13 @@ func NewResourceSchemaRepresentation(resourceType ResourceType, schema Schema) (
 func (r ResourceSchemaRepresentation) ResourceType() ResourceType { return r.resourceType }
 func (r ResourceSchemaRepresentation) Schema() Schema             { return r.schema }
 
-// ReporterSchemaRepresentation holds a reporter-specific schema.
+// ReporterSchemaRepresentation holds a reporter-specific schema and the schema policy that applies
+// to reports from that reporter.
 type ReporterSchemaRepresentation struct {
 	resourceType ResourceType
 	reporterType ReporterType
 	schema       Schema
+	policy       SchemaPolicy
 }
 
 func NewReporterSchemaRepresentation(resourceType ResourceType, reporterType ReporterType, schema Schema) (ReporterSchemaRepresentation, error) {
@@ -99,6 +101,13 @@ func NewReporterSchemaRepresentation(resourceType ResourceType, reporterType Rep
 func (r ReporterSchemaRepresentation) ResourceType() ResourceType { return r.resourceType }
 func (r ReporterSchemaRepresentation) ReporterType() ReporterType { return r.reporterType }
 func (r ReporterSchemaRepresentation) Schema() Schema             { return r.schema }
+func (r ReporterSchemaRepresentation) Policy() SchemaPolicy       { return r.policy }
+
+// WithPolicy returns a copy of the representation with the given schema policy.
+func (r ReporterSchemaRepresentation) WithPolicy(policy SchemaPolicy) ReporterSchemaRepresentation {
+	r.policy = policy
+	return r
+}
 
 // RelationDef describes how a field in a resource representation maps to a
 // relation tuple.  fieldName is the JSON key in the representation data;
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fields reported in schema violations, named after the ReportResourceRequest fields they refer to.
+const (
+	CommonRepresentationField   = "representations.common"
+	ReporterRepresentationField = "representations.reporter"
+)
+
+// SchemaPolicy is the validation policy a resource type declares in its config.yaml, on top of
+// what its JSON schemas enforce. A reporter's config.yaml may override it for that reporter.
+// The zero value enforces nothing beyond the JSON schemas.
+type SchemaPolicy struct {
+	// RequireCommonOnCreate rejects the first report of a resource by a reporter without a common representation.
+	RequireCommonOnCreate bool
+	// RequireReporterOnCreate rejects the first report of a resource by a reporter without a reporter representation.
+	RequireReporterOnCreate bool
+	// RejectUnknownProperties rejects top-level properties the representation's schema does not declare,
+	// regardless of the schema's own additionalProperties.
+	RejectUnknownProperties bool
+	// MaxRepresentationBytes limits the JSON-encoded size of each representation. Zero means no limit.
+	MaxRepresentationBytes int
+}
+
+// SchemaPropertiesProvider is implemented by schemas that can list the top-level properties they
+// declare, which is required to enforce RejectUnknownProperties.
+type SchemaPropertiesProvider interface {
+	Properties() []string
+}
+
+// SchemaViolationsError reports every policy and schema violation found in a report, rather than
+// only the first.
+type SchemaViolationsError struct {
+	Violations []ValidationError
+}
+
+func (e *SchemaViolationsError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// NewSchemaViolationsError returns nil when there are no violations.
+func NewSchemaViolationsError(violations []ValidationError) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	return &SchemaViolationsError{Violations: violations}
+}
+
+// checkRepresentationPolicy returns the violations of policy's size and unknown property limits.
+// schema may be nil, in which case unknown properties are not checked.
+func checkRepresentationPolicy(field string, representation Representation, policy SchemaPolicy, schema Schema) []ValidationError {
+	var violations []ValidationError
+
+	if policy.MaxRepresentationBytes > 0 {
+		encoded, err := json.Marshal(representation)
+		if err != nil {
+			violations = append(violations, ValidationError{Field: field, Message: fmt.Sprintf("cannot be encoded as JSON: %v", err)})
+		} else if len(encoded) > policy.MaxRepresentationBytes {
+			violations = append(violations, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("is %d bytes, exceeding the maximum of %d bytes", len(encoded), policy.MaxRepresentationBytes),
+			})
+		}
+	}
+
+	if policy.RejectUnknownProperties {
+		if provider, ok := schema.(SchemaPropertiesProvider); ok {
+			known := map[string]bool{}
+			for _, property := range provider.Properties() {
+				known[property] = true
+			}
+			var unknown []string
+			for property := range representation {
+				if !known[property] {
+					unknown = append(unknown, property)
+				}
+			}
+			sort.Strings(unknown)
+			for _, property := range unknown {
+				violations = append(violations, ValidationError{
+					Field:   field + "." + property,
+					Message: "unknown property is not allowed by the schema",
+				})
+			}
+		}
+	}
+
+	return violations
+}
@@ -46,7 +46,8 @@ func (sc *SchemaService) CalculateTuplesForResource(ctx context.Context, current
 
 // ValidateReportAgainstSchema validates that a resource report conforms to the configured schemas.
 // It checks that the reporter is allowed for the resource type, and validates both
-// reporter and common representations against their respective schemas.
+// reporter and common representations against their respective schemas and the reporter's
+// SchemaPolicy. Every violation found is returned in a single *SchemaViolationsError.
 func (sc *SchemaService) ValidateReportAgainstSchema(ctx context.Context, resourceType ResourceType, reporterType ReporterType, commonRepresentation, reporterRepresentation *Representation) error {
 	if isReporter, err := sc.IsReporterForResource(ctx, resourceType, reporterType); !isReporter {
 		if err != nil {
@@ -55,19 +56,59 @@ func (sc *SchemaService) ValidateReportAgainstSchema(ctx context.Context, resour
 		return fmt.Errorf("reporter %s does not report resource types: %s", reporterType, resourceType)
 	}
 
+	policy := sc.policyFor(ctx, resourceType, reporterType)
+	var violations []ValidationError
+
 	if reporterRepresentation != nil {
+		if reporter, err := sc.schemaRepository.GetReporterSchema(ctx, resourceType, reporterType); err == nil {
+			violations = append(violations, checkRepresentationPolicy(ReporterRepresentationField, *reporterRepresentation, policy, reporter.Schema())...)
+		}
 		if err := sc.ReporterShallowValidate(ctx, resourceType, reporterType, *reporterRepresentation); err != nil {
-			return err
+			violations = append(violations, ValidationError{Field: ReporterRepresentationField, Message: err.Error()})
 		}
 	}
 
 	if commonRepresentation != nil {
+		if resource, err := sc.schemaRepository.GetResourceSchema(ctx, resourceType); err == nil {
+			violations = append(violations, checkRepresentationPolicy(CommonRepresentationField, *commonRepresentation, policy, resource.Schema())...)
+		}
 		if err := sc.CommonShallowValidate(ctx, resourceType, *commonRepresentation); err != nil {
-			return err
+			violations = append(violations, ValidationError{Field: CommonRepresentationField, Message: err.Error()})
 		}
 	}
 
-	return nil
+	return NewSchemaViolationsError(violations)
+}
+
+// ValidateRepresentationsForCreate enforces the parts of the reporter's SchemaPolicy that only
+// apply when a reporter reports a resource for the first time.
+func (sc *SchemaService) ValidateRepresentationsForCreate(ctx context.Context, resourceType ResourceType, reporterType ReporterType, commonRepresentation, reporterRepresentation *Representation) error {
+	policy := sc.policyFor(ctx, resourceType, reporterType)
+	var violations []ValidationError
+
+	if policy.RequireCommonOnCreate && (commonRepresentation == nil || len(*commonRepresentation) == 0) {
+		violations = append(violations, ValidationError{
+			Field:   CommonRepresentationField,
+			Message: fmt.Sprintf("is required when a resource of type '%s' is first reported", resourceType),
+		})
+	}
+	if policy.RequireReporterOnCreate && (reporterRepresentation == nil || len(*reporterRepresentation) == 0) {
+		violations = append(violations, ValidationError{
+			Field:   ReporterRepresentationField,
+			Message: fmt.Sprintf("is required when a resource of type '%s' is first reported by '%s'", resourceType, reporterType),
+		})
+	}
+
+	return NewSchemaViolationsError(violations)
+}
+
+// policyFor returns the reporter's schema policy, or the zero policy if the reporter has no schema.
+func (sc *SchemaService) policyFor(ctx context.Context, resourceType ResourceType, reporterType ReporterType) SchemaPolicy {
+	reporter, err := sc.schemaRepository.GetReporterSchema(ctx, resourceType, reporterType)
+	if err != nil {
+		return SchemaPolicy{}
+	}
+	return reporter.Policy()
 }
 
 // IsReporterForResource validates the resourceType and reporterType combination is valid.
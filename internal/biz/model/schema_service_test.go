@@ -713,3 +713,130 @@ func TestValidateReportAgainstSchema(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestValidateReportAgainstSchema_Policy(t *testing.T) {
+	ctx := context.Background()
+
+	resourceType, err := model.NewResourceType("host")
+	require.NoError(t, err)
+	reporterType, err := model.NewReporterType("HBI")
+	require.NoError(t, err)
+
+	commonSchema := data.NewJsonSchemaWithWorkspacesFromString(`{
+		"type": "object",
+		"properties": { "workspace_id": { "type": "string" } },
+		"required": ["workspace_id"]
+	}`)
+	reporterSchema := data.NewJsonSchemaWithWorkspacesFromString(`{
+		"type": "object",
+		"properties": { "satellite_id": { "type": "string" } },
+		"required": ["satellite_id"]
+	}`)
+
+	setupService := func(policy model.SchemaPolicy) *model.SchemaService {
+		repo := data.NewInMemorySchemaRepository()
+		resourceRep, err := model.NewResourceSchemaRepresentation(resourceType, commonSchema)
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateResourceSchema(ctx, resourceRep))
+		reporterRep, err := model.NewReporterSchemaRepresentation(resourceType, reporterType, reporterSchema)
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateReporterSchema(ctx, reporterRep.WithPolicy(policy)))
+		return model.NewSchemaService(repo, log.NewHelper(log.DefaultLogger))
+	}
+
+	violationsOf := func(t *testing.T, err error) []model.ValidationError {
+		t.Helper()
+		var violations *model.SchemaViolationsError
+		require.ErrorAs(t, err, &violations)
+		return violations.Violations
+	}
+
+	t.Run("reports every violation rather than the first", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{})
+		invalidCommon := model.Representation(map[string]interface{}{"wrong_field": "value"})
+		invalidReporter := model.Representation(map[string]interface{}{"wrong_field": "value"})
+		err := sc.ValidateReportAgainstSchema(ctx, resourceType, reporterType, &invalidCommon, &invalidReporter)
+
+		violations := violationsOf(t, err)
+		require.Len(t, violations, 2)
+		assert.Equal(t, model.ReporterRepresentationField, violations[0].Field)
+		assert.Equal(t, model.CommonRepresentationField, violations[1].Field)
+	})
+
+	t.Run("unknown properties are rejected when the policy requires it", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{RejectUnknownProperties: true})
+		common := model.Representation(map[string]interface{}{"workspace_id": "ws-1", "extra": "x"})
+		reporter := model.Representation(map[string]interface{}{"satellite_id": "sat-1", "b": 1, "a": 2})
+		err := sc.ValidateReportAgainstSchema(ctx, resourceType, reporterType, &common, &reporter)
+
+		violations := violationsOf(t, err)
+		require.Len(t, violations, 3)
+		assert.Equal(t, "representations.reporter.a", violations[0].Field)
+		assert.Equal(t, "representations.reporter.b", violations[1].Field)
+		assert.Equal(t, "representations.common.extra", violations[2].Field)
+	})
+
+	t.Run("unknown properties are allowed by default", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{})
+		common := model.Representation(map[string]interface{}{"workspace_id": "ws-1", "extra": "x"})
+		err := sc.ValidateReportAgainstSchema(ctx, resourceType, reporterType, &common, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("representations over the size limit are rejected", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{MaxRepresentationBytes: 30})
+		common := model.Representation(map[string]interface{}{"workspace_id": "ws-1"})
+		reporter := model.Representation(map[string]interface{}{"satellite_id": "a-rather-long-satellite-identifier"})
+		err := sc.ValidateReportAgainstSchema(ctx, resourceType, reporterType, &common, &reporter)
+
+		violations := violationsOf(t, err)
+		require.Len(t, violations, 1)
+		assert.Equal(t, model.ReporterRepresentationField, violations[0].Field)
+		assert.Contains(t, violations[0].Message, "exceeding the maximum of 30 bytes")
+	})
+}
+
+func TestValidateRepresentationsForCreate(t *testing.T) {
+	ctx := context.Background()
+
+	resourceType, err := model.NewResourceType("host")
+	require.NoError(t, err)
+	reporterType, err := model.NewReporterType("HBI")
+	require.NoError(t, err)
+
+	setupService := func(policy model.SchemaPolicy) *model.SchemaService {
+		repo := data.NewInMemorySchemaRepository()
+		resourceRep, err := model.NewResourceSchemaRepresentation(resourceType, data.NewJsonSchemaWithWorkspacesFromString(`{"type": "object"}`))
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateResourceSchema(ctx, resourceRep))
+		reporterRep, err := model.NewReporterSchemaRepresentation(resourceType, reporterType, data.NewJsonSchemaWithWorkspacesFromString(`{"type": "object"}`))
+		require.NoError(t, err)
+		require.NoError(t, repo.CreateReporterSchema(ctx, reporterRep.WithPolicy(policy)))
+		return model.NewSchemaService(repo, log.NewHelper(log.DefaultLogger))
+	}
+
+	t.Run("nothing is required by default", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{})
+		assert.NoError(t, sc.ValidateRepresentationsForCreate(ctx, resourceType, reporterType, nil, nil))
+	})
+
+	t.Run("missing required representations are all reported", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{RequireCommonOnCreate: true, RequireReporterOnCreate: true})
+		empty := model.Representation(map[string]interface{}{})
+		err := sc.ValidateRepresentationsForCreate(ctx, resourceType, reporterType, nil, &empty)
+
+		var violations *model.SchemaViolationsError
+		require.ErrorAs(t, err, &violations)
+		require.Len(t, violations.Violations, 2)
+		assert.Equal(t, model.CommonRepresentationField, violations.Violations[0].Field)
+		assert.Equal(t, model.ReporterRepresentationField, violations.Violations[1].Field)
+		assert.Contains(t, violations.Violations[1].Message, "first reported by 'hbi'")
+	})
+
+	t.Run("present representations satisfy the policy", func(t *testing.T) {
+		sc := setupService(model.SchemaPolicy{RequireCommonOnCreate: true, RequireReporterOnCreate: true})
+		common := model.Representation(map[string]interface{}{"workspace_id": "ws-1"})
+		reporter := model.Representation(map[string]interface{}{"satellite_id": "sat-1"})
+		assert.NoError(t, sc.ValidateRepresentationsForCreate(ctx, resourceType, reporterType, &common, &reporter))
+	})
+}
@@ -33,6 +33,7 @@ type ReporterResourceKeySnapshot struct {
 	ReporterType       string `json:"reporter_type"`
 	ResourceType       string `json:"resource_type"`
 	ReporterInstanceID string `json:"reporter_instance_id"`
+	OrgID              string `json:"org_id,omitempty"`
 }
 
 // ReporterResourceSnapshot is a DTO that mirrors the GORM ReporterResource model structure
@@ -130,6 +130,7 @@ func newResourceEvent(operationType bizmodel.EventOperationType, resourceEvent *
 			Metadata: EventResourceMetadata{
 				Id:           resourceEvent.Id().String(),
 				ResourceType: resourceEvent.ResourceType().String(),
+				OrgId:        resourceEvent.ReporterResourceKey().OrgId().Serialize(),
 				CreatedAt:    createdAt,
 				UpdatedAt:    updatedAt,
 				DeletedAt:    deletedAt,
@@ -1,12 +1,26 @@
 package metaauthorizer
 
+import "time"
+
 type Config struct {
 	*Options
 }
 
 type completedConfig struct {
 	*Options
-	TupleCrudAllowlist []string
+	TupleCrudAllowlist    []string
+	CheckExplainAllowlist []string
+
+	PolicyFile           string
+	PolicyReloadInterval time.Duration
+	PolicyAuditOnly      bool
+
+	KesselEnabled           bool
+	KesselCacheTTL          time.Duration
+	KesselFallbackAllowlist []string
+
+	// ReporterBindings is nil when no bindings are configured.
+	ReporterBindings *ReporterBindings
 }
 
 type CompletedConfig struct {
@@ -19,7 +33,17 @@ func NewConfig(o *Options) *Config {
 
 func (c *Config) Complete() (CompletedConfig, []error) {
 	return CompletedConfig{&completedConfig{
-		Options:            c.Options,
-		TupleCrudAllowlist: c.TupleCrudAllowlist,
+		Options:               c.Options,
+		TupleCrudAllowlist:    c.TupleCrudAllowlist,
+		CheckExplainAllowlist: c.CheckExplainAllowlist,
+		PolicyFile:            c.PolicyFile,
+		PolicyReloadInterval:  c.PolicyReloadInterval,
+		PolicyAuditOnly:       c.PolicyAuditOnly,
+
+		KesselEnabled:           c.KesselEnabled,
+		KesselCacheTTL:          c.KesselCacheTTL,
+		KesselFallbackAllowlist: c.KesselFallbackAllowlist,
+
+		ReporterBindings: NewReporterBindings(c.Options.ReporterBindings),
 	}}, nil
 }
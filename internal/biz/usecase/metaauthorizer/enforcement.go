@@ -49,6 +49,12 @@ func EnforceMetaAuthzObject(ctx context.Context, authorizer MetaAuthorizer, rela
 		case TupleSystem:
 			resourceType = "tuple_system"
 			resourceId = "system"
+		case ConsistencyWatermarkRef:
+			resourceType = "consistency_watermark"
+			resourceId = "global"
+			if obj.ReporterType() != nil {
+				resourceId = obj.ReporterType().String()
+			}
 		}
 
 		// Auth failure - SEC-MON-REQ-1 compliance (EOI-8 authorization_failure, EOI-1 pii_manipulation)
@@ -0,0 +1,143 @@
+package metaauthorizer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/patrickmn/go-cache"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// Object and subject types of the meta-authorization schema (deploy/meta-authorization.zed).
+const (
+	kesselNamespace    = "inventory"
+	kesselReporterType = "reporter"
+	kesselSystemType   = "system"
+	// kesselSystemId is the single inventory/system object, used for tuple CRUD and the global
+	// consistency watermark.
+	kesselSystemId = "inventory"
+
+	// Callers with a client ID are inventory/client objects, other callers rbac/principal objects,
+	// so that a subject ID can't be granted what a client ID with the same name is.
+	kesselClientType       = "client"
+	kesselSubjectNamespace = "rbac"
+	kesselSubjectType      = "principal"
+)
+
+// kesselPermissions maps each meta relation to the permission checked on the reporter or system
+// object. Relations sharing a permission are granted together.
+var kesselPermissions = map[Relation]string{
+	RelationReportResource:          "report",
+	RelationDeleteResource:          "report",
+	RelationCheck:                   "check",
+	RelationCheckBulk:               "check",
+	RelationCheckForUpdate:          "check",
+	RelationCheckForUpdateBulk:      "check",
+	RelationLookupResources:         "lookup",
+	RelationLookupSubjects:          "lookup",
+	RelationCheckSelf:               "check_self",
+	RelationCheckSelfBulk:           "check_self",
+	RelationCheckForUpdateSelfBulk:  "check_self",
+	RelationCheckExplain:            "explain",
+	RelationGetConsistencyWatermark: "read_watermark",
+	RelationCreateTuples:            "write_tuples",
+	RelationDeleteTuples:            "write_tuples",
+	RelationAcquireLock:             "write_tuples",
+	RelationReadTuples:              "read_tuples",
+}
+
+// KesselMetaAuthorizer authorizes requests by checking the caller against the relations backend,
+// e.g. inventory/reporter:hbi#report@inventory/client:svc-hbi for a report of an hbi resource. The
+// caller is inventory/client named by its client ID, or rbac/principal named by its subject ID
+// when it has no client ID.
+//
+// Allowed decisions are cached, so granting access takes effect immediately while revoking it
+// takes up to the cache TTL. When the backend fails, including when it answers fail-open, callers on
+// the fallback allowlist are allowed and everyone else is denied.
+type KesselMetaAuthorizer struct {
+	relations         model.RelationsRepository
+	fallbackAllowlist []string
+
+	// allowed holds the relationships recently allowed by the backend.
+	allowed *cache.Cache
+}
+
+func NewKesselMetaAuthorizer(relations model.RelationsRepository, c CompletedConfig) *KesselMetaAuthorizer {
+	k := &KesselMetaAuthorizer{relations: relations, fallbackAllowlist: c.KesselFallbackAllowlist}
+	if c.KesselCacheTTL > 0 {
+		k.allowed = cache.New(c.KesselCacheTTL, 2*c.KesselCacheTTL)
+	}
+	return k
+}
+
+func (k *KesselMetaAuthorizer) Check(ctx context.Context, object MetaObject, relation Relation, authzCtx AuthzContext) (bool, error) {
+	if !authzCtx.IsAuthenticated() {
+		return false, nil
+	}
+	subjectNamespace, subjectType, principal := kesselSubject(authzCtx.Subject)
+	permission, ok := kesselPermissions[relation]
+	if principal == "" || !ok {
+		return false, nil
+	}
+
+	objectType, objectId := kesselObject(object)
+	cacheKey := objectType + ":" + objectId + "#" + permission + "@" + subjectType + ":" + principal
+	if k.allowed != nil {
+		if _, found := k.allowed.Get(cacheKey); found {
+			return true, nil
+		}
+	}
+
+	rel := model.NewRelationship(
+		model.NewResourceReference(model.DeserializeResourceType(objectType), model.DeserializeLocalResourceId(objectId),
+			kesselReporter(kesselNamespace)),
+		model.DeserializeRelation(permission),
+		model.NewSubjectReferenceWithoutRelation(model.NewResourceReference(model.DeserializeResourceType(subjectType),
+			model.DeserializeLocalResourceId(principal), kesselReporter(subjectNamespace))),
+	)
+	result, err := k.relations.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+	if err == nil && result.FailOpen() {
+		err = errors.New("relations backend failed and answered fail-open")
+	}
+	if err != nil {
+		allowed := isInAllowlist(authzCtx.Subject, k.fallbackAllowlist)
+		log.NewHelper(log.DefaultLogger).Warnw("msg", "Meta authorization check failed, using fallback allowlist",
+			"principal", principal,
+			"relation", string(relation),
+			"object", objectType+":"+objectId,
+			"allowed", allowed,
+			"error", err,
+		)
+		return allowed, nil
+	}
+
+	if result.Allowed() && k.allowed != nil {
+		k.allowed.SetDefault(cacheKey, struct{}{})
+	}
+	return result.Allowed(), nil
+}
+
+// kesselSubject returns the namespace, type and ID of the schema subject a caller is checked as.
+func kesselSubject(claims *authnapi.Claims) (string, string, string) {
+	if claims.ClientID != "" {
+		return kesselNamespace, kesselClientType, string(claims.ClientID)
+	}
+	return kesselSubjectNamespace, kesselSubjectType, string(claims.SubjectId)
+}
+
+// kesselObject returns the schema object a meta object is authorized on: the reporter of the
+// object's reporter type, or the system object for objects without one.
+func kesselObject(object MetaObject) (string, string) {
+	if reporterType := objectAttributesOf(object).reporterType; reporterType != "" {
+		return kesselReporterType, reporterType
+	}
+	return kesselSystemType, kesselSystemId
+}
+
+func kesselReporter(namespace string) *model.ReporterReference {
+	reporter := model.NewReporterReference(model.DeserializeReporterType(namespace), nil)
+	return &reporter
+}
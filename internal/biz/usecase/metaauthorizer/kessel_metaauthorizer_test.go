@@ -0,0 +1,189 @@
+package metaauthorizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// fakeRelations answers Check from a set of "type:id#permission@principal" grants.
+type fakeRelations struct {
+	model.RelationsRepository
+	grants   map[string]bool
+	err      error
+	failOpen bool
+	checks   []model.Relationship
+}
+
+func (f *fakeRelations) Check(_ context.Context, rel model.Relationship, _ model.Consistency) (model.CheckResult, error) {
+	f.checks = append(f.checks, rel)
+	if f.err != nil {
+		return model.CheckResult{}, f.err
+	}
+	if f.failOpen {
+		return model.NewFailOpenCheckResult(), nil
+	}
+	key := rel.Object().ResourceType().String() + ":" + rel.Object().ResourceId().String() + "#" +
+		rel.Relation().String() + "@" + rel.Subject().Resource().ResourceType().String() + ":" + rel.Subject().Resource().ResourceId().String()
+	return model.NewCheckResult(f.grants[key], ""), nil
+}
+
+func newKesselAuthorizer(t *testing.T, relations model.RelationsRepository, opts *Options) *KesselMetaAuthorizer {
+	t.Helper()
+	if opts == nil {
+		opts = NewOptions()
+	}
+	opts.KesselEnabled = true
+	completed, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+	return NewKesselMetaAuthorizer(relations, completed)
+}
+
+func TestKesselMetaAuthorizer_Check(t *testing.T) {
+	relations := &fakeRelations{grants: map[string]bool{
+		"reporter:hbi#report@client:svc-hbi":           true,
+		"reporter:hbi#check_self@principal:user-1":     true,
+		"system:inventory#write_tuples@client:rbac":    true,
+		"system:inventory#read_watermark@client:acm":   true,
+		"reporter:acm#read_watermark@client:svc-hbi":   false,
+		"reporter:hbi#lookup@client:notifications-svc": true,
+	}}
+	authorizer := newKesselAuthorizer(t, relations, &Options{})
+	hbi := model.ReporterType("hbi")
+
+	tests := []struct {
+		name     string
+		object   MetaObject
+		relation Relation
+		authzCtx authnapi.AuthzContext
+		want     bool
+	}{
+		{name: "reporter reports", object: NewInventoryResource("hbi", "host", "h1"), relation: RelationReportResource, authzCtx: grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "delete shares report", object: NewInventoryResource("hbi", "host", "h1"), relation: RelationDeleteResource, authzCtx: grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "other reporter type", object: NewInventoryResource("acm", "k8s_cluster", "c1"), relation: RelationReportResource, authzCtx: grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC), want: false},
+		{name: "lookup on a resource type", object: NewResourceTypeRef("hbi", "host"), relation: RelationLookupResources, authzCtx: grpcCaller("notifications-svc", "", authnapi.AuthTypeJWT), want: true},
+		{
+			name:     "subject id without client id",
+			object:   NewInventoryResource("hbi", "host", "h1"),
+			relation: RelationCheckSelf,
+			authzCtx: authnapi.AuthzContext{Protocol: authnapi.ProtocolHTTP, Subject: &authnapi.Claims{SubjectId: "user-1", AuthType: authnapi.AuthTypeXRhIdentity}},
+			want:     true,
+		},
+		{
+			name:     "subject id named like a granted client id",
+			object:   NewTupleSystem(),
+			relation: RelationCreateTuples,
+			authzCtx: authnapi.AuthzContext{Protocol: authnapi.ProtocolGRPC, Subject: &authnapi.Claims{SubjectId: "rbac", AuthType: authnapi.AuthTypeAPIKey}},
+			want:     false,
+		},
+		{name: "tuple system", object: NewTupleSystem(), relation: RelationCreateTuples, authzCtx: grpcCaller("rbac", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "global watermark", object: NewConsistencyWatermarkRef(nil), relation: RelationGetConsistencyWatermark, authzCtx: grpcCaller("acm", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "reporter watermark", object: NewConsistencyWatermarkRef(&hbi), relation: RelationGetConsistencyWatermark, authzCtx: grpcCaller("acm", "", authnapi.AuthTypeOIDC), want: false},
+		{name: "unauthenticated", object: NewTupleSystem(), relation: RelationCreateTuples, authzCtx: authnapi.AuthzContext{Protocol: authnapi.ProtocolGRPC}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := authorizer.Check(context.Background(), tt.object, tt.relation, tt.authzCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+func TestKesselMetaAuthorizer_Relationship(t *testing.T) {
+	relations := &fakeRelations{grants: map[string]bool{}}
+	authorizer := newKesselAuthorizer(t, relations, nil)
+
+	_, err := authorizer.Check(context.Background(), NewInventoryResource("hbi", "host", "h1"), RelationReportResource, grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC))
+	require.NoError(t, err)
+	require.Len(t, relations.checks, 1)
+
+	rel := relations.checks[0]
+	assert.Equal(t, "inventory", rel.Object().Reporter().ReporterType().String())
+	assert.Equal(t, "reporter", rel.Object().ResourceType().String())
+	assert.Equal(t, "hbi", rel.Object().ResourceId().String())
+	assert.Equal(t, "report", rel.Relation().String())
+	assert.Equal(t, "inventory", rel.Subject().Resource().Reporter().ReporterType().String())
+	assert.Equal(t, "client", rel.Subject().Resource().ResourceType().String())
+	assert.Equal(t, "svc-hbi", rel.Subject().Resource().ResourceId().String())
+}
+
+func TestKesselMetaAuthorizer_CachesAllowedDecisions(t *testing.T) {
+	relations := &fakeRelations{grants: map[string]bool{"reporter:hbi#report@client:svc-hbi": true}}
+	authorizer := newKesselAuthorizer(t, relations, &Options{KesselCacheTTL: time.Minute})
+	caller := grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC)
+
+	for i := 0; i < 3; i++ {
+		allowed, err := authorizer.Check(context.Background(), NewInventoryResource("hbi", "host", "h1"), RelationReportResource, caller)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	assert.Len(t, relations.checks, 1)
+
+	// Denied decisions are always checked again, so grants take effect immediately.
+	for i := 0; i < 2; i++ {
+		allowed, err := authorizer.Check(context.Background(), NewInventoryResource("acm", "k8s_cluster", "c1"), RelationReportResource, caller)
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	}
+	assert.Len(t, relations.checks, 3)
+
+	relations.grants["reporter:acm#report@client:svc-hbi"] = true
+	allowed, err := authorizer.Check(context.Background(), NewInventoryResource("acm", "k8s_cluster", "c1"), RelationReportResource, caller)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestKesselMetaAuthorizer_FallbackAllowlist(t *testing.T) {
+	relations := &fakeRelations{err: errors.New("connection refused")}
+	authorizer := newKesselAuthorizer(t, relations, &Options{KesselFallbackAllowlist: []string{"svc-hbi"}})
+
+	allowed, err := authorizer.Check(context.Background(), NewInventoryResource("hbi", "host", "h1"), RelationReportResource, grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC))
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = authorizer.Check(context.Background(), NewInventoryResource("hbi", "host", "h1"), RelationReportResource, grpcCaller("acm", "", authnapi.AuthTypeOIDC))
+	require.NoError(t, err)
+	assert.False(t, allowed)
+}
+
+func TestOptionsValidate_Kessel(t *testing.T) {
+	opts := NewOptions()
+	opts.KesselEnabled = true
+	assert.Empty(t, opts.Validate())
+
+	opts.PolicyFile = "policy.yaml"
+	opts.KesselCacheTTL = -time.Second
+	errs := opts.Validate()
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "kessel-enabled and policy-file are mutually exclusive unless policy-audit-only is set")
+	assert.EqualError(t, errs[1], "kessel-cache-ttl must not be negative")
+
+	opts.KesselCacheTTL = time.Second
+	opts.PolicyAuditOnly = true
+	assert.Empty(t, opts.Validate())
+}
+
+func TestKesselMetaAuthorizer_FailOpenAnswersUseFallbackAllowlist(t *testing.T) {
+	relations := &fakeRelations{failOpen: true}
+	authorizer := newKesselAuthorizer(t, relations, &Options{KesselFallbackAllowlist: []string{"svc-hbi"}, KesselCacheTTL: time.Minute})
+
+	allowed, err := authorizer.Check(context.Background(), NewInventoryResource("hbi", "host", "h1"), RelationReportResource, grpcCaller("acm", "", authnapi.AuthTypeOIDC))
+	require.NoError(t, err)
+	assert.False(t, allowed, "a fail-open answer doesn't grant meta-authorization")
+
+	for i := 0; i < 2; i++ {
+		allowed, err = authorizer.Check(context.Background(), NewInventoryResource("hbi", "host", "h1"), RelationReportResource, grpcCaller("svc-hbi", "", authnapi.AuthTypeOIDC))
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	}
+	assert.Len(t, relations.checks, 3, "fallback decisions are not cached")
+}
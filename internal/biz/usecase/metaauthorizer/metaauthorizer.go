@@ -102,3 +102,20 @@ func NewTupleSystem() TupleSystem {
 
 // metaObject seals the interface - only types in this package can implement MetaObject.
 func (TupleSystem) metaObject() {}
+
+// ConsistencyWatermarkRef represents a consistency watermark for meta-authorization.
+// The reporter type is nil for the global watermark.
+type ConsistencyWatermarkRef struct {
+	reporterType *model.ReporterType
+}
+
+// NewConsistencyWatermarkRef creates a new ConsistencyWatermarkRef for meta-authorization.
+func NewConsistencyWatermarkRef(reporterType *model.ReporterType) ConsistencyWatermarkRef {
+	return ConsistencyWatermarkRef{reporterType: reporterType}
+}
+
+// ReporterType returns the reporter type, or nil for the global watermark.
+func (cw ConsistencyWatermarkRef) ReporterType() *model.ReporterType { return cw.reporterType }
+
+// metaObject seals the interface - only types in this package can implement MetaObject.
+func (ConsistencyWatermarkRef) metaObject() {}
@@ -1,14 +1,49 @@
 package metaauthorizer
 
-import "github.com/spf13/pflag"
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultPolicyReloadInterval is how often the policy file is checked for changes.
+const DefaultPolicyReloadInterval = 30 * time.Second
+
+// DefaultKesselCacheTTL is how long positive decisions of the kessel meta authorizer are cached.
+const DefaultKesselCacheTTL = 30 * time.Second
 
 type Options struct {
-	TupleCrudAllowlist []string `mapstructure:"tuple-crud-allowlist"`
+	TupleCrudAllowlist    []string `mapstructure:"tuple-crud-allowlist"`
+	CheckExplainAllowlist []string `mapstructure:"check-explain-allowlist"`
+
+	PolicyFile           string        `mapstructure:"policy-file"`
+	PolicyReloadInterval time.Duration `mapstructure:"policy-reload-interval"`
+	PolicyAuditOnly      bool          `mapstructure:"policy-audit-only"`
+
+	KesselEnabled           bool          `mapstructure:"kessel-enabled"`
+	KesselCacheTTL          time.Duration `mapstructure:"kessel-cache-ttl"`
+	KesselFallbackAllowlist []string      `mapstructure:"kessel-fallback-allowlist"`
+
+	ReporterBindings []ReporterBindingOptions `mapstructure:"reporter-bindings"`
+}
+
+// ReporterBindingOptions lets the caller with ClientId report and delete resources of the reporter
+// types and instances matching its patterns ("*" wildcards, as in path.Match). An empty
+// ReporterInstanceIds allows every instance.
+type ReporterBindingOptions struct {
+	ClientId            string   `mapstructure:"client-id"`
+	ReporterTypes       []string `mapstructure:"reporter-types"`
+	ReporterInstanceIds []string `mapstructure:"reporter-instance-ids"`
 }
 
 func NewOptions() *Options {
 	return &Options{
-		TupleCrudAllowlist: []string{}, // Empty = deny all by default
+		TupleCrudAllowlist:      []string{}, // Empty = deny all by default
+		CheckExplainAllowlist:   []string{}, // Empty = deny all by default
+		PolicyReloadInterval:    DefaultPolicyReloadInterval,
+		KesselCacheTTL:          DefaultKesselCacheTTL,
+		KesselFallbackAllowlist: []string{}, // Empty = deny all while the relations backend is unavailable
 	}
 }
 
@@ -18,11 +53,43 @@ func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
 	}
 	fs.StringArrayVar(&o.TupleCrudAllowlist, prefix+"tuple-crud-allowlist", o.TupleCrudAllowlist,
 		"List of client IDs (or subject IDs) allowed to access tuple CRUD endpoints (RBAC-only). Empty list denies all. Use '*' for testing.")
+	fs.StringArrayVar(&o.CheckExplainAllowlist, prefix+"check-explain-allowlist", o.CheckExplainAllowlist,
+		"List of client IDs allowed to call CheckExplain. Empty list denies all. Use '*' for testing.")
+	fs.StringVar(&o.PolicyFile, prefix+"policy-file", o.PolicyFile,
+		"Path to a YAML meta-authorization policy. When set, its rules authorize every operation instead of the built-in rules and allowlists.")
+	fs.DurationVar(&o.PolicyReloadInterval, prefix+"policy-reload-interval", o.PolicyReloadInterval,
+		"How often the policy file is checked for changes. 0 disables reloading.")
+	fs.BoolVar(&o.PolicyAuditOnly, prefix+"policy-audit-only", o.PolicyAuditOnly,
+		"Only log requests the policy would decide differently, and keep authorizing with the built-in rules and allowlists, or the relations backend when kessel-enabled is set.")
+	fs.BoolVar(&o.KesselEnabled, prefix+"kessel-enabled", o.KesselEnabled,
+		"Authorize every operation by checking the caller against the relations backend (see deploy/meta-authorization.zed) instead of the built-in rules and allowlists.")
+	fs.DurationVar(&o.KesselCacheTTL, prefix+"kessel-cache-ttl", o.KesselCacheTTL,
+		"How long allowed decisions of the relations backend are cached. 0 disables caching.")
+	fs.StringArrayVar(&o.KesselFallbackAllowlist, prefix+"kessel-fallback-allowlist", o.KesselFallbackAllowlist,
+		"List of client IDs allowed while the relations backend is unavailable. Empty list denies all.")
 }
 
 func (o *Options) Validate() []error {
-	// No validation needed - empty list is valid (deny all)
-	return nil
+	// Empty allowlists are valid (deny all)
+	var errs []error
+	if o.PolicyReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("policy-reload-interval must not be negative"))
+	}
+	if o.PolicyAuditOnly && o.PolicyFile == "" {
+		errs = append(errs, fmt.Errorf("policy-audit-only requires policy-file"))
+	}
+	if o.KesselEnabled && o.PolicyFile != "" && !o.PolicyAuditOnly {
+		errs = append(errs, fmt.Errorf("kessel-enabled and policy-file are mutually exclusive unless policy-audit-only is set"))
+	}
+	if o.KesselCacheTTL < 0 {
+		errs = append(errs, fmt.Errorf("kessel-cache-ttl must not be negative"))
+	}
+	for i, binding := range o.ReporterBindings {
+		if err := binding.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("reporter-bindings[%d]: %w", i, err))
+		}
+	}
+	return errs
 }
 
 func (o *Options) Complete() []error {
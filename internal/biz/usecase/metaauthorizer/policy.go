@@ -0,0 +1,317 @@
+package metaauthorizer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// Policy rule effects.
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// Meta object kinds, as named in policy rules.
+const (
+	ObjectInventoryResource    = "inventory_resource"
+	ObjectResourceType         = "resource_type"
+	ObjectTupleSystem          = "tuple_system"
+	ObjectConsistencyWatermark = "consistency_watermark"
+)
+
+var knownRelations = map[Relation]bool{
+	RelationCheckSelf:               true,
+	RelationLookupResources:         true,
+	RelationLookupSubjects:          true,
+	RelationReportResource:          true,
+	RelationCheckForUpdate:          true,
+	RelationDeleteResource:          true,
+	RelationCheck:                   true,
+	RelationCheckExplain:            true,
+	RelationCheckBulk:               true,
+	RelationCheckSelfBulk:           true,
+	RelationCheckForUpdateBulk:      true,
+	RelationCheckForUpdateSelfBulk:  true,
+	RelationGetConsistencyWatermark: true,
+	RelationCreateTuples:            true,
+	RelationDeleteTuples:            true,
+	RelationReadTuples:              true,
+	RelationAcquireLock:             true,
+}
+
+var knownAuthTypes = map[authnapi.AuthType]bool{
+	authnapi.AuthTypeOIDC:                 true,
+	authnapi.AuthTypeXRhIdentity:          true,
+	authnapi.AuthTypeMTLS:                 true,
+	authnapi.AuthTypeJWT:                  true,
+	authnapi.AuthTypeAPIKey:               true,
+	authnapi.AuthTypeAllowUnauthenticated: true,
+}
+
+var knownObjects = map[string]bool{
+	ObjectInventoryResource:    true,
+	ObjectResourceType:         true,
+	ObjectTupleSystem:          true,
+	ObjectConsistencyWatermark: true,
+}
+
+// PolicyRuleConfig is a rule of a meta-authorization policy file. Each list restricts the requests
+// the rule matches; an empty list matches every request and "*" matches any non-empty value.
+type PolicyRuleConfig struct {
+	Name   string `yaml:"name"`
+	Effect string `yaml:"effect"`
+
+	ClientIds []string `yaml:"client_ids"`
+	Subjects  []string `yaml:"subjects"`
+	Orgs      []string `yaml:"orgs"`
+	AuthTypes []string `yaml:"auth_types"`
+	Protocols []string `yaml:"protocols"`
+
+	Relations     []string `yaml:"relations"`
+	Objects       []string `yaml:"objects"`
+	ReporterTypes []string `yaml:"reporter_types"`
+	ResourceTypes []string `yaml:"resource_types"`
+}
+
+// PolicyConfig is the content of a meta-authorization policy file.
+type PolicyConfig struct {
+	Rules []PolicyRuleConfig `yaml:"rules"`
+}
+
+// Policy is a parsed meta-authorization policy. A request is allowed when an allow rule matches it
+// and no deny rule does; requests no rule matches are denied.
+type Policy struct {
+	rules []policyRule
+}
+
+type policyRule struct {
+	name  string
+	allow bool
+
+	clientIds     matcher
+	subjects      matcher
+	orgs          matcher
+	authTypes     matcher
+	protocols     matcher
+	relations     matcher
+	objects       matcher
+	reporterTypes matcher
+	resourceTypes matcher
+}
+
+// matcher matches a request attribute against the values listed in a rule.
+type matcher struct {
+	values   map[string]bool // nil matches every value
+	wildcard bool            // "*" matches every non-empty value
+}
+
+func (m matcher) matches(value string) bool {
+	if m.values == nil && !m.wildcard {
+		return true
+	}
+	if value == "" {
+		return false
+	}
+	return m.wildcard || m.values[value]
+}
+
+// ParsePolicy parses a meta-authorization policy. Unknown keys, relations, auth types and object
+// kinds are rejected so that a typo does not silently change what a rule matches.
+func ParsePolicy(content []byte) (*Policy, error) {
+	var config PolicyConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if len(config.Rules) == 0 {
+		return nil, fmt.Errorf("policy has no rules")
+	}
+
+	policy := &Policy{rules: make([]policyRule, 0, len(config.Rules))}
+	for i, r := range config.Rules {
+		rule, err := newPolicyRule(r)
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		if rule.name == "" {
+			rule.name = fmt.Sprintf("rules[%d]", i)
+		}
+		policy.rules = append(policy.rules, rule)
+	}
+	return policy, nil
+}
+
+func newPolicyRule(r PolicyRuleConfig) (policyRule, error) {
+	rule := policyRule{name: r.Name}
+	switch r.Effect {
+	case EffectAllow:
+		rule.allow = true
+	case EffectDeny:
+	default:
+		return policyRule{}, fmt.Errorf("invalid effect %q, must be %q or %q", r.Effect, EffectAllow, EffectDeny)
+	}
+
+	var err error
+	if rule.clientIds, err = newMatcher("client_ids", r.ClientIds, nil); err != nil {
+		return policyRule{}, err
+	}
+	if rule.subjects, err = newMatcher("subjects", r.Subjects, nil); err != nil {
+		return policyRule{}, err
+	}
+	if rule.orgs, err = newMatcher("orgs", r.Orgs, nil); err != nil {
+		return policyRule{}, err
+	}
+	if rule.authTypes, err = newMatcher("auth_types", r.AuthTypes, func(v string) (string, error) {
+		if !knownAuthTypes[authnapi.AuthType(v)] {
+			return "", fmt.Errorf("unknown auth type")
+		}
+		return v, nil
+	}); err != nil {
+		return policyRule{}, err
+	}
+	if rule.protocols, err = newMatcher("protocols", r.Protocols, func(v string) (string, error) {
+		if v != string(authnapi.ProtocolHTTP) && v != string(authnapi.ProtocolGRPC) {
+			return "", fmt.Errorf("unknown protocol")
+		}
+		return v, nil
+	}); err != nil {
+		return policyRule{}, err
+	}
+	if rule.relations, err = newMatcher("relations", r.Relations, func(v string) (string, error) {
+		if !knownRelations[Relation(v)] {
+			return "", fmt.Errorf("unknown relation")
+		}
+		return v, nil
+	}); err != nil {
+		return policyRule{}, err
+	}
+	if rule.objects, err = newMatcher("objects", r.Objects, func(v string) (string, error) {
+		if !knownObjects[v] {
+			return "", fmt.Errorf("unknown object kind")
+		}
+		return v, nil
+	}); err != nil {
+		return policyRule{}, err
+	}
+	if rule.reporterTypes, err = newMatcher("reporter_types", r.ReporterTypes, func(v string) (string, error) {
+		reporterType, err := model.NewReporterType(v)
+		return reporterType.String(), err
+	}); err != nil {
+		return policyRule{}, err
+	}
+	if rule.resourceTypes, err = newMatcher("resource_types", r.ResourceTypes, func(v string) (string, error) {
+		resourceType, err := model.NewResourceType(v)
+		return resourceType.String(), err
+	}); err != nil {
+		return policyRule{}, err
+	}
+	return rule, nil
+}
+
+// newMatcher builds a matcher from the values of a rule field, normalizing each value that is not
+// the wildcard.
+func newMatcher(field string, values []string, normalize func(string) (string, error)) (matcher, error) {
+	if len(values) == 0 {
+		return matcher{}, nil
+	}
+	m := matcher{values: map[string]bool{}}
+	for _, v := range values {
+		if v == "*" {
+			m.wildcard = true
+			continue
+		}
+		if v == "" {
+			return matcher{}, fmt.Errorf("%s: empty value", field)
+		}
+		if normalize != nil {
+			normalized, err := normalize(v)
+			if err != nil {
+				return matcher{}, fmt.Errorf("%s: %q: %w", field, v, err)
+			}
+			v = normalized
+		}
+		m.values[v] = true
+	}
+	return m, nil
+}
+
+// Evaluate decides a request against the policy. Deny rules override allow rules. It returns the
+// name of the deciding rule, which is empty when no rule matched.
+func (p *Policy) Evaluate(object MetaObject, relation Relation, authzCtx AuthzContext) (bool, string) {
+	attrs := objectAttributesOf(object)
+	allowedBy := ""
+	for _, rule := range p.rules {
+		if !rule.matches(attrs, relation, authzCtx) {
+			continue
+		}
+		if !rule.allow {
+			return false, rule.name
+		}
+		if allowedBy == "" {
+			allowedBy = rule.name
+		}
+	}
+	return allowedBy != "", allowedBy
+}
+
+func (r policyRule) matches(attrs objectAttributes, relation Relation, authzCtx AuthzContext) bool {
+	var clientId, subjectId, orgId, authType string
+	if authzCtx.Subject != nil {
+		clientId = string(authzCtx.Subject.ClientID)
+		subjectId = string(authzCtx.Subject.SubjectId)
+		orgId = string(authzCtx.Subject.OrganizationId)
+		authType = string(authzCtx.Subject.AuthType)
+	}
+	return r.clientIds.matches(clientId) &&
+		r.subjects.matches(subjectId) &&
+		r.orgs.matches(orgId) &&
+		r.authTypes.matches(authType) &&
+		r.protocols.matches(string(authzCtx.Protocol)) &&
+		r.relations.matches(string(relation)) &&
+		r.objects.matches(attrs.kind) &&
+		r.reporterTypes.matches(attrs.reporterType) &&
+		r.resourceTypes.matches(attrs.resourceType)
+}
+
+// objectAttributes are the properties of a MetaObject that policy rules match on. Types an object
+// does not have (e.g. for the tuple system) are empty.
+type objectAttributes struct {
+	kind         string
+	reporterType string
+	resourceType string
+}
+
+func objectAttributesOf(object MetaObject) objectAttributes {
+	switch obj := object.(type) {
+	case InventoryResource:
+		return objectAttributes{
+			kind:         ObjectInventoryResource,
+			reporterType: obj.ReporterType().String(),
+			resourceType: obj.ResourceType().String(),
+		}
+	case ResourceTypeRef:
+		return objectAttributes{
+			kind:         ObjectResourceType,
+			reporterType: obj.ReporterType().String(),
+			resourceType: obj.ResourceType().String(),
+		}
+	case TupleSystem:
+		return objectAttributes{kind: ObjectTupleSystem}
+	case ConsistencyWatermarkRef:
+		attrs := objectAttributes{kind: ObjectConsistencyWatermark}
+		if obj.ReporterType() != nil {
+			attrs.reporterType = obj.ReporterType().String()
+		}
+		return attrs
+	default:
+		return objectAttributes{}
+	}
+}
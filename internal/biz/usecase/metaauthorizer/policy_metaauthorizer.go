@@ -0,0 +1,75 @@
+package metaauthorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/project-kessel/inventory-api/internal/filereload"
+)
+
+// PolicyMetaAuthorizer authorizes requests with the rules of a YAML policy file, which it re-reads
+// periodically so rules can be changed without a restart.
+type PolicyMetaAuthorizer struct {
+	policy *filereload.Reloader[Policy]
+}
+
+// NewPolicyMetaAuthorizer loads the configured policy file. It fails if the file can't be read or
+// parsed; later reload failures keep the previous policy.
+func NewPolicyMetaAuthorizer(c CompletedConfig) (*PolicyMetaAuthorizer, error) {
+	if c.PolicyFile == "" {
+		return nil, fmt.Errorf("policy-file is required")
+	}
+	policy, err := filereload.New("policy file", c.PolicyFile, c.PolicyReloadInterval, ParsePolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyMetaAuthorizer{policy: policy}, nil
+}
+
+func (p *PolicyMetaAuthorizer) Check(_ context.Context, object MetaObject, relation Relation, authzCtx AuthzContext) (bool, error) {
+	allowed, _ := p.policy.Load().Evaluate(object, relation, authzCtx)
+	return allowed, nil
+}
+
+// AuditOnly returns a meta authorizer that enforces the decisions of enforced and only logs the
+// requests the policy would decide differently, so a new policy can be tried against real traffic.
+func (p *PolicyMetaAuthorizer) AuditOnly(enforced MetaAuthorizer) MetaAuthorizer {
+	return &policyAuditMetaAuthorizer{policy: p, enforced: enforced}
+}
+
+type policyAuditMetaAuthorizer struct {
+	policy   *PolicyMetaAuthorizer
+	enforced MetaAuthorizer
+}
+
+func (a *policyAuditMetaAuthorizer) Check(ctx context.Context, object MetaObject, relation Relation, authzCtx AuthzContext) (bool, error) {
+	allowed, err := a.enforced.Check(ctx, object, relation, authzCtx)
+	if err != nil {
+		return allowed, err
+	}
+	policyAllowed, rule := a.policy.policy.Load().Evaluate(object, relation, authzCtx)
+	if policyAllowed == allowed {
+		return allowed, nil
+	}
+
+	attrs := objectAttributesOf(object)
+	log.NewHelper(log.DefaultLogger).Warnw("msg", "Meta authorization policy decides request differently (audit only)",
+		"event", "authorization_audit",
+		"principal", authzCtx.ExtractPrincipal(),
+		"relation", string(relation),
+		"object", attrs.kind,
+		"reporter_type", attrs.reporterType,
+		"resource_type", attrs.resourceType,
+		"rule", rule,
+		"allowed", allowed,
+		"policy_allowed", policyAllowed,
+	)
+	return allowed, nil
+}
+
+// Stop ends periodic reloading.
+func (p *PolicyMetaAuthorizer) Stop() {
+	p.policy.Stop()
+}
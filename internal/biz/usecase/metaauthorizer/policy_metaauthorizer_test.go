@@ -0,0 +1,265 @@
+package metaauthorizer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+const testPolicy = `
+rules:
+  - name: hbi-reports-hosts
+    effect: allow
+    client_ids: [hbi]
+    auth_types: [oidc, mtls]
+    protocols: [grpc]
+    relations: [report_resource, delete_resource]
+    reporter_types: [HBI]
+    resource_types: [host]
+  - name: services-check
+    effect: allow
+    client_ids: ["*"]
+    relations: [check, check_bulk, lookup_resources]
+  - name: no-checks-for-org-666
+    effect: deny
+    orgs: ["666"]
+  - name: rbac-tuples
+    effect: allow
+    client_ids: [rbac]
+    objects: [tuple_system]
+  - name: console-check-self
+    effect: allow
+    auth_types: [x-rh-identity]
+    protocols: [http]
+    relations: [check_self]
+`
+
+func writePolicy(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func newPolicyAuthorizer(t *testing.T, content string, opts *Options) *PolicyMetaAuthorizer {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy(t, path, content)
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.PolicyFile = path
+	completed, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+	authorizer, err := NewPolicyMetaAuthorizer(completed)
+	require.NoError(t, err)
+	t.Cleanup(authorizer.Stop)
+	return authorizer
+}
+
+func grpcCaller(clientId, orgId string, authType authnapi.AuthType) authnapi.AuthzContext {
+	return authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject: &authnapi.Claims{
+			SubjectId:      authnapi.SubjectId("service-" + clientId),
+			ClientID:       authnapi.ClientID(clientId),
+			OrganizationId: authnapi.OrganizationId(orgId),
+			AuthType:       authType,
+		},
+	}
+}
+
+func TestPolicyMetaAuthorizer_Check(t *testing.T) {
+	authorizer := newPolicyAuthorizer(t, testPolicy, nil)
+	host := NewInventoryResource("hbi", "host", "h1")
+	cluster := NewInventoryResource("acm", "k8s_cluster", "c1")
+	hostType := NewResourceTypeRef("hbi", "host")
+
+	tests := []struct {
+		name     string
+		object   MetaObject
+		relation Relation
+		authzCtx authnapi.AuthzContext
+		want     bool
+	}{
+		{name: "reporter reports its own type", object: host, relation: RelationReportResource, authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "reporter reports another reporter's type", object: cluster, relation: RelationReportResource, authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeOIDC), want: false},
+		{name: "reporter with another auth type", object: host, relation: RelationReportResource, authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeAPIKey), want: false},
+		{name: "other client reports", object: host, relation: RelationReportResource, authzCtx: grpcCaller("acm", "", authnapi.AuthTypeOIDC), want: false},
+		{name: "any client checks", object: cluster, relation: RelationCheck, authzCtx: grpcCaller("acm", "1", authnapi.AuthTypeOIDC), want: true},
+		{name: "lookup on a resource type", object: hostType, relation: RelationLookupResources, authzCtx: grpcCaller("acm", "1", authnapi.AuthTypeJWT), want: true},
+		{name: "check without client id", object: cluster, relation: RelationCheck, authzCtx: grpcCaller("", "1", authnapi.AuthTypeOIDC), want: false},
+		{name: "deny overrides allow", object: cluster, relation: RelationCheck, authzCtx: grpcCaller("acm", "666", authnapi.AuthTypeOIDC), want: false},
+		{name: "tuple system", object: NewTupleSystem(), relation: RelationCreateTuples, authzCtx: grpcCaller("rbac", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "tuple system for other client", object: NewTupleSystem(), relation: RelationCreateTuples, authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeOIDC), want: false},
+		{
+			name:     "console check self over http",
+			object:   host,
+			relation: RelationCheckSelf,
+			authzCtx: authnapi.AuthzContext{Protocol: authnapi.ProtocolHTTP, Subject: &authnapi.Claims{SubjectId: "u1", AuthType: authnapi.AuthTypeXRhIdentity}},
+			want:     true,
+		},
+		{name: "unauthenticated", object: host, relation: RelationCheckSelf, authzCtx: authnapi.AuthzContext{Protocol: authnapi.ProtocolHTTP}, want: false},
+		{name: "no matching rule", object: NewConsistencyWatermarkRef(nil), relation: RelationGetConsistencyWatermark, authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeOIDC), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := authorizer.Check(context.Background(), tt.object, tt.relation, tt.authzCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+func TestPolicy_EvaluateReturnsDecidingRule(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	object := NewInventoryResource("acm", "k8s_cluster", "c1")
+
+	allowed, rule := policy.Evaluate(object, RelationCheck, grpcCaller("acm", "1", authnapi.AuthTypeOIDC))
+	assert.True(t, allowed)
+	assert.Equal(t, "services-check", rule)
+
+	allowed, rule = policy.Evaluate(object, RelationCheck, grpcCaller("acm", "666", authnapi.AuthTypeOIDC))
+	assert.False(t, allowed)
+	assert.Equal(t, "no-checks-for-org-666", rule)
+
+	allowed, rule = policy.Evaluate(object, RelationDeleteResource, grpcCaller("acm", "1", authnapi.AuthTypeOIDC))
+	assert.False(t, allowed)
+	assert.Empty(t, rule)
+}
+
+func TestPolicy_ConsistencyWatermarkReporterType(t *testing.T) {
+	policy, err := ParsePolicy([]byte(`
+rules:
+  - effect: allow
+    objects: [consistency_watermark]
+    reporter_types: [hbi]
+`))
+	require.NoError(t, err)
+	hbi := model.ReporterType("hbi")
+	caller := grpcCaller("hbi", "", authnapi.AuthTypeOIDC)
+
+	allowed, rule := policy.Evaluate(NewConsistencyWatermarkRef(&hbi), RelationGetConsistencyWatermark, caller)
+	assert.True(t, allowed)
+	assert.Equal(t, "rules[0]", rule)
+
+	// The global watermark has no reporter type, so the rule doesn't match it.
+	allowed, _ = policy.Evaluate(NewConsistencyWatermarkRef(nil), RelationGetConsistencyWatermark, caller)
+	assert.False(t, allowed)
+}
+
+func TestPolicyMetaAuthorizer_AuditOnly(t *testing.T) {
+	authorizer := newPolicyAuthorizer(t, testPolicy, &Options{PolicyAuditOnly: true})
+	tupleAuthorizer := authorizer.AuditOnly(NewWhitelistMetaAuthorizer([]string{"tuple-admin"}))
+
+	tests := []struct {
+		name     string
+		authzCtx authnapi.AuthzContext
+		want     bool
+	}{
+		{name: "the allowlist denies what the policy allows", authzCtx: grpcCaller("rbac", "", authnapi.AuthTypeOIDC), want: false},
+		{name: "the allowlist allows what the policy denies", authzCtx: grpcCaller("tuple-admin", "", authnapi.AuthTypeOIDC), want: true},
+		{name: "both deny", authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeOIDC), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, err := tupleAuthorizer.Check(context.Background(), NewTupleSystem(), RelationCreateTuples, tt.authzCtx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+func TestPolicyMetaAuthorizer_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	writePolicy(t, path, testPolicy)
+	completed, errs := NewConfig(&Options{PolicyFile: path, PolicyReloadInterval: 10 * time.Millisecond}).Complete()
+	require.Empty(t, errs)
+	authorizer, err := NewPolicyMetaAuthorizer(completed)
+	require.NoError(t, err)
+	t.Cleanup(authorizer.Stop)
+
+	caller := grpcCaller("acm", "", authnapi.AuthTypeOIDC)
+	allowed, err := authorizer.Check(context.Background(), NewTupleSystem(), RelationReadTuples, caller)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	writePolicy(t, path, `
+rules:
+  - effect: allow
+    client_ids: [acm]
+    relations: [read_tuples]
+`)
+	assert.Eventually(t, func() bool {
+		allowed, _ := authorizer.Check(context.Background(), NewTupleSystem(), RelationReadTuples, caller)
+		return allowed
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// An invalid policy keeps the previous one.
+	writePolicy(t, path, "rules: [")
+	time.Sleep(50 * time.Millisecond)
+	allowed, err = authorizer.Check(context.Background(), NewTupleSystem(), RelationReadTuples, caller)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestNewPolicyMetaAuthorizer_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	completed, errs := NewConfig(&Options{PolicyFile: path}).Complete()
+	require.Empty(t, errs)
+
+	_, err := NewPolicyMetaAuthorizer(completed)
+	assert.ErrorContains(t, err, "failed to read policy file")
+
+	writePolicy(t, path, "rules: []")
+	_, err = NewPolicyMetaAuthorizer(completed)
+	assert.ErrorContains(t, err, "policy has no rules")
+}
+
+func TestParsePolicy_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{name: "empty", policy: "", wantErr: "policy has no rules"},
+		{name: "unknown key", policy: "rules:\n  - effect: allow\n    client_id: [hbi]", wantErr: "field client_id not found"},
+		{name: "missing effect", policy: "rules:\n  - client_ids: [hbi]", wantErr: `rules[0]: invalid effect ""`},
+		{name: "unknown relation", policy: "rules:\n  - effect: allow\n    relations: [report]", wantErr: `rules[0]: relations: "report": unknown relation`},
+		{name: "unknown auth type", policy: "rules:\n  - effect: allow\n    auth_types: [basic]", wantErr: `rules[0]: auth_types: "basic": unknown auth type`},
+		{name: "unknown protocol", policy: "rules:\n  - effect: allow\n    protocols: [amqp]", wantErr: `rules[0]: protocols: "amqp": unknown protocol`},
+		{name: "unknown object", policy: "rules:\n  - effect: deny\n    objects: [host]", wantErr: `rules[0]: objects: "host": unknown object kind`},
+		{name: "empty value", policy: "rules:\n  - effect: deny\n    orgs: ['']", wantErr: "rules[0]: orgs: empty value"},
+		{name: "blank resource type", policy: "rules:\n  - effect: deny\n    resource_types: [' ']", wantErr: `rules[0]: resource_types: " "`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePolicy([]byte(tt.policy))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOptionsValidate_Policy(t *testing.T) {
+	opts := NewOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.PolicyAuditOnly = true
+	assert.Len(t, opts.Validate(), 1)
+
+	opts.PolicyFile = "policy.yaml"
+	opts.PolicyReloadInterval = -time.Second
+	errs := opts.Validate()
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "policy-reload-interval must not be negative")
+}
@@ -0,0 +1,135 @@
+package metaauthorizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/log"
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// ErrReporterNotBound indicates the caller is not bound to the reporter of the resource it writes.
+var ErrReporterNotBound = errors.New("reporter not bound to client")
+
+// ReporterBindingError explains why a caller may not write resources of a reporter.
+type ReporterBindingError struct {
+	Reason string
+}
+
+func (e *ReporterBindingError) Error() string { return e.Reason }
+
+func (e *ReporterBindingError) Unwrap() error { return ErrReporterNotBound }
+
+func (o ReporterBindingOptions) validate() error {
+	if o.ClientId == "" {
+		return fmt.Errorf("client-id is required")
+	}
+	if len(o.ReporterTypes) == 0 {
+		return fmt.Errorf("at least one reporter type is required")
+	}
+	for _, pattern := range append(append([]string{}, o.ReporterTypes...), o.ReporterInstanceIds...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// reporterBinding is a ReporterBindingOptions with its reporter type patterns normalized like
+// model.ReporterType.
+type reporterBinding struct {
+	reporterTypes       []string
+	reporterInstanceIds []string
+}
+
+// ReporterBindings restricts which reporter types and instances each client may report and delete
+// resources for, so that a compromised credential of one reporter can't overwrite the resources of
+// another. Clients without a binding may not write any resources.
+type ReporterBindings struct {
+	byClient map[string][]reporterBinding
+}
+
+// NewReporterBindings returns the bindings for validated options, or nil when there are none.
+func NewReporterBindings(options []ReporterBindingOptions) *ReporterBindings {
+	if len(options) == 0 {
+		return nil
+	}
+	b := &ReporterBindings{byClient: map[string][]reporterBinding{}}
+	for _, o := range options {
+		binding := reporterBinding{reporterInstanceIds: o.ReporterInstanceIds}
+		for _, pattern := range o.ReporterTypes {
+			binding.reporterTypes = append(binding.reporterTypes, strings.ToLower(strings.TrimSpace(pattern)))
+		}
+		b.byClient[o.ClientId] = append(b.byClient[o.ClientId], binding)
+	}
+	return b
+}
+
+// Check returns a *ReporterBindingError unless the caller's client ID is bound to the reporter type
+// and instance.
+func (b *ReporterBindings) Check(authzCtx AuthzContext, reporterType model.ReporterType, reporterInstanceId model.ReporterInstanceId) error {
+	if !authzCtx.IsAuthenticated() || authzCtx.Subject.ClientID == "" {
+		return &ReporterBindingError{Reason: "reporter bindings require a caller with a client ID"}
+	}
+	clientId := string(authzCtx.Subject.ClientID)
+	bindings, ok := b.byClient[clientId]
+	if !ok {
+		return &ReporterBindingError{Reason: fmt.Sprintf("client %q is not bound to any reporter", clientId)}
+	}
+
+	typeMatched := false
+	for _, binding := range bindings {
+		if !matchesAny(binding.reporterTypes, reporterType.String()) {
+			continue
+		}
+		typeMatched = true
+		if len(binding.reporterInstanceIds) == 0 || matchesAny(binding.reporterInstanceIds, reporterInstanceId.String()) {
+			return nil
+		}
+	}
+	if typeMatched {
+		return &ReporterBindingError{Reason: fmt.Sprintf("client %q is not bound to reporter_instance_id %q of reporter_type %q",
+			clientId, reporterInstanceId.String(), reporterType.String())}
+	}
+	return &ReporterBindingError{Reason: fmt.Sprintf("client %q is not bound to reporter_type %q", clientId, reporterType.String())}
+}
+
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		// Patterns were validated, so Match can't fail.
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// EnforceReporterBinding checks the caller of a resource write against the reporter bindings. It
+// allows every write when no bindings are configured.
+func EnforceReporterBinding(ctx context.Context, bindings *ReporterBindings, key model.ReporterResourceKey) error {
+	if bindings == nil {
+		return nil
+	}
+	authzCtx, ok := authnapi.FromAuthzContext(ctx)
+	if !ok {
+		return ErrMetaAuthzContextMissing
+	}
+	err := bindings.Check(authzCtx, key.ReporterType(), key.ReporterInstanceId())
+	if err != nil {
+		// Auth failure - SEC-MON-REQ-1 compliance (EOI-8 authorization_failure)
+		log.NewHelper(log.DefaultLogger).Warnw("msg", "Reporter binding denied",
+			"event", "authorization_failure",
+			"action", "authorize_reporter_binding",
+			"principal", authzCtx.ExtractPrincipal(),
+			"reporter_type", key.ReporterType().String(),
+			"reporter_instance_id", key.ReporterInstanceId().String(),
+			"reason", err.Error(),
+			"outcome", "failure",
+		)
+	}
+	return err
+}
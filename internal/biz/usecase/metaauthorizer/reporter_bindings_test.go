@@ -0,0 +1,103 @@
+package metaauthorizer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+func TestReporterBindings_Check(t *testing.T) {
+	bindings := NewReporterBindings([]ReporterBindingOptions{
+		{ClientId: "hbi", ReporterTypes: []string{"HBI"}},
+		{ClientId: "acs", ReporterTypes: []string{"acs"}, ReporterInstanceIds: []string{"acs-prod-*"}},
+		{ClientId: "acs", ReporterTypes: []string{"acs"}, ReporterInstanceIds: []string{"acs-stage"}},
+		{ClientId: "ocm", ReporterTypes: []string{"ocm", "acm*"}},
+	})
+	require.NotNil(t, bindings)
+
+	tests := []struct {
+		name               string
+		authzCtx           authnapi.AuthzContext
+		reporterType       model.ReporterType
+		reporterInstanceId model.ReporterInstanceId
+		wantReason         string
+	}{
+		{name: "bound reporter type", authzCtx: grpcCaller("hbi", "", authnapi.AuthTypeOIDC), reporterType: "hbi", reporterInstanceId: "any"},
+		{name: "bound instance pattern", authzCtx: grpcCaller("acs", "", authnapi.AuthTypeOIDC), reporterType: "acs", reporterInstanceId: "acs-prod-eu"},
+		{name: "second binding of a client", authzCtx: grpcCaller("acs", "", authnapi.AuthTypeOIDC), reporterType: "acs", reporterInstanceId: "acs-stage"},
+		{name: "reporter type pattern", authzCtx: grpcCaller("ocm", "", authnapi.AuthTypeOIDC), reporterType: "acm_hub", reporterInstanceId: "1"},
+		{
+			name:         "other reporter type",
+			authzCtx:     grpcCaller("acs", "", authnapi.AuthTypeOIDC),
+			reporterType: "hbi", reporterInstanceId: "acs-prod-eu",
+			wantReason: `client "acs" is not bound to reporter_type "hbi"`,
+		},
+		{
+			name:         "other instance",
+			authzCtx:     grpcCaller("acs", "", authnapi.AuthTypeOIDC),
+			reporterType: "acs", reporterInstanceId: "acs-dev",
+			wantReason: `client "acs" is not bound to reporter_instance_id "acs-dev" of reporter_type "acs"`,
+		},
+		{
+			name:         "unbound client",
+			authzCtx:     grpcCaller("notifications", "", authnapi.AuthTypeOIDC),
+			reporterType: "hbi",
+			wantReason:   `client "notifications" is not bound to any reporter`,
+		},
+		{
+			name:         "no client id",
+			authzCtx:     authnapi.AuthzContext{Protocol: authnapi.ProtocolHTTP, Subject: &authnapi.Claims{SubjectId: "u1", AuthType: authnapi.AuthTypeXRhIdentity}},
+			reporterType: "hbi",
+			wantReason:   "reporter bindings require a caller with a client ID",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := bindings.Check(tt.authzCtx, tt.reporterType, tt.reporterInstanceId)
+			if tt.wantReason == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrReporterNotBound)
+			assert.EqualError(t, err, tt.wantReason)
+		})
+	}
+}
+
+func TestEnforceReporterBinding(t *testing.T) {
+	key, err := model.NewReporterResourceKey("h1", "host", "hbi", "i1")
+	require.NoError(t, err)
+	ctx := authnapi.NewAuthzContext(context.Background(), grpcCaller("acs", "", authnapi.AuthTypeOIDC))
+
+	assert.NoError(t, EnforceReporterBinding(ctx, nil, key), "no bindings allow every write")
+
+	bindings := NewReporterBindings([]ReporterBindingOptions{{ClientId: "acs", ReporterTypes: []string{"acs"}}})
+	assert.ErrorIs(t, EnforceReporterBinding(ctx, bindings, key), ErrReporterNotBound)
+	assert.ErrorIs(t, EnforceReporterBinding(context.Background(), bindings, key), ErrMetaAuthzContextMissing)
+}
+
+func TestOptionsValidate_ReporterBindings(t *testing.T) {
+	opts := NewOptions()
+	opts.ReporterBindings = []ReporterBindingOptions{
+		{ClientId: "hbi", ReporterTypes: []string{"hbi"}},
+		{ReporterTypes: []string{"hbi"}},
+		{ClientId: "acs"},
+		{ClientId: "acs", ReporterTypes: []string{"acs"}, ReporterInstanceIds: []string{"["}},
+	}
+
+	errs := opts.Validate()
+	require.Len(t, errs, 3)
+	assert.EqualError(t, errs[0], "reporter-bindings[1]: client-id is required")
+	assert.EqualError(t, errs[1], "reporter-bindings[2]: at least one reporter type is required")
+	assert.ErrorContains(t, errs[2], `reporter-bindings[3]: invalid pattern "["`)
+
+	completed, cerrs := NewConfig(NewOptions()).Complete()
+	require.Empty(t, cerrs)
+	assert.Nil(t, completed.ReporterBindings)
+}
@@ -15,10 +15,12 @@ const RelationReportResource Relation = "report_resource"
 const RelationCheckForUpdate Relation = "check_for_update"
 const RelationDeleteResource Relation = "delete_resource"
 const RelationCheck Relation = "check"
+const RelationCheckExplain Relation = "check_explain"
 const RelationCheckBulk Relation = "check_bulk"
 const RelationCheckSelfBulk Relation = "check_self_bulk"
 const RelationCheckForUpdateBulk Relation = "check_for_update_bulk"
 const RelationCheckForUpdateSelfBulk Relation = "check_for_update_self_bulk"
+const RelationGetConsistencyWatermark Relation = "get_consistency_watermark"
 
 // Tuple-layer relations (DEPRECATED - for RBAC backward compatibility only)
 const RelationCreateTuples Relation = "create_tuples"
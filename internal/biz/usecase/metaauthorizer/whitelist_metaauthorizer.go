@@ -7,9 +7,9 @@ import (
 )
 
 // WhitelistMetaAuthorizer implements a whitelist-based authorization check.
-// It matches against ClientID from OIDC claims.
-// Designed for restricting deprecated tuple CRUD endpoints to specific services.
-// Only allows gRPC connections with OIDC authentication and valid ClientID.
+// It matches against ClientID from OIDC, JWT, api key or mTLS claims.
+// Designed for restricting deprecated tuple CRUD endpoints and CheckExplain to specific services.
+// Only allows gRPC connections with OIDC, JWT, api key or mTLS authentication and valid ClientID.
 type WhitelistMetaAuthorizer struct {
 	allowlist []string
 }
@@ -30,13 +30,15 @@ func (w *WhitelistMetaAuthorizer) Check(_ context.Context, _ MetaObject, _ Relat
 		return false, nil
 	}
 
-	// Deny if not gRPC (tuple CRUD endpoints are gRPC-only)
+	// Deny if not gRPC (allowlisted endpoints are gRPC-only)
 	if authzCtx.Protocol != authnapi.ProtocolGRPC {
 		return false, nil
 	}
 
-	// Deny if not OIDC (ClientID only populated for OIDC)
-	if authzCtx.Subject.AuthType != authnapi.AuthTypeOIDC {
+	// Deny unless OIDC, JWT, api key or mTLS (ClientID only populated for service identities)
+	switch authzCtx.Subject.AuthType {
+	case authnapi.AuthTypeOIDC, authnapi.AuthTypeJWT, authnapi.AuthTypeAPIKey, authnapi.AuthTypeMTLS:
+	default:
 		return false, nil
 	}
 
@@ -45,10 +47,10 @@ func (w *WhitelistMetaAuthorizer) Check(_ context.Context, _ MetaObject, _ Relat
 }
 
 // isInAllowlist checks if the caller's ClientID is in the allowlist.
-// Requires non-empty ClientID from OIDC claims.
+// Requires non-empty ClientID from OIDC, JWT, api key or mTLS claims.
 // Supports "*" wildcard to allow all.
 func isInAllowlist(claims *authnapi.Claims, allowlist []string) bool {
-	// Deny if ClientID is empty (OIDC service-to-service auth requires client_id)
+	// Deny if ClientID is empty (service-to-service auth requires a client ID)
 	if string(claims.ClientID) == "" {
 		return false
 	}
@@ -57,7 +59,7 @@ func isInAllowlist(claims *authnapi.Claims, allowlist []string) bool {
 		if allowed == "*" {
 			return true
 		}
-		// Match on ClientID (stable service identifier from OIDC client_id claim)
+		// Match on ClientID (stable service identifier from the client_id claim, an api key or an mTLS rule)
 		if allowed == string(claims.ClientID) {
 			return true
 		}
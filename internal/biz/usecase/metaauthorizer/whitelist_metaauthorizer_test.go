@@ -140,6 +140,57 @@ func TestWhitelistMetaAuthorizer_XRhIdentity_Denies(t *testing.T) {
 	assert.False(t, allowed)
 }
 
+func TestWhitelistMetaAuthorizer_MTLS_Allowed(t *testing.T) {
+	authorizer := NewWhitelistMetaAuthorizer([]string{"hbi"})
+	ctx := context.Background()
+	authzCtx := authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject: &authnapi.Claims{
+			SubjectId: "spiffe://kessel.example/ns/hbi/sa/hbi",
+			ClientID:  "hbi",
+			AuthType:  authnapi.AuthTypeMTLS,
+		},
+	}
+
+	allowed, err := authorizer.Check(ctx, NewTupleSystem(), RelationCreateTuples, authzCtx)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestWhitelistMetaAuthorizer_JWT_Allowed(t *testing.T) {
+	authorizer := NewWhitelistMetaAuthorizer([]string{"rbac-service"})
+	ctx := context.Background()
+	authzCtx := authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject: &authnapi.Claims{
+			SubjectId: "service-account-abc123",
+			ClientID:  "rbac-service",
+			AuthType:  authnapi.AuthTypeJWT,
+		},
+	}
+
+	allowed, err := authorizer.Check(ctx, NewTupleSystem(), RelationCreateTuples, authzCtx)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestWhitelistMetaAuthorizer_APIKey_Allowed(t *testing.T) {
+	authorizer := NewWhitelistMetaAuthorizer([]string{"ci"})
+	ctx := context.Background()
+	authzCtx := authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject: &authnapi.Claims{
+			SubjectId: "ci-bot",
+			ClientID:  "ci",
+			AuthType:  authnapi.AuthTypeAPIKey,
+		},
+	}
+
+	allowed, err := authorizer.Check(ctx, NewTupleSystem(), RelationCreateTuples, authzCtx)
+	assert.NoError(t, err)
+	assert.True(t, allowed)
+}
+
 func TestWhitelistMetaAuthorizer_RelationIndependent(t *testing.T) {
 	// Verify that the authorizer doesn't filter based on relation
 	authorizer := NewWhitelistMetaAuthorizer([]string{"rbac-service"})
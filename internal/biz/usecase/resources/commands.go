@@ -71,6 +71,15 @@ type CheckBulkResult struct {
 	ConsistencyToken model.ConsistencyToken
 }
 
+// StreamedCheckBulkCommand contains the request for a streamed bulk permission check.
+// Items are pulled from Next until it returns io.EOF, and results are delivered to
+// Send in request order. Send is never called concurrently.
+type StreamedCheckBulkCommand struct {
+	Consistency model.Consistency
+	Next        func() ([]CheckBulkItem, error)
+	Send        func([]CheckBulkResultPair) error
+}
+
 // CheckForUpdateBulkCommand contains the request for a bulk check-for-update (strongly consistent).
 // Reuses CheckBulkItem; no consistency token since each check is strongly consistent.
 type CheckForUpdateBulkCommand struct {
@@ -100,6 +109,27 @@ type LookupObjectsCommand struct {
 	Consistency model.Consistency
 }
 
+// RepresentationFilter matches resources whose common representation holds Equals at Path,
+// a dot-separated list of object keys. Equals is a decoded JSON value.
+type RepresentationFilter struct {
+	Path   string
+	Equals interface{}
+}
+
+// LookupObjectsEnrichment selects the inventory data joined onto LookupObjects results.
+type LookupObjectsEnrichment struct {
+	CommonFields []string
+	Filters      []RepresentationFilter
+}
+
+// EnrichedLookupObjectsItem is a LookupObjects result joined with inventory's stored resource.
+// Resource is nil when inventory has no live record of the object; its common representation
+// only holds the requested fields, keyed by path.
+type EnrichedLookupObjectsItem struct {
+	Item     model.LookupObjectsItem
+	Resource *model.ResourceSummary
+}
+
 // LookupSubjectsCommand contains the request for looking up subjects.
 type LookupSubjectsCommand struct {
 	Resource        model.ResourceReference
@@ -0,0 +1,139 @@
+package resources
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// lookupEnrichmentChunkSize bounds the number of lookup results joined with inventory per query.
+const lookupEnrichmentChunkSize = 100
+
+// LookupObjectsEnriched runs LookupObjects and joins each result with inventory's stored
+// resource, dropping results that don't match the enrichment filters. Results keep their
+// continuation tokens, so a filtered stream can be resumed like an unfiltered one.
+func (uc *Usecase) LookupObjectsEnriched(ctx context.Context, cmd LookupObjectsCommand, enrichment LookupObjectsEnrichment) (model.ResultStream[EnrichedLookupObjectsItem], error) {
+	orgId, err := uc.Tenancy.OrgIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := uc.LookupObjects(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	var reporterType model.ReporterType
+	if cmd.ObjectType.HasReporterType() {
+		reporterType = *cmd.ObjectType.ReporterType()
+	}
+	return &enrichedLookupObjectsStream{
+		inner:        stream,
+		repo:         uc.resourceRepository,
+		orgId:        orgId,
+		reporterType: reporterType,
+		resourceType: cmd.ObjectType.ResourceType(),
+		enrichment:   enrichment,
+	}, nil
+}
+
+// enrichedLookupObjectsStream reads the underlying lookup in chunks, joining each chunk with
+// inventory in a single query before handing its results out one at a time.
+type enrichedLookupObjectsStream struct {
+	inner        model.ResultStream[model.LookupObjectsItem]
+	repo         model.ResourceRepository
+	orgId        model.OrgId
+	reporterType model.ReporterType
+	resourceType model.ResourceType
+	enrichment   LookupObjectsEnrichment
+
+	buffered []EnrichedLookupObjectsItem
+	err      error
+}
+
+func (s *enrichedLookupObjectsStream) Recv() (EnrichedLookupObjectsItem, error) {
+	for len(s.buffered) == 0 {
+		if s.err != nil {
+			return EnrichedLookupObjectsItem{}, s.err
+		}
+		s.fill()
+	}
+	item := s.buffered[0]
+	s.buffered = s.buffered[1:]
+	return item, nil
+}
+
+// fill reads the next chunk from the underlying stream and joins it with inventory. A read
+// error is held back until the results read before it have been handed out.
+func (s *enrichedLookupObjectsStream) fill() {
+	var items []model.LookupObjectsItem
+	for len(items) < lookupEnrichmentChunkSize {
+		item, err := s.inner.Recv()
+		if err != nil {
+			s.err = err
+			break
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	ids := make([]model.LocalResourceId, len(items))
+	for i, item := range items {
+		ids[i] = model.DeserializeLocalResourceId(item.Object().ResourceId().Serialize())
+	}
+	// Passing nil tx is deliberate: enrichment is a read-only, best-effort join.
+	summaries, err := s.repo.FindResourceSummaries(nil, s.orgId, s.reporterType, s.resourceType, ids)
+	if err != nil {
+		s.err = err
+		return
+	}
+	byId := make(map[model.LocalResourceId]model.ResourceSummary, len(summaries))
+	for _, summary := range summaries {
+		byId[summary.LocalResourceId()] = summary
+	}
+
+	for i, item := range items {
+		summary, found := byId[ids[i]]
+		if !matchesRepresentationFilters(summary.Common(), found, s.enrichment.Filters) {
+			continue
+		}
+		enriched := EnrichedLookupObjectsItem{Item: item}
+		if found {
+			selected := model.NewResourceSummary(summary.LocalResourceId(), summary.ApiHref(), summary.ConsoleHref(),
+				selectRepresentationFields(summary.Common(), s.enrichment.CommonFields))
+			enriched.Resource = &selected
+		}
+		s.buffered = append(s.buffered, enriched)
+	}
+}
+
+// matchesRepresentationFilters reports whether a stored common representation satisfies every
+// filter. Objects inventory has no record of only match an empty filter list.
+func matchesRepresentationFilters(common model.Representation, found bool, filters []RepresentationFilter) bool {
+	if len(filters) == 0 {
+		return true
+	}
+	if !found {
+		return false
+	}
+	for _, filter := range filters {
+		value, ok := common.Lookup(filter.Path)
+		if !ok || !reflect.DeepEqual(value, filter.Equals) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectRepresentationFields returns the values at the given paths, keyed by path. Paths
+// missing from the representation are left out.
+func selectRepresentationFields(common model.Representation, paths []string) model.Representation {
+	selected := model.NewEmptyRepresentation()
+	for _, path := range paths {
+		if value, ok := common.Lookup(path); ok {
+			selected[path] = value
+		}
+	}
+	return selected
+}
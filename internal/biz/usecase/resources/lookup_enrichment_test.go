@@ -0,0 +1,160 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/data"
+)
+
+// sliceLookupObjectsStream yields fixed items followed by err (io.EOF when nil).
+type sliceLookupObjectsStream struct {
+	items []model.LookupObjectsItem
+	err   error
+}
+
+func (s *sliceLookupObjectsStream) Recv() (model.LookupObjectsItem, error) {
+	if len(s.items) == 0 {
+		if s.err != nil {
+			return model.LookupObjectsItem{}, s.err
+		}
+		return model.LookupObjectsItem{}, io.EOF
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
+func hostLookupCommand(t *testing.T) LookupObjectsCommand {
+	t.Helper()
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	resourceType, err := model.NewResourceType("host")
+	require.NoError(t, err)
+	reporterType, err := model.NewReporterType("hbi")
+	require.NoError(t, err)
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+	return LookupObjectsCommand{
+		ObjectType:  model.NewRepresentationTypeRequired(resourceType, reporterType),
+		Relation:    relation,
+		Subject:     subject,
+		Consistency: model.NewConsistencyUnspecified(),
+	}
+}
+
+func collectEnriched(t *testing.T, stream model.ResultStream[EnrichedLookupObjectsItem]) map[string]EnrichedLookupObjectsItem {
+	t.Helper()
+	items := map[string]EnrichedLookupObjectsItem{}
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			return items
+		}
+		require.NoError(t, err)
+		items[item.Item.Object().ResourceId().String()] = item
+	}
+}
+
+func TestLookupObjectsEnriched_JoinsInventoryData(t *testing.T) {
+	simpleAuthz := data.NewSimpleRelationsRepository()
+	for _, id := range []string{"host-1", "host-2", "host-3"} {
+		simpleAuthz.Grant("user-1", "view", "hbi", "host", id)
+	}
+	h := newTestHarness(t, withMeta(true), withRelations(simpleAuthz))
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "ws-1")))
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-2", "ws-2")))
+	h.resetMeta()
+
+	stream, err := h.usecase.LookupObjectsEnriched(h.ctx, hostLookupCommand(t), LookupObjectsEnrichment{
+		CommonFields: []string{"workspace_id", "missing"},
+	})
+	require.NoError(t, err)
+	items := collectEnriched(t, stream)
+
+	require.Len(t, items, 3)
+	require.NotNil(t, items["host-1"].Resource)
+	assert.Equal(t, "https://api.example.com/resource/123", items["host-1"].Resource.ApiHref().String())
+	require.NotNil(t, items["host-1"].Resource.ConsoleHref())
+	assert.Equal(t, model.Representation{"workspace_id": "ws-1"}, items["host-1"].Resource.Common())
+	require.NotNil(t, items["host-2"].Resource)
+	assert.Equal(t, model.Representation{"workspace_id": "ws-2"}, items["host-2"].Resource.Common())
+	assert.Nil(t, items["host-3"].Resource, "objects unknown to inventory are streamed without inventory data")
+	assert.Equal(t, 1, h.meta.calls)
+}
+
+func TestLookupObjectsEnriched_FiltersByRepresentation(t *testing.T) {
+	simpleAuthz := data.NewSimpleRelationsRepository()
+	for _, id := range []string{"host-1", "host-2", "host-3"} {
+		simpleAuthz.Grant("user-1", "view", "hbi", "host", id)
+	}
+	h := newTestHarness(t, withMeta(true), withRelations(simpleAuthz))
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "ws-1")))
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-2", "ws-2")))
+
+	tests := []struct {
+		name    string
+		filters []RepresentationFilter
+		wantIDs []string
+	}{
+		{"matching value", []RepresentationFilter{{Path: "workspace_id", Equals: "ws-2"}}, []string{"host-2"}},
+		{"no match", []RepresentationFilter{{Path: "workspace_id", Equals: "ws-3"}}, nil},
+		{"missing path", []RepresentationFilter{{Path: "labels.env", Equals: "prod"}}, nil},
+		{"all filters must match", []RepresentationFilter{
+			{Path: "workspace_id", Equals: "ws-1"},
+			{Path: "workspace_id", Equals: "ws-2"},
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := h.usecase.LookupObjectsEnriched(h.ctx, hostLookupCommand(t), LookupObjectsEnrichment{Filters: tt.filters})
+			require.NoError(t, err)
+
+			var ids []string
+			for id := range collectEnriched(t, stream) {
+				ids = append(ids, id)
+			}
+			assert.ElementsMatch(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestEnrichedLookupObjectsStream_KeepsContinuationTokensAcrossChunks(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+
+	const total = 2*lookupEnrichmentChunkSize + 50
+	items := make([]model.LookupObjectsItem, total)
+	for i := range items {
+		id := fmt.Sprintf("host-%d", i)
+		if i%2 == 0 {
+			require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", id, "even")))
+		}
+		key := createReporterResourceKey(t, id, "host", "hbi", "instance-1")
+		items[i] = model.NewLookupObjectsItem(resourceRefFromKey(key), model.DeserializeContinuationToken(fmt.Sprintf("token-%d", i)))
+	}
+	backendErr := errors.New("backend went away")
+
+	stream := &enrichedLookupObjectsStream{
+		inner:        &sliceLookupObjectsStream{items: items, err: backendErr},
+		repo:         h.resourceRepo,
+		reporterType: model.DeserializeReporterType("hbi"),
+		resourceType: model.DeserializeResourceType("host"),
+		enrichment:   LookupObjectsEnrichment{Filters: []RepresentationFilter{{Path: "workspace_id", Equals: "even"}}},
+	}
+
+	for i := 0; i < total; i += 2 {
+		item, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("host-%d", i), item.Item.Object().ResourceId().String())
+		assert.Equal(t, fmt.Sprintf("token-%d", i), item.Item.ContinuationToken().String())
+		require.NotNil(t, item.Resource)
+	}
+	_, err := stream.Recv()
+	assert.ErrorIs(t, err, backendErr, "the backend error is returned after the results read before it")
+}
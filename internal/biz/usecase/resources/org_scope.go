@@ -0,0 +1,238 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// Relations tuples identify a resource by its reporter type, resource type and local resource ID,
+// without its organization. With tenancy enabled, a local resource ID therefore belongs to a single
+// organization: reporting an ID another organization already uses is rejected, and checks and
+// lookups answer for another organization's resources as if they had no relationships. Resources
+// reported before tenancy was enabled have no organization and are treated as another
+// organization's, except by admin clients naming an organization, which claim them for it.
+
+// orgScopeChunkSize bounds the number of lookup results checked against inventory per query.
+const orgScopeChunkSize = 100
+
+// foreignResources reports, for each reference, whether it is a live inventory resource of other
+// organizations than the caller's. Nothing is foreign when tenancy is disabled.
+func (uc *Usecase) foreignResources(ctx context.Context, refs []model.ResourceReference) ([]bool, error) {
+	orgId, err := uc.Tenancy.OrgIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return findForeignResources(uc.resourceRepository, orgId, uc.Tenancy.ClaimsUnowned(ctx), refs)
+}
+
+// isForeignResource reports whether ref is a live inventory resource of other organizations than
+// the caller's.
+func (uc *Usecase) isForeignResource(ctx context.Context, ref model.ResourceReference) (bool, error) {
+	foreign, err := uc.foreignResources(ctx, []model.ResourceReference{ref})
+	if err != nil {
+		return false, err
+	}
+	return foreign[0], nil
+}
+
+// findForeignResources reports, for each reference, whether it is a live inventory resource that
+// belongs to organizations other than orgId. Resources without an organization are foreign unless
+// claimsUnowned. References are looked up with one query per reporter and resource type.
+func findForeignResources(repo model.ResourceRepository, orgId model.OrgId, claimsUnowned bool, refs []model.ResourceReference) ([]bool, error) {
+	foreign := make([]bool, len(refs))
+	if orgId == "" {
+		return foreign, nil
+	}
+
+	type resourceTypeKey struct {
+		reporterType model.ReporterType
+		resourceType model.ResourceType
+	}
+	groups := map[resourceTypeKey][]int{}
+	for i, ref := range refs {
+		if ref.Reporter() == nil {
+			continue
+		}
+		key := resourceTypeKey{reporterType: ref.Reporter().ReporterType(), resourceType: ref.ResourceType()}
+		groups[key] = append(groups[key], i)
+	}
+
+	for key, indexes := range groups {
+		ids := make([]model.LocalResourceId, len(indexes))
+		for j, i := range indexes {
+			ids[j] = model.DeserializeLocalResourceId(refs[i].ResourceId().Serialize())
+		}
+		// Passing nil tx is deliberate: scoping is a read-only lookup outside any transaction.
+		orgIds, err := repo.FindResourceOrgIds(nil, key.reporterType, key.resourceType, ids)
+		if err != nil {
+			return nil, err
+		}
+		for j, i := range indexes {
+			foreign[i] = isForeignOrg(orgIds[ids[j]], orgId, claimsUnowned)
+		}
+	}
+	return foreign, nil
+}
+
+// isForeignOrg reports whether a resource owned by owners is out of reach of orgId. An empty owner,
+// a resource without an organization, is only within reach when claimsUnowned.
+func isForeignOrg(owners []model.OrgId, orgId model.OrgId, claimsUnowned bool) bool {
+	if len(owners) == 0 || slices.Contains(owners, orgId) {
+		return false
+	}
+	return !claimsUnowned || !slices.Contains(owners, "")
+}
+
+// ensureResourceIdAvailable rejects creating a resource in orgId when another organization, or no
+// organization, already has a live resource with the same local resource ID, since both would share
+// relations tuples. Callers that may claim resources without an organization find them instead of
+// creating one.
+func ensureResourceIdAvailable(tx *gorm.DB, repo model.ResourceRepository, orgId model.OrgId, cmd ReportResourceCommand) error {
+	if orgId == "" {
+		return nil
+	}
+	orgIds, err := repo.FindResourceOrgIds(tx, cmd.ReporterType, cmd.ResourceType, []model.LocalResourceId{cmd.LocalResourceId})
+	if err != nil {
+		return fmt.Errorf("failed to lookup resource organizations: %w", err)
+	}
+	if isForeignOrg(orgIds[cmd.LocalResourceId], orgId, false) {
+		return fmt.Errorf("%w: %s/%s %q is reported by another organization", model.ErrResourceAlreadyExists,
+			cmd.ReporterType, cmd.ResourceType, cmd.LocalResourceId)
+	}
+	return nil
+}
+
+// findResourceInOrg finds the resource with the given key in the key's organization. When
+// claimsUnowned and the organization has no resource with the key, a resource with the key but
+// without an organization is returned claimed by the organization, which owns it from the next
+// time it is saved.
+func findResourceInOrg(tx *gorm.DB, repo model.ResourceRepository, key model.ReporterResourceKey, claimsUnowned bool) (*model.Resource, error) {
+	res, err := repo.FindResourceByKeys(tx, key)
+	if key.OrgId() == "" || !claimsUnowned || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return res, err
+	}
+	res, err = repo.FindResourceByKeys(tx, key.WithOrgId(""))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || !res.ClaimOrgId(key) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return res, nil
+}
+
+// checkBulkInOrg runs check on the relationships whose objects aren't resources of another
+// organization, answers the others as denied, and returns the results in request order.
+func (uc *Usecase) checkBulkInOrg(ctx context.Context, rels []model.Relationship, check func([]model.Relationship) (model.CheckBulkResult, error)) (model.CheckBulkResult, error) {
+	refs := make([]model.ResourceReference, len(rels))
+	for i, rel := range rels {
+		refs[i] = rel.Object()
+	}
+	foreign, err := uc.foreignResources(ctx, refs)
+	if err != nil {
+		return model.CheckBulkResult{}, err
+	}
+
+	var checked []model.Relationship
+	for i, rel := range rels {
+		if !foreign[i] {
+			checked = append(checked, rel)
+		}
+	}
+	if len(checked) == len(rels) {
+		return check(rels)
+	}
+
+	var result model.CheckBulkResult
+	if len(checked) > 0 {
+		result, err = check(checked)
+		if err != nil {
+			return model.CheckBulkResult{}, err
+		}
+		if err := validateBulkResultLength(len(checked), len(result.Pairs())); err != nil {
+			return model.CheckBulkResult{}, err
+		}
+	}
+
+	pairs := make([]model.CheckBulkResultPair, len(rels))
+	next := 0
+	for i, rel := range rels {
+		if foreign[i] {
+			pairs[i] = model.NewCheckBulkResultPair(rel, model.NewCheckBulkResultItem(false, nil, 0))
+			continue
+		}
+		pairs[i] = result.Pairs()[next]
+		next++
+	}
+	return model.NewCheckBulkResult(pairs, result.ConsistencyToken()), nil
+}
+
+// orgScopedLookupObjectsStream drops lookup results that are resources of another organization.
+// The underlying stream is read in chunks, each checked against inventory with a single query.
+type orgScopedLookupObjectsStream struct {
+	inner         model.ResultStream[model.LookupObjectsItem]
+	repo          model.ResourceRepository
+	orgId         model.OrgId
+	claimsUnowned bool
+
+	buffered []model.LookupObjectsItem
+	err      error
+}
+
+func (s *orgScopedLookupObjectsStream) Recv() (model.LookupObjectsItem, error) {
+	for len(s.buffered) == 0 {
+		if s.err != nil {
+			return model.LookupObjectsItem{}, s.err
+		}
+		s.fill()
+	}
+	item := s.buffered[0]
+	s.buffered = s.buffered[1:]
+	return item, nil
+}
+
+// fill reads the next chunk from the underlying stream and keeps the results of the caller's
+// organization. A read error is held back until the results read before it have been handed out.
+func (s *orgScopedLookupObjectsStream) fill() {
+	var items []model.LookupObjectsItem
+	for len(items) < orgScopeChunkSize {
+		item, err := s.inner.Recv()
+		if err != nil {
+			s.err = err
+			break
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return
+	}
+
+	refs := make([]model.ResourceReference, len(items))
+	for i, item := range items {
+		refs[i] = item.Object()
+	}
+	foreign, err := findForeignResources(s.repo, s.orgId, s.claimsUnowned, refs)
+	if err != nil {
+		s.err = err
+		return
+	}
+	for i, item := range items {
+		if !foreign[i] {
+			s.buffered = append(s.buffered, item)
+		}
+	}
+}
+
+// emptyLookupSubjectsStream answers a lookup of another organization's resource.
+type emptyLookupSubjectsStream struct{}
+
+func (emptyLookupSubjectsStream) Recv() (model.LookupSubjectsItem, error) {
+	return model.LookupSubjectsItem{}, io.EOF
+}
@@ -4,18 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
 	kratosErrors "github.com/go-kratos/kratos/v2/errors"
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/google/uuid"
 	"github.com/project-kessel/inventory-api/cmd/common"
+	"github.com/project-kessel/inventory-api/internal/audit"
 	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/tenancy"
 	"github.com/project-kessel/inventory-api/internal/metricscollector"
 	"github.com/project-kessel/inventory-api/internal/pubsub"
 	"github.com/sony/gobreaker/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
@@ -42,6 +46,13 @@ var (
 
 const listenTimeout = 10 * time.Second
 
+const (
+	// streamedCheckBulkChunkSize bounds the number of items sent to the relations backend per call.
+	streamedCheckBulkChunkSize = 100
+	// streamedCheckBulkMaxInFlight bounds the number of chunks being checked concurrently.
+	streamedCheckBulkMaxInFlight = 4
+)
+
 // UsecaseConfig contains configuration flags that control the behavior of usecase operations.
 // These flags should be consistent across all handlers.
 type UsecaseConfig struct {
@@ -72,6 +83,19 @@ type Usecase struct {
 	Config              *UsecaseConfig
 	MetricsCollector    *metricscollector.MetricsCollector
 	SelfSubjectStrategy SelfSubjectStrategy
+	// Audit records SEC-MON-REQ-1 audit entries; New defaults it to the log.
+	Audit *audit.Auditor
+
+	// ExplainMetaAuthorizer authorizes CheckExplain; when nil, MetaAuthorizer is used.
+	ExplainMetaAuthorizer metaauthorizer.MetaAuthorizer
+	// ReporterBindings restricts the reporter types and instances each client may report and
+	// delete; when nil, any caller allowed by MetaAuthorizer may write any reporter's resources.
+	ReporterBindings *metaauthorizer.ReporterBindings
+	// Tenancy scopes resources to the caller's organization; nil when tenancy is disabled.
+	Tenancy *tenancy.Tenancy
+	// ConsistencyWatermarks resolves at_least_as_acknowledged for LookupObjects; when nil,
+	// those lookups fall back to minimize_latency.
+	ConsistencyWatermarks model.ConsistencyWatermarkRepository
 }
 
 func New(resourceRepository model.ResourceRepository, schemaRepository model.SchemaRepository,
@@ -93,6 +117,7 @@ func New(resourceRepository model.ResourceRepository, schemaRepository model.Sch
 		Config:              usecaseConfig,
 		MetricsCollector:    metricsCollector,
 		SelfSubjectStrategy: selfSubjectStrategy,
+		Audit:               audit.NewLogAuditor(logger),
 	}
 }
 
@@ -112,7 +137,16 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 		log.Error("failed to create reporter resource key: ", err)
 		return status.Errorf(codes.InvalidArgument, "failed to create reporter resource key: %v", err)
 	}
+	orgId, err := uc.Tenancy.OrgIdFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	reporterResourceKey = reporterResourceKey.WithOrgId(orgId)
+	claimsUnowned := uc.Tenancy.ClaimsUnowned(ctx)
 
+	if err := metaauthorizer.EnforceReporterBinding(ctx, uc.ReporterBindings, reporterResourceKey); err != nil {
+		return err
+	}
 	if err := uc.enforceMetaAuthzObject(ctx, metaauthorizer.RelationReportResource, metaauthorizer.NewInventoryResourceFromKey(reporterResourceKey)); err != nil {
 		return err
 	}
@@ -129,7 +163,7 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 	}
 
 	if err := uc.schemaService.ValidateReportAgainstSchema(ctx, cmd.ResourceType, cmd.ReporterType, cmd.CommonRepresentation, cmd.ReporterRepresentation); err != nil {
-		return status.Errorf(codes.InvalidArgument, "failed validation for report resource: %v", err)
+		return schemaValidationError(err)
 	}
 
 	readAfterWriteEnabled := computeReadAfterWrite(uc, cmd.WriteVisibility, authzCtx.Subject.SubjectId)
@@ -159,7 +193,7 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 			ReportResourceOperationName,
 			uc.resourceRepository.GetDB(),
 			func(tx *gorm.DB) error {
-				res, err := uc.resourceRepository.FindResourceByKeys(tx, reporterResourceKey)
+				res, err := findResourceInOrg(tx, uc.resourceRepository, reporterResourceKey, claimsUnowned)
 				if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 					return fmt.Errorf("failed to lookup existing resource: %w", err)
 				}
@@ -167,12 +201,16 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 				if err == nil && res != nil {
 					log.Info("Resource already exists, updating: ")
 					operationType = model.OperationTypeUpdated
-					return uc.updateResource(tx, cmd, res, txid)
+					return uc.updateResource(tx, cmd, orgId, res, txid)
+				}
+
+				if err := uc.schemaService.ValidateRepresentationsForCreate(ctx, cmd.ResourceType, cmd.ReporterType, cmd.CommonRepresentation, cmd.ReporterRepresentation); err != nil {
+					return err
 				}
 
 				log.Info("Creating new resource")
 				operationType = model.OperationTypeCreated
-				return uc.createResource(tx, cmd, txid)
+				return uc.createResource(tx, cmd, orgId, txid)
 			},
 		)
 	}
@@ -189,27 +227,23 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 	}
 
 	if err != nil {
-		// Extract principal for failure logging
-		principal := authzCtx.ExtractPrincipal()
-
 		// CRUD operation failed - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation, EOI-11 warnings_or_errors)
-		uc.Log.Warnw("msg", "Resource operation failed",
-			"action", "REPORT_RESOURCE",
-			"resource_type", cmd.ResourceType.String(),
-			"resource_id", cmd.LocalResourceId,
-			"reporter_type", cmd.ReporterType.String(),
-			"reporter_instance_id", cmd.ReporterInstanceId.String(),
-			"principal", principal,
-			"outcome", "failure",
-			"reason", err.Error(),
-		)
+		uc.Audit.Record(ctx, audit.Event{
+			Message:   "Resource operation failed",
+			Action:    "REPORT_RESOURCE",
+			Principal: authzCtx.ExtractPrincipal(),
+			Resource:  auditResource(reporterResourceKey),
+			Outcome:   audit.OutcomeFailure,
+			Reason:    err.Error(),
+		})
 
+		var violations *model.SchemaViolationsError
+		if errors.As(err, &violations) {
+			return schemaValidationError(violations)
+		}
 		return err
 	}
 
-	// Extract principal for success logging
-	principal := authzCtx.ExtractPrincipal()
-
 	// Determine action based on operation type
 	var action string
 	switch operationType {
@@ -222,15 +256,13 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 	}
 
 	// CRUD operation - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation)
-	uc.Log.Infow("msg", "Resource operation completed",
-		"action", action,
-		"resource_type", cmd.ResourceType.String(),
-		"resource_id", cmd.LocalResourceId,
-		"reporter_type", cmd.ReporterType.String(),
-		"reporter_instance_id", cmd.ReporterInstanceId.String(),
-		"principal", principal,
-		"outcome", "success",
-	)
+	uc.Audit.Record(ctx, audit.Event{
+		Message:   "Resource operation completed",
+		Action:    action,
+		Principal: authzCtx.ExtractPrincipal(),
+		Resource:  auditResource(reporterResourceKey),
+		Outcome:   audit.OutcomeSuccess,
+	})
 
 	// Increment outbox metrics only after successful transaction commit
 	if operationType != nil {
@@ -270,7 +302,31 @@ func (uc *Usecase) ReportResource(ctx context.Context, cmd ReportResourceCommand
 	return nil
 }
 
-func (uc *Usecase) createResource(tx *gorm.DB, cmd ReportResourceCommand, txid model.TransactionId) error {
+// schemaValidationError converts a schema validation failure into an InvalidArgument status.
+// Policy and schema violations are attached as BadRequest field violations, so clients can
+// see every violation rather than only the first.
+func schemaValidationError(err error) error {
+	st := status.Newf(codes.InvalidArgument, "failed validation for report resource: %v", err)
+
+	var violations *model.SchemaViolationsError
+	if !errors.As(err, &violations) {
+		return st.Err()
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range violations.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Message,
+		})
+	}
+	if withDetails, detailsErr := st.WithDetails(badRequest); detailsErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+func (uc *Usecase) createResource(tx *gorm.DB, cmd ReportResourceCommand, orgId model.OrgId, txid model.TransactionId) error {
 	resourceId, err := uc.resourceRepository.NextResourceId()
 	if err != nil {
 		return err
@@ -298,11 +354,15 @@ func (uc *Usecase) createResource(tx *gorm.DB, cmd ReportResourceCommand, txid m
 	if err != nil {
 		return err
 	}
+	resource.AssignOrgId(orgId)
+	if err := ensureResourceIdAvailable(tx, uc.resourceRepository, orgId, cmd); err != nil {
+		return err
+	}
 
 	return uc.resourceRepository.Save(tx, resource, model.OperationTypeCreated, txid)
 }
 
-func (uc *Usecase) updateResource(tx *gorm.DB, cmd ReportResourceCommand, existingResource *model.Resource, txid model.TransactionId) error {
+func (uc *Usecase) updateResource(tx *gorm.DB, cmd ReportResourceCommand, orgId model.OrgId, existingResource *model.Resource, txid model.TransactionId) error {
 	reporterResourceKey, err := model.NewReporterResourceKey(
 		cmd.LocalResourceId,
 		cmd.ResourceType,
@@ -314,7 +374,7 @@ func (uc *Usecase) updateResource(tx *gorm.DB, cmd ReportResourceCommand, existi
 	}
 
 	err = existingResource.Update(
-		reporterResourceKey,
+		reporterResourceKey.WithOrgId(orgId),
 		cmd.ApiHref,
 		cmd.ConsoleHref,
 		cmd.ReporterVersion,
@@ -330,6 +390,17 @@ func (uc *Usecase) updateResource(tx *gorm.DB, cmd ReportResourceCommand, existi
 }
 
 func (uc *Usecase) Delete(ctx context.Context, reporterResourceKey model.ReporterResourceKey) error {
+	// Resources of other organizations are reported as not found.
+	orgId, err := uc.Tenancy.OrgIdFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	reporterResourceKey = reporterResourceKey.WithOrgId(orgId)
+	claimsUnowned := uc.Tenancy.ClaimsUnowned(ctx)
+
+	if err := metaauthorizer.EnforceReporterBinding(ctx, uc.ReporterBindings, reporterResourceKey); err != nil {
+		return err
+	}
 	if err := uc.enforceMetaAuthzObject(ctx, metaauthorizer.RelationDeleteResource, metaauthorizer.NewInventoryResourceFromKey(reporterResourceKey)); err != nil {
 		return err
 	}
@@ -346,7 +417,7 @@ func (uc *Usecase) Delete(ctx context.Context, reporterResourceKey model.Reporte
 		DeleteResourceOperationName,
 		uc.resourceRepository.GetDB(),
 		func(tx *gorm.DB) error {
-			res, err := uc.resourceRepository.FindResourceByKeys(tx, reporterResourceKey)
+			res, err := findResourceInOrg(tx, uc.resourceRepository, reporterResourceKey, claimsUnowned)
 
 			if err == nil && res != nil {
 				log.Info("Found Resource, deleting: ", res)
@@ -364,34 +435,27 @@ func (uc *Usecase) Delete(ctx context.Context, reporterResourceKey model.Reporte
 		},
 	)
 
-	// Extract principal for logging
-	principal := authzCtx.ExtractPrincipal()
-
 	if err != nil {
 		// DELETE operation failed - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation, EOI-11 warnings_or_errors)
-		uc.Log.Warnw("msg", "Delete resource failed",
-			"action", "DELETE",
-			"resource_type", reporterResourceKey.ResourceType().String(),
-			"resource_id", reporterResourceKey.LocalResourceId(),
-			"reporter_type", reporterResourceKey.ReporterType().String(),
-			"reporter_instance_id", reporterResourceKey.ReporterInstanceId().String(),
-			"principal", principal,
-			"outcome", "failure",
-			"reason", err.Error(),
-		)
+		uc.Audit.Record(ctx, audit.Event{
+			Message:   "Delete resource failed",
+			Action:    "DELETE",
+			Principal: authzCtx.ExtractPrincipal(),
+			Resource:  auditResource(reporterResourceKey),
+			Outcome:   audit.OutcomeFailure,
+			Reason:    err.Error(),
+		})
 		return err
 	}
 
 	// DELETE operation - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation)
-	uc.Log.Infow("msg", "Resource deleted",
-		"action", "DELETE",
-		"resource_type", reporterResourceKey.ResourceType().String(),
-		"resource_id", reporterResourceKey.LocalResourceId(),
-		"reporter_type", reporterResourceKey.ReporterType().String(),
-		"reporter_instance_id", reporterResourceKey.ReporterInstanceId().String(),
-		"principal", principal,
-		"outcome", "success",
-	)
+	uc.Audit.Record(ctx, audit.Event{
+		Message:   "Resource deleted",
+		Action:    "DELETE",
+		Principal: authzCtx.ExtractPrincipal(),
+		Resource:  auditResource(reporterResourceKey),
+		Outcome:   audit.OutcomeSuccess,
+	})
 
 	// Increment outbox metrics only after successful transaction commit
 	metricscollector.Incr(uc.MetricsCollector.OutboxEventWrites, string(model.OperationTypeDeleted.OperationType()))
@@ -409,37 +473,49 @@ func (uc *Usecase) Check(ctx context.Context, relation model.Relation, sub model
 	}
 	result, err := uc.checkPermission(ctx, relation, sub, resourceRef, resolved)
 
-	// Get authz context for logging
-	authzCtx, _ := authnapi.FromAuthzContext(ctx)
-	principal := authzCtx.ExtractPrincipal()
-
 	if err != nil {
 		// Operation failed - SEC-MON-REQ-1 compliance (EOI-11 warnings_or_errors)
-		uc.Log.Warnw("msg", "Permission check operation failed",
-			"action", "CHECK",
-			"resource_type", resourceRef.ResourceType().String(),
-			"resource_id", string(resourceRef.ResourceId()),
-			"relation", relation.String(),
-			"principal", principal,
-			"outcome", "failure",
-			"reason", err.Error(),
-		)
+		uc.recordCheck(ctx, "Permission check operation failed", "CHECK", relation, resourceRef, audit.OutcomeFailure, err.Error())
 	} else if !result.Allowed() {
 		// Log permission denials - SEC-MON-REQ-1 compliance (EOI-8 authorization_failure)
-		uc.Log.Warnw("msg", "Permission denied",
-			"event", "authorization_failure",
-			"action", "CHECK",
-			"resource_type", resourceRef.ResourceType().String(),
-			"resource_id", string(resourceRef.ResourceId()),
-			"relation", relation.String(),
-			"principal", principal,
-			"outcome", "failure",
-		)
+		uc.recordCheck(ctx, "Permission denied", "CHECK", relation, resourceRef, audit.OutcomeDenied, "")
 	}
 
 	return result, err
 }
 
+// CheckExplain performs a Check and returns how the result was resolved. It is meta-authorized
+// with the check_explain relation by ExplainMetaAuthorizer rather than by the Check rules.
+func (uc *Usecase) CheckExplain(ctx context.Context, relation model.Relation, sub model.SubjectReference, resourceRef model.ResourceReference, consistency model.Consistency) (model.CheckExplainResult, error) {
+	explainAuthorizer := uc.ExplainMetaAuthorizer
+	if explainAuthorizer == nil {
+		explainAuthorizer = uc.MetaAuthorizer
+	}
+	metaObject := metaauthorizer.NewInventoryResource(resourceRef.Reporter().ReporterType(), resourceRef.ResourceType(), resourceRef.ResourceId())
+	if err := metaauthorizer.EnforceMetaAuthzObject(ctx, explainAuthorizer, metaauthorizer.RelationCheckExplain, metaObject); err != nil {
+		return model.CheckExplainResult{}, err
+	}
+	resolved, err := uc.resolveConsistency(ctx, consistency, resourceRef, false)
+	if err != nil {
+		return model.CheckExplainResult{}, err
+	}
+	rel := model.NewRelationship(resourceRef, relation, sub)
+	foreign, err := uc.isForeignResource(ctx, resourceRef)
+	if err != nil {
+		return model.CheckExplainResult{}, err
+	}
+	if foreign {
+		trace := model.NewCheckTrace(rel, model.CheckTraceKindUnspecified, false, "", false, nil)
+		return model.NewCheckExplainResult(model.NewCheckResult(false, ""), trace), nil
+	}
+	result, err := uc.Relations.CheckExplain(ctx, rel, resolved)
+	if err != nil {
+		// Operation failed - SEC-MON-REQ-1 compliance (EOI-11 warnings_or_errors)
+		uc.recordCheck(ctx, "Permission check explain operation failed", "CHECK_EXPLAIN", relation, resourceRef, audit.OutcomeFailure, err.Error())
+	}
+	return result, err
+}
+
 // CheckSelf verifies access for the authenticated user using the self-subject strategy.
 func (uc *Usecase) CheckSelf(ctx context.Context, relation model.Relation, resourceRef model.ResourceReference, consistency model.Consistency) (model.CheckResult, error) {
 	if err := uc.enforceMetaAuthzObject(ctx, metaauthorizer.RelationCheckSelf, metaauthorizer.NewInventoryResource(resourceRef.Reporter().ReporterType(), resourceRef.ResourceType(), resourceRef.ResourceId())); err != nil {
@@ -455,32 +531,12 @@ func (uc *Usecase) CheckSelf(ctx context.Context, relation model.Relation, resou
 	}
 	result, err := uc.checkPermission(ctx, relation, subjectRef, resourceRef, resolved)
 
-	// Get authz context for logging
-	authzCtx, _ := authnapi.FromAuthzContext(ctx)
-	principal := authzCtx.ExtractPrincipal()
-
 	if err != nil {
 		// Operation failed - SEC-MON-REQ-1 compliance (EOI-11 warnings_or_errors)
-		uc.Log.Warnw("msg", "Self permission check operation failed",
-			"action", "CHECK_SELF",
-			"resource_type", resourceRef.ResourceType().String(),
-			"resource_id", string(resourceRef.ResourceId()),
-			"relation", relation.String(),
-			"principal", principal,
-			"outcome", "failure",
-			"reason", err.Error(),
-		)
+		uc.recordCheck(ctx, "Self permission check operation failed", "CHECK_SELF", relation, resourceRef, audit.OutcomeFailure, err.Error())
 	} else if !result.Allowed() {
 		// Log permission denials - SEC-MON-REQ-1 compliance (EOI-8 authorization_failure)
-		uc.Log.Warnw("msg", "Self permission denied",
-			"event", "authorization_failure",
-			"action", "CHECK_SELF",
-			"resource_type", resourceRef.ResourceType().String(),
-			"resource_id", string(resourceRef.ResourceId()),
-			"relation", relation.String(),
-			"principal", principal,
-			"outcome", "failure",
-		)
+		uc.recordCheck(ctx, "Self permission denied", "CHECK_SELF", relation, resourceRef, audit.OutcomeDenied, "")
 	}
 
 	return result, err
@@ -492,6 +548,14 @@ func (uc *Usecase) CheckForUpdate(ctx context.Context, relation model.Relation,
 		return model.CheckResult{}, err
 	}
 
+	foreign, err := uc.isForeignResource(ctx, resourceRef)
+	if err != nil {
+		return model.CheckResult{}, err
+	}
+	if foreign {
+		return model.NewCheckResult(false, ""), nil
+	}
+
 	rel := model.NewRelationship(resourceRef, relation, sub)
 	return uc.Relations.CheckForUpdate(ctx, rel)
 }
@@ -506,7 +570,9 @@ func (uc *Usecase) CheckForUpdateBulk(ctx context.Context, cmd CheckForUpdateBul
 	}
 
 	rels := checkBulkItemsToRelationships(cmd.Items)
-	result, err := uc.Relations.CheckForUpdateBulk(ctx, rels)
+	result, err := uc.checkBulkInOrg(ctx, rels, func(rels []model.Relationship) (model.CheckBulkResult, error) {
+		return uc.Relations.CheckForUpdateBulk(ctx, rels)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -529,7 +595,9 @@ func (uc *Usecase) CheckBulk(ctx context.Context, cmd CheckBulkCommand) (*CheckB
 	}
 
 	rels := checkBulkItemsToRelationships(cmd.Items)
-	result, err := uc.Relations.CheckBulk(ctx, rels, cmd.Consistency)
+	result, err := uc.checkBulkInOrg(ctx, rels, func(rels []model.Relationship) (model.CheckBulkResult, error) {
+		return uc.Relations.CheckBulk(ctx, rels, cmd.Consistency)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -539,6 +607,119 @@ func (uc *Usecase) CheckBulk(ctx context.Context, cmd CheckBulkCommand) (*CheckB
 	return checkBulkResultFromModel(result), nil
 }
 
+// StreamedCheckBulk performs bulk permission checks over an incrementally received
+// stream of items. Items are checked in chunks of at most streamedCheckBulkChunkSize,
+// with up to streamedCheckBulkMaxInFlight chunks in flight, and results are sent in
+// request order. A chunk that fails is reported as per-item errors rather than failing
+// the stream. The returned token is the last one reported by the relations backend.
+func (uc *Usecase) StreamedCheckBulk(ctx context.Context, cmd StreamedCheckBulkCommand) (model.ConsistencyToken, error) {
+	if model.ConsistencyTypeOf(cmd.Consistency) == model.ConsistencyAtLeastAsAcknowledged {
+		return "", status.Errorf(codes.InvalidArgument, "inventory-managed consistency tokens aren't available")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Each chunk gets its own result channel; queueing them in order lets chunks
+	// complete out of order while results are still sent in request order.
+	pending := make(chan chan streamedCheckBulkChunk, streamedCheckBulkMaxInFlight)
+	sendDone := make(chan error, 1)
+	var token model.ConsistencyToken
+	go func() {
+		var err error
+		for result := range pending {
+			chunk := <-result
+			if err != nil || ctx.Err() != nil {
+				continue
+			}
+			if chunk.token != "" {
+				token = chunk.token
+			}
+			if err = cmd.Send(chunk.pairs); err != nil {
+				cancel()
+			}
+		}
+		sendDone <- err
+	}()
+
+	recvErr := uc.dispatchStreamedCheckBulk(ctx, cmd, pending)
+	if recvErr != nil {
+		cancel()
+	}
+	close(pending)
+	if err := <-sendDone; err != nil {
+		return "", err
+	}
+	if recvErr != nil {
+		return "", recvErr
+	}
+	return token, nil
+}
+
+// streamedCheckBulkChunk holds the results of checking one chunk of a streamed bulk check.
+type streamedCheckBulkChunk struct {
+	pairs []CheckBulkResultPair
+	token model.ConsistencyToken
+}
+
+// dispatchStreamedCheckBulk reads items from cmd.Next and starts a check for each chunk,
+// queueing its result channel on pending. It returns nil once cmd.Next returns io.EOF.
+func (uc *Usecase) dispatchStreamedCheckBulk(ctx context.Context, cmd StreamedCheckBulkCommand, pending chan<- chan streamedCheckBulkChunk) error {
+	for {
+		items, err := cmd.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err := uc.enforceMetaAuthzObject(ctx, metaauthorizer.RelationCheckBulk, metaauthorizer.NewInventoryResource(item.Resource.Reporter().ReporterType(), item.Resource.ResourceType(), item.Resource.ResourceId())); err != nil {
+				uc.Log.WithContext(ctx).Errorf("meta authz failed for streamed check bulk item: %v error: %v", item.Resource, err)
+				return err
+			}
+		}
+
+		for start := 0; start < len(items); start += streamedCheckBulkChunkSize {
+			chunk := items[start:min(start+streamedCheckBulkChunkSize, len(items))]
+			result := make(chan streamedCheckBulkChunk, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			go func() {
+				result <- uc.checkBulkChunk(ctx, chunk, cmd.Consistency)
+			}()
+		}
+	}
+}
+
+// checkBulkChunk checks a single chunk of a streamed bulk check. Backend failures are
+// attributed to every item in the chunk so the rest of the stream can proceed.
+func (uc *Usecase) checkBulkChunk(ctx context.Context, items []CheckBulkItem, consistency model.Consistency) streamedCheckBulkChunk {
+	rels := checkBulkItemsToRelationships(items)
+	result, err := uc.checkBulkInOrg(ctx, rels, func(rels []model.Relationship) (model.CheckBulkResult, error) {
+		return uc.Relations.CheckBulk(ctx, rels, consistency)
+	})
+	if err == nil {
+		err = validateBulkResultLength(len(rels), len(result.Pairs()))
+	}
+	if err != nil {
+		uc.Log.WithContext(ctx).Errorf("streamed check bulk chunk of %d items failed: %v", len(items), err)
+		pairs := make([]CheckBulkResultPair, len(items))
+		for i, item := range items {
+			pairs[i] = CheckBulkResultPair{
+				Request: item,
+				Result:  CheckBulkResultItem{Error: err, ErrorCode: int32(status.Code(err))},
+			}
+		}
+		return streamedCheckBulkChunk{pairs: pairs}
+	}
+	converted := checkBulkResultFromModel(result)
+	return streamedCheckBulkChunk{pairs: converted.Pairs, token: converted.ConsistencyToken}
+}
+
 // CheckSelfBulk performs bulk permission checks for the authenticated user.
 func (uc *Usecase) CheckSelfBulk(ctx context.Context, cmd CheckSelfBulkCommand) (*CheckBulkResult, error) {
 	if model.ConsistencyTypeOf(cmd.Consistency) == model.ConsistencyAtLeastAsAcknowledged {
@@ -561,7 +742,9 @@ func (uc *Usecase) CheckSelfBulk(ctx context.Context, cmd CheckSelfBulkCommand)
 		rels[i] = model.NewRelationship(item.Resource, item.Relation, subjectRef)
 	}
 
-	result, err := uc.Relations.CheckBulk(ctx, rels, cmd.Consistency)
+	result, err := uc.checkBulkInOrg(ctx, rels, func(rels []model.Relationship) (model.CheckBulkResult, error) {
+		return uc.Relations.CheckBulk(ctx, rels, cmd.Consistency)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -571,17 +754,25 @@ func (uc *Usecase) CheckSelfBulk(ctx context.Context, cmd CheckSelfBulkCommand)
 	return checkBulkResultFromModel(result), nil
 }
 
-// checkPermission runs Relations.Check with the resolved consistency.
+// checkPermission runs Relations.Check with the resolved consistency. Resources of another
+// organization are answered as denied.
 func (uc *Usecase) checkPermission(ctx context.Context, relation model.Relation, sub model.SubjectReference, resourceRef model.ResourceReference, consistency model.Consistency) (model.CheckResult, error) {
+	foreign, err := uc.isForeignResource(ctx, resourceRef)
+	if err != nil {
+		return model.CheckResult{}, err
+	}
+	if foreign {
+		return model.NewCheckResult(false, ""), nil
+	}
 	rel := model.NewRelationship(resourceRef, relation, sub)
 	return uc.Relations.Check(ctx, rel, consistency)
 }
 
 // LookupObjects delegates resource lookup to the authorization service.
+// At_least_as_acknowledged resolves to the consumer's watermark for the subject, or, as the
+// consumer only records watermarks for the subjects of replicated tuples, for the reporter type of
+// the objects or the global one.
 func (uc *Usecase) LookupObjects(ctx context.Context, cmd LookupObjectsCommand) (model.ResultStream[model.LookupObjectsItem], error) {
-	if model.ConsistencyTypeOf(cmd.Consistency) == model.ConsistencyAtLeastAsAcknowledged {
-		return nil, status.Errorf(codes.InvalidArgument, "inventory-managed consistency tokens aren't available")
-	}
 	var reporterType model.ReporterType
 	if cmd.ObjectType.HasReporterType() {
 		reporterType = *cmd.ObjectType.ReporterType()
@@ -591,19 +782,74 @@ func (uc *Usecase) LookupObjects(ctx context.Context, cmd LookupObjectsCommand)
 		return nil, err
 	}
 
-	return uc.Relations.LookupObjects(ctx, cmd.ObjectType, cmd.Relation, cmd.Subject, cmd.Pagination, cmd.Consistency)
+	consistency := cmd.Consistency
+	if model.ConsistencyTypeOf(consistency) == model.ConsistencyAtLeastAsAcknowledged {
+		var err error
+		scopes := []model.WatermarkScope{model.NewSubjectWatermarkScope(cmd.Subject)}
+		if reporterType != "" {
+			scopes = append(scopes, model.NewReporterTypeWatermarkScope(reporterType))
+		}
+		scopes = append(scopes, model.GlobalWatermarkScope)
+		consistency, err = uc.resolveFromWatermark(ctx, scopes...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	orgId, err := uc.Tenancy.OrgIdFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := uc.Relations.LookupObjects(ctx, cmd.ObjectType, cmd.Relation, cmd.Subject, cmd.Pagination, consistency)
+	if err != nil || orgId == "" {
+		return stream, err
+	}
+	return &orgScopedLookupObjectsStream{inner: stream, repo: uc.resourceRepository, orgId: orgId, claimsUnowned: uc.Tenancy.ClaimsUnowned(ctx)}, nil
 }
 
 // LookupSubjects delegates subject lookup to the authorization service.
+// At_least_as_acknowledged resolves to the resource's consistency token, as for Check.
 func (uc *Usecase) LookupSubjects(ctx context.Context, cmd LookupSubjectsCommand) (model.ResultStream[model.LookupSubjectsItem], error) {
-	if model.ConsistencyTypeOf(cmd.Consistency) == model.ConsistencyAtLeastAsAcknowledged {
-		return nil, status.Errorf(codes.InvalidArgument, "inventory-managed consistency tokens aren't available")
-	}
 	if err := uc.enforceMetaAuthzObject(ctx, metaauthorizer.RelationLookupSubjects, metaauthorizer.NewInventoryResource(cmd.Resource.Reporter().ReporterType(), cmd.Resource.ResourceType(), cmd.Resource.ResourceId())); err != nil {
 		return nil, err
 	}
 
-	return uc.Relations.LookupSubjects(ctx, cmd.Resource, cmd.Relation, cmd.SubjectType, cmd.SubjectRelation, cmd.Pagination, cmd.Consistency)
+	consistency := cmd.Consistency
+	if model.ConsistencyTypeOf(consistency) == model.ConsistencyAtLeastAsAcknowledged {
+		var err error
+		consistency, err = uc.resolveFromDB(ctx, cmd.Resource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	foreign, err := uc.isForeignResource(ctx, cmd.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if foreign {
+		return emptyLookupSubjectsStream{}, nil
+	}
+
+	return uc.Relations.LookupSubjects(ctx, cmd.Resource, cmd.Relation, cmd.SubjectType, cmd.SubjectRelation, cmd.Pagination, consistency)
+}
+
+// GetConsistencyWatermark returns the watermark of the latest replicated write for the reporter type,
+// or across all reporters when reporterType is nil. The watermark is empty if nothing has been replicated.
+func (uc *Usecase) GetConsistencyWatermark(ctx context.Context, reporterType *model.ReporterType) (model.ConsistencyWatermark, error) {
+	if err := uc.enforceMetaAuthzObject(ctx, metaauthorizer.RelationGetConsistencyWatermark, metaauthorizer.NewConsistencyWatermarkRef(reporterType)); err != nil {
+		return model.ConsistencyWatermark{}, err
+	}
+	if uc.ConsistencyWatermarks == nil {
+		return model.ConsistencyWatermark{}, status.Error(codes.Unimplemented, "consistency watermarks are not available")
+	}
+
+	scope := model.GlobalWatermarkScope
+	if reporterType != nil {
+		scope = model.NewReporterTypeWatermarkScope(*reporterType)
+	}
+	// Passing nil tx is deliberate: this read-only consistency lookup should not run in a transaction.
+	return uc.ConsistencyWatermarks.Find(nil, scope)
 }
 
 // lookupConsistencyTokenFromDB looks up the consistency token from the inventory database.
@@ -614,8 +860,15 @@ func (uc *Usecase) lookupConsistencyTokenFromDB(ctx context.Context, resourceRef
 	if err != nil {
 		return "", fmt.Errorf("failed to build reporter resource key from reference: %w", err)
 	}
+	orgId, err := uc.Tenancy.OrgIdFromContext(ctx)
+	if err != nil {
+		// Callers without an organization can't see any resource's token, but may still check.
+		uc.Log.WithContext(ctx).Debugf("No organization for consistency lookup, falling back to minimize_latency: %v", err)
+		return "", nil
+	}
+	reporterResourceKey = reporterResourceKey.WithOrgId(orgId)
 	// Passing nil tx is deliberate: this read-only consistency lookup should not run in a transaction.
-	res, err := uc.resourceRepository.FindResourceByKeys(nil, reporterResourceKey)
+	res, err := findResourceInOrg(nil, uc.resourceRepository, reporterResourceKey, uc.Tenancy.ClaimsUnowned(ctx))
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Resource doesn't exist in inventory, fall back to minimize_latency.
@@ -681,6 +934,29 @@ func (uc *Usecase) resolveFromDB(ctx context.Context, resourceRef model.Resource
 	return model.NewConsistencyAtLeastAsFresh(model.DeserializeConsistencyToken(tokenStr)), nil
 }
 
+// resolveFromWatermark looks up the consumer's consistency watermark for the first of the scopes
+// that has one recorded and returns a model.Consistency. Falls back to minimize_latency when
+// watermarks aren't configured or none has been recorded for any of the scopes.
+func (uc *Usecase) resolveFromWatermark(ctx context.Context, scopes ...model.WatermarkScope) (model.Consistency, error) {
+	if uc.ConsistencyWatermarks == nil {
+		uc.Log.WithContext(ctx).Warnf("Consistency watermarks not configured, falling back to minimize_latency for scopes: %v", scopes)
+		return model.NewConsistencyMinimizeLatency(), nil
+	}
+	for _, scope := range scopes {
+		// Passing nil tx is deliberate: this read-only consistency lookup should not run in a transaction.
+		watermark, err := uc.ConsistencyWatermarks.Find(nil, scope)
+		if err != nil {
+			return nil, err
+		}
+		if !watermark.IsEmpty() {
+			uc.Log.WithContext(ctx).Debugf("Found consistency watermark for scope: %s", scope)
+			return model.NewConsistencyAtLeastAsFresh(watermark.Token()), nil
+		}
+	}
+	uc.Log.WithContext(ctx).Warnf("No consistency watermark recorded, falling back to minimize_latency for scopes: %v", scopes)
+	return model.NewConsistencyMinimizeLatency(), nil
+}
+
 func (uc *Usecase) selfSubjectFromContext(ctx context.Context) (model.SubjectReference, error) {
 	authzCtx, ok := authnapi.FromAuthzContext(ctx)
 	if !ok {
@@ -696,6 +972,38 @@ func (uc *Usecase) selfSubjectFromContext(ctx context.Context) (model.SubjectRef
 	return subjectRef, nil
 }
 
+// recordCheck audits the outcome of a check of relation on resourceRef by the caller.
+func (uc *Usecase) recordCheck(ctx context.Context, message, action string, relation model.Relation, resourceRef model.ResourceReference, outcome audit.Outcome, reason string) {
+	authzCtx, _ := authnapi.FromAuthzContext(ctx)
+	resource := audit.Resource{
+		ResourceType: resourceRef.ResourceType().String(),
+		ResourceId:   resourceRef.ResourceId().String(),
+	}
+	if reporter := resourceRef.Reporter(); reporter != nil {
+		resource.ReporterType = reporter.ReporterType().String()
+	}
+	uc.Audit.Record(ctx, audit.Event{
+		Message:   message,
+		Action:    action,
+		Principal: authzCtx.ExtractPrincipal(),
+		Resource:  resource,
+		Relation:  relation.String(),
+		Outcome:   outcome,
+		Reason:    reason,
+	})
+}
+
+// auditResource returns the audited resource of a reporter resource key.
+func auditResource(key model.ReporterResourceKey) audit.Resource {
+	return audit.Resource{
+		ResourceType:       key.ResourceType().String(),
+		ResourceId:         key.LocalResourceId().String(),
+		ReporterType:       key.ReporterType().String(),
+		ReporterInstanceId: key.ReporterInstanceId().String(),
+		OrgId:              key.OrgId().String(),
+	}
+}
+
 // enforceMetaAuthzObject calls the MetaAuthorizer to validate access using a MetaObject.
 func (uc *Usecase) enforceMetaAuthzObject(ctx context.Context, relation metaauthorizer.Relation, metaObject metaauthorizer.MetaObject) error {
 	return metaauthorizer.EnforceMetaAuthzObject(ctx, uc.MetaAuthorizer, relation, metaObject)
@@ -3,22 +3,28 @@ package resources
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/go-kratos/kratos/v2/transport"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 
+	"github.com/project-kessel/inventory-api/internal/audit"
 	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/tenancy"
 	"github.com/project-kessel/inventory-api/internal/data"
 	"github.com/project-kessel/inventory-api/internal/metricscollector"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -247,6 +253,273 @@ func TestDelete_UsesDeleteRelation(t *testing.T) {
 	assert.Equal(t, []metaauthorizer.Relation{metaauthorizer.RelationDeleteResource}, h.meta.relations)
 }
 
+func TestReportResource_ReporterBindings(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+	h.usecase.ReporterBindings = metaauthorizer.NewReporterBindings([]metaauthorizer.ReporterBindingOptions{
+		{ClientId: "acs", ReporterTypes: []string{"acs"}},
+		{ClientId: "hbi", ReporterTypes: []string{"hbi"}},
+	})
+	ctx := authnapi.NewAuthzContext(context.Background(), authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject:  &authnapi.Claims{SubjectId: "svc-acs", ClientID: "acs", AuthType: authnapi.AuthTypeOIDC},
+	})
+
+	err := h.usecase.ReportResource(ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1"))
+	assert.ErrorIs(t, err, metaauthorizer.ErrReporterNotBound)
+	assert.EqualError(t, err, `client "acs" is not bound to reporter_type "hbi"`)
+	assert.Equal(t, 0, h.meta.calls, "bindings are enforced before meta authorization")
+
+	err = h.usecase.Delete(ctx, createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1"))
+	assert.ErrorIs(t, err, metaauthorizer.ErrReporterNotBound)
+	assert.Equal(t, 0, h.meta.calls)
+
+	hbiCtx := authnapi.NewAuthzContext(context.Background(), authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject:  &authnapi.Claims{SubjectId: "svc-hbi", ClientID: "hbi", AuthType: authnapi.AuthTypeOIDC},
+	})
+	err = h.usecase.ReportResource(hbiCtx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1"))
+	require.NoError(t, err)
+	assert.Equal(t, 1, h.meta.calls)
+}
+
+func TestReportResource_Tenancy(t *testing.T) {
+	h := newTestHarness(t)
+	tenancyOpts := tenancy.NewOptions()
+	tenancyOpts.Enabled = true
+	tenancyConfig, errs := tenancy.NewConfig(tenancyOpts).Complete()
+	require.Empty(t, errs)
+	h.usecase.Tenancy = tenancy.New(tenancyConfig)
+
+	orgCtx := func(orgId string) context.Context {
+		return authnapi.NewAuthzContext(context.Background(), authnapi.AuthzContext{
+			Protocol: authnapi.ProtocolGRPC,
+			Subject: &authnapi.Claims{
+				SubjectId:      "svc-hbi",
+				ClientID:       "hbi",
+				OrganizationId: authnapi.OrganizationId(orgId),
+				AuthType:       authnapi.AuthTypeOIDC,
+			},
+		})
+	}
+
+	err := h.usecase.ReportResource(orgCtx("org-a"), fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1"))
+	require.NoError(t, err)
+	err = h.usecase.ReportResource(orgCtx("org-b"), fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-2"))
+	assert.ErrorIs(t, err, model.ErrResourceAlreadyExists, "organizations can't share a local resource ID")
+
+	key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+	orgA, err := h.resourceRepo.FindResourceByKeys(nil, key.WithOrgId("org-a"))
+	require.NoError(t, err)
+	assert.Equal(t, model.OrgId("org-a"), orgA.ReporterResources()[0].OrgId())
+	_, err = h.resourceRepo.FindResourceByKeys(nil, key.WithOrgId("org-b"))
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	err = h.usecase.Delete(orgCtx("org-c"), key)
+	assert.ErrorIs(t, err, ErrResourceNotFound, "resources of other organizations are not found")
+
+	err = h.usecase.ReportResource(orgCtx(""), fixture(t).Basic("host", "hbi", "instance-1", "host-2", "workspace-1"))
+	assert.ErrorIs(t, err, tenancy.ErrOrgRequired)
+
+	require.NoError(t, h.usecase.Delete(orgCtx("org-a"), key))
+	orgA, err = h.resourceRepo.FindResourceByKeys(nil, key.WithOrgId("org-a"))
+	require.NoError(t, err)
+	assert.True(t, orgA.ReporterResources()[0].Serialize().Tombstone)
+
+	err = h.usecase.ReportResource(orgCtx("org-b"), fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-2"))
+	assert.NoError(t, err, "a deleted resource's ID is free for another organization")
+}
+
+// orgHeaderTransporter carries the tenancy org header of a request.
+type orgHeaderTransporter struct {
+	transport.Transporter
+	orgId string
+}
+
+func (o orgHeaderTransporter) RequestHeader() transport.Header {
+	header := transport.Header(headerCarrier{})
+	header.Set(tenancy.DefaultOrgHeader, o.orgId)
+	return header
+}
+
+type headerCarrier map[string]string
+
+func (h headerCarrier) Get(key string) string      { return h[key] }
+func (h headerCarrier) Set(key, value string)      { h[key] = value }
+func (h headerCarrier) Add(key, value string)      { h[key] = value }
+func (h headerCarrier) Keys() []string             { return nil }
+func (h headerCarrier) Values(key string) []string { return []string{h[key]} }
+
+func TestReportResource_TenancyAdminClaimsResourcesWithoutOrganization(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+	// Reported while tenancy was disabled, as before the organization migration.
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1")))
+	key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+	legacy, err := h.resourceRepo.FindResourceByKeys(nil, key)
+	require.NoError(t, err)
+	require.Equal(t, model.OrgId(""), legacy.ReporterResources()[0].OrgId())
+
+	tenancyOpts := tenancy.NewOptions()
+	tenancyOpts.Enabled = true
+	tenancyOpts.AdminClientIds = []string{"tenancy-admin"}
+	tenancyConfig, errs := tenancy.NewConfig(tenancyOpts).Complete()
+	require.Empty(t, errs)
+	h.usecase.Tenancy = tenancy.New(tenancyConfig)
+	callerCtx := func(clientId, orgId string) context.Context {
+		return authnapi.NewAuthzContext(context.Background(), authnapi.AuthzContext{
+			Protocol: authnapi.ProtocolGRPC,
+			Subject: &authnapi.Claims{
+				SubjectId:      authnapi.SubjectId("svc-" + clientId),
+				ClientID:       authnapi.ClientID(clientId),
+				OrganizationId: authnapi.OrganizationId(orgId),
+				AuthType:       authnapi.AuthTypeOIDC,
+			},
+		})
+	}
+	orgCtx := func(orgId string) context.Context { return callerCtx("hbi", orgId) }
+	adminCtx := transport.NewServerContext(callerCtx("tenancy-admin", "admin-org"), orgHeaderTransporter{orgId: "org-a"})
+
+	// Until it is claimed, the resource is out of reach of the organizations' own clients.
+	err = h.usecase.ReportResource(orgCtx("org-a"), fixture(t).Updated("host", "hbi", "instance-1", "host-1", "workspace-2"))
+	assert.ErrorIs(t, err, model.ErrResourceAlreadyExists, "reporting doesn't claim the resource")
+	assert.ErrorIs(t, h.usecase.Delete(orgCtx("org-a"), key), ErrResourceNotFound)
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+	result, err := h.usecase.Check(orgCtx("org-a"), relation, subject, resourceRefFromKey(key), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.False(t, result.Allowed(), "checks on resources without an organization are denied")
+
+	// An admin client naming the organization claims it.
+	require.NoError(t, h.usecase.ReportResource(adminCtx, fixture(t).Updated("host", "hbi", "instance-1", "host-1", "workspace-2")))
+	claimed, err := h.resourceRepo.FindResourceByKeys(nil, key.WithOrgId("org-a"))
+	require.NoError(t, err)
+	assert.Equal(t, legacy.ReporterResources()[0].Id(), claimed.ReporterResources()[0].Id(),
+		"the admin client claims the resource instead of creating a duplicate")
+
+	require.NoError(t, h.usecase.ReportResource(orgCtx("org-a"), fixture(t).Updated("host", "hbi", "instance-1", "host-1", "workspace-3")))
+	err = h.usecase.ReportResource(orgCtx("org-b"), fixture(t).Updated("host", "hbi", "instance-1", "host-1", "workspace-3"))
+	assert.ErrorIs(t, err, model.ErrResourceAlreadyExists)
+	require.NoError(t, h.usecase.Delete(orgCtx("org-a"), key))
+}
+
+func TestReadPaths_Tenancy(t *testing.T) {
+	simpleAuthz := data.NewSimpleRelationsRepository()
+	simpleAuthz.Grant("user-1", "view", "hbi", "host", "host-1")
+	simpleAuthz.Grant("user-1", "view", "hbi", "host", "host-2")
+	h := newTestHarness(t, withMeta(true), withRelations(simpleAuthz))
+	tenancyOpts := tenancy.NewOptions()
+	tenancyOpts.Enabled = true
+	tenancyConfig, errs := tenancy.NewConfig(tenancyOpts).Complete()
+	require.Empty(t, errs)
+	h.usecase.Tenancy = tenancy.New(tenancyConfig)
+
+	orgCtx := func(orgId string) context.Context {
+		return authnapi.NewAuthzContext(context.Background(), authnapi.AuthzContext{
+			Protocol: authnapi.ProtocolGRPC,
+			Subject: &authnapi.Claims{
+				SubjectId:      "svc-hbi",
+				ClientID:       "hbi",
+				OrganizationId: authnapi.OrganizationId(orgId),
+				AuthType:       authnapi.AuthTypeOIDC,
+			},
+		})
+	}
+	require.NoError(t, h.usecase.ReportResource(orgCtx("org-a"), fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1")))
+	require.NoError(t, h.usecase.ReportResource(orgCtx("org-b"), fixture(t).Basic("host", "hbi", "instance-1", "host-2", "workspace-2")))
+
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+	orgAHost := resourceRefFromKey(createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1"))
+	orgBHost := resourceRefFromKey(createReporterResourceKey(t, "host-2", "host", "hbi", "instance-1"))
+
+	result, err := h.usecase.Check(orgCtx("org-a"), relation, subject, orgAHost, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.True(t, result.Allowed(), "checks within the organization are answered by relations")
+	result, err = h.usecase.Check(orgCtx("org-b"), relation, subject, orgAHost, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.False(t, result.Allowed(), "checks on another organization's resource are denied")
+
+	bulk, err := h.usecase.CheckBulk(orgCtx("org-b"), CheckBulkCommand{
+		Items: []CheckBulkItem{
+			{Resource: orgAHost, Relation: relation, Subject: subject},
+			{Resource: orgBHost, Relation: relation, Subject: subject},
+		},
+		Consistency: model.NewConsistencyUnspecified(),
+	})
+	require.NoError(t, err)
+	require.Len(t, bulk.Pairs, 2)
+	assert.False(t, bulk.Pairs[0].Result.Allowed)
+	assert.Equal(t, orgAHost, bulk.Pairs[0].Request.Resource)
+	assert.True(t, bulk.Pairs[1].Result.Allowed)
+
+	objects, err := h.usecase.LookupObjects(orgCtx("org-b"), hostLookupCommand(t))
+	require.NoError(t, err)
+	var ids []string
+	for {
+		item, err := objects.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		ids = append(ids, item.Object().ResourceId().String())
+	}
+	assert.Equal(t, []string{"host-2"}, ids)
+
+	principal, err := model.NewResourceType("principal")
+	require.NoError(t, err)
+	rbac, err := model.NewReporterType("rbac")
+	require.NoError(t, err)
+	subjects, err := h.usecase.LookupSubjects(orgCtx("org-b"), LookupSubjectsCommand{
+		Resource:    orgAHost,
+		Relation:    relation,
+		SubjectType: model.NewRepresentationTypeRequired(principal, rbac),
+		Consistency: model.NewConsistencyUnspecified(),
+	})
+	require.NoError(t, err)
+	_, err = subjects.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+type recordingAuditSink struct {
+	entries []audit.Entry
+}
+
+func (s *recordingAuditSink) Write(_ context.Context, entry audit.Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *recordingAuditSink) Close() error { return nil }
+
+func TestReportResource_Audits(t *testing.T) {
+	h := newTestHarness(t)
+	sink := &recordingAuditSink{}
+	h.usecase.Audit = audit.NewAuditor([]audit.Sink{sink}, log.DefaultLogger)
+
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1")))
+	require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Updated("host", "hbi", "instance-1", "host-1", "workspace-2")))
+	key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+	require.NoError(t, h.usecase.Delete(h.ctx, key))
+	missing := createReporterResourceKey(t, "host-2", "host", "hbi", "instance-1")
+	require.ErrorIs(t, h.usecase.Delete(h.ctx, missing), ErrResourceNotFound)
+	require.NoError(t, h.usecase.Audit.Close())
+
+	require.Len(t, sink.entries, 4)
+	var actions []string
+	for _, entry := range sink.entries {
+		actions = append(actions, entry.Action)
+	}
+	assert.Equal(t, []string{"CREATE", "UPDATE", "DELETE", "DELETE"}, actions)
+	assert.Equal(t, audit.Resource{ResourceType: "host", ResourceId: "host-1", ReporterType: "hbi", ReporterInstanceId: "instance-1"}, sink.entries[0].Resource)
+	assert.Equal(t, audit.OutcomeSuccess, sink.entries[2].Outcome)
+	assert.Equal(t, audit.OutcomeFailure, sink.entries[3].Outcome)
+	assert.Equal(t, ErrResourceNotFound.Error(), sink.entries[3].Reason)
+	assert.NoError(t, audit.Verify(nil, sink.entries))
+}
+
 func TestCheck_UsesCheckRelation(t *testing.T) {
 	h := newTestHarness(t, withMeta(true))
 
@@ -263,6 +536,53 @@ func TestCheck_UsesCheckRelation(t *testing.T) {
 	assert.Equal(t, []metaauthorizer.Relation{metaauthorizer.RelationCheck}, h.meta.relations)
 }
 
+func TestCheckExplain_UsesExplainMetaAuthorizer(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+	explainMeta := &recordingMetaAuthorizer{allowed: true}
+	h.usecase.ExplainMetaAuthorizer = explainMeta
+
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+
+	result, err := h.usecase.CheckExplain(h.ctx, relation, subject, resourceRefFromKey(key), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+	assert.Equal(t, relation, result.Trace().Relationship().Relation())
+	assert.Equal(t, 0, h.meta.calls)
+	assert.Equal(t, []metaauthorizer.Relation{metaauthorizer.RelationCheckExplain}, explainMeta.relations)
+}
+
+func TestCheckExplain_DeniedByExplainMetaAuthz(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+	h.usecase.ExplainMetaAuthorizer = &recordingMetaAuthorizer{allowed: false}
+
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+
+	_, err = h.usecase.CheckExplain(h.ctx, relation, subject, resourceRefFromKey(key), model.NewConsistencyUnspecified())
+	assert.ErrorIs(t, err, metaauthorizer.ErrMetaAuthorizationDenied)
+}
+
+func TestCheckExplain_FallsBackToMetaAuthorizer(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+
+	_, err = h.usecase.CheckExplain(h.ctx, relation, subject, resourceRefFromKey(key), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.Equal(t, []metaauthorizer.Relation{metaauthorizer.RelationCheckExplain}, h.meta.relations)
+}
+
 func TestCheckForUpdate_UsesCheckForUpdateRelation(t *testing.T) {
 	h := newTestHarness(t, withMeta(true))
 
@@ -1567,6 +1887,104 @@ func TestReportResource_ValidationErrorFormat(t *testing.T) {
 	}
 }
 
+func TestReportResource_SchemaPolicy(t *testing.T) {
+	ctx := testAuthzContext()
+
+	newPolicyUsecase := func(t *testing.T, policy model.SchemaPolicy) *Usecase {
+		t.Helper()
+		schemaRepository := data.NewInMemorySchemaRepository()
+		rt, err := model.NewResourceType("host")
+		require.NoError(t, err)
+		rpt, err := model.NewReporterType("hbi")
+		require.NoError(t, err)
+
+		resourceSchema, err := model.NewResourceSchemaRepresentation(rt, data.NewJsonSchemaWithWorkspacesFromString(`{
+			"type": "object",
+			"properties": { "workspace_id": { "type": "string" } }
+		}`))
+		require.NoError(t, err)
+		require.NoError(t, schemaRepository.CreateResourceSchema(context.Background(), resourceSchema))
+
+		reporterSchema, err := model.NewReporterSchemaRepresentation(rt, rpt, data.NewJsonSchemaWithWorkspacesFromString(`{
+			"type": "object",
+			"properties": { "satellite_id": { "type": "string" } }
+		}`))
+		require.NoError(t, err)
+		require.NoError(t, schemaRepository.CreateReporterSchema(context.Background(), reporterSchema.WithPolicy(policy)))
+
+		return New(
+			data.NewFakeResourceRepository(),
+			schemaRepository,
+			&data.AllowAllRelationsRepository{},
+			"test-topic",
+			log.DefaultLogger,
+			nil, nil,
+			NewUsecaseConfig(),
+			metricscollector.NewFakeMetricsCollector(),
+			nil,
+			newTestSelfSubjectStrategy(),
+		)
+	}
+
+	fieldViolations := func(t *testing.T, err error) map[string]string {
+		t.Helper()
+		st, ok := status.FromError(err)
+		require.True(t, ok)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+
+		violations := map[string]string{}
+		for _, detail := range st.Details() {
+			if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+				for _, violation := range badRequest.GetFieldViolations() {
+					violations[violation.GetField()] = violation.GetDescription()
+				}
+			}
+		}
+		return violations
+	}
+
+	t.Run("every violation is listed in the error details", func(t *testing.T) {
+		uc := newPolicyUsecase(t, model.SchemaPolicy{RejectUnknownProperties: true, MaxRepresentationBytes: 64})
+		cmd := fixture(t).WithData("host", "hbi", "instance-1", "host-1",
+			map[string]interface{}{"satellite_id": "sat-1", "extra": "x"},
+			map[string]interface{}{"workspace_id": strings.Repeat("w", 64)},
+		)
+
+		err := uc.ReportResource(ctx, cmd)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed validation for report resource")
+
+		violations := fieldViolations(t, err)
+		assert.Len(t, violations, 2)
+		assert.Contains(t, violations, "representations.reporter.extra")
+		assert.Contains(t, violations["representations.common"], "exceeding the maximum of 64 bytes")
+	})
+
+	t.Run("required representations are only enforced on create", func(t *testing.T) {
+		uc := newPolicyUsecase(t, model.SchemaPolicy{RequireCommonOnCreate: true, RequireReporterOnCreate: true})
+
+		reporterOnly := fixture(t).WithData("host", "hbi", "instance-1", "host-2",
+			map[string]interface{}{"satellite_id": "sat-1"},
+			map[string]interface{}{"workspace_id": "ws-1"},
+		)
+		reporterOnly.CommonRepresentation = nil
+		err := uc.ReportResource(ctx, reporterOnly)
+		require.Error(t, err)
+		violations := fieldViolations(t, err)
+		assert.Len(t, violations, 1)
+		assert.Contains(t, violations["representations.common"], "is required when a resource of type 'host' is first reported")
+
+		both := fixture(t).WithData("host", "hbi", "instance-1", "host-2",
+			map[string]interface{}{"satellite_id": "sat-1"},
+			map[string]interface{}{"workspace_id": "ws-1"},
+		)
+		require.NoError(t, uc.ReportResource(ctx, both))
+
+		reporterOnly.TransactionId = nil
+		assert.NoError(t, uc.ReportResource(ctx, reporterOnly))
+	})
+}
+
 func TestResolveConsistency(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -1919,6 +2337,133 @@ func TestCheckBulk_RelationsMixedResults(t *testing.T) {
 	assert.Equal(t, 2, h.meta.calls)
 }
 
+// failingCheckBulkRelations fails any CheckBulk call that includes failID.
+type failingCheckBulkRelations struct {
+	model.RelationsRepository
+	failID string
+}
+
+func (r *failingCheckBulkRelations) CheckBulk(ctx context.Context, rels []model.Relationship, consistency model.Consistency) (model.CheckBulkResult, error) {
+	for _, rel := range rels {
+		if rel.Object().ResourceId().String() == r.failID {
+			return model.CheckBulkResult{}, status.Error(codes.Unavailable, "backend unavailable")
+		}
+	}
+	return r.RelationsRepository.CheckBulk(ctx, rels, consistency)
+}
+
+// streamedCheckBulkItems builds n view checks for user-1 on hosts host-0..host-(n-1).
+func streamedCheckBulkItems(t *testing.T, n int) []CheckBulkItem {
+	t.Helper()
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	relation, err := model.NewRelation("view")
+	require.NoError(t, err)
+	items := make([]CheckBulkItem, n)
+	for i := range items {
+		key := createReporterResourceKey(t, fmt.Sprintf("host-%d", i), "host", "hbi", "instance-1")
+		items[i] = CheckBulkItem{Resource: resourceRefFromKey(key), Relation: relation, Subject: subject}
+	}
+	return items
+}
+
+// runStreamedCheckBulk feeds batches to StreamedCheckBulk and collects everything it sends.
+func runStreamedCheckBulk(h *testHarness, consistency model.Consistency, batches ...[]CheckBulkItem) ([]CheckBulkResultPair, model.ConsistencyToken, error) {
+	var sent []CheckBulkResultPair
+	token, err := h.usecase.StreamedCheckBulk(h.ctx, StreamedCheckBulkCommand{
+		Consistency: consistency,
+		Next: func() ([]CheckBulkItem, error) {
+			if len(batches) == 0 {
+				return nil, io.EOF
+			}
+			batch := batches[0]
+			batches = batches[1:]
+			return batch, nil
+		},
+		Send: func(pairs []CheckBulkResultPair) error {
+			sent = append(sent, pairs...)
+			return nil
+		},
+	})
+	return sent, token, err
+}
+
+func TestStreamedCheckBulk_ResultsInRequestOrder(t *testing.T) {
+	simpleAuthz := data.NewSimpleRelationsRepository()
+	for i := 0; i < 350; i += 2 {
+		simpleAuthz.Grant("user-1", "view", "hbi", "host", fmt.Sprintf("host-%d", i))
+	}
+	h := newTestHarness(t, withMeta(true), withRelations(simpleAuthz))
+
+	items := streamedCheckBulkItems(t, 350)
+	sent, token, err := runStreamedCheckBulk(h, model.NewConsistencyMinimizeLatency(), items[:230], items[230:])
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	require.Len(t, sent, len(items))
+	for i, pair := range sent {
+		assert.Equal(t, items[i].Resource.ResourceId(), pair.Request.Resource.ResourceId())
+		assert.Equal(t, i%2 == 0, pair.Result.Allowed, "item %d", i)
+		assert.NoError(t, pair.Result.Error)
+	}
+	assert.Equal(t, len(items), h.meta.calls)
+}
+
+func TestStreamedCheckBulk_ChunkFailureReportedPerItem(t *testing.T) {
+	h := newTestHarness(t, withMeta(true), withRelations(&failingCheckBulkRelations{
+		RelationsRepository: &data.AllowAllRelationsRepository{},
+		failID:              "host-150",
+	}))
+
+	items := streamedCheckBulkItems(t, 250)
+	sent, _, err := runStreamedCheckBulk(h, model.NewConsistencyMinimizeLatency(), items)
+	require.NoError(t, err)
+	require.Len(t, sent, len(items))
+	for i, pair := range sent {
+		if i >= 100 && i < 200 {
+			require.Error(t, pair.Result.Error, "item %d", i)
+			assert.Equal(t, int32(codes.Unavailable), pair.Result.ErrorCode)
+			continue
+		}
+		assert.NoError(t, pair.Result.Error, "item %d", i)
+		assert.True(t, pair.Result.Allowed, "item %d", i)
+	}
+}
+
+func TestStreamedCheckBulk_MetaAuthzDenied(t *testing.T) {
+	h := newTestHarness(t, withMeta(false))
+
+	_, _, err := runStreamedCheckBulk(h, model.NewConsistencyMinimizeLatency(), streamedCheckBulkItems(t, 3))
+	assert.ErrorIs(t, err, metaauthorizer.ErrMetaAuthorizationDenied)
+	assert.Equal(t, 1, h.meta.calls)
+	assert.Equal(t, []metaauthorizer.Relation{metaauthorizer.RelationCheckBulk}, h.meta.relations)
+}
+
+func TestStreamedCheckBulk_SendErrorStopsStream(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+
+	sendErr := errors.New("client went away")
+	items := streamedCheckBulkItems(t, 10)
+	_, err := h.usecase.StreamedCheckBulk(h.ctx, StreamedCheckBulkCommand{
+		Consistency: model.NewConsistencyMinimizeLatency(),
+		Next: func() ([]CheckBulkItem, error) {
+			return items, nil
+		},
+		Send: func([]CheckBulkResultPair) error {
+			return sendErr
+		},
+	})
+	assert.ErrorIs(t, err, sendErr)
+}
+
+func TestStreamedCheckBulk_RejectsInventoryManagedConsistency(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+
+	_, _, err := runStreamedCheckBulk(h, model.NewConsistencyAtLeastAsAcknowledged(), streamedCheckBulkItems(t, 1))
+	require.Error(t, err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 0, h.meta.calls)
+}
+
 func TestLookupObjects_StreamResults(t *testing.T) {
 	type grant struct {
 		subjectID, resourceID string
@@ -2046,3 +2591,235 @@ func TestLookupSubjects_StreamResults(t *testing.T) {
 		})
 	}
 }
+
+// consistencyRecordingRelations records the consistency each lookup reaches the backend with.
+type consistencyRecordingRelations struct {
+	model.RelationsRepository
+	consistencies []model.Consistency
+}
+
+func (r *consistencyRecordingRelations) LookupObjects(ctx context.Context, objectType model.RepresentationType, relation model.Relation, subject model.SubjectReference, pagination *model.Pagination, consistency model.Consistency) (model.ResultStream[model.LookupObjectsItem], error) {
+	r.consistencies = append(r.consistencies, consistency)
+	return r.RelationsRepository.LookupObjects(ctx, objectType, relation, subject, pagination, consistency)
+}
+
+func (r *consistencyRecordingRelations) LookupSubjects(ctx context.Context, object model.ResourceReference, relation model.Relation, subjectType model.RepresentationType, subjectRelation *model.Relation, pagination *model.Pagination, consistency model.Consistency) (model.ResultStream[model.LookupSubjectsItem], error) {
+	r.consistencies = append(r.consistencies, consistency)
+	return r.RelationsRepository.LookupSubjects(ctx, object, relation, subjectType, subjectRelation, pagination, consistency)
+}
+
+func lookupObjectsCommand(t *testing.T, consistency model.Consistency) LookupObjectsCommand {
+	t.Helper()
+	subject, err := buildTestSubjectReference("user-1")
+	require.NoError(t, err)
+	objectType := model.NewRepresentationTypeRequired(model.DeserializeResourceType("host"), model.DeserializeReporterType("hbi"))
+	return LookupObjectsCommand{
+		ObjectType:  objectType,
+		Relation:    model.DeserializeRelation("view"),
+		Subject:     subject,
+		Consistency: consistency,
+	}
+}
+
+func TestLookupObjects_AtLeastAsAcknowledged(t *testing.T) {
+	tests := []struct {
+		name         string
+		watermarks   bool
+		watermark    model.ConsistencyToken
+		expectedType model.ConsistencyType
+	}{
+		{"uses the subject watermark", true, "subject-watermark", model.ConsistencyAtLeastAsFresh},
+		{"no watermark recorded falls back to minimize_latency", true, "", model.ConsistencyMinimizeLatency},
+		{"watermarks not configured falls back to minimize_latency", false, "", model.ConsistencyMinimizeLatency},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relations := &consistencyRecordingRelations{RelationsRepository: data.NewSimpleRelationsRepository()}
+			h := newTestHarness(t, withMeta(true), withRelations(relations))
+			cmd := lookupObjectsCommand(t, model.NewConsistencyAtLeastAsAcknowledged())
+			if tt.watermarks {
+				watermarks := data.NewFakeConsistencyWatermarkRepository()
+				require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{model.NewSubjectWatermarkScope(cmd.Subject)}, tt.watermark, time.Now()))
+				h.usecase.ConsistencyWatermarks = watermarks
+			}
+
+			_, err := h.usecase.LookupObjects(h.ctx, cmd)
+			require.NoError(t, err)
+
+			require.Len(t, relations.consistencies, 1)
+			assert.Equal(t, tt.expectedType, model.ConsistencyTypeOf(relations.consistencies[0]))
+			if tt.expectedType == model.ConsistencyAtLeastAsFresh {
+				assert.Equal(t, tt.watermark, *model.ConsistencyAtLeastAsFreshToken(relations.consistencies[0]))
+			}
+		})
+	}
+}
+
+func TestLookupObjects_AtLeastAsAcknowledged_IgnoresOtherSubjects(t *testing.T) {
+	relations := &consistencyRecordingRelations{RelationsRepository: data.NewSimpleRelationsRepository()}
+	h := newTestHarness(t, withMeta(true), withRelations(relations))
+	other, err := buildTestSubjectReference("user-2")
+	require.NoError(t, err)
+	watermarks := data.NewFakeConsistencyWatermarkRepository()
+	require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{model.NewSubjectWatermarkScope(other)}, "other-watermark", time.Now()))
+	h.usecase.ConsistencyWatermarks = watermarks
+
+	_, err = h.usecase.LookupObjects(h.ctx, lookupObjectsCommand(t, model.NewConsistencyAtLeastAsAcknowledged()))
+	require.NoError(t, err)
+
+	require.Len(t, relations.consistencies, 1)
+	assert.Equal(t, model.ConsistencyMinimizeLatency, model.ConsistencyTypeOf(relations.consistencies[0]))
+}
+
+func TestLookupObjects_AtLeastAsAcknowledged_PrincipalFallsBackToBroaderWatermarks(t *testing.T) {
+	hbi := model.DeserializeReporterType("hbi")
+	acs := model.DeserializeReporterType("acs")
+	tests := []struct {
+		name          string
+		scopes        map[model.WatermarkScope]model.ConsistencyToken
+		expectedToken model.ConsistencyToken
+	}{
+		{
+			name: "reporter type of the objects",
+			scopes: map[model.WatermarkScope]model.ConsistencyToken{
+				model.GlobalWatermarkScope:               "global-watermark",
+				model.NewReporterTypeWatermarkScope(hbi): "hbi-watermark",
+			},
+			expectedToken: "hbi-watermark",
+		},
+		{
+			name: "global without one for the reporter type",
+			scopes: map[model.WatermarkScope]model.ConsistencyToken{
+				model.GlobalWatermarkScope:               "global-watermark",
+				model.NewReporterTypeWatermarkScope(acs): "acs-watermark",
+			},
+			expectedToken: "global-watermark",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relations := &consistencyRecordingRelations{RelationsRepository: data.NewSimpleRelationsRepository()}
+			h := newTestHarness(t, withMeta(true), withRelations(relations))
+			// The consumer records watermarks for the subjects of replicated tuples, such as
+			// workspaces, but never for the principals lookups are run for.
+			cmd := lookupObjectsCommand(t, model.NewConsistencyAtLeastAsAcknowledged())
+			require.Equal(t, "principal", cmd.Subject.Resource().ResourceType().Serialize())
+			watermarks := data.NewFakeConsistencyWatermarkRepository()
+			for scope, token := range tt.scopes {
+				require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{scope}, token, time.Now()))
+			}
+			h.usecase.ConsistencyWatermarks = watermarks
+
+			_, err := h.usecase.LookupObjects(h.ctx, cmd)
+			require.NoError(t, err)
+
+			require.Len(t, relations.consistencies, 1)
+			require.Equal(t, model.ConsistencyAtLeastAsFresh, model.ConsistencyTypeOf(relations.consistencies[0]))
+			assert.Equal(t, tt.expectedToken, *model.ConsistencyAtLeastAsFreshToken(relations.consistencies[0]))
+		})
+	}
+}
+
+func TestLookupObjects_AtLeastAsAcknowledged_DeniedBeforeWatermarkLookup(t *testing.T) {
+	relations := &consistencyRecordingRelations{RelationsRepository: data.NewSimpleRelationsRepository()}
+	h := newTestHarness(t, withMeta(false), withRelations(relations))
+	h.usecase.ConsistencyWatermarks = data.NewFakeConsistencyWatermarkRepository()
+
+	_, err := h.usecase.LookupObjects(h.ctx, lookupObjectsCommand(t, model.NewConsistencyAtLeastAsAcknowledged()))
+	require.Error(t, err)
+	assert.Empty(t, relations.consistencies)
+}
+
+func TestLookupObjects_OtherConsistencyPassesThrough(t *testing.T) {
+	relations := &consistencyRecordingRelations{RelationsRepository: data.NewSimpleRelationsRepository()}
+	h := newTestHarness(t, withMeta(true), withRelations(relations))
+	h.usecase.ConsistencyWatermarks = data.NewFakeConsistencyWatermarkRepository()
+
+	_, err := h.usecase.LookupObjects(h.ctx, lookupObjectsCommand(t, model.NewConsistencyUnspecified()))
+	require.NoError(t, err)
+
+	require.Len(t, relations.consistencies, 1)
+	assert.Equal(t, model.ConsistencyUnspecified, model.ConsistencyTypeOf(relations.consistencies[0]))
+}
+
+func TestLookupSubjects_AtLeastAsAcknowledged(t *testing.T) {
+	tests := []struct {
+		name           string
+		resourceExists bool
+	}{
+		{"resource exists", true},
+		{"resource not found falls back to minimize_latency", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			relations := &consistencyRecordingRelations{RelationsRepository: data.NewSimpleRelationsRepository()}
+			h := newTestHarness(t, withMeta(true), withRelations(relations))
+			if tt.resourceExists {
+				require.NoError(t, h.usecase.ReportResource(h.ctx, fixture(t).Basic("host", "hbi", "instance-1", "host-1", "workspace-1")))
+			}
+
+			key := createReporterResourceKey(t, "host-1", "host", "hbi", "instance-1")
+			subjectType := model.NewRepresentationTypeRequired(model.DeserializeResourceType("principal"), model.DeserializeReporterType("rbac"))
+			_, err := h.usecase.LookupSubjects(h.ctx, LookupSubjectsCommand{
+				Resource:    resourceRefFromKey(key),
+				Relation:    model.DeserializeRelation("view"),
+				SubjectType: subjectType,
+				Consistency: model.NewConsistencyAtLeastAsAcknowledged(),
+			})
+			require.NoError(t, err)
+
+			// The fake resource repository stores no consistency token, so both resolve to minimize_latency.
+			require.Len(t, relations.consistencies, 1)
+			assert.Equal(t, model.ConsistencyMinimizeLatency, model.ConsistencyTypeOf(relations.consistencies[0]))
+		})
+	}
+}
+
+func TestGetConsistencyWatermark(t *testing.T) {
+	hbi := model.DeserializeReporterType("hbi")
+	acm := model.DeserializeReporterType("acm")
+	replicatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		reporterType  *model.ReporterType
+		expectedToken model.ConsistencyToken
+	}{
+		{"global", nil, "global-watermark"},
+		{"reporter type", &hbi, "hbi-watermark"},
+		{"reporter type without replicated writes", &acm, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHarness(t, withMeta(true))
+			watermarks := data.NewFakeConsistencyWatermarkRepository()
+			require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{model.GlobalWatermarkScope}, "global-watermark", replicatedAt))
+			require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{model.NewReporterTypeWatermarkScope(hbi)}, "hbi-watermark", replicatedAt))
+			h.usecase.ConsistencyWatermarks = watermarks
+
+			watermark, err := h.usecase.GetConsistencyWatermark(h.ctx, tt.reporterType)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedToken, watermark.Token())
+			assert.Equal(t, []metaauthorizer.Relation{metaauthorizer.RelationGetConsistencyWatermark}, h.meta.relations)
+		})
+	}
+}
+
+func TestGetConsistencyWatermark_DeniedByMetaAuthz(t *testing.T) {
+	h := newTestHarness(t, withMeta(false))
+	h.usecase.ConsistencyWatermarks = data.NewFakeConsistencyWatermarkRepository()
+
+	_, err := h.usecase.GetConsistencyWatermark(h.ctx, nil)
+	assert.ErrorIs(t, err, metaauthorizer.ErrMetaAuthorizationDenied)
+}
+
+func TestGetConsistencyWatermark_NotConfigured(t *testing.T) {
+	h := newTestHarness(t, withMeta(true))
+
+	_, err := h.usecase.GetConsistencyWatermark(h.ctx, nil)
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,35 @@
+package resources
+
+import (
+	"errors"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// ChainSelfSubjectStrategy derives the subject with the first of its strategies that succeeds.
+type ChainSelfSubjectStrategy struct {
+	strategies []SelfSubjectStrategy
+}
+
+// NewChainSelfSubjectStrategy chains strategies in the order given.
+func NewChainSelfSubjectStrategy(strategies ...SelfSubjectStrategy) *ChainSelfSubjectStrategy {
+	return &ChainSelfSubjectStrategy{strategies: strategies}
+}
+
+// SubjectFromAuthorizationContext returns the subject of the first strategy that derives one, or the
+// errors of all strategies.
+func (c *ChainSelfSubjectStrategy) SubjectFromAuthorizationContext(authzContext authnapi.AuthzContext) (model.SubjectReference, error) {
+	var errs []error
+	for _, strategy := range c.strategies {
+		subjectRef, err := strategy.SubjectFromAuthorizationContext(authzContext)
+		if err == nil {
+			return subjectRef, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return model.SubjectReference{}, errors.New("no self-subject strategies configured")
+	}
+	return model.SubjectReference{}, errors.Join(errs...)
+}
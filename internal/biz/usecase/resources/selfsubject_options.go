@@ -4,11 +4,29 @@ import (
 	"fmt"
 
 	"github.com/spf13/pflag"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+// Self-subject strategy names, as used in SelfSubjectOptions.Chain.
+const (
+	SelfSubjectStrategyRedHatRbac = "redhatRbac"
+	SelfSubjectStrategyTemplate   = "template"
+)
+
+// Template rule defaults, matching the subjects of the Red Hat RBAC strategy.
+const (
+	DefaultSelfSubjectNamespace   = "rbac"
+	DefaultSelfSubjectSubjectType = "principal"
 )
 
 // SelfSubjectOptions holds settings for self-subject derivation strategies.
 type SelfSubjectOptions struct {
 	RedHatRbac *RedHatRbacOptions `mapstructure:"redhatRbacSelfSubjectStrategy"`
+	Template   *TemplateOptions   `mapstructure:"templateSelfSubjectStrategy"`
+	// Chain orders the enabled strategies; the first one that derives a subject is used. When set, it
+	// must list every enabled strategy. Defaults to redhatRbac, then template.
+	Chain []string `mapstructure:"chain"`
 }
 
 // RedHatRbacOptions configures the Red Hat RBAC strategy.
@@ -25,10 +43,31 @@ type OIDCIssuerDomainEntry struct {
 	Domain string `mapstructure:"domain"`
 }
 
+// TemplateOptions configures the claim-template strategy.
+type TemplateOptions struct {
+	Enabled bool                  `mapstructure:"enabled"`
+	Rules   []TemplateRuleOptions `mapstructure:"rules"`
+
+	// rules are the Rules with parsed templates, set by Complete.
+	rules []TemplateSelfSubjectRule
+}
+
+// TemplateRuleOptions maps the claims of requests with one of AuthTypes and Issuers (empty matches
+// all) onto a subject. SubjectId, Namespace and SubjectType are Go templates over the claims, e.g.
+// "{{.Issuer | domain}}/{{.SubjectId}}".
+type TemplateRuleOptions struct {
+	AuthTypes   []string `mapstructure:"authTypes"`
+	Issuers     []string `mapstructure:"issuers"`
+	SubjectId   string   `mapstructure:"subjectId"`
+	Namespace   string   `mapstructure:"namespace"`
+	SubjectType string   `mapstructure:"subjectType"`
+}
+
 // NewSelfSubjectOptions returns a new SelfSubjectOptions with default values.
 func NewSelfSubjectOptions() *SelfSubjectOptions {
 	return &SelfSubjectOptions{
 		RedHatRbac: &RedHatRbacOptions{},
+		Template:   &TemplateOptions{},
 	}
 }
 
@@ -40,6 +79,8 @@ func (o *SelfSubjectOptions) AddFlags(fs *pflag.FlagSet, prefix string) {
 
 	fs.BoolVar(&o.RedHatRbac.Enabled, prefix+"redhat-rbac.enabled", o.RedHatRbac.Enabled, "Enable Red Hat RBAC self-subject strategy")
 	fs.StringVar(&o.RedHatRbac.XRhIdentityDomain, prefix+"redhat-rbac.issuer-domain", o.RedHatRbac.XRhIdentityDomain, "Domain for x-rh-identity subjects")
+	fs.BoolVar(&o.Template.Enabled, prefix+"template.enabled", o.Template.Enabled, "Enable claim-template self-subject strategy (rules are set in the config file)")
+	fs.StringSliceVar(&o.Chain, prefix+"chain", o.Chain, "Order in which enabled self-subject strategies are tried (redhatRbac, template)")
 }
 
 // Validate checks that the configuration is valid.
@@ -55,12 +96,53 @@ func (o *SelfSubjectOptions) Validate() []error {
 		}
 	}
 
+	if o.Template != nil && o.Template.Enabled && len(o.Template.Rules) == 0 {
+		errs = append(errs, fmt.Errorf("selfsubject.template.rules is required when enabled"))
+	}
+
+	seen := map[string]bool{}
+	for _, name := range o.Chain {
+		switch {
+		case name != SelfSubjectStrategyRedHatRbac && name != SelfSubjectStrategyTemplate:
+			errs = append(errs, fmt.Errorf("selfsubject.chain: unknown strategy %q", name))
+		case seen[name]:
+			errs = append(errs, fmt.Errorf("selfsubject.chain: duplicate strategy %q", name))
+		case !o.strategyEnabled(name):
+			errs = append(errs, fmt.Errorf("selfsubject.chain: strategy %q is not enabled", name))
+		}
+		seen[name] = true
+	}
+	if len(o.Chain) > 0 {
+		for _, name := range []string{SelfSubjectStrategyRedHatRbac, SelfSubjectStrategyTemplate} {
+			if o.strategyEnabled(name) && !seen[name] {
+				errs = append(errs, fmt.Errorf("selfsubject.chain: enabled strategy %q is missing", name))
+			}
+		}
+	}
+
 	return errs
 }
 
+func (o *SelfSubjectOptions) strategyEnabled(name string) bool {
+	switch name {
+	case SelfSubjectStrategyRedHatRbac:
+		return o.RedHatRbac != nil && o.RedHatRbac.Enabled
+	case SelfSubjectStrategyTemplate:
+		return o.Template != nil && o.Template.Enabled
+	default:
+		return false
+	}
+}
+
 // Complete finalizes the configuration.
 func (o *SelfSubjectOptions) Complete() []error {
-	if o == nil || o.RedHatRbac == nil {
+	if o == nil {
+		return nil
+	}
+	if errs := o.Template.complete(); len(errs) > 0 {
+		return errs
+	}
+	if o.RedHatRbac == nil {
 		return nil
 	}
 
@@ -79,6 +161,54 @@ func (o *SelfSubjectOptions) Complete() []error {
 	return nil
 }
 
+// complete parses the templates of the rules.
+func (o *TemplateOptions) complete() []error {
+	if o == nil || !o.Enabled {
+		return nil
+	}
+
+	var errs []error
+	o.rules = make([]TemplateSelfSubjectRule, 0, len(o.Rules))
+	for i, r := range o.Rules {
+		rule, err := r.parse()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("selfsubject.template.rules[%d]: %w", i, err))
+			continue
+		}
+		o.rules = append(o.rules, rule)
+	}
+	return errs
+}
+
+func (r TemplateRuleOptions) parse() (TemplateSelfSubjectRule, error) {
+	if r.SubjectId == "" {
+		return TemplateSelfSubjectRule{}, fmt.Errorf("subjectId is required")
+	}
+	namespace, subjectType := r.Namespace, r.SubjectType
+	if namespace == "" {
+		namespace = DefaultSelfSubjectNamespace
+	}
+	if subjectType == "" {
+		subjectType = DefaultSelfSubjectSubjectType
+	}
+
+	rule := TemplateSelfSubjectRule{Issuers: r.Issuers}
+	for _, authType := range r.AuthTypes {
+		rule.AuthTypes = append(rule.AuthTypes, authnapi.AuthType(authType))
+	}
+	var err error
+	if rule.SubjectId, err = ParseSelfSubjectTemplate("subjectId", r.SubjectId); err != nil {
+		return TemplateSelfSubjectRule{}, fmt.Errorf("invalid subjectId template: %w", err)
+	}
+	if rule.Namespace, err = ParseSelfSubjectTemplate("namespace", namespace); err != nil {
+		return TemplateSelfSubjectRule{}, fmt.Errorf("invalid namespace template: %w", err)
+	}
+	if rule.SubjectType, err = ParseSelfSubjectTemplate("subjectType", subjectType); err != nil {
+		return TemplateSelfSubjectRule{}, fmt.Errorf("invalid subjectType template: %w", err)
+	}
+	return rule, nil
+}
+
 func buildOIDCIssuerDomainMap(entries []OIDCIssuerDomainEntry) (map[string]string, error) {
 	out := make(map[string]string)
 	for _, entry := range entries {
@@ -93,15 +223,40 @@ func buildOIDCIssuerDomainMap(entries []OIDCIssuerDomainEntry) (map[string]strin
 	return out, nil
 }
 
-// Build constructs the configured SelfSubjectStrategy or returns nil when disabled.
+// Build constructs the configured SelfSubjectStrategy, chaining the enabled strategies when there
+// are several, or returns nil when all are disabled.
 func (o *SelfSubjectOptions) Build() SelfSubjectStrategy {
-	if o == nil || o.RedHatRbac == nil || !o.RedHatRbac.Enabled {
+	if o == nil {
 		return nil
 	}
 
-	return NewRedHatRbacSelfSubjectStrategy(RedHatRbacSelfSubjectStrategyConfig{
-		Enabled:             o.RedHatRbac.Enabled,
-		XRhIdentityDomain:   o.RedHatRbac.XRhIdentityDomain,
-		OIDCIssuerDomainMap: o.RedHatRbac.OIDCIssuerDomainMap,
-	})
+	chain := o.Chain
+	if len(chain) == 0 {
+		chain = []string{SelfSubjectStrategyRedHatRbac, SelfSubjectStrategyTemplate}
+	}
+	var strategies []SelfSubjectStrategy
+	for _, name := range chain {
+		if !o.strategyEnabled(name) {
+			continue
+		}
+		switch name {
+		case SelfSubjectStrategyRedHatRbac:
+			strategies = append(strategies, NewRedHatRbacSelfSubjectStrategy(RedHatRbacSelfSubjectStrategyConfig{
+				Enabled:             o.RedHatRbac.Enabled,
+				XRhIdentityDomain:   o.RedHatRbac.XRhIdentityDomain,
+				OIDCIssuerDomainMap: o.RedHatRbac.OIDCIssuerDomainMap,
+			}))
+		case SelfSubjectStrategyTemplate:
+			strategies = append(strategies, NewTemplateSelfSubjectStrategy(o.Template.rules))
+		}
+	}
+
+	switch len(strategies) {
+	case 0:
+		return nil
+	case 1:
+		return strategies[0]
+	default:
+		return NewChainSelfSubjectStrategy(strategies...)
+	}
 }
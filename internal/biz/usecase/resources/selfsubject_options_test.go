@@ -57,6 +57,28 @@ func TestSelfSubjectOptions_Validate_EnabledWithDomainPasses(t *testing.T) {
 	assert.Empty(t, errs)
 }
 
+func TestSelfSubjectOptions_Validate_ChainListsEveryEnabledStrategy(t *testing.T) {
+	opts := NewSelfSubjectOptions()
+	opts.RedHatRbac.Enabled = true
+	opts.RedHatRbac.XRhIdentityDomain = "redhat"
+	opts.RedHatRbac.OIDCIssuerDomainMap = map[string]string{
+		"https://sso.redhat.com/auth/realms/redhat-external": "redhat",
+	}
+	opts.Template.Enabled = true
+	opts.Template.Rules = []TemplateRuleOptions{{SubjectId: "{{.SubjectId}}"}}
+
+	opts.Chain = []string{SelfSubjectStrategyTemplate}
+	errs := opts.Validate()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), `enabled strategy "redhatRbac" is missing`)
+
+	opts.Chain = []string{SelfSubjectStrategyTemplate, SelfSubjectStrategyRedHatRbac}
+	assert.Empty(t, opts.Validate())
+
+	opts.Chain = nil
+	assert.Empty(t, opts.Validate())
+}
+
 func TestSelfSubjectOptions_Build_DisabledReturnsNil(t *testing.T) {
 	opts := NewSelfSubjectOptions()
 	opts.RedHatRbac.Enabled = false
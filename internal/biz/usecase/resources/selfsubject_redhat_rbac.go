@@ -94,15 +94,20 @@ func (s *RedHatRbacSelfSubjectStrategy) resolveOIDCIssuerDomain(issuer string) s
 // buildSubjectReference creates a SubjectReference for RBAC authorization.
 // Uses fixed values: namespace="rbac", resource type="principal".
 func buildSubjectReference(subjectID string) (model.SubjectReference, error) {
+	return buildTypedSubjectReference("rbac", "principal", subjectID)
+}
+
+// buildTypedSubjectReference creates a SubjectReference for a subject of the given namespace and type.
+func buildTypedSubjectReference(namespace, subjectType, subjectID string) (model.SubjectReference, error) {
 	localResourceId, err := model.NewLocalResourceId(subjectID)
 	if err != nil {
 		return model.SubjectReference{}, fmt.Errorf("invalid subject ID: %w", err)
 	}
-	resourceType, err := model.NewResourceType("principal")
+	resourceType, err := model.NewResourceType(subjectType)
 	if err != nil {
 		return model.SubjectReference{}, fmt.Errorf("invalid resource type: %w", err)
 	}
-	reporterType, err := model.NewReporterType("rbac")
+	reporterType, err := model.NewReporterType(namespace)
 	if err != nil {
 		return model.SubjectReference{}, fmt.Errorf("invalid reporter type: %w", err)
 	}
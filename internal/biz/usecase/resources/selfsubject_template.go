@@ -0,0 +1,122 @@
+package resources
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"text/template"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// selfSubjectTemplateFuncs are the functions available to self-subject templates, e.g.
+// {{.Issuer | domain}}/{{.SubjectId}}.
+var selfSubjectTemplateFuncs = template.FuncMap{
+	// domain returns the host name of a URL such as an OIDC issuer, or the value itself if it has none.
+	"domain": func(value string) string {
+		if u, err := url.Parse(value); err == nil && u.Hostname() != "" {
+			return u.Hostname()
+		}
+		return value
+	},
+	"lower":      strings.ToLower,
+	"upper":      strings.ToUpper,
+	"trimPrefix": func(prefix, value string) string { return strings.TrimPrefix(value, prefix) },
+	"trimSuffix": func(suffix, value string) string { return strings.TrimSuffix(value, suffix) },
+	"replace":    func(old, new, value string) string { return strings.ReplaceAll(value, old, new) },
+}
+
+// selfSubjectTemplateData is what self-subject templates are rendered against.
+type selfSubjectTemplateData struct {
+	SubjectId      string
+	OrganizationId string
+	Issuer         string
+	ClientID       string
+	AuthType       string
+}
+
+func newSelfSubjectTemplateData(claims *authnapi.Claims) selfSubjectTemplateData {
+	return selfSubjectTemplateData{
+		SubjectId:      strings.TrimSpace(string(claims.SubjectId)),
+		OrganizationId: string(claims.OrganizationId),
+		Issuer:         string(claims.Issuer),
+		ClientID:       string(claims.ClientID),
+		AuthType:       string(claims.AuthType),
+	}
+}
+
+// ParseSelfSubjectTemplate parses a self-subject template, and checks that it only refers to known
+// claims by rendering it against sample claims.
+func ParseSelfSubjectTemplate(name, text string) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(selfSubjectTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sample := newSelfSubjectTemplateData(&authnapi.Claims{
+		SubjectId: "sub", OrganizationId: "org", Issuer: "https://issuer.example.com", ClientID: "client", AuthType: authnapi.AuthTypeOIDC,
+	})
+	if err := tmpl.Execute(&bytes.Buffer{}, sample); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// TemplateSelfSubjectStrategy derives subjects from the claims of a request with the first rule
+// matching its auth type and issuer, so deployments can map any identity provider onto subjects of
+// their schema.
+type TemplateSelfSubjectStrategy struct {
+	rules []TemplateSelfSubjectRule
+}
+
+// NewTemplateSelfSubjectStrategy constructs the strategy from rules with parsed templates.
+func NewTemplateSelfSubjectStrategy(rules []TemplateSelfSubjectRule) *TemplateSelfSubjectStrategy {
+	return &TemplateSelfSubjectStrategy{rules: rules}
+}
+
+// SubjectFromAuthorizationContext derives a SubjectReference for self-authorization.
+func (s *TemplateSelfSubjectStrategy) SubjectFromAuthorizationContext(authzContext authnapi.AuthzContext) (model.SubjectReference, error) {
+	// Un-authenticated requests - we cannot derive a subject for them
+	if !authzContext.IsAuthenticated() {
+		return model.SubjectReference{}, fmt.Errorf("subject not found")
+	}
+	claims := authzContext.Subject
+	data := newSelfSubjectTemplateData(claims)
+	if data.SubjectId == "" {
+		return model.SubjectReference{}, fmt.Errorf("missing subject for %s", claims.AuthType)
+	}
+
+	for _, rule := range s.rules {
+		if len(rule.AuthTypes) > 0 && !slices.Contains(rule.AuthTypes, claims.AuthType) {
+			continue
+		}
+		if len(rule.Issuers) > 0 && !slices.Contains(rule.Issuers, data.Issuer) {
+			continue
+		}
+
+		subjectID, err := renderSelfSubjectTemplate(rule.SubjectId, data)
+		if err != nil {
+			return model.SubjectReference{}, err
+		}
+		namespace, err := renderSelfSubjectTemplate(rule.Namespace, data)
+		if err != nil {
+			return model.SubjectReference{}, err
+		}
+		subjectType, err := renderSelfSubjectTemplate(rule.SubjectType, data)
+		if err != nil {
+			return model.SubjectReference{}, err
+		}
+		return buildTypedSubjectReference(namespace, subjectType, subjectID)
+	}
+	return model.SubjectReference{}, fmt.Errorf("no self-subject template for auth type %q and issuer %q", claims.AuthType, data.Issuer)
+}
+
+func renderSelfSubjectTemplate(tmpl *template.Template, data selfSubjectTemplateData) (string, error) {
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s template: %w", tmpl.Name(), err)
+	}
+	return strings.TrimSpace(out.String()), nil
+}
@@ -0,0 +1,157 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
+func newTemplateStrategy(t *testing.T, rules ...TemplateRuleOptions) SelfSubjectStrategy {
+	t.Helper()
+	opts := NewSelfSubjectOptions()
+	opts.Template.Enabled = true
+	opts.Template.Rules = rules
+	require.Empty(t, opts.Complete())
+	require.Empty(t, opts.Validate())
+	strategy := opts.Build()
+	require.NotNil(t, strategy)
+	return strategy
+}
+
+func TestTemplateSelfSubjectStrategy_IssuerDomain(t *testing.T) {
+	strategy := newTemplateStrategy(t, TemplateRuleOptions{SubjectId: "{{.Issuer | domain}}/{{.SubjectId}}"})
+
+	subjectRef, err := strategy.SubjectFromAuthorizationContext(authnapi.AuthzContext{
+		Subject: &authnapi.Claims{
+			AuthType:  authnapi.AuthTypeOIDC,
+			Issuer:    "https://login.example.com:8443/realms/main",
+			SubjectId: "user-123",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "login.example.com/user-123", subjectRef.Resource().ResourceId().String())
+	assert.Equal(t, "principal", subjectRef.Resource().ResourceType().String())
+	assert.Equal(t, "rbac", subjectRef.Resource().Reporter().ReporterType().String())
+}
+
+func TestTemplateSelfSubjectStrategy_FirstMatchingRule(t *testing.T) {
+	strategy := newTemplateStrategy(t,
+		TemplateRuleOptions{
+			AuthTypes:   []string{"jwt"},
+			Issuers:     []string{"https://internal.example.com"},
+			SubjectId:   "{{.OrganizationId}}/{{.SubjectId | lower}}",
+			Namespace:   "acme",
+			SubjectType: "{{if .ClientID}}service{{else}}user{{end}}",
+		},
+		TemplateRuleOptions{AuthTypes: []string{"jwt", "oidc"}, SubjectId: "{{.SubjectId | trimPrefix \"f:\"}}"},
+	)
+
+	subjectRef, err := strategy.SubjectFromAuthorizationContext(authnapi.AuthzContext{
+		Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeJWT, Issuer: "https://internal.example.com", OrganizationId: "42", SubjectId: "Alice"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "42/alice", subjectRef.Resource().ResourceId().String())
+	assert.Equal(t, "user", subjectRef.Resource().ResourceType().String())
+	assert.Equal(t, "acme", subjectRef.Resource().Reporter().ReporterType().String())
+
+	subjectRef, err = strategy.SubjectFromAuthorizationContext(authnapi.AuthzContext{
+		Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeOIDC, Issuer: "https://sso.example.com", SubjectId: "f:abc"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", subjectRef.Resource().ResourceId().String())
+	assert.Equal(t, "rbac", subjectRef.Resource().Reporter().ReporterType().String())
+}
+
+func TestTemplateSelfSubjectStrategy_Errors(t *testing.T) {
+	strategy := newTemplateStrategy(t, TemplateRuleOptions{AuthTypes: []string{"oidc"}, SubjectId: "{{.SubjectId}}"})
+
+	tests := []struct {
+		name     string
+		authzCtx authnapi.AuthzContext
+		wantErr  string
+	}{
+		{name: "unauthenticated", authzCtx: authnapi.AuthzContext{Subject: authnapi.UnauthenticatedClaims()}, wantErr: "subject not found"},
+		{name: "missing subject", authzCtx: authnapi.AuthzContext{Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeOIDC}}, wantErr: "missing subject for oidc"},
+		{
+			name:     "no matching rule",
+			authzCtx: authnapi.AuthzContext{Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeMTLS, Issuer: "CN=ca", SubjectId: "svc"}},
+			wantErr:  `no self-subject template for auth type "mtls" and issuer "CN=ca"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := strategy.SubjectFromAuthorizationContext(tt.authzCtx)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestChainSelfSubjectStrategy(t *testing.T) {
+	opts := NewSelfSubjectOptions()
+	opts.RedHatRbac.Enabled = true
+	opts.RedHatRbac.XRhIdentityDomain = "redhat"
+	opts.RedHatRbac.OIDCIssuerDomainMap = map[string]string{"https://sso.redhat.com/auth/realms/redhat-external": "redhat"}
+	opts.Template.Enabled = true
+	opts.Template.Rules = []TemplateRuleOptions{{SubjectId: "{{.Issuer | domain}}/{{.SubjectId}}"}}
+	require.Empty(t, opts.Complete())
+	require.Empty(t, opts.Validate())
+
+	strategy := opts.Build()
+	require.IsType(t, &ChainSelfSubjectStrategy{}, strategy)
+
+	subjectRef, err := strategy.SubjectFromAuthorizationContext(authnapi.AuthzContext{
+		Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeOIDC, Issuer: "https://sso.redhat.com/auth/realms/redhat-external", SubjectId: "u1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "redhat/u1", subjectRef.Resource().ResourceId().String(), "the Red Hat strategy is tried first")
+
+	subjectRef, err = strategy.SubjectFromAuthorizationContext(authnapi.AuthzContext{
+		Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeJWT, Issuer: "https://login.example.com", SubjectId: "u1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "login.example.com/u1", subjectRef.Resource().ResourceId().String(), "falls through to the template strategy")
+
+	// An explicit chain changes the order.
+	opts.Chain = []string{SelfSubjectStrategyTemplate, SelfSubjectStrategyRedHatRbac}
+	require.Empty(t, opts.Validate())
+	subjectRef, err = opts.Build().SubjectFromAuthorizationContext(authnapi.AuthzContext{
+		Subject: &authnapi.Claims{AuthType: authnapi.AuthTypeOIDC, Issuer: "https://sso.redhat.com/auth/realms/redhat-external", SubjectId: "u1"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "sso.redhat.com/u1", subjectRef.Resource().ResourceId().String())
+
+	_, err = strategy.SubjectFromAuthorizationContext(authnapi.AuthzContext{Subject: authnapi.UnauthenticatedClaims()})
+	assert.Error(t, err)
+}
+
+func TestSelfSubjectOptions_TemplateValidation(t *testing.T) {
+	opts := NewSelfSubjectOptions()
+	opts.Template.Enabled = true
+	assert.Empty(t, opts.Complete())
+	errs := opts.Validate()
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "selfsubject.template.rules is required when enabled")
+
+	opts.Template.Rules = []TemplateRuleOptions{
+		{Namespace: "acme"},
+		{SubjectId: "{{.Subject}}"},
+		{SubjectId: "{{.SubjectId | nope}}"},
+	}
+	errs = opts.Complete()
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), "selfsubject.template.rules[0]: subjectId is required")
+	assert.Contains(t, errs[1].Error(), "selfsubject.template.rules[1]: invalid subjectId template")
+	assert.Contains(t, errs[2].Error(), `function "nope" not defined`)
+
+	opts.Template.Rules = []TemplateRuleOptions{{SubjectId: "{{.SubjectId}}"}}
+	opts.Chain = []string{SelfSubjectStrategyTemplate, SelfSubjectStrategyTemplate, SelfSubjectStrategyRedHatRbac, "ldap"}
+	errs = opts.Validate()
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0].Error(), `duplicate strategy "template"`)
+	assert.Contains(t, errs[1].Error(), `strategy "redhatRbac" is not enabled`)
+	assert.Contains(t, errs[2].Error(), `unknown strategy "ldap"`)
+}
@@ -1,8 +1,24 @@
 package resources
 
+import (
+	"text/template"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+)
+
 // RedHatRbacSelfSubjectStrategyConfig configures the Red Hat RBAC strategy.
 type RedHatRbacSelfSubjectStrategyConfig struct {
 	Enabled             bool
 	XRhIdentityDomain   string
 	OIDCIssuerDomainMap map[string]string
 }
+
+// TemplateSelfSubjectRule derives the subject of requests matching AuthTypes and Issuers (empty
+// matches all) by rendering the templates against the request's claims.
+type TemplateSelfSubjectRule struct {
+	AuthTypes   []authnapi.AuthType
+	Issuers     []string
+	SubjectId   *template.Template
+	Namespace   *template.Template
+	SubjectType *template.Template
+}
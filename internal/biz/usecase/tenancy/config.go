@@ -0,0 +1,29 @@
+package tenancy
+
+type Config struct {
+	*Options
+}
+
+type completedConfig struct {
+	*Options
+	Enabled        bool
+	AdminClientIds []string
+	OrgHeader      string
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+func (c *Config) Complete() (CompletedConfig, []error) {
+	return CompletedConfig{&completedConfig{
+		Options:        c.Options,
+		Enabled:        c.Enabled,
+		AdminClientIds: c.AdminClientIds,
+		OrgHeader:      c.OrgHeader,
+	}}, nil
+}
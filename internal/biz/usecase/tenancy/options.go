@@ -0,0 +1,53 @@
+package tenancy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultOrgHeader is the request header admin clients set to act on another organization.
+const DefaultOrgHeader = "x-kessel-org-id"
+
+type Options struct {
+	Enabled        bool     `mapstructure:"enabled"`
+	AdminClientIds []string `mapstructure:"admin-client-ids"`
+	OrgHeader      string   `mapstructure:"org-header"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		AdminClientIds: []string{}, // Empty = no client may act cross-org
+		OrgHeader:      DefaultOrgHeader,
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.BoolVar(&o.Enabled, prefix+"enabled", o.Enabled,
+		"Scope resources to the organization of the caller. Resources are stamped with the caller's org ID when reported, and only callers of that org may update, delete or read them.")
+	fs.StringArrayVar(&o.AdminClientIds, prefix+"admin-client-ids", o.AdminClientIds,
+		"List of client IDs allowed to act on resources of any organization by naming it in the org header.")
+	fs.StringVar(&o.OrgHeader, prefix+"org-header", o.OrgHeader,
+		"Request header in which admin clients name the organization they act on.")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Enabled && strings.TrimSpace(o.OrgHeader) == "" {
+		errs = append(errs, fmt.Errorf("tenancy.org-header is required when tenancy is enabled"))
+	}
+	for i, clientId := range o.AdminClientIds {
+		if strings.TrimSpace(clientId) == "" {
+			errs = append(errs, fmt.Errorf("tenancy.admin-client-ids[%d] must not be empty", i))
+		}
+	}
+	return errs
+}
+
+func (o *Options) Complete() []error {
+	return nil
+}
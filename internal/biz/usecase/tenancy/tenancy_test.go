@@ -0,0 +1,133 @@
+package tenancy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// mockTransporter is a test helper that implements transport.Transporter
+type mockTransporter struct {
+	headers map[string]string
+}
+
+func (m *mockTransporter) Kind() transport.Kind            { return transport.KindGRPC }
+func (m *mockTransporter) Endpoint() string                { return "/test" }
+func (m *mockTransporter) Operation() string               { return "test" }
+func (m *mockTransporter) RequestHeader() transport.Header { return &mockHeader{headers: m.headers} }
+func (m *mockTransporter) ReplyHeader() transport.Header {
+	return &mockHeader{headers: make(map[string]string)}
+}
+
+type mockHeader struct {
+	headers map[string]string
+}
+
+func (m *mockHeader) Get(key string) string      { return m.headers[key] }
+func (m *mockHeader) Set(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Add(key, value string)      { m.headers[key] = value }
+func (m *mockHeader) Keys() []string             { return nil }
+func (m *mockHeader) Values(key string) []string { return nil }
+
+func newTenancy(t *testing.T, opts *Options) *Tenancy {
+	t.Helper()
+	require.Empty(t, opts.Validate())
+	completed, errs := NewConfig(opts).Complete()
+	require.Empty(t, errs)
+	return New(completed)
+}
+
+func callerContext(clientId, orgId, requestedOrgId string) context.Context {
+	ctx := authnapi.NewAuthzContext(context.Background(), authnapi.AuthzContext{
+		Protocol: authnapi.ProtocolGRPC,
+		Subject: &authnapi.Claims{
+			SubjectId:      authnapi.SubjectId("service-" + clientId),
+			ClientID:       authnapi.ClientID(clientId),
+			OrganizationId: authnapi.OrganizationId(orgId),
+			AuthType:       authnapi.AuthTypeOIDC,
+		},
+	})
+	headers := map[string]string{}
+	if requestedOrgId != "" {
+		headers[DefaultOrgHeader] = requestedOrgId
+	}
+	return transport.NewServerContext(ctx, &mockTransporter{headers: headers})
+}
+
+func TestTenancy_OrgIdFromContext(t *testing.T) {
+	opts := NewOptions()
+	opts.Enabled = true
+	opts.AdminClientIds = []string{"inventory-admin"}
+	tenancy := newTenancy(t, opts)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		want    model.OrgId
+		wantErr error
+	}{
+		{name: "caller's own org", ctx: callerContext("hbi", "12345", ""), want: "12345"},
+		{name: "caller names its own org", ctx: callerContext("hbi", "12345", "12345"), want: "12345"},
+		{name: "caller without org", ctx: callerContext("hbi", "", ""), wantErr: ErrOrgRequired},
+		{name: "caller names another org", ctx: callerContext("hbi", "12345", "67890"), wantErr: ErrCrossOrgDenied},
+		{name: "admin names another org", ctx: callerContext("inventory-admin", "12345", "67890"), want: "67890"},
+		{name: "admin without org names one", ctx: callerContext("inventory-admin", "", "67890"), want: "67890"},
+		{name: "admin without org or header", ctx: callerContext("inventory-admin", "", ""), wantErr: ErrOrgRequired},
+		{name: "unauthenticated", ctx: context.Background(), wantErr: ErrOrgRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgId, err := tenancy.OrgIdFromContext(tt.ctx)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, orgId)
+		})
+	}
+}
+
+func TestTenancy_ClaimsUnowned(t *testing.T) {
+	opts := NewOptions()
+	opts.Enabled = true
+	opts.AdminClientIds = []string{"admin"}
+	tenancy := newTenancy(t, opts)
+
+	assert.True(t, tenancy.ClaimsUnowned(callerContext("admin", "12345", "67890")), "admin client naming an organization")
+	assert.False(t, tenancy.ClaimsUnowned(callerContext("admin", "12345", "")), "admin client acting on its own organization")
+	assert.False(t, tenancy.ClaimsUnowned(callerContext("hbi", "12345", "12345")), "client that isn't an admin")
+	assert.False(t, tenancy.ClaimsUnowned(context.Background()))
+
+	var disabled *Tenancy
+	assert.False(t, disabled.ClaimsUnowned(callerContext("admin", "12345", "67890")))
+}
+
+func TestTenancy_Disabled(t *testing.T) {
+	tenancy := newTenancy(t, NewOptions())
+	assert.Nil(t, tenancy)
+
+	orgId, err := tenancy.OrgIdFromContext(callerContext("hbi", "12345", "67890"))
+	require.NoError(t, err)
+	assert.Empty(t, orgId, "a disabled tenancy doesn't scope requests")
+}
+
+func TestOptions_Validate(t *testing.T) {
+	opts := NewOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.Enabled = true
+	opts.OrgHeader = " "
+	opts.AdminClientIds = []string{"admin", ""}
+	errs := opts.Validate()
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "tenancy.org-header is required when tenancy is enabled")
+	assert.EqualError(t, errs[1], "tenancy.admin-client-ids[1] must not be empty")
+}
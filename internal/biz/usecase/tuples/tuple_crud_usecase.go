@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"github.com/project-kessel/inventory-api/internal/audit"
 	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
@@ -16,6 +17,8 @@ type TupleCrudUseCase struct {
 	Authz          model.RelationsRepository
 	MetaAuthorizer metaauthorizer.MetaAuthorizer
 	Log            *log.Helper
+	// Audit records SEC-MON-REQ-1 audit entries; New defaults it to the log.
+	Audit *audit.Auditor
 }
 
 // New creates a new TupleCrudUseCase.
@@ -24,6 +27,7 @@ func New(authz model.RelationsRepository, metaAuthorizer metaauthorizer.MetaAuth
 		Authz:          authz,
 		MetaAuthorizer: metaAuthorizer,
 		Log:            log.NewHelper(logger),
+		Audit:          audit.NewLogAuditor(logger),
 	}
 }
 
@@ -36,38 +40,22 @@ func (uc *TupleCrudUseCase) CreateTuples(ctx context.Context, cmd CreateTuplesCo
 		return nil, err
 	}
 
-	// Get authz context for logging
-	authzCtx, _ := authnapi.FromAuthzContext(ctx)
-	principal := authzCtx.ExtractPrincipal()
-
 	var fencing *model.FencingCheck
 	if cmd.FencingCheck != nil {
 		fc := model.NewFencingCheck(cmd.FencingCheck.LockId, cmd.FencingCheck.LockToken)
 		fencing = &fc
 	}
 
+	resourceId := fmt.Sprintf("batch_%d_tuples", len(cmd.Tuples))
 	result, err := uc.Authz.CreateTuples(ctx, cmd.Tuples, cmd.Upsert, fencing)
 	if err != nil {
 		// CRUD operation failed - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation, EOI-11 warnings_or_errors)
-		uc.Log.Warnw("msg", "Create tuples failed",
-			"action", "CREATE",
-			"resource_type", "tuple",
-			"resource_id", fmt.Sprintf("batch_%d_tuples", len(cmd.Tuples)),
-			"principal", principal,
-			"outcome", "failure",
-			"reason", err.Error(),
-		)
+		uc.record(ctx, "Create tuples failed", "CREATE", tupleResource(resourceId), err)
 		return nil, err
 	}
 
 	// CRUD operation - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation)
-	uc.Log.Infow("msg", "Tuples created",
-		"action", "CREATE",
-		"resource_type", "tuple",
-		"resource_id", fmt.Sprintf("batch_%d_tuples", len(cmd.Tuples)),
-		"principal", principal,
-		"outcome", "success",
-	)
+	uc.record(ctx, "Tuples created", "CREATE", tupleResource(resourceId), nil)
 
 	return &CreateTuplesResult{
 		ConsistencyToken: result.ConsistencyToken(),
@@ -83,10 +71,6 @@ func (uc *TupleCrudUseCase) DeleteTuples(ctx context.Context, cmd DeleteTuplesCo
 		return nil, err
 	}
 
-	// Get authz context for logging
-	authzCtx, _ := authnapi.FromAuthzContext(ctx)
-	principal := authzCtx.ExtractPrincipal()
-
 	var fencing *model.FencingCheck
 	if cmd.FencingCheck != nil {
 		fc := model.NewFencingCheck(cmd.FencingCheck.LockId, cmd.FencingCheck.LockToken)
@@ -96,25 +80,12 @@ func (uc *TupleCrudUseCase) DeleteTuples(ctx context.Context, cmd DeleteTuplesCo
 	result, err := uc.Authz.DeleteTuples(ctx, cmd.Filter, fencing)
 	if err != nil {
 		// CRUD operation failed - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation, EOI-11 warnings_or_errors)
-		uc.Log.Warnw("msg", "Delete tuples failed",
-			"action", "DELETE",
-			"resource_type", "tuple",
-			"resource_id", "filtered_delete",
-			"principal", principal,
-			"outcome", "failure",
-			"reason", err.Error(),
-		)
+		uc.record(ctx, "Delete tuples failed", "DELETE", tupleResource("filtered_delete"), err)
 		return nil, err
 	}
 
 	// CRUD operation - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation)
-	uc.Log.Infow("msg", "Tuples deleted",
-		"action", "DELETE",
-		"resource_type", "tuple",
-		"resource_id", "filtered_delete",
-		"principal", principal,
-		"outcome", "success",
-	)
+	uc.record(ctx, "Tuples deleted", "DELETE", tupleResource("filtered_delete"), nil)
 
 	return &DeleteTuplesResult{
 		ConsistencyToken: result.ConsistencyToken(),
@@ -130,7 +101,16 @@ func (uc *TupleCrudUseCase) ReadTuples(ctx context.Context, cmd ReadTuplesComman
 		return nil, err
 	}
 
-	return uc.Authz.ReadTuples(ctx, cmd.Filter, cmd.Pagination, cmd.Consistency)
+	stream, err := uc.Authz.ReadTuples(ctx, cmd.Filter, cmd.Pagination, cmd.Consistency)
+	if err != nil {
+		// Read failed - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation, EOI-11 warnings_or_errors)
+		uc.record(ctx, "Read tuples failed", "READ", tupleResource("filtered_read"), err)
+		return nil, err
+	}
+
+	// Read - SEC-MON-REQ-1 compliance (EOI-1 pii_manipulation)
+	uc.record(ctx, "Tuples read", "READ", tupleResource("filtered_read"), nil)
+	return stream, nil
 }
 
 // AcquireLock acquires a distributed lock (DEPRECATED).
@@ -142,12 +122,39 @@ func (uc *TupleCrudUseCase) AcquireLock(ctx context.Context, cmd AcquireLockComm
 		return nil, err
 	}
 
+	lock := audit.Resource{ResourceType: "lock", ResourceId: string(cmd.LockId)}
 	result, err := uc.Authz.AcquireLock(ctx, cmd.LockId)
 	if err != nil {
+		// Lock operation failed - SEC-MON-REQ-1 compliance (EOI-2 system_object_manipulation, EOI-11 warnings_or_errors)
+		uc.record(ctx, "Acquire lock failed", "ACQUIRE_LOCK", lock, err)
 		return nil, err
 	}
 
+	// Lock operation - SEC-MON-REQ-1 compliance (EOI-2 system_object_manipulation)
+	uc.record(ctx, "Lock acquired", "ACQUIRE_LOCK", lock, nil)
+
 	return &AcquireLockResult{
 		LockToken: result.LockToken(),
 	}, nil
 }
+
+// record audits a tuple operation by the caller; a nil err records a success.
+func (uc *TupleCrudUseCase) record(ctx context.Context, message, action string, resource audit.Resource, err error) {
+	authzCtx, _ := authnapi.FromAuthzContext(ctx)
+	event := audit.Event{
+		Message:   message,
+		Action:    action,
+		Principal: authzCtx.ExtractPrincipal(),
+		Resource:  resource,
+		Outcome:   audit.OutcomeSuccess,
+	}
+	if err != nil {
+		event.Outcome = audit.OutcomeFailure
+		event.Reason = err.Error()
+	}
+	uc.Audit.Record(ctx, event)
+}
+
+func tupleResource(resourceId string) audit.Resource {
+	return audit.Resource{ResourceType: "tuple", ResourceId: resourceId}
+}
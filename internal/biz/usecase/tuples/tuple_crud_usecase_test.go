@@ -2,12 +2,14 @@ package tuples
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/project-kessel/inventory-api/internal/audit"
 	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
@@ -321,6 +323,82 @@ func TestAcquireLock_MetaAuthzContextMissing(t *testing.T) {
 	assert.ErrorIs(t, err, metaauthorizer.ErrMetaAuthzContextMissing)
 }
 
+// Audit tests
+
+type recordingSink struct {
+	entries []audit.Entry
+}
+
+func (s *recordingSink) Write(_ context.Context, entry audit.Entry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *recordingSink) Close() error { return nil }
+
+type failingLockRepository struct {
+	*data.AllowAllRelationsRepository
+}
+
+func (f *failingLockRepository) AcquireLock(_ context.Context, _ model.LockId) (model.AcquireLockResult, error) {
+	return model.AcquireLockResult{}, errors.New("lock held")
+}
+
+func TestTupleCrud_AuditsEveryOperation(t *testing.T) {
+	ctx := testAuthzContext()
+	sink := &recordingSink{}
+	uc := New(&data.AllowAllRelationsRepository{}, &recordingMetaAuthorizer{allowed: true}, log.DefaultLogger)
+	uc.Audit = audit.NewAuditor([]audit.Sink{sink}, log.DefaultLogger)
+
+	_, err := uc.CreateTuples(ctx, CreateTuplesCommand{Tuples: []model.RelationsTuple{createTestTuple()}})
+	require.NoError(t, err)
+	_, err = uc.DeleteTuples(ctx, DeleteTuplesCommand{Filter: model.NewTupleFilter()})
+	require.NoError(t, err)
+	_, err = uc.ReadTuples(ctx, ReadTuplesCommand{Filter: model.NewTupleFilter(), Consistency: model.NewConsistencyMinimizeLatency()})
+	require.NoError(t, err)
+	_, err = uc.AcquireLock(ctx, AcquireLockCommand{LockId: model.DeserializeLockId("lock-123")})
+	require.NoError(t, err)
+	require.NoError(t, uc.Audit.Close())
+
+	require.Len(t, sink.entries, 4)
+	var actions []string
+	for _, entry := range sink.entries {
+		actions = append(actions, entry.Action)
+		assert.Equal(t, "test-user", entry.Principal)
+		assert.Equal(t, audit.OutcomeSuccess, entry.Outcome)
+	}
+	assert.Equal(t, []string{"CREATE", "DELETE", "READ", "ACQUIRE_LOCK"}, actions)
+	assert.Equal(t, audit.Resource{ResourceType: "tuple", ResourceId: "batch_1_tuples"}, sink.entries[0].Resource)
+	assert.Equal(t, audit.Resource{ResourceType: "lock", ResourceId: "lock-123"}, sink.entries[3].Resource)
+	assert.NoError(t, audit.Verify(nil, sink.entries))
+}
+
+func TestAcquireLock_AuditsFailure(t *testing.T) {
+	sink := &recordingSink{}
+	uc := New(&failingLockRepository{&data.AllowAllRelationsRepository{}}, &recordingMetaAuthorizer{allowed: true}, log.DefaultLogger)
+	uc.Audit = audit.NewAuditor([]audit.Sink{sink}, log.DefaultLogger)
+
+	_, err := uc.AcquireLock(testAuthzContext(), AcquireLockCommand{LockId: model.DeserializeLockId("lock-123")})
+	require.Error(t, err)
+	require.NoError(t, uc.Audit.Close())
+
+	require.Len(t, sink.entries, 1)
+	assert.Equal(t, "ACQUIRE_LOCK", sink.entries[0].Action)
+	assert.Equal(t, audit.OutcomeFailure, sink.entries[0].Outcome)
+	assert.Equal(t, "lock held", sink.entries[0].Reason)
+}
+
+func TestTupleCrud_MetaAuthzDeniedIsNotAudited(t *testing.T) {
+	sink := &recordingSink{}
+	uc := New(&data.AllowAllRelationsRepository{}, &recordingMetaAuthorizer{allowed: false}, log.DefaultLogger)
+	uc.Audit = audit.NewAuditor([]audit.Sink{sink}, log.DefaultLogger)
+
+	_, err := uc.ReadTuples(testAuthzContext(), ReadTuplesCommand{Filter: model.NewTupleFilter()})
+	require.ErrorIs(t, err, metaauthorizer.ErrMetaAuthorizationDenied)
+	require.NoError(t, uc.Audit.Close())
+	assert.Empty(t, sink.entries, "meta-authorization denials are logged by the meta authorizer")
+}
+
 // WhitelistMetaAuthorizer Integration Tests
 //
 // These tests verify the full authorization flow with WhitelistMetaAuthorizer
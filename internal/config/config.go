@@ -8,10 +8,12 @@ import (
 	"github.com/project-kessel/inventory-api/cmd/common"
 	clowder "github.com/redhatinsights/app-common-go/pkg/api/v1"
 
+	"github.com/project-kessel/inventory-api/internal/audit"
 	"github.com/project-kessel/inventory-api/internal/authn"
 	authnFactory "github.com/project-kessel/inventory-api/internal/authn/factory"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
 	resources "github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/tenancy"
 	"github.com/project-kessel/inventory-api/internal/config/relations"
 	"github.com/project-kessel/inventory-api/internal/config/schema"
 	"github.com/project-kessel/inventory-api/internal/consistency"
@@ -37,6 +39,8 @@ type OptionsConfig struct {
 	SelfSubjectStrategy *resources.SelfSubjectOptions
 	BusinessMetrics     *metricscollector.Options `mapstructure:"business-metrics"`
 	MetaAuthorizer      *metaauthorizer.Options   `mapstructure:"metaauthorizer"`
+	Tenancy             *tenancy.Options          `mapstructure:"tenancy"`
+	Audit               *audit.Options            `mapstructure:"audit"`
 }
 
 // NewOptionsConfig returns a new OptionsConfig with default options set
@@ -53,6 +57,8 @@ func NewOptionsConfig() *OptionsConfig {
 		SelfSubjectStrategy: resources.NewSelfSubjectOptions(),
 		BusinessMetrics:     metricscollector.NewOptions(),
 		MetaAuthorizer:      metaauthorizer.NewOptions(),
+		Tenancy:             tenancy.NewOptions(),
+		Audit:               audit.NewOptions(),
 	}
 }
 
@@ -102,6 +108,20 @@ func LogConfigurationInfo(options *OptionsConfig) {
 			options.Authz.SpiceDB.UseTLS,
 			options.Authz.SpiceDB.FullyConsistent,
 		)
+	case relations.Embedded:
+		log.Debugf("Authz Configuration: Embedded Schema File: %s",
+			options.Authz.Embedded.SchemaFile,
+		)
+	case relations.Postgres:
+		log.Debugf("Authz Configuration: Postgres Schema File: %s",
+			options.Authz.Postgres.SchemaFile,
+		)
+	case relations.Shadow:
+		log.Debugf("Authz Configuration: Shadow Primary: %s, Secondary: %s, Mirror Writes?: %t",
+			options.Authz.Shadow.Primary,
+			options.Authz.Shadow.Secondary,
+			options.Authz.Shadow.MirrorWrites,
+		)
 	}
 
 	log.Debugf("Consumer Configuration: Bootstrap Server: %s, Topic: %s, Consumer Max Retries: %d, Operation Max Retries: %d, Backoff Factor: %d, Max Backoff Seconds: %d",
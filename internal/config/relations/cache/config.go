@@ -0,0 +1,29 @@
+package cache
+
+import "time"
+
+type Config struct {
+	*Options
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+type completedConfig struct {
+	Enabled    bool
+	TTL        time.Duration
+	MaxEntries int
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func (c *Config) Complete() (CompletedConfig, []error) {
+	return CompletedConfig{&completedConfig{
+		Enabled:    c.Enabled,
+		TTL:        time.Duration(c.TTLSeconds) * time.Second,
+		MaxEntries: c.MaxEntries,
+	}}, nil
+}
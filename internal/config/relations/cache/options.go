@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// Options configures the decision cache placed in front of the relations repository.
+type Options struct {
+	Enabled    bool `mapstructure:"enabled"`
+	TTLSeconds int  `mapstructure:"ttl-seconds"`
+	MaxEntries int  `mapstructure:"max-entries"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Enabled:    false,
+		TTLSeconds: 5,
+		MaxEntries: 10000,
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.BoolVar(&o.Enabled, prefix+"enabled", o.Enabled, "Cache Check and CheckBulk decisions for minimize_latency and at_least_as_fresh requests")
+	fs.IntVar(&o.TTLSeconds, prefix+"ttl-seconds", o.TTLSeconds, "Number of seconds a cached decision is served for")
+	fs.IntVar(&o.MaxEntries, prefix+"max-entries", o.MaxEntries, "Maximum number of cached decisions; the least recently used are evicted first")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if !o.Enabled {
+		return errs
+	}
+
+	if o.TTLSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("relations cache ttl-seconds must be greater than 0"))
+	}
+
+	if o.MaxEntries <= 0 {
+		errs = append(errs, fmt.Errorf("relations cache max-entries must be greater than 0"))
+	}
+
+	return errs
+}
+
+func (o *Options) Complete() []error {
+	var errs []error
+
+	return errs
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/project-kessel/inventory-api/internal/helpers"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert.Equal(t, &Options{
+		Enabled:    false,
+		TTLSeconds: 5,
+		MaxEntries: 10000,
+	}, NewOptions())
+}
+
+func TestOptions_AddFlags(t *testing.T) {
+	options := NewOptions()
+	prefix := "cache"
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	options.AddFlags(fs, prefix)
+
+	helpers.AllOptionsHaveFlags(t, prefix, fs, *options, nil)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     *Options
+		expectError bool
+	}{
+		{
+			name:        "disabled cache ignores limits",
+			options:     &Options{Enabled: false},
+			expectError: false,
+		},
+		{
+			name:        "enabled with defaults",
+			options:     &Options{Enabled: true, TTLSeconds: 5, MaxEntries: 10000},
+			expectError: false,
+		},
+		{
+			name:        "enabled without ttl",
+			options:     &Options{Enabled: true, TTLSeconds: 0, MaxEntries: 10000},
+			expectError: true,
+		},
+		{
+			name:        "enabled without max entries",
+			options:     &Options{Enabled: true, TTLSeconds: 5, MaxEntries: -1},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.options.Validate()
+			if test.expectError {
+				assert.NotEmpty(t, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestConfig_Complete(t *testing.T) {
+	completed, errs := NewConfig(&Options{Enabled: true, TTLSeconds: 30, MaxEntries: 100}).Complete()
+	assert.Nil(t, errs)
+	assert.True(t, completed.Enabled)
+	assert.Equal(t, 30*time.Second, completed.TTL)
+	assert.Equal(t, 100, completed.MaxEntries)
+}
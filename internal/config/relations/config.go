@@ -4,14 +4,24 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/project-kessel/inventory-api/internal/config/relations/cache"
+	"github.com/project-kessel/inventory-api/internal/config/relations/embedded"
 	"github.com/project-kessel/inventory-api/internal/config/relations/kessel"
+	"github.com/project-kessel/inventory-api/internal/config/relations/postgres"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
 	"github.com/project-kessel/inventory-api/internal/config/relations/spicedb"
 )
 
 type Config struct {
-	Authz   string
-	Kessel  *kessel.Config
-	SpiceDB *spicedb.Config
+	Authz      string
+	Kessel     *kessel.Config
+	SpiceDB    *spicedb.Config
+	Embedded   *embedded.Config
+	Postgres   *postgres.Config
+	Shadow     *shadow.Config
+	Cache      *cache.Config
+	Resilience *resilience.Config
 }
 
 func NewConfig(o *Options) *Config {
@@ -19,21 +29,46 @@ func NewConfig(o *Options) *Config {
 		Authz: o.Authz,
 	}
 
-	if o.Authz == Kessel {
+	if usesImpl(o.Authz, o.Shadow, Kessel) {
 		cfg.Kessel = kessel.NewConfig(o.Kessel)
 	}
 
-	if o.Authz == SpiceDB {
+	if usesImpl(o.Authz, o.Shadow, SpiceDB) {
 		cfg.SpiceDB = spicedb.NewConfig(o.SpiceDB)
 	}
 
+	if usesImpl(o.Authz, o.Shadow, Embedded) {
+		cfg.Embedded = embedded.NewConfig(o.Embedded)
+	}
+
+	if usesImpl(o.Authz, o.Shadow, Postgres) {
+		cfg.Postgres = postgres.NewConfig(o.Postgres)
+	}
+
+	if o.Authz == Shadow {
+		cfg.Shadow = shadow.NewConfig(o.Shadow)
+	}
+
+	if o.Cache != nil {
+		cfg.Cache = cache.NewConfig(o.Cache)
+	}
+
+	if o.Resilience != nil {
+		cfg.Resilience = resilience.NewConfig(o.Resilience)
+	}
+
 	return cfg
 }
 
 type completedConfig struct {
-	Authz   string
-	Kessel  kessel.CompletedConfig
-	SpiceDB spicedb.CompletedConfig
+	Authz      string
+	Kessel     kessel.CompletedConfig
+	SpiceDB    spicedb.CompletedConfig
+	Embedded   embedded.CompletedConfig
+	Postgres   postgres.CompletedConfig
+	Shadow     shadow.CompletedConfig
+	Cache      cache.CompletedConfig
+	Resilience resilience.CompletedConfig
 }
 
 type CompletedConfig struct {
@@ -45,7 +80,7 @@ func (c *Config) Complete(ctx context.Context) (CompletedConfig, []error) {
 		Authz: c.Authz,
 	}
 
-	if c.Authz == Kessel {
+	if usesImpl(c.Authz, c.shadowOptions(), Kessel) {
 		if ksl, errs := c.Kessel.Complete(ctx); errs != nil {
 			return CompletedConfig{}, nil
 		} else {
@@ -53,7 +88,7 @@ func (c *Config) Complete(ctx context.Context) (CompletedConfig, []error) {
 		}
 	}
 
-	if c.Authz == SpiceDB {
+	if usesImpl(c.Authz, c.shadowOptions(), SpiceDB) {
 		if c.SpiceDB == nil {
 			return CompletedConfig{}, []error{fmt.Errorf("authz.spicedb config is required when authz.impl=%q", SpiceDB)}
 		}
@@ -64,12 +99,70 @@ func (c *Config) Complete(ctx context.Context) (CompletedConfig, []error) {
 		}
 	}
 
+	if usesImpl(c.Authz, c.shadowOptions(), Embedded) {
+		if c.Embedded == nil {
+			return CompletedConfig{}, []error{fmt.Errorf("authz.embedded config is required when authz.impl=%q", Embedded)}
+		}
+		if emb, errs := c.Embedded.Complete(); errs != nil {
+			return CompletedConfig{}, errs
+		} else {
+			cfg.Embedded = emb
+		}
+	}
+
+	if usesImpl(c.Authz, c.shadowOptions(), Postgres) {
+		if c.Postgres == nil {
+			return CompletedConfig{}, []error{fmt.Errorf("authz.postgres config is required when authz.impl=%q", Postgres)}
+		}
+		if pg, errs := c.Postgres.Complete(); errs != nil {
+			return CompletedConfig{}, errs
+		} else {
+			cfg.Postgres = pg
+		}
+	}
+
+	if c.Authz == Shadow {
+		if c.Shadow == nil {
+			return CompletedConfig{}, []error{fmt.Errorf("authz.shadow config is required when authz.impl=%q", Shadow)}
+		}
+		if shd, errs := c.Shadow.Complete(); errs != nil {
+			return CompletedConfig{}, errs
+		} else {
+			cfg.Shadow = shd
+		}
+	}
+
+	if c.Cache == nil {
+		c.Cache = cache.NewConfig(cache.NewOptions())
+	}
+	if cch, errs := c.Cache.Complete(); errs != nil {
+		return CompletedConfig{}, errs
+	} else {
+		cfg.Cache = cch
+	}
+
+	if c.Resilience == nil {
+		c.Resilience = resilience.NewConfig(resilience.NewOptions())
+	}
+	if res, errs := c.Resilience.Complete(); errs != nil {
+		return CompletedConfig{}, errs
+	} else {
+		cfg.Resilience = res
+	}
+
 	return CompletedConfig{cfg}, nil
 }
 
+func (c *Config) shadowOptions() *shadow.Options {
+	if c.Shadow == nil {
+		return nil
+	}
+	return c.Shadow.Options
+}
+
 func CheckRelationsImpl(config CompletedConfig) string {
 	switch config.Authz {
-	case AllowAll, Kessel, SpiceDB:
+	case AllowAll, Kessel, SpiceDB, Embedded, Postgres, Shadow:
 		return config.Authz
 	default:
 		return "unknown"
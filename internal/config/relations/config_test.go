@@ -4,7 +4,9 @@ import (
 	"context"
 	"testing"
 
+	"github.com/project-kessel/inventory-api/internal/config/relations/embedded"
 	"github.com/project-kessel/inventory-api/internal/config/relations/kessel"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
 	"github.com/project-kessel/inventory-api/internal/config/relations/spicedb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,6 +45,22 @@ func TestConfig_Complete_SpiceDB_Success(t *testing.T) {
 	assert.Equal(t, SpiceDB, completed.Authz, "Authz should be SpiceDB")
 }
 
+func TestConfig_Complete_Shadow_CompletesBothImpls(t *testing.T) {
+	options := NewOptions()
+	options.Authz = Shadow
+	options.Shadow = &shadow.Options{Primary: Embedded, Secondary: AllowAll, TimeoutSeconds: 5, MaxInFlight: 10}
+	options.Embedded = &embedded.Options{SchemaFile: "deploy/schema.zed"}
+
+	completed, errs := NewConfig(options).Complete(context.Background())
+
+	require.Nil(t, errs)
+	assert.Equal(t, Shadow, completed.Authz)
+	assert.Equal(t, Embedded, completed.Shadow.Primary)
+	assert.Equal(t, AllowAll, completed.Shadow.Secondary)
+	assert.Equal(t, "deploy/schema.zed", completed.Embedded.SchemaFile, "the primary's config is completed")
+	assert.Equal(t, Shadow, CheckRelationsImpl(completed))
+}
+
 func TestConfig_Complete_Kessel_SwallowsErrors(t *testing.T) {
 	// This test documents the buggy behavior where Complete swallows
 	// errors from kessel.Config.Complete() and returns nil instead.
@@ -0,0 +1,40 @@
+package embedded
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// Options configures the in-process relations engine that evaluates a SpiceDB schema
+// over tuples held in memory.
+type Options struct {
+	SchemaFile string `mapstructure:"schema-file"`
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.StringVar(&o.SchemaFile, prefix+"schema-file", o.SchemaFile, "Path to the SpiceDB (Zed) schema file evaluated by the embedded relations engine")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if len(o.SchemaFile) == 0 {
+		errs = append(errs, fmt.Errorf("embedded schema-file may not be empty"))
+	}
+
+	return errs
+}
+
+func (o *Options) Complete() []error {
+	var errs []error
+
+	return errs
+}
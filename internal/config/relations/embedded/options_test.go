@@ -0,0 +1,52 @@
+package embedded
+
+import (
+	"testing"
+
+	"github.com/project-kessel/inventory-api/internal/helpers"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert.Equal(t, &Options{}, NewOptions())
+}
+
+func TestOptions_AddFlags(t *testing.T) {
+	options := NewOptions()
+	prefix := "embedded"
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	options.AddFlags(fs, prefix)
+
+	helpers.AllOptionsHaveFlags(t, prefix, fs, *options, nil)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     *Options
+		expectError bool
+	}{
+		{
+			name:        "schema file set",
+			options:     &Options{SchemaFile: "deploy/schema.zed"},
+			expectError: false,
+		},
+		{
+			name:        "schema file missing",
+			options:     &Options{},
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.options.Validate()
+			if test.expectError {
+				assert.NotEmpty(t, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
@@ -5,30 +5,48 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/project-kessel/inventory-api/internal/config/relations/cache"
+	"github.com/project-kessel/inventory-api/internal/config/relations/embedded"
 	"github.com/project-kessel/inventory-api/internal/config/relations/kessel"
+	"github.com/project-kessel/inventory-api/internal/config/relations/postgres"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
 	"github.com/project-kessel/inventory-api/internal/config/relations/spicedb"
 )
 
 type Options struct {
-	// Authz selects the relations implementation ("allow-all", "kessel", or "spicedb").
+	// Authz selects the relations implementation ("allow-all", "kessel", "spicedb", "embedded", "postgres", or "shadow").
 	// Named "Authz" for backward compatibility with the --authz.* CLI flags.
-	Authz   string           `mapstructure:"impl"`
-	Kessel  *kessel.Options  `mapstructure:"kessel"`
-	SpiceDB *spicedb.Options `mapstructure:"spicedb"`
+	Authz      string              `mapstructure:"impl"`
+	Kessel     *kessel.Options     `mapstructure:"kessel"`
+	SpiceDB    *spicedb.Options    `mapstructure:"spicedb"`
+	Embedded   *embedded.Options   `mapstructure:"embedded"`
+	Postgres   *postgres.Options   `mapstructure:"postgres"`
+	Shadow     *shadow.Options     `mapstructure:"shadow"`
+	Cache      *cache.Options      `mapstructure:"cache"`
+	Resilience *resilience.Options `mapstructure:"resilience"`
 }
 
 const (
 	AllowAll     = "allow-all"
 	Kessel       = "kessel"
 	SpiceDB      = "spicedb"
+	Embedded     = "embedded"
+	Postgres     = "postgres"
+	Shadow       = "shadow"
 	RelationsAPI = "kessel-relations"
 )
 
 func NewOptions() *Options {
 	return &Options{
-		Authz:   AllowAll,
-		Kessel:  kessel.NewOptions(),
-		SpiceDB: spicedb.NewOptions(),
+		Authz:      AllowAll,
+		Kessel:     kessel.NewOptions(),
+		SpiceDB:    spicedb.NewOptions(),
+		Embedded:   embedded.NewOptions(),
+		Postgres:   postgres.NewOptions(),
+		Shadow:     shadow.NewOptions(),
+		Cache:      cache.NewOptions(),
+		Resilience: resilience.NewOptions(),
 	}
 }
 
@@ -43,20 +61,53 @@ func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
 	if o.SpiceDB == nil {
 		o.SpiceDB = spicedb.NewOptions()
 	}
+	if o.Embedded == nil {
+		o.Embedded = embedded.NewOptions()
+	}
+	if o.Postgres == nil {
+		o.Postgres = postgres.NewOptions()
+	}
+	if o.Shadow == nil {
+		o.Shadow = shadow.NewOptions()
+	}
+	if o.Cache == nil {
+		o.Cache = cache.NewOptions()
+	}
+	if o.Resilience == nil {
+		o.Resilience = resilience.NewOptions()
+	}
 
-	fs.StringVar(&o.Authz, prefix+"impl", o.Authz, "Authz impl to use.  Options are 'allow-all', 'kessel', and 'spicedb'.")
+	fs.StringVar(&o.Authz, prefix+"impl", o.Authz, "Authz impl to use.  Options are 'allow-all', 'kessel', 'spicedb', 'embedded', 'postgres', and 'shadow'.")
 	o.Kessel.AddFlags(fs, prefix+"kessel")
 	o.SpiceDB.AddFlags(fs, prefix+"spicedb")
+	o.Embedded.AddFlags(fs, prefix+"embedded")
+	o.Postgres.AddFlags(fs, prefix+"postgres")
+	o.Shadow.AddFlags(fs, prefix+"shadow")
+	o.Cache.AddFlags(fs, prefix+"cache")
+	o.Resilience.AddFlags(fs, prefix+"resilience")
 }
 
 func (o *Options) Validate() []error {
 	var errs []error
 
-	if o.Authz != AllowAll && o.Authz != Kessel && o.Authz != SpiceDB {
-		errs = append(errs, fmt.Errorf("invalid authz.impl: %s.  Options are 'allow-all', 'kessel', and 'spicedb'", o.Authz))
+	if !isBackendImpl(o.Authz) && o.Authz != Shadow {
+		errs = append(errs, fmt.Errorf("invalid authz.impl: %s.  Options are 'allow-all', 'kessel', 'spicedb', 'embedded', 'postgres', and 'shadow'", o.Authz))
+	}
+
+	if o.Authz == Shadow {
+		if o.Shadow == nil {
+			errs = append(errs, fmt.Errorf("authz.shadow config is required when authz.impl=%q", Shadow))
+		} else {
+			errs = append(errs, o.Shadow.Validate()...)
+			for _, impl := range []string{o.Shadow.Primary, o.Shadow.Secondary} {
+				if impl != "" && !isBackendImpl(impl) {
+					errs = append(errs, fmt.Errorf("invalid authz.shadow impl: %s.  Options are 'allow-all', 'kessel', 'spicedb', 'embedded', and 'postgres'", impl))
+				}
+			}
+		}
 	}
 
-	if o.Authz == Kessel {
+	if usesImpl(o.Authz, o.Shadow, Kessel) {
 		if o.Kessel == nil {
 			errs = append(errs, fmt.Errorf("authz.kessel config is required when authz.impl=%q", Kessel))
 		} else {
@@ -64,7 +115,7 @@ func (o *Options) Validate() []error {
 		}
 	}
 
-	if o.Authz == SpiceDB {
+	if usesImpl(o.Authz, o.Shadow, SpiceDB) {
 		if o.SpiceDB == nil {
 			errs = append(errs, fmt.Errorf("authz.spicedb config is required when authz.impl=%q", SpiceDB))
 		} else {
@@ -72,9 +123,52 @@ func (o *Options) Validate() []error {
 		}
 	}
 
+	if usesImpl(o.Authz, o.Shadow, Embedded) {
+		if o.Embedded == nil {
+			errs = append(errs, fmt.Errorf("authz.embedded config is required when authz.impl=%q", Embedded))
+		} else {
+			errs = append(errs, o.Embedded.Validate()...)
+		}
+	}
+
+	if usesImpl(o.Authz, o.Shadow, Postgres) {
+		if o.Postgres == nil {
+			errs = append(errs, fmt.Errorf("authz.postgres config is required when authz.impl=%q", Postgres))
+		} else {
+			errs = append(errs, o.Postgres.Validate()...)
+		}
+	}
+
+	if o.Cache != nil {
+		errs = append(errs, o.Cache.Validate()...)
+	}
+
+	if o.Resilience != nil {
+		errs = append(errs, o.Resilience.Validate()...)
+	}
+
 	return errs
 }
 
+// isBackendImpl reports whether impl names a relations implementation that serves requests itself,
+// i.e. anything but shadow.
+func isBackendImpl(impl string) bool {
+	switch impl {
+	case AllowAll, Kessel, SpiceDB, Embedded, Postgres:
+		return true
+	default:
+		return false
+	}
+}
+
+// usesImpl reports whether impl is selected, either directly or as a shadow primary or secondary.
+func usesImpl(authz string, shadowOptions *shadow.Options, impl string) bool {
+	if authz == impl {
+		return true
+	}
+	return authz == Shadow && shadowOptions != nil && (shadowOptions.Primary == impl || shadowOptions.Secondary == impl)
+}
+
 func (o *Options) Complete() []error {
 	var errs []error
 
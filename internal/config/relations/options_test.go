@@ -3,7 +3,12 @@ package relations
 import (
 	"testing"
 
+	"github.com/project-kessel/inventory-api/internal/config/relations/cache"
+	"github.com/project-kessel/inventory-api/internal/config/relations/embedded"
 	"github.com/project-kessel/inventory-api/internal/config/relations/kessel"
+	"github.com/project-kessel/inventory-api/internal/config/relations/postgres"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
 	"github.com/project-kessel/inventory-api/internal/config/relations/spicedb"
 	"github.com/project-kessel/inventory-api/internal/helpers"
 	"github.com/spf13/pflag"
@@ -17,9 +22,14 @@ func TestNewOptions(t *testing.T) {
 	}{
 		options: NewOptions(),
 		expectedOptions: &Options{
-			Authz:   AllowAll,
-			Kessel:  kessel.NewOptions(),
-			SpiceDB: spicedb.NewOptions(),
+			Authz:      AllowAll,
+			Kessel:     kessel.NewOptions(),
+			SpiceDB:    spicedb.NewOptions(),
+			Embedded:   embedded.NewOptions(),
+			Postgres:   postgres.NewOptions(),
+			Shadow:     shadow.NewOptions(),
+			Cache:      cache.NewOptions(),
+			Resilience: resilience.NewOptions(),
 		},
 	}
 	assert.Equal(t, test.expectedOptions, NewOptions())
@@ -36,9 +46,9 @@ func TestOptions_AddFlags(t *testing.T) {
 	test.options.AddFlags(fs, prefix)
 
 	// the below logic ensures that every possible option defined in the Options type
-	// has a defined flag for that option; kessel and spicedb sections are skipped
+	// has a defined flag for that option; kessel, spicedb, embedded, postgres, shadow, cache and resilience sections are skipped
 	// in favor of testing in their own packages or via config files
-	helpers.AllOptionsHaveFlags(t, prefix, fs, *test.options, []string{"kessel", "spicedb"})
+	helpers.AllOptionsHaveFlags(t, prefix, fs, *test.options, []string{"kessel", "spicedb", "embedded", "postgres", "shadow", "cache", "resilience"})
 }
 
 func TestOptions_Validate(t *testing.T) {
@@ -76,6 +86,64 @@ func TestOptions_Validate(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "embedded impl",
+			options: &Options{
+				Authz:    "embedded",
+				Embedded: &embedded.Options{SchemaFile: "deploy/schema.zed"},
+			},
+			expectError: false,
+		},
+		{
+			name: "embedded impl without schema file",
+			options: &Options{
+				Authz:    "embedded",
+				Embedded: &embedded.Options{},
+			},
+			expectError: true,
+		},
+		{
+			name: "postgres impl",
+			options: &Options{
+				Authz:    "postgres",
+				Postgres: &postgres.Options{SchemaFile: "deploy/schema.zed"},
+			},
+			expectError: false,
+		},
+		{
+			name: "postgres impl without schema file",
+			options: &Options{
+				Authz:    "postgres",
+				Postgres: &postgres.Options{},
+			},
+			expectError: true,
+		},
+		{
+			name: "shadow impl",
+			options: &Options{
+				Authz:    "shadow",
+				Shadow:   &shadow.Options{Primary: "embedded", Secondary: "allow-all", TimeoutSeconds: 5, MaxInFlight: 10},
+				Embedded: &embedded.Options{SchemaFile: "deploy/schema.zed"},
+			},
+			expectError: false,
+		},
+		{
+			name: "shadow impl validates the secondary's config",
+			options: &Options{
+				Authz:    "shadow",
+				Shadow:   &shadow.Options{Primary: "allow-all", Secondary: "embedded", TimeoutSeconds: 5, MaxInFlight: 10},
+				Embedded: &embedded.Options{},
+			},
+			expectError: true,
+		},
+		{
+			name: "shadow impl cannot shadow itself",
+			options: &Options{
+				Authz:  "shadow",
+				Shadow: &shadow.Options{Primary: "allow-all", Secondary: "shadow", TimeoutSeconds: 5, MaxInFlight: 10},
+			},
+			expectError: true,
+		},
 		{
 			name: "invalid impl",
 			options: &Options{
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// Options configures the relations implementation that stores tuples in the inventory
+// database and evaluates a SpiceDB schema over them.
+type Options struct {
+	SchemaFile string `mapstructure:"schema-file"`
+}
+
+func NewOptions() *Options {
+	return &Options{}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.StringVar(&o.SchemaFile, prefix+"schema-file", o.SchemaFile, "Path to the SpiceDB (Zed) schema file evaluated over the tuples stored in postgres")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if len(o.SchemaFile) == 0 {
+		errs = append(errs, fmt.Errorf("postgres schema-file may not be empty"))
+	}
+
+	return errs
+}
+
+func (o *Options) Complete() []error {
+	var errs []error
+
+	return errs
+}
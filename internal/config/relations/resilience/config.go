@@ -0,0 +1,74 @@
+package resilience
+
+import (
+	"slices"
+	"time"
+)
+
+type Config struct {
+	*Options
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+type completedConfig struct {
+	Enabled             bool
+	RetryMaxAttempts    int
+	RetryInitialBackoff time.Duration
+	RetryMaxBackoff     time.Duration
+	MethodMaxAttempts   map[string]int
+	BreakerFailures     uint32
+	BreakerOpenTimeout  time.Duration
+	BreakerHalfOpenMax  uint32
+	HedgeCheck          bool
+	HedgePercentile     float64
+	HedgeMinDelay       time.Duration
+	CheckFailureMode    string
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func (c *Config) Complete() (CompletedConfig, []error) {
+	methodMaxAttempts := make(map[string]int, len(c.MethodMaxAttempts))
+	for name, attempts := range c.MethodMaxAttempts {
+		if method := retryableMethod(name); method != "" {
+			methodMaxAttempts[method] = attempts
+		}
+	}
+	return CompletedConfig{&completedConfig{
+		Enabled:             c.Enabled,
+		RetryMaxAttempts:    c.RetryMaxAttempts,
+		RetryInitialBackoff: time.Duration(c.RetryInitialBackoffMs) * time.Millisecond,
+		RetryMaxBackoff:     time.Duration(c.RetryMaxBackoffMs) * time.Millisecond,
+		MethodMaxAttempts:   methodMaxAttempts,
+		BreakerFailures:     uint32(max(c.BreakerFailureThreshold, 0)),
+		BreakerOpenTimeout:  time.Duration(c.BreakerOpenSeconds) * time.Second,
+		BreakerHalfOpenMax:  uint32(max(c.BreakerHalfOpenRequests, 0)),
+		HedgeCheck:          c.HedgeCheck,
+		HedgePercentile:     c.HedgePercentile,
+		HedgeMinDelay:       time.Duration(c.HedgeMinDelayMs) * time.Millisecond,
+		CheckFailureMode:    c.CheckFailureMode,
+	}}, nil
+}
+
+// MaxAttempts returns the number of attempts for method, which is 1 unless method is retryable.
+func (c CompletedConfig) MaxAttempts(method string) int {
+	if !slices.Contains(RetryableMethods, method) {
+		return 1
+	}
+	if attempts, ok := c.MethodMaxAttempts[method]; ok {
+		return attempts
+	}
+	return c.RetryMaxAttempts
+}
+
+// WithCheckFailureMode returns a copy of the config with a different Check failure mode.
+func (c CompletedConfig) WithCheckFailureMode(mode string) CompletedConfig {
+	config := *c.completedConfig
+	config.CheckFailureMode = mode
+	return CompletedConfig{&config}
+}
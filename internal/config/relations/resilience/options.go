@@ -0,0 +1,147 @@
+package resilience
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// FailClosed returns the backend error from Check when the backend is failing.
+	FailClosed = "fail-closed"
+	// FailOpen answers Check with allowed when the backend is failing.
+	FailOpen = "fail-open"
+)
+
+// RetryableMethods are the idempotent relations reads that may be retried.
+var RetryableMethods = []string{
+	"Check", "CheckForUpdate", "CheckBulk", "CheckForUpdateBulk", "CheckExplain",
+	"LookupObjects", "LookupSubjects", "ReadTuples",
+}
+
+// Options configures the resilience decorator placed in front of the relations repository: retries
+// for idempotent reads, a circuit breaker around the backend, hedged Check requests and the Check
+// behaviour when the backend is failing.
+type Options struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	RetryMaxAttempts      int            `mapstructure:"retry-max-attempts"`
+	RetryInitialBackoffMs int            `mapstructure:"retry-initial-backoff-ms"`
+	RetryMaxBackoffMs     int            `mapstructure:"retry-max-backoff-ms"`
+	MethodMaxAttempts     map[string]int `mapstructure:"method-max-attempts"`
+
+	BreakerFailureThreshold int `mapstructure:"breaker-failure-threshold"`
+	BreakerOpenSeconds      int `mapstructure:"breaker-open-seconds"`
+	BreakerHalfOpenRequests int `mapstructure:"breaker-half-open-requests"`
+
+	HedgeCheck      bool    `mapstructure:"hedge-check"`
+	HedgePercentile float64 `mapstructure:"hedge-percentile"`
+	HedgeMinDelayMs int     `mapstructure:"hedge-min-delay-ms"`
+
+	CheckFailureMode string `mapstructure:"check-failure-mode"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		Enabled:                 false,
+		RetryMaxAttempts:        3,
+		RetryInitialBackoffMs:   50,
+		RetryMaxBackoffMs:       1000,
+		MethodMaxAttempts:       map[string]int{},
+		BreakerFailureThreshold: 5,
+		BreakerOpenSeconds:      30,
+		BreakerHalfOpenRequests: 1,
+		HedgeCheck:              false,
+		HedgePercentile:         0.95,
+		HedgeMinDelayMs:         10,
+		CheckFailureMode:        FailClosed,
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.BoolVar(&o.Enabled, prefix+"enabled", o.Enabled, "Retry, circuit-break and optionally hedge calls to the relations backend")
+	fs.IntVar(&o.RetryMaxAttempts, prefix+"retry-max-attempts", o.RetryMaxAttempts, "Number of attempts for idempotent reads that fail with a transient error (1 disables retries)")
+	fs.IntVar(&o.RetryInitialBackoffMs, prefix+"retry-initial-backoff-ms", o.RetryInitialBackoffMs, "Milliseconds to back off before the first retry; doubled for each further retry")
+	fs.IntVar(&o.RetryMaxBackoffMs, prefix+"retry-max-backoff-ms", o.RetryMaxBackoffMs, "Maximum number of milliseconds to back off between retries")
+	fs.StringToIntVar(&o.MethodMaxAttempts, prefix+"method-max-attempts", o.MethodMaxAttempts, "Per-method overrides of retry-max-attempts, e.g. Check=5,LookupObjects=1")
+	fs.IntVar(&o.BreakerFailureThreshold, prefix+"breaker-failure-threshold", o.BreakerFailureThreshold, "Number of consecutive backend failures that open the circuit")
+	fs.IntVar(&o.BreakerOpenSeconds, prefix+"breaker-open-seconds", o.BreakerOpenSeconds, "Number of seconds the circuit stays open before letting trial requests through")
+	fs.IntVar(&o.BreakerHalfOpenRequests, prefix+"breaker-half-open-requests", o.BreakerHalfOpenRequests, "Number of trial requests let through while the circuit is half-open")
+	fs.BoolVar(&o.HedgeCheck, prefix+"hedge-check", o.HedgeCheck, "Send a second Check when the first has not answered within the hedge-percentile latency")
+	fs.Float64Var(&o.HedgePercentile, prefix+"hedge-percentile", o.HedgePercentile, "Percentile of recent Check latencies after which a hedged Check is sent (0 to 1, exclusive)")
+	fs.IntVar(&o.HedgeMinDelayMs, prefix+"hedge-min-delay-ms", o.HedgeMinDelayMs, "Minimum number of milliseconds to wait before sending a hedged Check")
+	fs.StringVar(&o.CheckFailureMode, prefix+"check-failure-mode", o.CheckFailureMode, "Check behaviour when the backend fails or the circuit is open: 'fail-closed' returns the error, 'fail-open' answers allowed")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if !o.Enabled {
+		return errs
+	}
+
+	if o.RetryMaxAttempts <= 0 {
+		errs = append(errs, fmt.Errorf("relations resilience retry-max-attempts must be greater than 0"))
+	}
+
+	if o.RetryInitialBackoffMs < 0 || o.RetryMaxBackoffMs < o.RetryInitialBackoffMs {
+		errs = append(errs, fmt.Errorf("relations resilience retry backoff must satisfy 0 <= retry-initial-backoff-ms <= retry-max-backoff-ms"))
+	}
+
+	for method, attempts := range o.MethodMaxAttempts {
+		if retryableMethod(method) == "" {
+			errs = append(errs, fmt.Errorf("relations resilience method-max-attempts: %s is not a retryable method", method))
+		}
+		if attempts <= 0 {
+			errs = append(errs, fmt.Errorf("relations resilience method-max-attempts for %s must be greater than 0", method))
+		}
+	}
+
+	if o.BreakerFailureThreshold <= 0 {
+		errs = append(errs, fmt.Errorf("relations resilience breaker-failure-threshold must be greater than 0"))
+	}
+
+	if o.BreakerOpenSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("relations resilience breaker-open-seconds must be greater than 0"))
+	}
+
+	if o.BreakerHalfOpenRequests <= 0 {
+		errs = append(errs, fmt.Errorf("relations resilience breaker-half-open-requests must be greater than 0"))
+	}
+
+	if o.HedgeCheck {
+		if o.HedgePercentile <= 0 || o.HedgePercentile >= 1 {
+			errs = append(errs, fmt.Errorf("relations resilience hedge-percentile must be between 0 and 1, exclusive"))
+		}
+		if o.HedgeMinDelayMs < 0 {
+			errs = append(errs, fmt.Errorf("relations resilience hedge-min-delay-ms may not be negative"))
+		}
+	}
+
+	if o.CheckFailureMode != FailClosed && o.CheckFailureMode != FailOpen {
+		errs = append(errs, fmt.Errorf("invalid relations resilience check-failure-mode: %s.  Options are '%s' and '%s'", o.CheckFailureMode, FailClosed, FailOpen))
+	}
+
+	return errs
+}
+
+// retryableMethod returns the retryable method named name, ignoring case since config file keys are
+// lowercased, or "" if there is none.
+func retryableMethod(name string) string {
+	for _, method := range RetryableMethods {
+		if strings.EqualFold(method, name) {
+			return method
+		}
+	}
+	return ""
+}
+
+func (o *Options) Complete() []error {
+	var errs []error
+
+	return errs
+}
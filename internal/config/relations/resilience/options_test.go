@@ -0,0 +1,149 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+
+	"github.com/project-kessel/inventory-api/internal/helpers"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert.Equal(t, &Options{
+		Enabled:                 false,
+		RetryMaxAttempts:        3,
+		RetryInitialBackoffMs:   50,
+		RetryMaxBackoffMs:       1000,
+		MethodMaxAttempts:       map[string]int{},
+		BreakerFailureThreshold: 5,
+		BreakerOpenSeconds:      30,
+		BreakerHalfOpenRequests: 1,
+		HedgeCheck:              false,
+		HedgePercentile:         0.95,
+		HedgeMinDelayMs:         10,
+		CheckFailureMode:        FailClosed,
+	}, NewOptions())
+}
+
+func TestOptions_AddFlags(t *testing.T) {
+	options := NewOptions()
+	prefix := "resilience"
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	options.AddFlags(fs, prefix)
+
+	helpers.AllOptionsHaveFlags(t, prefix, fs, *options, nil)
+}
+
+func TestOptions_AddFlags_MethodMaxAttempts(t *testing.T) {
+	options := NewOptions()
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	options.AddFlags(fs, "resilience")
+
+	assert.NoError(t, fs.Parse([]string{"--resilience.method-max-attempts=Check=5,LookupObjects=1"}))
+	assert.Equal(t, map[string]int{"Check": 5, "LookupObjects": 1}, options.MethodMaxAttempts)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	enabled := func() *Options {
+		o := NewOptions()
+		o.Enabled = true
+		return o
+	}
+
+	tests := []struct {
+		name        string
+		options     func() *Options
+		expectError bool
+	}{
+		{
+			name:        "disabled ignores invalid settings",
+			options:     func() *Options { return &Options{CheckFailureMode: "bogus"} },
+			expectError: false,
+		},
+		{
+			name:        "enabled with defaults",
+			options:     enabled,
+			expectError: false,
+		},
+		{
+			name:        "no attempts",
+			options:     func() *Options { o := enabled(); o.RetryMaxAttempts = 0; return o },
+			expectError: true,
+		},
+		{
+			name:        "max backoff below initial backoff",
+			options:     func() *Options { o := enabled(); o.RetryMaxBackoffMs = 10; return o },
+			expectError: true,
+		},
+		{
+			name:        "per-method override",
+			options:     func() *Options { o := enabled(); o.MethodMaxAttempts = map[string]int{"Check": 5}; return o },
+			expectError: false,
+		},
+		{
+			name:        "per-method override from a config file with a lowercased key",
+			options:     func() *Options { o := enabled(); o.MethodMaxAttempts = map[string]int{"lookupobjects": 1}; return o },
+			expectError: false,
+		},
+		{
+			name:        "per-method override for a write",
+			options:     func() *Options { o := enabled(); o.MethodMaxAttempts = map[string]int{"CreateTuples": 3}; return o },
+			expectError: true,
+		},
+		{
+			name:        "per-method override without attempts",
+			options:     func() *Options { o := enabled(); o.MethodMaxAttempts = map[string]int{"Check": 0}; return o },
+			expectError: true,
+		},
+		{
+			name:        "breaker without threshold",
+			options:     func() *Options { o := enabled(); o.BreakerFailureThreshold = 0; return o },
+			expectError: true,
+		},
+		{
+			name:        "hedging with percentile of 1",
+			options:     func() *Options { o := enabled(); o.HedgeCheck = true; o.HedgePercentile = 1; return o },
+			expectError: true,
+		},
+		{
+			name:        "fail open",
+			options:     func() *Options { o := enabled(); o.CheckFailureMode = FailOpen; return o },
+			expectError: false,
+		},
+		{
+			name:        "unknown failure mode",
+			options:     func() *Options { o := enabled(); o.CheckFailureMode = "fail-maybe"; return o },
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.options().Validate()
+			if test.expectError {
+				assert.NotEmpty(t, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestConfig_Complete(t *testing.T) {
+	o := NewOptions()
+	o.Enabled = true
+	o.MethodMaxAttempts = map[string]int{"Check": 5, "lookupsubjects": 2}
+	completed, errs := NewConfig(o).Complete()
+	assert.Nil(t, errs)
+	assert.True(t, completed.Enabled)
+	assert.Equal(t, 50*time.Millisecond, completed.RetryInitialBackoff)
+	assert.Equal(t, time.Second, completed.RetryMaxBackoff)
+	assert.Equal(t, uint32(5), completed.BreakerFailures)
+	assert.Equal(t, 30*time.Second, completed.BreakerOpenTimeout)
+	assert.Equal(t, 10*time.Millisecond, completed.HedgeMinDelay)
+	assert.Equal(t, 5, completed.MaxAttempts("Check"))
+	assert.Equal(t, 3, completed.MaxAttempts("LookupObjects"))
+	assert.Equal(t, 2, completed.MaxAttempts("LookupSubjects"))
+	assert.Equal(t, 1, completed.MaxAttempts("CreateTuples"))
+}
@@ -0,0 +1,35 @@
+package shadow
+
+import "time"
+
+type Config struct {
+	*Options
+}
+
+func NewConfig(o *Options) *Config {
+	return &Config{Options: o}
+}
+
+type completedConfig struct {
+	Primary       string
+	Secondary     string
+	MirrorWrites  bool
+	LogSampleRate float64
+	Timeout       time.Duration
+	MaxInFlight   int
+}
+
+type CompletedConfig struct {
+	*completedConfig
+}
+
+func (c *Config) Complete() (CompletedConfig, []error) {
+	return CompletedConfig{&completedConfig{
+		Primary:       c.Primary,
+		Secondary:     c.Secondary,
+		MirrorWrites:  c.MirrorWrites,
+		LogSampleRate: c.LogSampleRate,
+		Timeout:       time.Duration(c.TimeoutSeconds) * time.Second,
+		MaxInFlight:   c.MaxInFlight,
+	}}, nil
+}
@@ -0,0 +1,76 @@
+package shadow
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// Options configures the shadow relations mode, which serves every request from a primary
+// relations implementation and mirrors reads (and optionally writes) to a secondary one to compare
+// their answers. Primary and secondary name other authz impls, configured in their own sections.
+type Options struct {
+	Primary        string  `mapstructure:"primary"`
+	Secondary      string  `mapstructure:"secondary"`
+	MirrorWrites   bool    `mapstructure:"mirror-writes"`
+	LogSampleRate  float64 `mapstructure:"log-sample-rate"`
+	TimeoutSeconds int     `mapstructure:"timeout-seconds"`
+	MaxInFlight    int     `mapstructure:"max-in-flight"`
+}
+
+func NewOptions() *Options {
+	return &Options{
+		MirrorWrites:   false,
+		LogSampleRate:  0.01,
+		TimeoutSeconds: 5,
+		MaxInFlight:    100,
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet, prefix string) {
+	if prefix != "" {
+		prefix = prefix + "."
+	}
+	fs.StringVar(&o.Primary, prefix+"primary", o.Primary, "Authz impl that serves requests in shadow mode")
+	fs.StringVar(&o.Secondary, prefix+"secondary", o.Secondary, "Authz impl that requests are mirrored to in shadow mode")
+	fs.BoolVar(&o.MirrorWrites, prefix+"mirror-writes", o.MirrorWrites, "Also mirror tuple writes and deletes to the secondary")
+	fs.Float64Var(&o.LogSampleRate, prefix+"log-sample-rate", o.LogSampleRate, "Fraction of mismatches logged in detail (0 to 1); every mismatch is counted")
+	fs.IntVar(&o.TimeoutSeconds, prefix+"timeout-seconds", o.TimeoutSeconds, "Number of seconds a mirrored request may take")
+	fs.IntVar(&o.MaxInFlight, prefix+"max-in-flight", o.MaxInFlight, "Maximum number of concurrent mirrored requests; requests beyond it are not mirrored")
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+
+	if len(o.Primary) == 0 {
+		errs = append(errs, fmt.Errorf("shadow primary may not be empty"))
+	}
+
+	if len(o.Secondary) == 0 {
+		errs = append(errs, fmt.Errorf("shadow secondary may not be empty"))
+	}
+
+	if len(o.Primary) > 0 && o.Primary == o.Secondary {
+		errs = append(errs, fmt.Errorf("shadow primary and secondary must be different impls"))
+	}
+
+	if o.LogSampleRate < 0 || o.LogSampleRate > 1 {
+		errs = append(errs, fmt.Errorf("shadow log-sample-rate must be between 0 and 1"))
+	}
+
+	if o.TimeoutSeconds <= 0 {
+		errs = append(errs, fmt.Errorf("shadow timeout-seconds must be greater than 0"))
+	}
+
+	if o.MaxInFlight <= 0 {
+		errs = append(errs, fmt.Errorf("shadow max-in-flight must be greater than 0"))
+	}
+
+	return errs
+}
+
+func (o *Options) Complete() []error {
+	var errs []error
+
+	return errs
+}
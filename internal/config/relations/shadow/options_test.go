@@ -0,0 +1,93 @@
+package shadow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/project-kessel/inventory-api/internal/helpers"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptions(t *testing.T) {
+	assert.Equal(t, &Options{
+		MirrorWrites:   false,
+		LogSampleRate:  0.01,
+		TimeoutSeconds: 5,
+		MaxInFlight:    100,
+	}, NewOptions())
+}
+
+func TestOptions_AddFlags(t *testing.T) {
+	options := NewOptions()
+	prefix := "shadow"
+	fs := pflag.NewFlagSet("", pflag.ContinueOnError)
+	options.AddFlags(fs, prefix)
+
+	helpers.AllOptionsHaveFlags(t, prefix, fs, *options, nil)
+}
+
+func TestOptions_Validate(t *testing.T) {
+	valid := func() *Options {
+		o := NewOptions()
+		o.Primary = "kessel"
+		o.Secondary = "spicedb"
+		return o
+	}
+
+	tests := []struct {
+		name        string
+		options     func() *Options
+		expectError bool
+	}{
+		{
+			name:        "primary and secondary set",
+			options:     valid,
+			expectError: false,
+		},
+		{
+			name:        "secondary missing",
+			options:     func() *Options { o := valid(); o.Secondary = ""; return o },
+			expectError: true,
+		},
+		{
+			name:        "primary and secondary are the same",
+			options:     func() *Options { o := valid(); o.Secondary = o.Primary; return o },
+			expectError: true,
+		},
+		{
+			name:        "sample rate above 1",
+			options:     func() *Options { o := valid(); o.LogSampleRate = 1.5; return o },
+			expectError: true,
+		},
+		{
+			name:        "no timeout",
+			options:     func() *Options { o := valid(); o.TimeoutSeconds = 0; return o },
+			expectError: true,
+		},
+		{
+			name:        "no in-flight mirrors",
+			options:     func() *Options { o := valid(); o.MaxInFlight = 0; return o },
+			expectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := test.options().Validate()
+			if test.expectError {
+				assert.NotEmpty(t, errs)
+			} else {
+				assert.Empty(t, errs)
+			}
+		})
+	}
+}
+
+func TestConfig_Complete(t *testing.T) {
+	completed, errs := NewConfig(&Options{Primary: "kessel", Secondary: "spicedb", TimeoutSeconds: 2, MaxInFlight: 10}).Complete()
+	assert.Nil(t, errs)
+	assert.Equal(t, "kessel", completed.Primary)
+	assert.Equal(t, 2*time.Second, completed.Timeout)
+	assert.Equal(t, 10, completed.MaxInFlight)
+}
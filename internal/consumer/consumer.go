@@ -75,6 +75,9 @@ type InventoryConsumer struct {
 	lockToken          model.LockToken
 	lockId             model.LockId
 	ResourceRepository model.ResourceRepository
+	// ConsistencyWatermarks records the token of each replicated write against the subjects it
+	// touched; when nil, watermarks are not tracked.
+	ConsistencyWatermarks model.ConsistencyWatermarkRepository
 }
 
 // New instantiates a new InventoryConsumer
@@ -124,21 +127,22 @@ func New(config CompletedConfig, db *gorm.DB, schemaRepository model.SchemaRepos
 	schemaService := model.NewSchemaService(schemaRepository, logger)
 
 	return InventoryConsumer{
-		Consumer:           consumer,
-		OffsetStorage:      make([]kafka.TopicPartition, 0),
-		Config:             config,
-		DB:                 db,
-		ResourceRepository: resourceRepository,
-		Relations:          relations,
-		Errors:             errChan,
-		MetricsCollector:   &mc,
-		Logger:             logger,
-		AuthOptions:        authnOptions,
-		RetryOptions:       retryOptions,
-		Notifier:           notifier,
-		SchemaService:      schemaService,
-		offsetMutex:        sync.Mutex{},
-		shutdownInProgress: false,
+		Consumer:              consumer,
+		OffsetStorage:         make([]kafka.TopicPartition, 0),
+		Config:                config,
+		DB:                    db,
+		ResourceRepository:    resourceRepository,
+		ConsistencyWatermarks: data.NewConsistencyWatermarkRepository(db),
+		Relations:             relations,
+		Errors:                errChan,
+		MetricsCollector:      &mc,
+		Logger:                logger,
+		AuthOptions:           authnOptions,
+		RetryOptions:          retryOptions,
+		Notifier:              notifier,
+		SchemaService:         schemaService,
+		offsetMutex:           sync.Mutex{},
+		shutdownInProgress:    false,
 	}, nil
 }
 
@@ -168,18 +172,10 @@ func (i *InventoryConsumer) Consume() error {
 	sigchan := make(chan os.Signal, 1)
 	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
 
-	// Both gRPC (relations-api) and SpiceDB (direct) backends write tuples;
-	// only allow-all is a no-op. The type switch gates the consumer's tuple
-	// write path so new RelationsRepository implementations must opt-in here.
-	var relationsEnabled bool
-	switch i.Relations.(type) {
-	case *data.GRPCRelationsRepository:
-		relationsEnabled = true
-	case *data.SpiceDBRelationsRepository:
-		relationsEnabled = true
-	case *data.AllowAllRelationsRepository:
-		relationsEnabled = false
-	}
+	// Tuples are only replicated to repositories that store them; allow-all is a no-op.
+	// Repositories opt in through model.TupleWriter, and decorators report the capability of the
+	// repository they wrap.
+	relationsEnabled := model.WritesTuples(i.Relations)
 	// Process messages
 	run := true
 	i.Logger.Info("Consumer ready: waiting for messages...")
@@ -324,17 +320,18 @@ func (i *InventoryConsumer) ProcessMessage(headers map[string]string, relationsE
 		}
 	case string(model.OperationTypeDeleted):
 		if relationsEnabled {
-			return i.processRelationsOperation(operation, txid, msg, operationConfig{
+			// The token only advances watermarks; there is no resource left to store it on.
+			_, err := i.processRelationsOperation(operation, txid, msg, operationConfig{
 				fetchRepresentations: func(i *InventoryConsumer, key model.ReporterResourceKey, version *model.Version) (*model.Representations, *model.Representations, error) {
 					previous, err := i.ResourceRepository.FindLatestRepresentations(nil, key)
 					return nil, previous, err
 				},
 				executeSpiceDB: func(i *InventoryConsumer, tuples model.TuplesToReplicate) (string, error) {
-					_, err := i.DeleteTuple(context.Background(), *tuples.TuplesToDelete())
-					return "", err
+					return i.DeleteTuple(context.Background(), *tuples.TuplesToDelete())
 				},
 				metricName: "DeleteTuple",
 			})
+			return "", err
 		}
 	default:
 		metricscollector.Incr(i.MetricsCollector.MsgProcessFailures, "unknown-operation-type")
@@ -395,9 +392,39 @@ func (i *InventoryConsumer) processRelationsOperation(
 		return "", err
 	}
 
+	i.advanceConsistencyWatermarks(key, tuplesToReplicate, resp, time.Now())
+
 	return resp, nil
 }
 
+// advanceConsistencyWatermarks records token against the global scope, the reporter type of the
+// replicated resource and the subjects of its tuples. Failures are logged rather than returned:
+// the tuples are already written, and a stale watermark only weakens at_least_as_acknowledged
+// reads until the next write advances it.
+func (i *InventoryConsumer) advanceConsistencyWatermarks(key model.ReporterResourceKey, tuples model.TuplesToReplicate, token string, replicatedAt time.Time) {
+	if i.ConsistencyWatermarks == nil || token == "" {
+		return
+	}
+
+	scopes := []model.WatermarkScope{
+		model.GlobalWatermarkScope,
+		model.NewReporterTypeWatermarkScope(key.ReporterType()),
+	}
+	for _, batch := range []*[]model.RelationsTuple{tuples.TuplesToCreate(), tuples.TuplesToDelete()} {
+		if batch == nil {
+			continue
+		}
+		for _, tuple := range *batch {
+			scopes = append(scopes, model.NewSubjectWatermarkScope(tuple.Subject()))
+		}
+	}
+
+	if err := i.ConsistencyWatermarks.Advance(nil, scopes, model.DeserializeConsistencyToken(token), replicatedAt); err != nil {
+		metricscollector.Incr(i.MetricsCollector.ConsumerErrors, "UpdateConsistencyWatermarks")
+		i.Logger.Errorf("failed to update consistency watermarks: %v", err)
+	}
+}
+
 func ParseHeaders(msg *kafka.Message) (map[string]string, error) {
 	headers := make(map[string]string)
 	for _, v := range msg.Headers {
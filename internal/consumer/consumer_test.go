@@ -3,7 +3,9 @@ package consumer
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/google/uuid"
@@ -15,6 +17,9 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/cache"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
 	"github.com/project-kessel/inventory-api/internal/data"
 	datamodel "github.com/project-kessel/inventory-api/internal/data/model"
 	"github.com/project-kessel/inventory-api/internal/mocks"
@@ -978,3 +983,245 @@ func TestInventoryConsumer_UpdateWithSameWorkspace_NoOp(t *testing.T) {
 	// Verify no relations operations occurred - version should still be 1 (initial)
 	assert.Equal(t, int64(1), relationsRepo.Version(), "No relations operations should occur when workspace doesn't change")
 }
+
+func TestInventoryConsumer_ProcessMessage_AdvancesConsistencyWatermarks(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	testData, err := model.NewResourceFixture("test-resource-4321", "integration", "notifications", "test-instance-1", "test-workspace-v0")
+	require.NoError(t, err)
+	require.NoError(t, tester.inv.ResourceRepository.Save(tester.inv.DB, *testData.Resource, model.OperationTypeCreated, testData.InitialTransactionId))
+
+	msg := &kafka.Message{
+		Key:   []byte(testMessageKey),
+		Value: []byte(testCreateMessage),
+		Headers: []kafka.Header{
+			{Key: "operation", Value: []byte(string(model.OperationTypeCreated))},
+			{Key: "txid", Value: []byte("123456")},
+		},
+	}
+	parsedHeaders, err := ParseHeaders(msg)
+	require.NoError(t, err)
+	resp, err := tester.inv.ProcessMessage(parsedHeaders, true, msg)
+	require.NoError(t, err)
+	require.NotEmpty(t, resp)
+
+	rbac := model.NewReporterReference(model.DeserializeReporterType("rbac"), nil)
+	workspace := model.NewResourceReference(model.DeserializeResourceType("workspace"), model.DeserializeLocalResourceId("test-workspace-v0"), &rbac)
+	scopes := []model.WatermarkScope{
+		model.GlobalWatermarkScope,
+		model.NewReporterTypeWatermarkScope(model.DeserializeReporterType("notifications")),
+		model.NewSubjectWatermarkScope(model.NewSubjectReferenceWithoutRelation(workspace)),
+	}
+	for _, scope := range scopes {
+		watermark, err := tester.inv.ConsistencyWatermarks.Find(nil, scope)
+		require.NoError(t, err)
+		assert.Equal(t, model.DeserializeConsistencyToken(resp), watermark.Token(), scope.String())
+	}
+
+	watermark, err := tester.inv.ConsistencyWatermarks.Find(nil, model.NewReporterTypeWatermarkScope(model.DeserializeReporterType("hbi")))
+	require.NoError(t, err)
+	assert.True(t, watermark.IsEmpty(), "other reporter types are not advanced")
+}
+
+func TestInventoryConsumer_ProcessMessage_DeleteAdvancesConsistencyWatermarks(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	testData, err := model.NewResourceFixture("test-resource-4321", "integration", "notifications", "test-instance-1", "test-workspace-v0")
+	require.NoError(t, err)
+	require.NoError(t, tester.inv.ResourceRepository.Save(tester.inv.DB, *testData.Resource, model.OperationTypeCreated, testData.InitialTransactionId))
+
+	rbac := model.NewReporterReference(model.DeserializeReporterType("rbac"), nil)
+	workspace := model.NewResourceReference(model.DeserializeResourceType("workspace"), model.DeserializeLocalResourceId("test-workspace-v0"), &rbac)
+	scope := model.NewSubjectWatermarkScope(model.NewSubjectReferenceWithoutRelation(workspace))
+	require.NoError(t, tester.inv.ConsistencyWatermarks.Advance(nil, []model.WatermarkScope{scope}, model.ConsistencyToken("before-delete"), time.Now().Add(-time.Minute)))
+
+	msg := &kafka.Message{
+		Key:   []byte(testMessageKey),
+		Value: []byte(testDeleteMessage),
+		Headers: []kafka.Header{
+			{Key: "operation", Value: []byte(string(model.OperationTypeDeleted))},
+			{Key: "txid", Value: []byte("")},
+		},
+	}
+	parsedHeaders, err := ParseHeaders(msg)
+	require.NoError(t, err)
+	resp, err := tester.inv.ProcessMessage(parsedHeaders, true, msg)
+	require.NoError(t, err)
+	assert.Equal(t, "", resp, "deletes have no resource to store a token on")
+
+	watermark, err := tester.inv.ConsistencyWatermarks.Find(nil, scope)
+	require.NoError(t, err)
+	assert.NotEqual(t, model.ConsistencyToken("before-delete"), watermark.Token())
+	assert.False(t, watermark.IsEmpty())
+}
+
+func TestInventoryConsumer_ProcessMessage_WithoutConsistencyWatermarks(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+	tester.inv.ConsistencyWatermarks = nil
+
+	testData, err := model.NewResourceFixture("test-resource-4321", "integration", "notifications", "test-instance-1", "test-workspace-v0")
+	require.NoError(t, err)
+	require.NoError(t, tester.inv.ResourceRepository.Save(tester.inv.DB, *testData.Resource, model.OperationTypeCreated, testData.InitialTransactionId))
+
+	msg := &kafka.Message{
+		Key:   []byte(testMessageKey),
+		Value: []byte(testCreateMessage),
+		Headers: []kafka.Header{
+			{Key: "operation", Value: []byte(string(model.OperationTypeCreated))},
+			{Key: "txid", Value: []byte("123456")},
+		},
+	}
+	parsedHeaders, err := ParseHeaders(msg)
+	require.NoError(t, err)
+	resp, err := tester.inv.ProcessMessage(parsedHeaders, true, msg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp)
+}
+
+// replicateTestResource saves the test resource and processes its created or deleted message,
+// replicating tuples only when the consumer's relations repository writes them, as Consume does.
+func replicateTestResource(t *testing.T, tester *TestCase, operation model.EventOperationType) {
+	t.Helper()
+	value := testDeleteMessage
+	if operation == model.OperationTypeCreated {
+		testData, err := model.NewResourceFixture("test-resource-4321", "integration", "notifications", "test-instance-1", "test-workspace-v0")
+		require.NoError(t, err)
+		require.NoError(t, tester.inv.ResourceRepository.Save(tester.inv.DB, *testData.Resource, model.OperationTypeCreated, testData.InitialTransactionId))
+		value = testCreateMessage
+	}
+
+	msg := &kafka.Message{
+		Key:   []byte(testMessageKey),
+		Value: []byte(value),
+		Headers: []kafka.Header{
+			{Key: "operation", Value: []byte(string(operation.OperationType()))},
+			{Key: "txid", Value: []byte("123456")},
+		},
+	}
+	parsedHeaders, err := ParseHeaders(msg)
+	require.NoError(t, err)
+	_, err = tester.inv.ProcessMessage(parsedHeaders, model.WritesTuples(tester.inv.Relations), msg)
+	require.NoError(t, err)
+}
+
+// replicatedTuples returns the tuples the consumer replicated for the test resource.
+func replicatedTuples(t *testing.T, relations model.RelationsRepository) []model.ReadTuplesItem {
+	t.Helper()
+	filter := model.NewTupleFilter().
+		WithObjectType(model.DeserializeResourceType("integration")).
+		WithReporterType(model.DeserializeReporterType("notifications"))
+	stream, err := relations.ReadTuples(context.Background(), filter, nil, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+
+	var items []model.ReadTuplesItem
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return items
+		}
+		require.NoError(t, err)
+		items = append(items, item)
+	}
+}
+
+// assertReplicates checks that the consumer writes the test resource's tuples to backend through
+// relations, and deletes them again.
+func assertReplicates(t *testing.T, tester *TestCase, relations, backend model.RelationsRepository) {
+	t.Helper()
+	tester.inv.Relations = relations
+	require.True(t, model.WritesTuples(relations))
+
+	// Backends that store tuples enforce the fencing token the consumer holds for its partition.
+	lockId, err := model.NewLockId("test-group/0")
+	require.NoError(t, err)
+	lock, err := relations.AcquireLock(context.Background(), lockId)
+	require.NoError(t, err)
+	tester.inv.lockId, tester.inv.lockToken = lockId, lock.LockToken()
+
+	replicateTestResource(t, tester, model.OperationTypeCreated)
+	tuples := replicatedTuples(t, backend)
+	require.Len(t, tuples, 1, "the created resource's tuple is written")
+	assert.Equal(t, "test-resource-4321", tuples[0].Object().ResourceId().String())
+	assert.Equal(t, "test-workspace-v0", tuples[0].Subject().Resource().ResourceId().String())
+
+	replicateTestResource(t, tester, model.OperationTypeDeleted)
+	assert.Empty(t, replicatedTuples(t, backend), "the deleted resource's tuple is deleted")
+}
+
+func TestInventoryConsumer_ReplicatesThroughDecisionCache(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	cacheConfig, errs := cache.NewConfig(&cache.Options{Enabled: true, TTLSeconds: 10, MaxEntries: 10}).Complete()
+	require.Empty(t, errs)
+	backend := data.NewSimpleRelationsRepository()
+	assertReplicates(t, &tester, data.NewCachingRelationsRepository(backend, cacheConfig, &tester.metrics, tester.logger), backend)
+
+	allowAll := data.NewCachingRelationsRepository(data.NewAllowAllRelationsRepository(tester.logger), cacheConfig, &tester.metrics, tester.logger)
+	assert.False(t, model.WritesTuples(allowAll), "the cache reports the capability of the repository it wraps")
+}
+
+func TestInventoryConsumer_ReplicatesToEmbeddedRelations(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	backend, err := data.NewEmbeddedRelationsRepositoryFromFile("../../deploy/schema.zed")
+	require.NoError(t, err)
+	assertReplicates(t, &tester, backend, backend)
+}
+
+func TestInventoryConsumer_ReplicatesToPostgresRelations(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	// The tuple store shares the consumer's database, which holds the relation_tuples table.
+	backend, err := data.NewPostgresRelationsRepositoryFromFile(tester.inv.DB, data.NewGormTransactionManager(tester.inv.MetricsCollector, 3), "../../deploy/schema.zed")
+	require.NoError(t, err)
+	assertReplicates(t, &tester, backend, backend)
+}
+
+func TestInventoryConsumer_ReplicatesThroughShadowRelations(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	shadowConfig, errs := shadow.NewConfig(&shadow.Options{
+		Primary:        "embedded",
+		Secondary:      "allow-all",
+		TimeoutSeconds: 5,
+		MaxInFlight:    10,
+	}).Complete()
+	require.Empty(t, errs)
+	primary := data.NewSimpleRelationsRepository()
+	repo := data.NewShadowRelationsRepository(primary, data.NewAllowAllRelationsRepository(tester.logger), shadowConfig, &tester.metrics, tester.logger)
+	assertReplicates(t, &tester, repo, primary)
+
+	repo = data.NewShadowRelationsRepository(data.NewAllowAllRelationsRepository(tester.logger), data.NewSimpleRelationsRepository(), shadowConfig, &tester.metrics, tester.logger)
+	assert.False(t, model.WritesTuples(repo), "the shadow reports the capability of its primary")
+}
+
+func TestInventoryConsumer_ReplicatesThroughResilientRelations(t *testing.T) {
+	tester := TestCase{}
+	errs := tester.TestSetup(t)
+	require.Nil(t, errs)
+
+	options := resilience.NewOptions()
+	options.Enabled = true
+	require.Empty(t, options.Validate())
+	resilienceConfig, errs := resilience.NewConfig(options).Complete()
+	require.Empty(t, errs)
+	backend := data.NewSimpleRelationsRepository()
+	assertReplicates(t, &tester, data.NewResilientRelationsRepository(backend, resilienceConfig, &tester.metrics, tester.logger), backend)
+
+	allowAll := data.NewResilientRelationsRepository(data.NewAllowAllRelationsRepository(tester.logger), resilienceConfig, &tester.metrics, tester.logger)
+	assert.False(t, model.WritesTuples(allowAll), "resilience reports the capability of the repository it wraps")
+}
@@ -0,0 +1,90 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+	datamodel "github.com/project-kessel/inventory-api/internal/data/model"
+)
+
+// apiKeyRepository stores api keys in the api_keys table.
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+var _ apikey.AdminStore = &apiKeyRepository{}
+
+func NewAPIKeyRepository(db *gorm.DB) apikey.AdminStore {
+	return &apiKeyRepository{db: db}
+}
+
+func (r *apiKeyRepository) Find(ctx context.Context, id string) (*apikey.Key, error) {
+	// Find rather than First: unknown keys are expected and shouldn't be logged as an error.
+	var row datamodel.APIKey
+	result := r.db.WithContext(ctx).Where("id = ?", id).Limit(1).Find(&row)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	key := toAPIKey(row)
+	return &key, nil
+}
+
+func (r *apiKeyRepository) MarkUsed(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&datamodel.APIKey{}).Where("id = ?", id).Update("last_used_at", at.UTC()).Error
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key apikey.Key) error {
+	return r.db.WithContext(ctx).Create(&datamodel.APIKey{
+		ID:        key.Id,
+		Hash:      key.Hash,
+		SubjectID: key.SubjectId,
+		ClientID:  key.ClientId,
+		OrgID:     key.OrgId,
+		CreatedAt: key.CreatedAt.UTC(),
+	}).Error
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id string, at time.Time) error {
+	result := r.db.WithContext(ctx).Model(&datamodel.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at.UTC())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("api key %q not found or already revoked", id)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]apikey.Key, error) {
+	var rows []datamodel.APIKey
+	if err := r.db.WithContext(ctx).Order("created_at, id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	keys := make([]apikey.Key, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, toAPIKey(row))
+	}
+	return keys, nil
+}
+
+func toAPIKey(row datamodel.APIKey) apikey.Key {
+	return apikey.Key{
+		Id:         row.ID,
+		Hash:       row.Hash,
+		SubjectId:  row.SubjectID,
+		ClientId:   row.ClientID,
+		OrgId:      row.OrgID,
+		CreatedAt:  row.CreatedAt,
+		LastUsedAt: row.LastUsedAt,
+		RevokedAt:  row.RevokedAt,
+	}
+}
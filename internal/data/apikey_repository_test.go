@@ -0,0 +1,55 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/authn/apikey"
+)
+
+func TestAPIKeyRepository_FindUnknown(t *testing.T) {
+	repo := NewAPIKeyRepository(setupInMemoryDB(t))
+
+	key, err := repo.Find(context.Background(), "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, key)
+}
+
+func TestAPIKeyRepository_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAPIKeyRepository(setupInMemoryDB(t))
+	created := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, repo.Create(ctx, apikey.Key{Id: "0011", Hash: "$2b$hash", SubjectId: "ci-bot", ClientId: "ci", OrgId: "12345", CreatedAt: created}))
+	require.NoError(t, repo.Create(ctx, apikey.Key{Id: "0022", Hash: "$2b$hash", SubjectId: "other", CreatedAt: created.Add(time.Minute)}))
+	assert.Error(t, repo.Create(ctx, apikey.Key{Id: "0011", Hash: "$2b$hash", SubjectId: "dup", CreatedAt: created}))
+
+	key, err := repo.Find(ctx, "0011")
+	require.NoError(t, err)
+	require.NotNil(t, key)
+	assert.Equal(t, "ci-bot", key.SubjectId)
+	assert.Equal(t, "ci", key.ClientId)
+	assert.Equal(t, "12345", key.OrgId)
+	assert.Nil(t, key.LastUsedAt)
+	assert.False(t, key.Revoked())
+
+	used := created.Add(time.Hour)
+	require.NoError(t, repo.MarkUsed(ctx, "0011", used))
+	require.NoError(t, repo.Revoke(ctx, "0011", used.Add(time.Hour)))
+	assert.ErrorContains(t, repo.Revoke(ctx, "0011", used.Add(time.Hour)), "not found or already revoked")
+	assert.ErrorContains(t, repo.Revoke(ctx, "unknown", used), "not found or already revoked")
+
+	keys, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "0011", keys[0].Id)
+	require.NotNil(t, keys[0].LastUsedAt)
+	assert.True(t, used.Equal(*keys[0].LastUsedAt))
+	assert.True(t, keys[0].Revoked())
+	assert.Equal(t, "0022", keys[1].Id)
+	assert.False(t, keys[1].Revoked())
+}
@@ -0,0 +1,137 @@
+package data
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/project-kessel/inventory-api/internal/audit"
+	datamodel "github.com/project-kessel/inventory-api/internal/data/model"
+)
+
+// auditEventRepository stores audit entries in the audit_events table, and the checkpoint of each
+// chain in the audit_chains table.
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+var _ audit.Store = &auditEventRepository{}
+var _ audit.Checkpointer = &auditEventRepository{}
+
+func NewAuditEventRepository(db *gorm.DB) audit.Store {
+	return &auditEventRepository{db: db}
+}
+
+// Write stores the entry and moves its chain's checkpoint to it, in one transaction.
+func (r *auditEventRepository) Write(ctx context.Context, entry audit.Entry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(toAuditEvent(entry)).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chain_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_seq", "last_hash"}),
+		}).Create(&datamodel.AuditChain{
+			ChainID:  entry.ChainId,
+			FirstSeq: entry.Sequence,
+			LastSeq:  entry.Sequence,
+			LastHash: entry.Hash,
+		}).Error
+	})
+}
+
+// Checkpoints returns the checkpoint of every chain.
+func (r *auditEventRepository) Checkpoints(ctx context.Context) ([]audit.Checkpoint, error) {
+	var rows []datamodel.AuditChain
+	if err := r.db.WithContext(ctx).Order("chain_id").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	checkpoints := make([]audit.Checkpoint, len(rows))
+	for i, row := range rows {
+		checkpoints[i] = audit.Checkpoint{ChainId: row.ChainID, First: row.FirstSeq, Last: row.LastSeq, Hash: row.LastHash}
+	}
+	return checkpoints, nil
+}
+
+func toAuditEvent(entry audit.Entry) *datamodel.AuditEvent {
+	return &datamodel.AuditEvent{
+		ChainID:            entry.ChainId,
+		Seq:                entry.Sequence,
+		Time:               entry.Time,
+		RequestID:          entry.RequestId,
+		Message:            entry.Message,
+		Action:             entry.Action,
+		Principal:          entry.Principal,
+		ResourceType:       entry.Resource.ResourceType,
+		ResourceID:         entry.Resource.ResourceId,
+		ReporterType:       entry.Resource.ReporterType,
+		ReporterInstanceID: entry.Resource.ReporterInstanceId,
+		OrgID:              entry.Resource.OrgId,
+		Relation:           entry.Relation,
+		Outcome:            string(entry.Outcome),
+		Reason:             entry.Reason,
+		PrevHash:           entry.PrevHash,
+		Hash:               entry.Hash,
+	}
+}
+
+// auditEventBatchSize is the number of entries Each reads per query.
+const auditEventBatchSize = 1000
+
+// Each reads entries in batches, ordered by chain and sequence. Batches are paged by the last
+// (chain_id, seq) key read, since the table has no single-column primary key to page by.
+func (r *auditEventRepository) Each(ctx context.Context, fn func(audit.Entry) error) error {
+	var lastChainId string
+	var lastSeq uint64
+	for first := true; ; first = false {
+		var rows []datamodel.AuditEvent
+		query := r.db.WithContext(ctx).Order("chain_id, seq").Limit(auditEventBatchSize)
+		if !first {
+			query = query.Where("(chain_id, seq) > (?, ?)", lastChainId, lastSeq)
+		}
+		if err := query.Find(&rows).Error; err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if err := fn(toAuditEntry(row)); err != nil {
+				return err
+			}
+		}
+		if len(rows) < auditEventBatchSize {
+			return nil
+		}
+		lastChainId, lastSeq = rows[len(rows)-1].ChainID, rows[len(rows)-1].Seq
+	}
+}
+
+// Close is a no-op: the database is owned by the caller.
+func (r *auditEventRepository) Close() error {
+	return nil
+}
+
+func toAuditEntry(row datamodel.AuditEvent) audit.Entry {
+	return audit.Entry{
+		ChainId:   row.ChainID,
+		Sequence:  row.Seq,
+		Time:      row.Time.UTC(),
+		RequestId: row.RequestID,
+		Event: audit.Event{
+			Message:   row.Message,
+			Action:    row.Action,
+			Principal: row.Principal,
+			Resource: audit.Resource{
+				ResourceType:       row.ResourceType,
+				ResourceId:         row.ResourceID,
+				ReporterType:       row.ReporterType,
+				ReporterInstanceId: row.ReporterInstanceID,
+				OrgId:              row.OrgID,
+			},
+			Relation: row.Relation,
+			Outcome:  audit.Outcome(row.Outcome),
+			Reason:   row.Reason,
+		},
+		PrevHash: row.PrevHash,
+		Hash:     row.Hash,
+	}
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/audit"
+	datamodel "github.com/project-kessel/inventory-api/internal/data/model"
+)
+
+func TestAuditEventRepository_ChainRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	db := setupInMemoryDB(t)
+	repo := NewAuditEventRepository(db)
+
+	auditor := audit.NewAuditor([]audit.Sink{repo}, log.DefaultLogger)
+	auditor.Record(ctx, audit.Event{
+		Message:   "Resource operation completed",
+		Action:    "CREATE",
+		Principal: "hbi",
+		Resource:  audit.Resource{ResourceType: "host", ResourceId: "host-1", ReporterType: "hbi", ReporterInstanceId: "instance-1", OrgId: "12345"},
+		Outcome:   audit.OutcomeSuccess,
+	})
+	auditor.Record(ctx, audit.Event{
+		Message:   "Permission denied",
+		Action:    "CHECK",
+		Principal: "acs",
+		Resource:  audit.Resource{ResourceType: "host", ResourceId: "host-1"},
+		Relation:  "view",
+		Outcome:   audit.OutcomeDenied,
+	})
+	require.NoError(t, auditor.Close())
+
+	var entries []audit.Entry
+	require.NoError(t, repo.Each(ctx, func(entry audit.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "12345", entries[0].Resource.OrgId)
+	assert.Equal(t, "view", entries[1].Relation)
+	assert.NoError(t, audit.Verify(nil, entries), "entries read back hash as written")
+
+	checkpoints, err := repo.(audit.Checkpointer).Checkpoints(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []audit.Checkpoint{{ChainId: entries[0].ChainId, First: 1, Last: 2, Hash: entries[1].Hash}}, checkpoints)
+
+	// Changing a stored entry breaks the chain.
+	require.NoError(t, db.Model(&datamodel.AuditEvent{}).Where("seq = ?", 2).Update("outcome", "success").Error)
+	entries = nil
+	require.NoError(t, repo.Each(ctx, func(entry audit.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	assert.ErrorIs(t, audit.Verify(nil, entries), audit.ErrChainBroken)
+}
+
+func TestAuditEventRepository_EachPagesThroughChains(t *testing.T) {
+	ctx := context.Background()
+	db := setupInMemoryDB(t)
+	repo := NewAuditEventRepository(db)
+
+	// Two chains, each longer than a batch.
+	for range 2 {
+		auditor := audit.NewAuditor([]audit.Sink{repo}, log.DefaultLogger)
+		for range auditEventBatchSize + 250 {
+			auditor.Record(ctx, audit.Event{Message: "Resource deleted", Action: "DELETE", Principal: "hbi", Outcome: audit.OutcomeSuccess})
+		}
+		require.NoError(t, auditor.Close())
+	}
+
+	var entries []audit.Entry
+	require.NoError(t, repo.Each(ctx, func(entry audit.Entry) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	assert.Len(t, entries, 2*(auditEventBatchSize+250))
+	assert.NoError(t, audit.Verify(nil, entries))
+
+	verifier := audit.NewVerifier(nil)
+	require.NoError(t, repo.Each(ctx, verifier.Add))
+	assert.Equal(t, 2, verifier.Chains())
+}
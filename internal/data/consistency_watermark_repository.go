@@ -0,0 +1,77 @@
+package data
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+	datamodel "github.com/project-kessel/inventory-api/internal/data/model"
+)
+
+// consistencyWatermarkRepository stores watermarks in the consistency_watermarks table.
+//
+// Consistency tokens are opaque and can't be compared, so watermarks are ordered by the time the
+// consumer replicated the write instead; updated_at holds that time.
+type consistencyWatermarkRepository struct {
+	db *gorm.DB
+}
+
+var _ bizmodel.ConsistencyWatermarkRepository = &consistencyWatermarkRepository{}
+
+func NewConsistencyWatermarkRepository(db *gorm.DB) bizmodel.ConsistencyWatermarkRepository {
+	return &consistencyWatermarkRepository{db: db}
+}
+
+func (r *consistencyWatermarkRepository) Advance(tx *gorm.DB, scopes []bizmodel.WatermarkScope, token bizmodel.ConsistencyToken, replicatedAt time.Time) error {
+	if len(scopes) == 0 || token == bizmodel.MinimizeLatencyToken {
+		return nil
+	}
+
+	// Sorted, distinct rows keep concurrent upserts from deadlocking on the scope index.
+	sorted := slices.Clone(scopes)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	replicatedAt = replicatedAt.UTC()
+	rows := make([]datamodel.ConsistencyWatermark, 0, len(sorted))
+	for _, scope := range sorted {
+		rows = append(rows, datamodel.ConsistencyWatermark{
+			ID:        uuid.New(),
+			Scope:     scope.String(),
+			Token:     token.Serialize(),
+			UpdatedAt: replicatedAt,
+		})
+	}
+
+	return r.getDBSession(tx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "scope"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Expr{SQL: "consistency_watermarks.updated_at <= excluded.updated_at"},
+		}},
+	}).Create(&rows).Error
+}
+
+func (r *consistencyWatermarkRepository) Find(tx *gorm.DB, scope bizmodel.WatermarkScope) (bizmodel.ConsistencyWatermark, error) {
+	// Find rather than First: a missing watermark is expected and shouldn't be logged as an error.
+	var watermark datamodel.ConsistencyWatermark
+	result := r.getDBSession(tx).Where("scope = ?", scope.String()).Limit(1).Find(&watermark)
+	if result.Error != nil {
+		return bizmodel.ConsistencyWatermark{}, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return bizmodel.ConsistencyWatermark{}, nil
+	}
+	return bizmodel.NewConsistencyWatermark(bizmodel.DeserializeConsistencyToken(watermark.Token), watermark.UpdatedAt), nil
+}
+
+func (r *consistencyWatermarkRepository) getDBSession(tx *gorm.DB) *gorm.DB {
+	if tx == nil {
+		return r.db.Session(&gorm.Session{})
+	}
+	return tx
+}
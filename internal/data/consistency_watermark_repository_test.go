@@ -0,0 +1,65 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+func TestConsistencyWatermarkRepository_FindWithoutWatermark(t *testing.T) {
+	repo := NewConsistencyWatermarkRepository(setupInMemoryDB(t))
+
+	watermark, err := repo.Find(nil, model.NewSubjectWatermarkScope(testSubjectRef("alice")))
+	require.NoError(t, err)
+	assert.True(t, watermark.IsEmpty())
+}
+
+func TestConsistencyWatermarkRepository_AdvanceOverwrites(t *testing.T) {
+	repo := NewConsistencyWatermarkRepository(setupInMemoryDB(t))
+	alice := model.NewSubjectWatermarkScope(testSubjectRef("alice"))
+	bob := model.NewSubjectWatermarkScope(testSubjectRef("bob"))
+	first := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	second := first.Add(time.Second)
+
+	require.NoError(t, repo.Advance(nil, []model.WatermarkScope{alice, bob, alice}, model.ConsistencyToken("token-1"), first))
+	require.NoError(t, repo.Advance(nil, []model.WatermarkScope{alice}, model.ConsistencyToken("token-2"), second))
+
+	watermark, err := repo.Find(nil, alice)
+	require.NoError(t, err)
+	assert.Equal(t, model.ConsistencyToken("token-2"), watermark.Token())
+	assert.True(t, second.Equal(watermark.ReplicatedAt()))
+
+	watermark, err = repo.Find(nil, bob)
+	require.NoError(t, err)
+	assert.Equal(t, model.ConsistencyToken("token-1"), watermark.Token())
+}
+
+func TestConsistencyWatermarkRepository_AdvanceNeverMovesBackwards(t *testing.T) {
+	repo := NewConsistencyWatermarkRepository(setupInMemoryDB(t))
+	later := time.Date(2026, 10, 18, 12, 0, 1, 500, time.UTC)
+	earlier := later.Add(-time.Millisecond)
+
+	require.NoError(t, repo.Advance(nil, []model.WatermarkScope{model.GlobalWatermarkScope}, model.ConsistencyToken("later"), later))
+	require.NoError(t, repo.Advance(nil, []model.WatermarkScope{model.GlobalWatermarkScope}, model.ConsistencyToken("earlier"), earlier))
+
+	watermark, err := repo.Find(nil, model.GlobalWatermarkScope)
+	require.NoError(t, err)
+	assert.Equal(t, model.ConsistencyToken("later"), watermark.Token())
+}
+
+func TestConsistencyWatermarkRepository_AdvanceIgnoresEmptyToken(t *testing.T) {
+	repo := NewConsistencyWatermarkRepository(setupInMemoryDB(t))
+	alice := model.NewSubjectWatermarkScope(testSubjectRef("alice"))
+	now := time.Now()
+
+	require.NoError(t, repo.Advance(nil, []model.WatermarkScope{alice}, model.ConsistencyToken("token-1"), now))
+	require.NoError(t, repo.Advance(nil, []model.WatermarkScope{alice}, model.MinimizeLatencyToken, now.Add(time.Second)))
+
+	watermark, err := repo.Find(nil, alice)
+	require.NoError(t, err)
+	assert.Equal(t, model.ConsistencyToken("token-1"), watermark.Token())
+}
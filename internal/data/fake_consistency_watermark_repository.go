@@ -0,0 +1,42 @@
+package data
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+type fakeConsistencyWatermarkRepository struct {
+	mu         sync.RWMutex
+	watermarks map[bizmodel.WatermarkScope]bizmodel.ConsistencyWatermark
+}
+
+func NewFakeConsistencyWatermarkRepository() bizmodel.ConsistencyWatermarkRepository {
+	return &fakeConsistencyWatermarkRepository{
+		watermarks: make(map[bizmodel.WatermarkScope]bizmodel.ConsistencyWatermark),
+	}
+}
+
+func (r *fakeConsistencyWatermarkRepository) Advance(_ *gorm.DB, scopes []bizmodel.WatermarkScope, token bizmodel.ConsistencyToken, replicatedAt time.Time) error {
+	if token == bizmodel.MinimizeLatencyToken {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, scope := range scopes {
+		if current, ok := r.watermarks[scope]; ok && current.ReplicatedAt().After(replicatedAt) {
+			continue
+		}
+		r.watermarks[scope] = bizmodel.NewConsistencyWatermark(token, replicatedAt)
+	}
+	return nil
+}
+
+func (r *fakeConsistencyWatermarkRepository) Find(_ *gorm.DB, scope bizmodel.WatermarkScope) (bizmodel.ConsistencyWatermark, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.watermarks[scope], nil
+}
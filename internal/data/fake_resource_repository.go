@@ -2,6 +2,7 @@ package data
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -33,6 +34,9 @@ type storedResource struct {
 	localResourceID       string
 	reporterType          string
 	reporterInstanceID    string
+	orgID                 string
+	apiHref               string
+	consoleHref           *string
 	representationVersion uint
 	generation            uint
 	tombstone             bool
@@ -88,6 +92,7 @@ func (f *fakeResourceRepository) Save(tx *gorm.DB, resource bizmodel.Resource, o
 		reporterResourceSnapshot.ReporterResourceKey.ReporterType,
 		reporterResourceSnapshot.ReporterResourceKey.ResourceType,
 		reporterResourceSnapshot.ReporterResourceKey.ReporterInstanceID,
+		reporterResourceSnapshot.ReporterResourceKey.OrgID,
 		reporterResourceSnapshot.RepresentationVersion,
 		reporterResourceSnapshot.Generation,
 	)
@@ -100,6 +105,7 @@ func (f *fakeResourceRepository) Save(tx *gorm.DB, resource bizmodel.Resource, o
 			existingResource.reporterType,
 			existingResource.resourceType,
 			existingResource.reporterInstanceID,
+			existingResource.orgID,
 			existingResource.representationVersion,
 			existingResource.generation,
 		)
@@ -134,6 +140,9 @@ func (f *fakeResourceRepository) Save(tx *gorm.DB, resource bizmodel.Resource, o
 		localResourceID:       reporterResourceSnapshot.ReporterResourceKey.LocalResourceID,
 		reporterType:          reporterResourceSnapshot.ReporterResourceKey.ReporterType,
 		reporterInstanceID:    reporterResourceSnapshot.ReporterResourceKey.ReporterInstanceID,
+		orgID:                 reporterResourceSnapshot.ReporterResourceKey.OrgID,
+		apiHref:               reporterResourceSnapshot.APIHref,
+		consoleHref:           reporterResourceSnapshot.ConsoleHref,
 		representationVersion: reporterResourceSnapshot.RepresentationVersion,
 		createdAt:             reporterResourceSnapshot.CreatedAt,
 		updatedAt:             reporterResourceSnapshot.UpdatedAt,
@@ -149,6 +158,7 @@ func (f *fakeResourceRepository) Save(tx *gorm.DB, resource bizmodel.Resource, o
 		stored.reporterType,
 		stored.resourceType,
 		stored.reporterInstanceID,
+		stored.orgID,
 	)
 	if _, ok := f.representationsByVersion[historyKey]; !ok {
 		f.representationsByVersion[historyKey] = make(map[uint]*storedRepresentation)
@@ -180,6 +190,7 @@ func (f *fakeResourceRepository) FindResourceByKeys(tx *gorm.DB, key bizmodel.Re
 	// Match the real repository's behavior: if reporterInstanceId is empty,
 	// find any resource that matches the other key components
 	searchReporterInstanceId := key.ReporterInstanceId().Serialize()
+	searchOrgId := key.OrgId().Serialize()
 
 	// Find the latest version for the given natural key.
 	// Prefer non-tombstoned resources (a live resource from a newer lifecycle always
@@ -189,7 +200,8 @@ func (f *fakeResourceRepository) FindResourceByKeys(tx *gorm.DB, key bizmodel.Re
 	for _, stored := range f.resourcesByPrimaryKey {
 		if strings.EqualFold(stored.localResourceID, key.LocalResourceId().Serialize()) &&
 			strings.EqualFold(stored.resourceType, key.ResourceType().Serialize()) &&
-			strings.EqualFold(stored.reporterType, key.ReporterType().Serialize()) {
+			strings.EqualFold(stored.reporterType, key.ReporterType().Serialize()) &&
+			(searchOrgId == "" || stored.orgID == searchOrgId) {
 
 			if searchReporterInstanceId == "" || strings.EqualFold(stored.reporterInstanceID, searchReporterInstanceId) {
 				if latestResource == nil {
@@ -231,6 +243,7 @@ func (f *fakeResourceRepository) FindResourceByKeys(tx *gorm.DB, key bizmodel.Re
 				ReporterType:       latestResource.reporterType,
 				ResourceType:       latestResource.resourceType,
 				ReporterInstanceID: latestResource.reporterInstanceID,
+				OrgID:              latestResource.orgID,
 			},
 			ResourceID:            latestResource.resourceID,
 			APIHref:               "",
@@ -263,6 +276,7 @@ func (f *fakeResourceRepository) FindCurrentAndPreviousVersionedRepresentations(
 		key.ReporterType().Serialize(),
 		key.ResourceType().Serialize(),
 		key.ReporterInstanceId().Serialize(),
+		key.OrgId().Serialize(),
 	)
 
 	f.mu.RLock()
@@ -316,6 +330,7 @@ func (f *fakeResourceRepository) FindLatestRepresentations(tx *gorm.DB, key bizm
 		key.ReporterType().Serialize(),
 		key.ResourceType().Serialize(),
 		key.ReporterInstanceId().Serialize(),
+		key.OrgId().Serialize(),
 	)
 
 	f.mu.RLock()
@@ -344,6 +359,83 @@ func (f *fakeResourceRepository) FindLatestRepresentations(tx *gorm.DB, key bizm
 	)
 }
 
+func (f *fakeResourceRepository) FindResourceSummaries(tx *gorm.DB, orgId bizmodel.OrgId, reporterType bizmodel.ReporterType, resourceType bizmodel.ResourceType, localResourceIds []bizmodel.LocalResourceId) ([]bizmodel.ResourceSummary, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(localResourceIds))
+	for _, id := range localResourceIds {
+		wanted[id.Serialize()] = true
+	}
+
+	// Mirror the real repository: latest live row per local resource ID wins.
+	latest := make(map[string]*storedResource)
+	for _, stored := range f.resourcesByPrimaryKey {
+		if stored.tombstone || !wanted[stored.localResourceID] ||
+			stored.reporterType != reporterType.Serialize() || stored.resourceType != resourceType.Serialize() ||
+			(orgId != "" && stored.orgID != orgId.Serialize()) {
+			continue
+		}
+		if current, ok := latest[stored.localResourceID]; !ok || stored.updatedAt.After(current.updatedAt) {
+			latest[stored.localResourceID] = stored
+		}
+	}
+
+	summaries := make([]bizmodel.ResourceSummary, 0, len(latest))
+	for _, id := range localResourceIds {
+		stored, ok := latest[id.Serialize()]
+		if !ok {
+			continue
+		}
+		delete(latest, id.Serialize())
+
+		var common internal.JsonObject
+		var commonVersion uint
+		historyKey := f.makeHistoryKey(stored.localResourceID, stored.reporterType, stored.resourceType, stored.reporterInstanceID, stored.orgID)
+		for _, entry := range f.representationsByVersion[historyKey] {
+			if entry.commonData != nil && (common == nil || entry.commonVersion > commonVersion) {
+				common = entry.commonData
+				commonVersion = entry.commonVersion
+			}
+		}
+
+		summaries = append(summaries, bizmodel.NewResourceSummary(
+			bizmodel.DeserializeLocalResourceId(stored.localResourceID),
+			bizmodel.DeserializeApiHref(stored.apiHref),
+			bizmodel.DeserializeConsoleHref(stored.consoleHref),
+			bizmodel.DeserializeRepresentation(cloneJsonObject(common)),
+		))
+	}
+	return summaries, nil
+}
+
+func (f *fakeResourceRepository) FindResourceOrgIds(tx *gorm.DB, reporterType bizmodel.ReporterType, resourceType bizmodel.ResourceType, localResourceIds []bizmodel.LocalResourceId) (map[bizmodel.LocalResourceId][]bizmodel.OrgId, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(localResourceIds))
+	for _, id := range localResourceIds {
+		wanted[id.Serialize()] = true
+	}
+
+	orgIds := make(map[bizmodel.LocalResourceId][]bizmodel.OrgId)
+	for _, stored := range f.resourcesByPrimaryKey {
+		if stored.tombstone || !wanted[stored.localResourceID] ||
+			stored.reporterType != reporterType.Serialize() || stored.resourceType != resourceType.Serialize() {
+			continue
+		}
+		id := bizmodel.DeserializeLocalResourceId(stored.localResourceID)
+		orgId := bizmodel.DeserializeOrgId(stored.orgID)
+		if !slices.Contains(orgIds[id], orgId) {
+			orgIds[id] = append(orgIds[id], orgId)
+		}
+	}
+	for _, ids := range orgIds {
+		slices.Sort(ids)
+	}
+	return orgIds, nil
+}
+
 func (f *fakeResourceRepository) GetDB() *gorm.DB {
 	// Fake repository doesn't use a real database
 	return nil
@@ -354,12 +446,12 @@ func (f *fakeResourceRepository) GetTransactionManager() bizmodel.TransactionMan
 	return NewFakeTransactionManager(3) // Default retry count
 }
 
-func (f *fakeResourceRepository) makeCompositeKey(localResourceID, reporterType, resourceType, reporterInstanceID string, representationVersion, generation uint) string {
-	return fmt.Sprintf("%s|%s|%s|%s|%d|%d", localResourceID, reporterType, resourceType, reporterInstanceID, representationVersion, generation)
+func (f *fakeResourceRepository) makeCompositeKey(localResourceID, reporterType, resourceType, reporterInstanceID, orgID string, representationVersion, generation uint) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%d|%d", localResourceID, reporterType, resourceType, reporterInstanceID, orgID, representationVersion, generation)
 }
 
-func (f *fakeResourceRepository) makeHistoryKey(localResourceID, reporterType, resourceType, reporterInstanceID string) string {
-	return strings.ToLower(fmt.Sprintf("%s|%s|%s|%s", localResourceID, reporterType, resourceType, reporterInstanceID))
+func (f *fakeResourceRepository) makeHistoryKey(localResourceID, reporterType, resourceType, reporterInstanceID, orgID string) string {
+	return strings.ToLower(fmt.Sprintf("%s|%s|%s|%s|%s", localResourceID, reporterType, resourceType, reporterInstanceID, orgID))
 }
 
 // markTransactionIdAsProcessed marks a transaction ID as processed for idempotency testing
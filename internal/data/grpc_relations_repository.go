@@ -89,6 +89,11 @@ func (a *GRPCRelationsRepository) getCallOptions() ([]grpc.CallOption, error) {
 	return opts, nil
 }
 
+// WritesTuples reports true: tuples are written to relations-api.
+func (a *GRPCRelationsRepository) WritesTuples() bool {
+	return true
+}
+
 func (a *GRPCRelationsRepository) Health(ctx context.Context) (model.HealthResult, error) {
 	opts, err := a.getCallOptions()
 	if err != nil {
@@ -139,6 +144,12 @@ func (a *GRPCRelationsRepository) Check(ctx context.Context, rel model.Relations
 	), nil
 }
 
+// CheckExplain is not supported: the Relations API does not expose check traces.
+func (a *GRPCRelationsRepository) CheckExplain(_ context.Context, _ model.Relationship, _ model.Consistency,
+) (model.CheckExplainResult, error) {
+	return model.CheckExplainResult{}, status.Error(codes.Unimplemented, "check explanations are not supported by the relations-api backend")
+}
+
 func (a *GRPCRelationsRepository) CheckForUpdate(ctx context.Context, rel model.Relationship,
 ) (model.CheckResult, error) {
 	opts, err := a.getCallOptions()
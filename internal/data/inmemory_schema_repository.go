@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -201,6 +202,11 @@ func NewInMemorySchemaRepositoryFromDir(ctx context.Context, resourceDir string,
 			}
 		}
 
+		resourcePolicy, err := loadSchemaPolicyConfig(filepath.Join(resourceDir, resourceType.String(), "config.yaml"))
+		if err != nil {
+			return nil, err
+		}
+
 		reportersDir := filepath.Join(resourceDir, resourceType.String(), "reporters")
 		if _, err := os.Stat(reportersDir); os.IsNotExist(err) {
 			continue
@@ -226,6 +232,11 @@ func NewInMemorySchemaRepositoryFromDir(ctx context.Context, resourceDir string,
 				if err != nil {
 					return nil, err
 				}
+				reporterPolicy, err := loadSchemaPolicyConfig(filepath.Join(reportersDir, reporter.Name(), "config.yaml"))
+				if err != nil {
+					return nil, err
+				}
+				reporterSchemaRepr = reporterSchemaRepr.WithPolicy(reporterPolicy.Apply(resourcePolicy.Apply(model.SchemaPolicy{})))
 				err = repository.CreateReporterSchema(ctx, reporterSchemaRepr)
 				if err != nil {
 					return nil, err
@@ -316,7 +327,11 @@ func NewFromJsonBytes(ctx context.Context, jsonBytes []byte, schemaFromString mo
 		if err != nil {
 			return nil, err
 		}
-		err = repository.CreateReporterSchema(ctx, reporterSchemaRepr)
+		policy, err := schemaPolicyFromJsonContent(jsonContent, resourceType, reporterType)
+		if err != nil {
+			return nil, err
+		}
+		err = repository.CreateReporterSchema(ctx, reporterSchemaRepr.WithPolicy(policy))
 		if err != nil {
 			return nil, err
 		}
@@ -325,6 +340,48 @@ func NewFromJsonBytes(ctx context.Context, jsonBytes []byte, schemaFromString mo
 	return &repository, nil
 }
 
+// loadSchemaPolicyConfig reads the policy block of a config.yaml, which may not exist.
+func loadSchemaPolicyConfig(configPath string) (SchemaPolicyConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return SchemaPolicyConfig{}, nil
+		}
+		return SchemaPolicyConfig{}, fmt.Errorf("failed to read %q: %w", configPath, err)
+	}
+
+	policy, err := ParseSchemaPolicyConfig(content)
+	if err != nil {
+		return SchemaPolicyConfig{}, fmt.Errorf("invalid %q: %w", configPath, err)
+	}
+	return policy, nil
+}
+
+// schemaPolicyFromJsonContent merges the policies of the base64 encoded config:{resource_type} and
+// config:{resource_type}:{reporter_type} entries of the schema cache.
+func schemaPolicyFromJsonContent(jsonContent map[string]interface{}, resourceType model.ResourceType, reporterType model.ReporterType) (model.SchemaPolicy, error) {
+	policy := model.SchemaPolicy{}
+	for _, key := range []string{
+		fmt.Sprintf("config:%s", resourceType),
+		fmt.Sprintf("config:%s:%s", resourceType, reporterType),
+	} {
+		value, ok := jsonContent[key].(string)
+		if !ok {
+			continue
+		}
+		content, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return model.SchemaPolicy{}, fmt.Errorf("invalid base64 config in schema JSON key %q: %w", key, err)
+		}
+		config, err := ParseSchemaPolicyConfig(content)
+		if err != nil {
+			return model.SchemaPolicy{}, fmt.Errorf("invalid config in schema JSON key %q: %w", key, err)
+		}
+		policy = config.Apply(policy)
+	}
+	return policy, nil
+}
+
 func loadResourceSchema(resourceType string, reporterType string, dir string) (string, bool, error) {
 	schemaPath := filepath.Join(dir, resourceType, "reporters", reporterType, fmt.Sprintf("%s.json", resourceType))
 
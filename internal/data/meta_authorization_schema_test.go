@@ -0,0 +1,85 @@
+package data
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authnapi "github.com/project-kessel/inventory-api/internal/authn/api"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
+)
+
+// TestMetaAuthorizationSchema checks the bootstrap schema against the permissions the kessel meta
+// authorizer checks, using the embedded relations implementation.
+func TestMetaAuthorizationSchema(t *testing.T) {
+	base, err := os.ReadFile("../../" + SpicedbSchemaBootstrapFile)
+	require.NoError(t, err)
+	bootstrap, err := os.ReadFile("../../deploy/meta-authorization.zed")
+	require.NoError(t, err)
+	repo, err := NewEmbeddedRelationsRepository(string(base) + "\n" + string(bootstrap))
+	require.NoError(t, err)
+
+	_, err = repo.CreateTuples(context.Background(), []model.RelationsTuple{
+		createRelationship("inventory", "reporter", "hbi", "reporter", "inventory", "client", "svc-hbi", ""),
+		createRelationship("inventory", "reporter", "hbi", "reader", "inventory", "client", "*", ""),
+		createRelationship("inventory", "reporter", "hbi", "reader", "rbac", "principal", "*", ""),
+		createRelationship("inventory", "reporter", "hbi", "explainer", "rbac", "group", "admins", "member"),
+		createRelationship("rbac", "group", "admins", "member", "rbac", "principal", "debugger", ""),
+		createRelationship("inventory", "system", "inventory", "tuple_writer", "inventory", "client", "rbac", ""),
+		createRelationship("inventory", "system", "inventory", "tuple_reader", "inventory", "client", "auditor", ""),
+	}, true, nil)
+	require.NoError(t, err)
+
+	opts := metaauthorizer.NewOptions()
+	opts.KesselEnabled = true
+	opts.KesselCacheTTL = 0
+	completed, errs := metaauthorizer.NewConfig(opts).Complete()
+	require.Empty(t, errs)
+	authorizer := metaauthorizer.NewKesselMetaAuthorizer(repo, completed)
+
+	host := metaauthorizer.NewInventoryResource("hbi", "host", "h1")
+	caller := func(clientId string) authnapi.AuthzContext {
+		return authnapi.AuthzContext{Protocol: authnapi.ProtocolGRPC, Subject: &authnapi.Claims{SubjectId: "s", ClientID: authnapi.ClientID(clientId), AuthType: authnapi.AuthTypeOIDC}}
+	}
+	user := func(subjectId string) authnapi.AuthzContext {
+		return authnapi.AuthzContext{Protocol: authnapi.ProtocolHTTP, Subject: &authnapi.Claims{SubjectId: authnapi.SubjectId(subjectId), AuthType: authnapi.AuthTypeXRhIdentity}}
+	}
+	tests := []struct {
+		name     string
+		object   metaauthorizer.MetaObject
+		relation metaauthorizer.Relation
+		clientId string
+		subject  string
+		want     bool
+	}{
+		{name: "reporter reports", object: host, relation: metaauthorizer.RelationReportResource, clientId: "svc-hbi", want: true},
+		{name: "reporter checks", object: host, relation: metaauthorizer.RelationCheckBulk, clientId: "svc-hbi", want: true},
+		{name: "reader checks through wildcard", object: host, relation: metaauthorizer.RelationCheck, clientId: "notifications", want: true},
+		{name: "reader can't report", object: host, relation: metaauthorizer.RelationReportResource, clientId: "notifications", want: false},
+		{name: "explain through group", object: host, relation: metaauthorizer.RelationCheckExplain, subject: "debugger", want: true},
+		{name: "console user checks self through wildcard", object: host, relation: metaauthorizer.RelationCheckSelf, subject: "user-1", want: true},
+		{name: "user named like a client can't report", object: host, relation: metaauthorizer.RelationReportResource, subject: "svc-hbi", want: false},
+		{name: "user named like a client can't write tuples", object: metaauthorizer.NewTupleSystem(), relation: metaauthorizer.RelationCreateTuples, subject: "rbac", want: false},
+		{name: "reporter can't explain", object: host, relation: metaauthorizer.RelationCheckExplain, clientId: "svc-hbi", want: false},
+		{name: "tuple writer", object: metaauthorizer.NewTupleSystem(), relation: metaauthorizer.RelationAcquireLock, clientId: "rbac", want: true},
+		{name: "tuple reader reads", object: metaauthorizer.NewTupleSystem(), relation: metaauthorizer.RelationReadTuples, clientId: "auditor", want: true},
+		{name: "tuple reader can't write", object: metaauthorizer.NewTupleSystem(), relation: metaauthorizer.RelationDeleteTuples, clientId: "auditor", want: false},
+		{name: "global watermark", object: metaauthorizer.NewConsistencyWatermarkRef(nil), relation: metaauthorizer.RelationGetConsistencyWatermark, clientId: "svc-hbi", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authzCtx := caller(tt.clientId)
+			if tt.subject != "" {
+				authzCtx = user(tt.subject)
+			}
+			allowed, err := authorizer.Check(context.Background(), tt.object, tt.relation, authzCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, allowed)
+		})
+	}
+}
@@ -16,6 +16,12 @@ var MigrationsList = []*gormigrate.Migration{
 	schema.MetricsSummaryMigration(),
 	schema.ReporterResourcesNotTombstoneIdxMigration(),
 	schema.DropOutboxEventsMigration(),
+	schema.RelationTuplesMigration(),
+	schema.ConsistencyWatermarksMigration(),
+	schema.APIKeysMigration(),
+	schema.ReporterResourcesOrgIDMigration(),
+	schema.AuditEventsMigration(),
+	schema.AuditChainsMigration(),
 }
 
 func init() {
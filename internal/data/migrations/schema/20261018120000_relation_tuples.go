@@ -0,0 +1,29 @@
+package schema
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RelationTuple struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResourceType    string    `gorm:"size:512;not null;index:relation_tuple_key_idx,unique,priority:1"`
+	ResourceID      string    `gorm:"size:256;not null;index:relation_tuple_key_idx,unique,priority:2"`
+	Relation        string    `gorm:"size:128;not null;index:relation_tuple_key_idx,unique,priority:3"`
+	SubjectType     string    `gorm:"size:512;not null;index:relation_tuple_key_idx,unique,priority:4;index:relation_tuple_subject_idx,priority:1"`
+	SubjectID       string    `gorm:"size:256;not null;index:relation_tuple_key_idx,unique,priority:5;index:relation_tuple_subject_idx,priority:2"`
+	SubjectRelation string    `gorm:"size:128;not null;default:'';index:relation_tuple_key_idx,unique,priority:6"`
+}
+
+func RelationTuplesMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261018120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&RelationTuple{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&RelationTuple{})
+		},
+	}
+}
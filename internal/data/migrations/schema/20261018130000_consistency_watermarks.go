@@ -0,0 +1,28 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ConsistencyWatermark struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Scope     string    `gorm:"size:1024;not null;uniqueIndex:consistency_watermark_scope_idx"`
+	Token     string    `gorm:"size:1024;not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func ConsistencyWatermarksMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261018130000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&ConsistencyWatermark{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&ConsistencyWatermark{})
+		},
+	}
+}
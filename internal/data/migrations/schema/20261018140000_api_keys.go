@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type APIKey struct {
+	ID         string    `gorm:"size:64;primaryKey"`
+	Hash       string    `gorm:"size:256;not null"`
+	SubjectID  string    `gorm:"size:1024;not null"`
+	ClientID   string    `gorm:"size:1024"`
+	OrgID      string    `gorm:"size:1024"`
+	CreatedAt  time.Time `gorm:"not null"`
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func APIKeysMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261018140000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&APIKey{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&APIKey{})
+		},
+	}
+}
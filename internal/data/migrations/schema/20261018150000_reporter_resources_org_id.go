@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+// ReporterResourcesOrgIDMigration adds the owning organization to reporter resources and makes it
+// part of their natural key, so that resources of different organizations can share local IDs
+// when tenancy is enabled. Existing rows get an empty organization, and are out of reach of
+// callers with tenancy enabled until an admin client claims them for an organization.
+func ReporterResourcesOrgIDMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261018150000",
+		Migrate: func(tx *gorm.DB) error {
+			if err := tx.Exec(`ALTER TABLE reporter_resources ADD COLUMN org_id varchar(128) NOT NULL DEFAULT ''`).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`DROP INDEX IF EXISTS reporter_resource_key_idx`).Error; err != nil {
+				return err
+			}
+			return tx.Exec(`
+				CREATE UNIQUE INDEX reporter_resource_key_idx
+				ON reporter_resources (local_resource_id, reporter_type, resource_type, reporter_instance_id, org_id, representation_version, generation)
+			`).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Exec(`DROP INDEX IF EXISTS reporter_resource_key_idx`).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec(`
+				CREATE UNIQUE INDEX reporter_resource_key_idx
+				ON reporter_resources (local_resource_id, reporter_type, resource_type, reporter_instance_id, representation_version, generation)
+			`).Error; err != nil {
+				return err
+			}
+			return tx.Exec(`ALTER TABLE reporter_resources DROP COLUMN org_id`).Error
+		},
+	}
+}
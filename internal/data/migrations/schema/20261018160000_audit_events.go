@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type AuditEvent struct {
+	ChainID            string    `gorm:"size:36;primaryKey"`
+	Seq                uint64    `gorm:"primaryKey;autoIncrement:false"`
+	Time               time.Time `gorm:"not null;index"`
+	RequestID          string    `gorm:"size:256"`
+	Message            string    `gorm:"size:256"`
+	Action             string    `gorm:"size:64;not null"`
+	Principal          string    `gorm:"size:1024"`
+	ResourceType       string    `gorm:"size:128"`
+	ResourceID         string    `gorm:"size:1024"`
+	ReporterType       string    `gorm:"size:128"`
+	ReporterInstanceID string    `gorm:"size:1024"`
+	OrgID              string    `gorm:"size:128"`
+	Relation           string    `gorm:"size:128"`
+	Outcome            string    `gorm:"size:16;not null"`
+	Reason             string
+	PrevHash           string `gorm:"size:64"`
+	Hash               string `gorm:"size:64;not null"`
+}
+
+func AuditEventsMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261018160000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuditEvent{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AuditEvent{})
+		},
+	}
+}
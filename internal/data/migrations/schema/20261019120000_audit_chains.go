@@ -0,0 +1,25 @@
+package schema
+
+import (
+	"github.com/go-gormigrate/gormigrate/v2"
+	"gorm.io/gorm"
+)
+
+type AuditChain struct {
+	ChainID  string `gorm:"size:36;primaryKey"`
+	FirstSeq uint64 `gorm:"not null"`
+	LastSeq  uint64 `gorm:"not null"`
+	LastHash string `gorm:"size:64;not null"`
+}
+
+func AuditChainsMigration() *gormigrate.Migration {
+	return &gormigrate.Migration{
+		ID: "20261019120000",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(&AuditChain{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&AuditChain{})
+		},
+	}
+}
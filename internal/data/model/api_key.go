@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// APIKey is an api key issued with the apikey command. Hash is a bcrypt or argon2id hash of the
+// whole key; the key itself is never stored.
+type APIKey struct {
+	ID         string    `gorm:"size:64;primaryKey"`
+	Hash       string    `gorm:"size:256;not null"`
+	SubjectID  string    `gorm:"size:1024;not null"`
+	ClientID   string    `gorm:"size:1024"`
+	OrgID      string    `gorm:"size:1024"`
+	CreatedAt  time.Time `gorm:"not null"`
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
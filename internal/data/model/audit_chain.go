@@ -0,0 +1,10 @@
+package model
+
+// AuditChain is the checkpoint of an audit chain written by the postgres audit sink: its first
+// and last entries.
+type AuditChain struct {
+	ChainID  string `gorm:"size:36;primaryKey"`
+	FirstSeq uint64 `gorm:"not null"`
+	LastSeq  uint64 `gorm:"not null"`
+	LastHash string `gorm:"size:64;not null"`
+}
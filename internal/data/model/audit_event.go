@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+// AuditEvent is an entry of the audit chain written by the postgres audit sink.
+type AuditEvent struct {
+	ChainID            string    `gorm:"size:36;primaryKey"`
+	Seq                uint64    `gorm:"primaryKey;autoIncrement:false"`
+	Time               time.Time `gorm:"not null;index"`
+	RequestID          string    `gorm:"size:256"`
+	Message            string    `gorm:"size:256"`
+	Action             string    `gorm:"size:64;not null"`
+	Principal          string    `gorm:"size:1024"`
+	ResourceType       string    `gorm:"size:128"`
+	ResourceID         string    `gorm:"size:1024"`
+	ReporterType       string    `gorm:"size:128"`
+	ReporterInstanceID string    `gorm:"size:1024"`
+	OrgID              string    `gorm:"size:128"`
+	Relation           string    `gorm:"size:128"`
+	Outcome            string    `gorm:"size:16;not null"`
+	Reason             string
+	PrevHash           string `gorm:"size:64"`
+	Hash               string `gorm:"size:64;not null"`
+}
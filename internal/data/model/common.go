@@ -16,6 +16,7 @@ const (
 	MaxConsoleHrefLength        = MaxFieldSize512
 	MaxConsistencyTokenLength   = MaxFieldSize1024
 	MaxTransactionIdLength      = MaxFieldSize128
+	MaxOrgIDLength              = MaxFieldSize128
 
 	MinVersionValue    = 0
 	MinGenerationValue = 0
@@ -34,6 +35,7 @@ const (
 	ColumnRRResourceType     = "resource_type"
 	ColumnReporterType       = "reporter_type"
 	ColumnReporterInstanceID = "reporter_instance_id"
+	ColumnOrgID              = "org_id"
 
 	ColumnRRAPIHref     = "api_href"
 	ColumnRRConsoleHref = "console_href"
@@ -48,6 +50,13 @@ const (
 	ColumnCommonVersion         = "common_version"
 	ColumnTombstone             = "tombstone"
 	ColumnReporterVersion       = "reporter_version"
+
+	ColumnRTResourceType    = "resource_type"
+	ColumnRTResourceID      = "resource_id"
+	ColumnRTRelation        = "relation"
+	ColumnRTSubjectType     = "subject_type"
+	ColumnRTSubjectID       = "subject_id"
+	ColumnRTSubjectRelation = "subject_relation"
 )
 
 const (
@@ -0,0 +1,16 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ConsistencyWatermark is the consistency token of the latest relationships replicated for a scope.
+// UpdatedAt is when the write that produced Token was replicated.
+type ConsistencyWatermark struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Scope     string    `gorm:"size:1024;not null;uniqueIndex:consistency_watermark_scope_idx"`
+	Token     string    `gorm:"size:1024;not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,16 @@
+package model
+
+import "github.com/google/uuid"
+
+// RelationTuple is a relationship stored by the postgres relations implementation. Types use the
+// SpiceDB "reporter/type" form and relations carry the same "t_" prefix the SpiceDB repository
+// writes. SubjectRelation is empty for subjects that are not subject sets.
+type RelationTuple struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ResourceType    string    `gorm:"size:512;not null;index:relation_tuple_key_idx,unique,priority:1"`
+	ResourceID      string    `gorm:"size:256;not null;index:relation_tuple_key_idx,unique,priority:2"`
+	Relation        string    `gorm:"size:128;not null;index:relation_tuple_key_idx,unique,priority:3"`
+	SubjectType     string    `gorm:"size:512;not null;index:relation_tuple_key_idx,unique,priority:4;index:relation_tuple_subject_idx,priority:1"`
+	SubjectID       string    `gorm:"size:256;not null;index:relation_tuple_key_idx,unique,priority:5;index:relation_tuple_subject_idx,priority:2"`
+	SubjectRelation string    `gorm:"size:128;not null;default:'';index:relation_tuple_key_idx,unique,priority:6"`
+}
@@ -16,6 +16,8 @@ type ReporterResourceKey struct {
 	ReporterType       string `gorm:"size:128;index:reporter_resource_key_idx,unique;index:reporter_resource_search_idx,priority:2;not null"`
 	ResourceType       string `gorm:"size:128;index:reporter_resource_key_idx,unique;index:reporter_resource_search_idx,priority:3;not null"`
 	ReporterInstanceID string `gorm:"size:256;index:reporter_resource_key_idx,unique;index:reporter_resource_search_idx,priority:4;not null"`
+	// OrgID is the owning organization when tenancy is enabled, and empty otherwise.
+	OrgID string `gorm:"size:128;index:reporter_resource_key_idx,unique;not null;default:''"`
 }
 
 // ReporterResource is the *latest-state row* for a resource coming from a reporter. It combines an opaque
@@ -93,6 +95,7 @@ func validateReporterResource(r *ReporterResource) error {
 		bizmodel.ValidateMaxLength("ResourceType", r.ResourceType, MaxResourceTypeLength),
 		bizmodel.ValidateStringRequired("ReporterInstanceID", r.ReporterInstanceID),
 		bizmodel.ValidateMaxLength("ReporterInstanceID", r.ReporterInstanceID, MaxReporterInstanceIDLength),
+		bizmodel.ValidateMaxLength("OrgID", r.OrgID, MaxOrgIDLength),
 		bizmodel.ValidateUUIDRequired("ResourceID", r.ResourceID),
 		bizmodel.ValidateMinValue("Generation", r.Generation, MinGenerationValue),
 		bizmodel.ValidateMinValue("RepresentationVersion", r.RepresentationVersion, 0),
@@ -108,6 +111,7 @@ func (rr ReporterResource) SerializeToSnapshot() bizmodel.ReporterResourceSnapsh
 		ReporterType:       rr.ReporterType,
 		ResourceType:       rr.ResourceType,
 		ReporterInstanceID: rr.ReporterInstanceID,
+		OrgID:              rr.OrgID,
 	}
 
 	return bizmodel.ReporterResourceSnapshot{
@@ -133,6 +137,7 @@ func DeserializeReporterResourceFromSnapshot(snapshot bizmodel.ReporterResourceS
 			ReporterType:       snapshot.ReporterResourceKey.ReporterType,
 			ResourceType:       snapshot.ReporterResourceKey.ResourceType,
 			ReporterInstanceID: snapshot.ReporterResourceKey.ReporterInstanceID,
+			OrgID:              snapshot.ReporterResourceKey.OrgID,
 		},
 		ResourceID:            snapshot.ResourceID,
 		APIHref:               snapshot.APIHref,
@@ -20,6 +20,11 @@ func NewAllowAllRelationsRepository(logger *log.Helper) *AllowAllRelationsReposi
 	}
 }
 
+// WritesTuples reports false: tuples written to the repository are discarded.
+func (a *AllowAllRelationsRepository) WritesTuples() bool {
+	return false
+}
+
 func (a *AllowAllRelationsRepository) Health(_ context.Context) (model.HealthResult, error) {
 	return model.NewHealthResult("OK", 200), nil
 }
@@ -29,6 +34,12 @@ func (a *AllowAllRelationsRepository) Check(_ context.Context, _ model.Relations
 	return model.NewCheckResult(true, model.MinimizeLatencyToken), nil
 }
 
+func (a *AllowAllRelationsRepository) CheckExplain(_ context.Context, rel model.Relationship, _ model.Consistency,
+) (model.CheckExplainResult, error) {
+	trace := model.NewCheckTrace(rel, model.CheckTraceKindUnspecified, true, "", false, nil)
+	return model.NewCheckExplainResult(model.NewCheckResult(true, model.MinimizeLatencyToken), trace), nil
+}
+
 func (a *AllowAllRelationsRepository) CheckForUpdate(_ context.Context, _ model.Relationship,
 ) (model.CheckResult, error) {
 	return model.NewCheckResult(true, model.MinimizeLatencyToken), nil
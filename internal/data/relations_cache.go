@@ -0,0 +1,292 @@
+package data
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/cache"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+const (
+	checkCacheOperation     = "Check"
+	checkBulkCacheOperation = "CheckBulk"
+)
+
+// CachingRelationsRepository caches Check and CheckBulk decisions in front of another
+// RelationsRepository. Only minimize_latency and at_least_as_fresh requests are cached, keyed by
+// the relationship and the consistency token, so an at_least_as_fresh request is only ever
+// answered by a decision made for exactly the same token. Every other call passes through.
+//
+// Tuple writes made through the repository clear the cache. Because a write can change any
+// decision derived from the written tuples, the whole cache is cleared rather than the entries
+// for the written objects.
+type CachingRelationsRepository struct {
+	model.RelationsRepository
+
+	ttl        time.Duration
+	maxEntries int
+	metrics    *metricscollector.MetricsCollector
+	logger     *log.Helper
+	now        func() time.Time
+
+	mu         sync.Mutex
+	entries    map[decisionCacheKey]*list.Element
+	lru        *list.List
+	generation uint64
+}
+
+var _ model.RelationsRepository = &CachingRelationsRepository{}
+
+type decisionCacheKey struct {
+	consistency model.ConsistencyType
+	token       model.ConsistencyToken
+
+	objectType              string
+	objectId                string
+	objectReporterType      string
+	objectReporterInstance  string
+	relation                string
+	subjectType             string
+	subjectId               string
+	subjectReporterType     string
+	subjectReporterInstance string
+	subjectRelation         string
+}
+
+type decisionCacheEntry struct {
+	key       decisionCacheKey
+	allowed   bool
+	token     model.ConsistencyToken
+	expiresAt time.Time
+}
+
+// NewCachingRelationsRepository wraps repository with a decision cache configured by config.
+func NewCachingRelationsRepository(repository model.RelationsRepository, config cache.CompletedConfig, metrics *metricscollector.MetricsCollector, logger *log.Helper) *CachingRelationsRepository {
+	logger.Infof("Using relations decision cache: ttl=%s, max-entries=%d", config.TTL, config.MaxEntries)
+	return &CachingRelationsRepository{
+		RelationsRepository: repository,
+		ttl:                 config.TTL,
+		maxEntries:          config.MaxEntries,
+		metrics:             metrics,
+		logger:              logger,
+		now:                 time.Now,
+		entries:             map[decisionCacheKey]*list.Element{},
+		lru:                 list.New(),
+	}
+}
+
+// WritesTuples reports whether the wrapped repository stores tuples.
+func (c *CachingRelationsRepository) WritesTuples() bool {
+	return model.WritesTuples(c.RelationsRepository)
+}
+
+func (c *CachingRelationsRepository) Check(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	if !isCacheableConsistency(consistency) {
+		return c.RelationsRepository.Check(ctx, rel, consistency)
+	}
+
+	key := newDecisionCacheKey(rel, consistency)
+	if entry, ok := c.get(key); ok {
+		c.recordHit(checkCacheOperation)
+		return model.NewCheckResult(entry.allowed, entry.token), nil
+	}
+	c.recordMiss(checkCacheOperation)
+
+	generation := c.currentGeneration()
+	result, err := c.RelationsRepository.Check(ctx, rel, consistency)
+	if err != nil || result.FailOpen() {
+		return result, err
+	}
+	c.put(generation, key, result.Allowed(), result.ConsistencyToken())
+	return result, nil
+}
+
+func (c *CachingRelationsRepository) CheckBulk(ctx context.Context, rels []model.Relationship, consistency model.Consistency,
+) (model.CheckBulkResult, error) {
+	if !isCacheableConsistency(consistency) || len(rels) == 0 {
+		return c.RelationsRepository.CheckBulk(ctx, rels, consistency)
+	}
+
+	pairs := make([]model.CheckBulkResultPair, len(rels))
+	keys := make([]decisionCacheKey, len(rels))
+	var missed []int
+	var token model.ConsistencyToken
+	for i, rel := range rels {
+		keys[i] = newDecisionCacheKey(rel, consistency)
+		if entry, ok := c.get(keys[i]); ok {
+			c.recordHit(checkBulkCacheOperation)
+			pairs[i] = model.NewCheckBulkResultPair(rel, model.NewCheckBulkResultItem(entry.allowed, nil, 0))
+			if token == "" {
+				token = entry.token
+			}
+			continue
+		}
+		c.recordMiss(checkBulkCacheOperation)
+		missed = append(missed, i)
+	}
+
+	if len(missed) == 0 {
+		return model.NewCheckBulkResult(pairs, token), nil
+	}
+
+	missedRels := make([]model.Relationship, len(missed))
+	for j, i := range missed {
+		missedRels[j] = rels[i]
+	}
+
+	generation := c.currentGeneration()
+	result, err := c.RelationsRepository.CheckBulk(ctx, missedRels, consistency)
+	if err != nil {
+		return result, err
+	}
+	if len(result.Pairs()) != len(missed) {
+		// The backend did not answer one result per request, so results cannot be matched to
+		// cache keys. Fall back to the uncached answer for the whole request.
+		c.logger.Warnf("relations decision cache: expected %d CheckBulk results, got %d", len(missed), len(result.Pairs()))
+		return c.RelationsRepository.CheckBulk(ctx, rels, consistency)
+	}
+
+	for j, i := range missed {
+		pair := result.Pairs()[j]
+		pairs[i] = pair
+		if item := pair.Result(); item.Err() == nil {
+			c.put(generation, keys[i], item.Allowed(), result.ConsistencyToken())
+		}
+	}
+	return model.NewCheckBulkResult(pairs, result.ConsistencyToken()), nil
+}
+
+func (c *CachingRelationsRepository) CreateTuples(ctx context.Context, tuples []model.RelationsTuple,
+	upsert bool, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	defer c.invalidate()
+	return c.RelationsRepository.CreateTuples(ctx, tuples, upsert, fencing)
+}
+
+func (c *CachingRelationsRepository) DeleteTuples(ctx context.Context, filter model.TupleFilter,
+	fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	defer c.invalidate()
+	return c.RelationsRepository.DeleteTuples(ctx, filter, fencing)
+}
+
+// isCacheableConsistency reports whether decisions made with consistency may be cached.
+// Unspecified and at_least_as_acknowledged requests are resolved by the backend or the usecase
+// and always pass through.
+func isCacheableConsistency(consistency model.Consistency) bool {
+	switch model.ConsistencyTypeOf(consistency) {
+	case model.ConsistencyMinimizeLatency, model.ConsistencyAtLeastAsFresh:
+		return true
+	default:
+		return false
+	}
+}
+
+func newDecisionCacheKey(rel model.Relationship, consistency model.Consistency) decisionCacheKey {
+	key := decisionCacheKey{consistency: model.ConsistencyTypeOf(consistency)}
+	if token := model.ConsistencyAtLeastAsFreshToken(consistency); token != nil {
+		key.token = *token
+	}
+
+	object := rel.Object()
+	key.objectType = object.ResourceType().String()
+	key.objectId = object.ResourceId().String()
+	if reporter := object.Reporter(); reporter != nil {
+		key.objectReporterType = reporter.ReporterType().String()
+		if reporter.HasInstanceId() {
+			key.objectReporterInstance = reporter.InstanceId().String()
+		}
+	}
+	key.relation = rel.Relation().String()
+
+	subject := rel.Subject().Resource()
+	key.subjectType = subject.ResourceType().String()
+	key.subjectId = subject.ResourceId().String()
+	if reporter := subject.Reporter(); reporter != nil {
+		key.subjectReporterType = reporter.ReporterType().String()
+		if reporter.HasInstanceId() {
+			key.subjectReporterInstance = reporter.InstanceId().String()
+		}
+	}
+	if relation := rel.Subject().Relation(); relation != nil {
+		key.subjectRelation = relation.String()
+	}
+	return key
+}
+
+func (c *CachingRelationsRepository) get(key decisionCacheKey) (decisionCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return decisionCacheEntry{}, false
+	}
+	entry := element.Value.(*decisionCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.lru.Remove(element)
+		delete(c.entries, key)
+		return decisionCacheEntry{}, false
+	}
+	c.lru.MoveToFront(element)
+	return *entry, true
+}
+
+// put stores a decision unless the cache was invalidated since generation was read, in which
+// case the decision may predate a tuple write and is dropped.
+func (c *CachingRelationsRepository) put(generation uint64, key decisionCacheKey, allowed bool, token model.ConsistencyToken) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+
+	entry := &decisionCacheEntry{key: key, allowed: allowed, token: token, expiresAt: c.now().Add(c.ttl)}
+	if element, ok := c.entries[key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(entry)
+
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*decisionCacheEntry).key)
+	}
+}
+
+func (c *CachingRelationsRepository) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *CachingRelationsRepository) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.entries = map[decisionCacheKey]*list.Element{}
+	c.lru.Init()
+}
+
+func (c *CachingRelationsRepository) recordHit(operation string) {
+	if c.metrics != nil && c.metrics.RelationsCacheHits != nil {
+		metricscollector.Incr(c.metrics.RelationsCacheHits, operation)
+	}
+}
+
+func (c *CachingRelationsRepository) recordMiss(operation string) {
+	if c.metrics != nil && c.metrics.RelationsCacheMisses != nil {
+		metricscollector.Incr(c.metrics.RelationsCacheMisses, operation)
+	}
+}
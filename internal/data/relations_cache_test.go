@@ -0,0 +1,221 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/cache"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+// countingRelationsRepository records the Check and CheckBulk calls that reach the backend.
+type countingRelationsRepository struct {
+	*SimpleRelationsRepository
+	checks        int
+	bulkRequested []int
+}
+
+func (r *countingRelationsRepository) Check(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	r.checks++
+	return r.SimpleRelationsRepository.Check(ctx, rel, consistency)
+}
+
+func (r *countingRelationsRepository) CheckBulk(ctx context.Context, rels []model.Relationship, consistency model.Consistency,
+) (model.CheckBulkResult, error) {
+	r.bulkRequested = append(r.bulkRequested, len(rels))
+	return r.SimpleRelationsRepository.CheckBulk(ctx, rels, consistency)
+}
+
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func newTestCachingRelationsRepository(t *testing.T, maxEntries int) (*CachingRelationsRepository, *countingRelationsRepository, *fakeClock) {
+	t.Helper()
+	inner := &countingRelationsRepository{SimpleRelationsRepository: NewSimpleRelationsRepository()}
+	completed, errs := cache.NewConfig(&cache.Options{Enabled: true, TTLSeconds: 10, MaxEntries: maxEntries}).Complete()
+	require.Nil(t, errs)
+
+	repo := NewCachingRelationsRepository(inner, completed, metricscollector.NewFakeMetricsCollector(), log.NewHelper(log.DefaultLogger))
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	repo.now = clock.Now
+	return repo, inner, clock
+}
+
+func TestCachingRelationsRepository_Check(t *testing.T) {
+	ctx := context.Background()
+	rel := testRelationship("hbi", "host", "host-1", "view", "alice")
+
+	t.Run("minimize_latency decisions are cached until they expire", func(t *testing.T) {
+		repo, inner, clock := newTestCachingRelationsRepository(t, 10)
+
+		result, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.False(t, result.Allowed())
+
+		// A write that does not go through this process is not visible until the entry expires.
+		inner.Grant("alice", "view", "hbi", "host", "host-1")
+		result, err = repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.False(t, result.Allowed())
+		assert.Equal(t, 1, inner.checks)
+		assert.Equal(t, 1, metricscollector.GetRelationsCacheHitCount())
+		assert.Equal(t, 1, metricscollector.GetRelationsCacheMissCount())
+
+		clock.now = clock.now.Add(10 * time.Second)
+		result, err = repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.True(t, result.Allowed())
+		assert.Equal(t, 2, inner.checks)
+	})
+
+	t.Run("at_least_as_fresh decisions are only served for the same token", func(t *testing.T) {
+		repo, inner, _ := newTestCachingRelationsRepository(t, 10)
+		inner.Grant("alice", "view", "hbi", "host", "host-1")
+		token := model.ConsistencyToken(simpleFormatConsistencyToken(inner.Version()))
+
+		_, err := repo.Check(ctx, rel, model.NewConsistencyAtLeastAsFresh(token))
+		require.NoError(t, err)
+		_, err = repo.Check(ctx, rel, model.NewConsistencyAtLeastAsFresh(token))
+		require.NoError(t, err)
+		assert.Equal(t, 1, inner.checks)
+
+		_, err = repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		_, err = repo.Check(ctx, rel, model.NewConsistencyAtLeastAsFresh("other-token"))
+		require.NoError(t, err)
+		assert.Equal(t, 3, inner.checks)
+	})
+
+	t.Run("other consistency preferences are never cached", func(t *testing.T) {
+		repo, inner, _ := newTestCachingRelationsRepository(t, 10)
+
+		for _, consistency := range []model.Consistency{
+			model.NewConsistencyUnspecified(),
+			model.NewConsistencyAtLeastAsAcknowledged(),
+			nil,
+		} {
+			_, err := repo.Check(ctx, rel, consistency)
+			require.NoError(t, err)
+			_, err = repo.Check(ctx, rel, consistency)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 6, inner.checks)
+		assert.Equal(t, 0, metricscollector.GetRelationsCacheHitCount())
+		assert.Equal(t, 0, metricscollector.GetRelationsCacheMissCount())
+	})
+
+	t.Run("tuple writes through the repository invalidate cached decisions", func(t *testing.T) {
+		repo, inner, _ := newTestCachingRelationsRepository(t, 10)
+
+		result, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.False(t, result.Allowed())
+
+		_, err = repo.CreateTuples(ctx, []model.RelationsTuple{testPrincipalTuple("hbi", "host", "host-1", "view", "alice")}, true, nil)
+		require.NoError(t, err)
+		result, err = repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.True(t, result.Allowed())
+
+		_, err = repo.DeleteTuples(ctx, testTupleFilterForPrincipalTuple("hbi", "host", "host-1", "view", "alice"), nil)
+		require.NoError(t, err)
+		result, err = repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.False(t, result.Allowed())
+		assert.Equal(t, 3, inner.checks)
+	})
+
+	t.Run("decisions made before an invalidation are not stored", func(t *testing.T) {
+		repo, inner, _ := newTestCachingRelationsRepository(t, 10)
+
+		key := newDecisionCacheKey(rel, model.NewConsistencyMinimizeLatency())
+		generation := repo.currentGeneration()
+		repo.invalidate()
+		repo.put(generation, key, true, "")
+
+		_, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.Equal(t, 1, inner.checks)
+	})
+
+	t.Run("least recently used decisions are evicted", func(t *testing.T) {
+		repo, inner, _ := newTestCachingRelationsRepository(t, 2)
+		first := testRelationship("hbi", "host", "host-1", "view", "alice")
+		second := testRelationship("hbi", "host", "host-2", "view", "alice")
+		third := testRelationship("hbi", "host", "host-3", "view", "alice")
+
+		for _, r := range []model.Relationship{first, second, first, third, first} {
+			_, err := repo.Check(ctx, r, model.NewConsistencyMinimizeLatency())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 3, inner.checks)
+
+		_, err := repo.Check(ctx, second, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.Equal(t, 4, inner.checks)
+	})
+
+	t.Run("fail-open answers are not cached", func(t *testing.T) {
+		failing := newFailingRelationsRepository(errBackendUnavailable)
+		resilient := newTestResilientRelationsRepository(t, failing, func(o *resilience.Options) {
+			o.RetryMaxAttempts = 1
+			o.CheckFailureMode = resilience.FailOpen
+		})
+		completed, errs := cache.NewConfig(&cache.Options{Enabled: true, TTLSeconds: 10, MaxEntries: 10}).Complete()
+		require.Nil(t, errs)
+		repo := NewCachingRelationsRepository(resilient, completed, metricscollector.NewFakeMetricsCollector(), log.NewHelper(log.DefaultLogger))
+
+		result, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.True(t, result.FailOpen())
+
+		result, err = repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.False(t, result.Allowed(), "the backend's own decision is asked for once it recovers")
+		assert.Equal(t, 2, failing.callCount("Check"))
+	})
+}
+
+func TestCachingRelationsRepository_CheckBulk(t *testing.T) {
+	ctx := context.Background()
+	host1 := testRelationship("hbi", "host", "host-1", "view", "alice")
+	host2 := testRelationship("hbi", "host", "host-2", "view", "alice")
+	host3 := testRelationship("hbi", "host", "host-3", "view", "alice")
+
+	repo, inner, _ := newTestCachingRelationsRepository(t, 10)
+	inner.Grant("alice", "view", "hbi", "host", "host-2")
+
+	_, err := repo.Check(ctx, host2, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+
+	result, err := repo.CheckBulk(ctx, []model.Relationship{host1, host2, host3}, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	require.Len(t, result.Pairs(), 3)
+	assert.Equal(t, host1, result.Pairs()[0].Request())
+	assert.False(t, result.Pairs()[0].Result().Allowed())
+	assert.Equal(t, host2, result.Pairs()[1].Request())
+	assert.True(t, result.Pairs()[1].Result().Allowed())
+	assert.Equal(t, host3, result.Pairs()[2].Request())
+	assert.False(t, result.Pairs()[2].Result().Allowed())
+	assert.Equal(t, []int{2}, inner.bulkRequested)
+
+	result, err = repo.CheckBulk(ctx, []model.Relationship{host3, host2, host1}, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	require.Len(t, result.Pairs(), 3)
+	assert.Equal(t, host3, result.Pairs()[0].Request())
+	assert.True(t, result.Pairs()[1].Result().Allowed())
+	assert.Equal(t, []int{2}, inner.bulkRequested, "fully cached requests do not reach the backend")
+
+	_, err = repo.CheckBulk(ctx, []model.Relationship{host1, host2}, model.NewConsistencyAtLeastAsAcknowledged())
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 2}, inner.bulkRequested)
+}
@@ -0,0 +1,440 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/google/uuid"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// embeddedTuples holds tuples in memory, keyed by tupleset.
+type embeddedTuples map[zedTupleset]map[zedSubject]bool
+
+func (t embeddedTuples) clone() embeddedTuples {
+	cloned := make(embeddedTuples, len(t))
+	for tupleset, subjects := range t {
+		cloned[tupleset] = maps.Clone(subjects)
+	}
+	return cloned
+}
+
+func (t embeddedTuples) readSubjects(tupleset zedTupleset) ([]zedSubject, error) {
+	return sortedZedSubjects(slices.Collect(maps.Keys(t[tupleset]))), nil
+}
+
+func (t embeddedTuples) readObjectIDs(objectType, subjectRelation string) ([]string, error) {
+	ids := map[string]bool{}
+	for tupleset, subjects := range t {
+		if tupleset.ResourceType == objectType && subjectRelation == "" {
+			ids[tupleset.ResourceID] = true
+		}
+		for sub := range subjects {
+			if sub.Type == objectType && sub.ID != "*" && (sub.Relation == subjectRelation || subjectRelation == "") {
+				ids[sub.ID] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(ids)), nil
+}
+
+// EmbeddedRelationsRepository implements RelationsRepository in process by evaluating a SpiceDB
+// schema over tuples held in memory. Unlike SimpleRelationsRepository, permissions are computed
+// from the schema: unions, intersections, exclusions, arrows, subject sets and wildcards are all
+// resolved, so workspace inheritance and role bindings behave as they do against SpiceDB.
+// Tuple writes are validated against the schema in the same way SpiceDB validates them.
+//
+// # Consistency
+//
+// Every mutation advances a version counter and consistency tokens are the version number.
+// Only the latest state is kept unless a snapshot is retained with RetainCurrentSnapshot, in which
+// case "at least as fresh" reads use the oldest retained snapshot that is >= the requested version.
+//
+// # Pagination
+//
+// Results are returned in a stable order and continuation tokens are offsets into that order.
+// They are only meaningful while the tuples they were read from are unchanged.
+type EmbeddedRelationsRepository struct {
+	mu        sync.RWMutex
+	schema    *zedSchema
+	version   int64
+	tuples    embeddedTuples
+	snapshots map[int64]embeddedTuples
+}
+
+var _ model.RelationsRepository = &EmbeddedRelationsRepository{}
+
+// NewEmbeddedRelationsRepository parses the given SpiceDB schema and creates a repository with no
+// tuples at version 1.
+func NewEmbeddedRelationsRepository(schema string) (*EmbeddedRelationsRepository, error) {
+	parsed, err := parseZedSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing relations schema: %w", err)
+	}
+	return &EmbeddedRelationsRepository{
+		schema:    parsed,
+		version:   1,
+		tuples:    embeddedTuples{},
+		snapshots: map[int64]embeddedTuples{},
+	}, nil
+}
+
+// NewEmbeddedRelationsRepositoryFromFile creates an EmbeddedRelationsRepository from a schema file.
+func NewEmbeddedRelationsRepositoryFromFile(schemaFile string) (*EmbeddedRelationsRepository, error) {
+	schema, err := readFile(schemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading relations schema file: %w", err)
+	}
+	return NewEmbeddedRelationsRepository(schema)
+}
+
+// Version returns the current version number.
+func (e *EmbeddedRelationsRepository) Version() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.version
+}
+
+// RetainCurrentSnapshot saves the current tuple state as a retained snapshot.
+func (e *EmbeddedRelationsRepository) RetainCurrentSnapshot() int64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.snapshots[e.version] = e.tuples.clone()
+	return e.version
+}
+
+// ReleaseSnapshot removes a retained snapshot.
+func (e *EmbeddedRelationsRepository) ReleaseSnapshot(version int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.snapshots, version)
+}
+
+// snapshotFor returns the tuples and version to read for the given consistency.
+func (e *EmbeddedRelationsRepository) snapshotFor(consistency model.Consistency) (embeddedTuples, int64) {
+	var requested int64
+	if token := consistencyToSimpleToken(consistency); token != "" {
+		if parsed, err := simpleParseConsistencyToken(token); err == nil {
+			requested = parsed
+		}
+	}
+
+	versions := slices.Sorted(maps.Keys(e.snapshots))
+	idx, _ := slices.BinarySearch(versions, requested)
+	if idx < len(versions) {
+		return e.snapshots[versions[idx]], versions[idx]
+	}
+	return e.tuples, e.version
+}
+
+func (e *EmbeddedRelationsRepository) evaluator(tuples embeddedTuples, explain bool) *zedEvaluator {
+	return newZedEvaluator(e.schema, tuples, explain)
+}
+
+func embeddedConsistencyToken(version int64) model.ConsistencyToken {
+	return model.DeserializeConsistencyToken(simpleFormatConsistencyToken(version))
+}
+
+// WritesTuples reports true: tuples are stored in memory.
+func (e *EmbeddedRelationsRepository) WritesTuples() bool {
+	return true
+}
+
+func (e *EmbeddedRelationsRepository) Health(_ context.Context) (model.HealthResult, error) {
+	return model.NewHealthResult("OK", 200), nil
+}
+
+func (e *EmbeddedRelationsRepository) Check(_ context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tuples, version := e.snapshotFor(consistency)
+	allowed, _, err := e.evaluator(tuples, false).checkRelationship(rel)
+	if err != nil {
+		return model.CheckResult{}, err
+	}
+	return model.NewCheckResult(allowed, embeddedConsistencyToken(version)), nil
+}
+
+func (e *EmbeddedRelationsRepository) CheckForUpdate(_ context.Context, rel model.Relationship,
+) (model.CheckResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	allowed, _, err := e.evaluator(e.tuples, false).checkRelationship(rel)
+	if err != nil {
+		return model.CheckResult{}, err
+	}
+	return model.NewCheckResult(allowed, embeddedConsistencyToken(e.version)), nil
+}
+
+func (e *EmbeddedRelationsRepository) CheckBulk(_ context.Context, rels []model.Relationship, consistency model.Consistency,
+) (model.CheckBulkResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tuples, version := e.snapshotFor(consistency)
+	return e.evaluator(tuples, false).checkBulk(rels, embeddedConsistencyToken(version))
+}
+
+func (e *EmbeddedRelationsRepository) CheckForUpdateBulk(_ context.Context, rels []model.Relationship,
+) (model.CheckBulkResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.evaluator(e.tuples, false).checkBulk(rels, embeddedConsistencyToken(e.version))
+}
+
+// CheckExplain performs a Check and returns the evaluation tree: one step per relation or
+// permission visited, with the schema expression for permissions.
+func (e *EmbeddedRelationsRepository) CheckExplain(_ context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckExplainResult, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tuples, version := e.snapshotFor(consistency)
+	allowed, trace, err := e.evaluator(tuples, true).checkRelationship(rel)
+	if err != nil {
+		return model.CheckExplainResult{}, err
+	}
+	return model.NewCheckExplainResult(model.NewCheckResult(allowed, embeddedConsistencyToken(version)), *trace), nil
+}
+
+func (e *EmbeddedRelationsRepository) LookupObjects(_ context.Context,
+	objectType model.RepresentationType,
+	relation model.Relation, subject model.SubjectReference,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupObjectsItem], error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tuples, _ := e.snapshotFor(consistency)
+	results, err := e.evaluator(tuples, false).lookupObjects(objectType, relation, subject, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &simpleLookupObjectsStream{results: results}, nil
+}
+
+func (e *EmbeddedRelationsRepository) LookupSubjects(_ context.Context,
+	object model.ResourceReference, relation model.Relation,
+	subjectType model.RepresentationType,
+	subjectRelation *model.Relation,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupSubjectsItem], error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tuples, _ := e.snapshotFor(consistency)
+	results, err := e.evaluator(tuples, false).lookupSubjects(object, relation, subjectType, subjectRelation, pagination)
+	if err != nil {
+		return nil, err
+	}
+	return &simpleLookupSubjectsStream{results: results}, nil
+}
+
+func (e *EmbeddedRelationsRepository) CreateTuples(_ context.Context, tuples []model.RelationsTuple, upsert bool, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := e.checkFencing(fencing); err != nil {
+		return model.TuplesResult{}, err
+	}
+
+	type write struct {
+		tupleset zedTupleset
+		subject  zedSubject
+	}
+	writes := make([]write, 0, len(tuples))
+	for _, tuple := range tuples {
+		tupleset, subject := zedTupleFromModel(tuple)
+		if err := e.schema.allowsSubject(tupleset.ResourceType, tupleset.Relation, subject.Type, subject.ID, subject.Relation); err != nil {
+			return model.TuplesResult{}, fmt.Errorf("error writing relationships: %w", err)
+		}
+		if !upsert && e.tuples[tupleset][subject] {
+			return model.TuplesResult{}, fmt.Errorf("error writing relationships: relationship %s:%s#%s@%s:%s already exists",
+				tupleset.ResourceType, tupleset.ResourceID, tupleset.Relation, subject.Type, subject.ID)
+		}
+		writes = append(writes, write{tupleset: tupleset, subject: subject})
+	}
+
+	for _, w := range writes {
+		if e.tuples[w.tupleset] == nil {
+			e.tuples[w.tupleset] = map[zedSubject]bool{}
+		}
+		e.tuples[w.tupleset][w.subject] = true
+	}
+	e.version++
+
+	return model.NewTuplesResult(embeddedConsistencyToken(e.version)), nil
+}
+
+func (e *EmbeddedRelationsRepository) DeleteTuples(_ context.Context, filter model.TupleFilter, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	relationshipFilter, err := tupleFilterToSpiceDBFilter(filter)
+	if err != nil {
+		return model.TuplesResult{}, fmt.Errorf("relations request validation: %w", err)
+	}
+	if err := e.checkFencing(fencing); err != nil {
+		return model.TuplesResult{}, err
+	}
+
+	for tupleset, subjects := range e.tuples {
+		for subject := range subjects {
+			if zedMatchesFilter(tupleset, subject, relationshipFilter) {
+				delete(subjects, subject)
+			}
+		}
+		if len(subjects) == 0 {
+			delete(e.tuples, tupleset)
+		}
+	}
+	e.version++
+
+	return model.NewTuplesResult(embeddedConsistencyToken(e.version)), nil
+}
+
+func (e *EmbeddedRelationsRepository) ReadTuples(_ context.Context, filter model.TupleFilter, pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.ReadTuplesItem], error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	relationshipFilter, err := tupleFilterToSpiceDBFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf("relations request validation: %w", err)
+	}
+	tuples, version := e.snapshotFor(consistency)
+
+	tuplesets := slices.SortedFunc(maps.Keys(tuples), func(a, b zedTupleset) int {
+		return strings.Compare(a.ResourceType+":"+a.ResourceID+"#"+a.Relation, b.ResourceType+":"+b.ResourceID+"#"+b.Relation)
+	})
+	var items []model.ReadTuplesItem
+	for _, tupleset := range tuplesets {
+		for _, subject := range sortedZedSubjects(slices.Collect(maps.Keys(tuples[tupleset]))) {
+			if !zedMatchesFilter(tupleset, subject, relationshipFilter) {
+				continue
+			}
+			item, err := zedReadTuplesItem(tupleset, subject, model.DeserializeContinuationToken(""), embeddedConsistencyToken(version))
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	var results []model.ReadTuplesItem
+	for i, item := range paginate(items, pagination) {
+		results = append(results, model.NewReadTuplesItem(item.Object(), item.Relation(), item.Subject(),
+			continuationAfter(pagination, i), item.ConsistencyToken()))
+	}
+	return &simpleReadTuplesStream{results: results}, nil
+}
+
+// AcquireLock replaces the lock's fencing token, as the SpiceDB repository does.
+func (e *EmbeddedRelationsRepository) AcquireLock(_ context.Context, lockId model.LockId) (model.AcquireLockResult, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	token := uuid.New().String()
+	tupleset := zedTupleset{
+		ResourceType: lockType,
+		ResourceID:   lockId.Serialize(),
+		Relation:     addRelationPrefix(lockVersionRelation, relationPrefix),
+	}
+	if err := e.schema.allowsSubject(tupleset.ResourceType, tupleset.Relation, lockVersionType, token, ""); err != nil {
+		return model.AcquireLockResult{}, fmt.Errorf("error writing relationships: %w", err)
+	}
+
+	e.tuples[tupleset] = map[zedSubject]bool{{Type: lockVersionType, ID: token}: true}
+	e.version++
+
+	return model.NewAcquireLockResult(model.DeserializeLockToken(token)), nil
+}
+
+// checkFencing fails unless the lock still holds the fencing token.
+func (e *EmbeddedRelationsRepository) checkFencing(fencing *model.FencingCheck) error {
+	if fencing == nil {
+		return nil
+	}
+	tupleset := zedTupleset{
+		ResourceType: lockType,
+		ResourceID:   fencing.LockId().Serialize(),
+		Relation:     addRelationPrefix(lockVersionRelation, relationPrefix),
+	}
+	if !e.tuples[tupleset][zedSubject{Type: lockVersionType, ID: fencing.LockToken().Serialize()}] {
+		return fmt.Errorf("error writing relationships: fencing token for lock %q is no longer valid", fencing.LockId().Serialize())
+	}
+	return nil
+}
+
+func zedMatchesFilter(tupleset zedTupleset, subject zedSubject, filter *v1.RelationshipFilter) bool {
+	if filter.GetResourceType() != "" && filter.GetResourceType() != tupleset.ResourceType {
+		return false
+	}
+	if filter.GetOptionalResourceId() != "" && filter.GetOptionalResourceId() != tupleset.ResourceID {
+		return false
+	}
+	if filter.GetOptionalRelation() != "" && filter.GetOptionalRelation() != tupleset.Relation {
+		return false
+	}
+	if sf := filter.GetOptionalSubjectFilter(); sf != nil {
+		if sf.GetSubjectType() != "" && sf.GetSubjectType() != subject.Type {
+			return false
+		}
+		if sf.GetOptionalSubjectId() != "" && sf.GetOptionalSubjectId() != subject.ID {
+			return false
+		}
+		if sf.GetOptionalRelation() != nil && sf.GetOptionalRelation().GetRelation() != subject.Relation {
+			return false
+		}
+	}
+	return true
+}
+
+// paginate applies the offset carried in the continuation token and the page limit.
+func paginate[T any](items []T, pagination *model.Pagination) []T {
+	if pagination == nil {
+		return items
+	}
+	if pagination.Continuation != nil {
+		offset, err := strconv.Atoi(pagination.Continuation.Serialize())
+		if err != nil || offset < 0 || offset > len(items) {
+			offset = len(items)
+		}
+		items = items[offset:]
+	}
+	if pagination.Limit > 0 && int(pagination.Limit) < len(items) {
+		items = items[:pagination.Limit]
+	}
+	return items
+}
+
+// paginationOffset returns the offset carried in the continuation token, or 0 without one.
+func paginationOffset(pagination *model.Pagination) (int, error) {
+	if pagination == nil || pagination.Continuation == nil {
+		return 0, nil
+	}
+	offset, err := strconv.Atoi(pagination.Continuation.Serialize())
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid continuation token %q", pagination.Continuation.Serialize())
+	}
+	return offset, nil
+}
+
+// continuationAfter returns the token that resumes after the i-th item of the current page.
+func continuationAfter(pagination *model.Pagination, i int) model.ContinuationToken {
+	offset, _ := paginationOffset(pagination)
+	return model.DeserializeContinuationToken(strconv.Itoa(offset + i + 1))
+}
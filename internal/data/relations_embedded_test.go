@@ -0,0 +1,294 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+func newTestEmbeddedRelationsRepository(t *testing.T) *EmbeddedRelationsRepository {
+	t.Helper()
+	repo, err := NewEmbeddedRelationsRepositoryFromFile("../../" + SpicedbSchemaBootstrapFile)
+	require.NoError(t, err)
+	return repo
+}
+
+// embeddedRBACTuples grants bob view_widget on workspace "parent" through a role binding, and
+// makes workspace "child" a child of it.
+func embeddedRBACTuples() []model.RelationsTuple {
+	return []model.RelationsTuple{
+		createRelationship("rbac", "workspace", "parent", "user_grant", "rbac", "role_binding", "rb", ""),
+		createRelationship("rbac", "role_binding", "rb", "granted", "rbac", "role", "viewer", ""),
+		createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "principal", "bob", ""),
+		createRelationship("rbac", "role", "viewer", "view_widget", "rbac", "principal", "*", ""),
+		createRelationship("rbac", "workspace", "child", "parent", "rbac", "workspace", "parent", ""),
+		createRelationship("rbac", "widget", "w1", "workspace", "rbac", "workspace", "child", ""),
+	}
+}
+
+func embeddedCheck(t *testing.T, repo model.RelationsRepository, resourceType, resourceID, relation, subjectID string) bool {
+	t.Helper()
+	rel := model.NewRelationship(createResourceReference("rbac", resourceType, resourceID),
+		model.DeserializeRelation(relation), createSubjectReference("rbac", "principal", subjectID))
+	result, err := repo.Check(context.Background(), rel, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	return result.Allowed()
+}
+
+func TestEmbeddedRelationsRepository_Check(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+
+	assert.True(t, embeddedCheck(t, repo, "workspace", "parent", "view_widget", "bob"))
+	assert.True(t, embeddedCheck(t, repo, "workspace", "child", "view_widget", "bob"), "permissions are inherited through t_parent")
+	assert.True(t, embeddedCheck(t, repo, "widget", "w1", "view", "bob"), "arrows resolve through the widget's workspace")
+	assert.False(t, embeddedCheck(t, repo, "widget", "w1", "use", "bob"), "the role does not grant use_widget")
+	assert.False(t, embeddedCheck(t, repo, "widget", "w1", "view", "alice"), "intersection requires the binding subject")
+
+	_, err = repo.DeleteTuples(ctx, testTupleFilterForSubject("role_binding", "rb", "subject", "bob"), nil)
+	require.NoError(t, err)
+	assert.False(t, embeddedCheck(t, repo, "widget", "w1", "view", "bob"))
+}
+
+func TestEmbeddedRelationsRepository_CheckThroughGroupMembership(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	tuples := append(embeddedRBACTuples()[:4],
+		createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "group", "admins", "member"),
+		createRelationship("rbac", "group", "admins", "member", "rbac", "group", "nested", "member"),
+		createRelationship("rbac", "group", "nested", "member", "rbac", "principal", "carol", ""),
+	)
+	_, err := repo.CreateTuples(ctx, tuples, true, nil)
+	require.NoError(t, err)
+
+	assert.True(t, embeddedCheck(t, repo, "workspace", "parent", "view_widget", "carol"))
+	assert.False(t, embeddedCheck(t, repo, "workspace", "parent", "view_widget", "dave"))
+
+	lookupAdmins := func() (model.ResultStream[model.LookupSubjectsItem], error) {
+		return repo.LookupSubjects(ctx, createResourceReference("rbac", "group", "admins"), model.DeserializeRelation("member"),
+			model.NewRepresentationTypeRequired(model.DeserializeResourceType("principal"), model.DeserializeReporterType("rbac")),
+			nil, nil, model.NewConsistencyUnspecified())
+	}
+	members, err := lookupAdmins()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"carol": true}, collectSubjectIds(t, members))
+
+	// A membership cycle fails the evaluations it doesn't let resolve, as SpiceDB's max depth does.
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{
+		createRelationship("rbac", "group", "nested", "member", "rbac", "group", "admins", "member"),
+	}, true, nil)
+	require.NoError(t, err)
+
+	assert.True(t, embeddedCheck(t, repo, "workspace", "parent", "view_widget", "carol"), "a member is found despite the cycle")
+	_, err = repo.Check(ctx, model.NewRelationship(createResourceReference("rbac", "workspace", "parent"),
+		model.DeserializeRelation("view_widget"), createSubjectReference("rbac", "principal", "dave")), model.NewConsistencyUnspecified())
+	assert.ErrorIs(t, err, errZedCycle, "a check that only runs into the cycle fails instead of denying")
+	_, err = lookupAdmins()
+	assert.ErrorIs(t, err, errZedCycle)
+}
+
+func TestEmbeddedRelationsRepository_CheckErrors(t *testing.T) {
+	repo := newTestEmbeddedRelationsRepository(t)
+	subject := createSubjectReference("rbac", "principal", "bob")
+
+	_, err := repo.Check(context.Background(), model.NewRelationship(createResourceReference("hbi", "host", "h1"),
+		model.DeserializeRelation("view"), subject), model.NewConsistencyUnspecified())
+	assert.ErrorContains(t, err, `object definition "hbi/host" not found`)
+
+	_, err = repo.Check(context.Background(), model.NewRelationship(createResourceReference("rbac", "widget", "w1"),
+		model.DeserializeRelation("delete"), subject), model.NewConsistencyUnspecified())
+	assert.ErrorContains(t, err, `"delete" not found`)
+}
+
+func TestEmbeddedRelationsRepository_CreateTuplesValidatesSchema(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+
+	_, err := repo.CreateTuples(ctx, []model.RelationsTuple{
+		createRelationship("rbac", "widget", "w1", "workspace", "rbac", "principal", "bob", ""),
+	}, true, nil)
+	assert.ErrorContains(t, err, "is not allowed on rbac/widget#t_workspace")
+
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{
+		createRelationship("rbac", "widget", "w1", "view", "rbac", "principal", "bob", ""),
+	}, true, nil)
+	assert.ErrorContains(t, err, `relation "t_view" not found`)
+
+	tuple := createRelationship("rbac", "widget", "w1", "workspace", "rbac", "workspace", "ws", "")
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, false, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, false, nil)
+	assert.ErrorContains(t, err, "already exists")
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, true, nil)
+	assert.NoError(t, err)
+}
+
+func TestEmbeddedRelationsRepository_ConsistencyTokens(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	tuples := embeddedRBACTuples()
+	rel := model.NewRelationship(createResourceReference("rbac", "widget", "w1"),
+		model.DeserializeRelation("view"), createSubjectReference("rbac", "principal", "bob"))
+
+	_, err := repo.CreateTuples(ctx, tuples[:len(tuples)-1], true, nil)
+	require.NoError(t, err)
+	before := repo.RetainCurrentSnapshot()
+
+	written, err := repo.CreateTuples(ctx, tuples[len(tuples)-1:], true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, model.ConsistencyToken(simpleFormatConsistencyToken(before+1)), written.ConsistencyToken())
+
+	stale, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.False(t, stale.Allowed(), "without a token the oldest retained snapshot is read")
+	assert.Equal(t, model.ConsistencyToken(simpleFormatConsistencyToken(before)), stale.ConsistencyToken())
+
+	fresh, err := repo.Check(ctx, rel, model.NewConsistencyAtLeastAsFresh(written.ConsistencyToken()))
+	require.NoError(t, err)
+	assert.True(t, fresh.Allowed())
+
+	forUpdate, err := repo.CheckForUpdate(ctx, rel)
+	require.NoError(t, err)
+	assert.True(t, forUpdate.Allowed())
+
+	repo.ReleaseSnapshot(before)
+	latest, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.True(t, latest.Allowed())
+}
+
+func TestEmbeddedRelationsRepository_CheckBulk(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+
+	bob := createSubjectReference("rbac", "principal", "bob")
+	result, err := repo.CheckBulk(ctx, []model.Relationship{
+		model.NewRelationship(createResourceReference("rbac", "widget", "w1"), model.DeserializeRelation("view"), bob),
+		model.NewRelationship(createResourceReference("rbac", "widget", "w1"), model.DeserializeRelation("use"), bob),
+		model.NewRelationship(createResourceReference("hbi", "host", "h1"), model.DeserializeRelation("view"), bob),
+	}, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	require.Len(t, result.Pairs(), 3)
+	assert.True(t, result.Pairs()[0].Result().Allowed())
+	assert.False(t, result.Pairs()[1].Result().Allowed())
+	assert.Error(t, result.Pairs()[2].Result().Err())
+}
+
+func TestEmbeddedRelationsRepository_CheckExplain(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+
+	rel := model.NewRelationship(createResourceReference("rbac", "widget", "w1"),
+		model.DeserializeRelation("view"), createSubjectReference("rbac", "principal", "bob"))
+	result, err := repo.CheckExplain(ctx, rel, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+
+	trace := result.Trace()
+	assert.Equal(t, model.CheckTraceKindPermission, trace.Kind())
+	assert.Equal(t, "t_workspace->view_widget + use", trace.Expression())
+	require.Len(t, trace.Children(), 1)
+	assert.Equal(t, "child", trace.Children()[0].Relationship().Object().ResourceId().Serialize())
+
+	var matched []string
+	for _, leaf := range trace.MatchedTuples() {
+		matched = append(matched, leaf.Object().ResourceType().Serialize()+"#"+leaf.Relation().Serialize())
+	}
+	assert.Equal(t, []string{"role_binding#t_subject", "role#t_view_widget"}, matched)
+}
+
+func TestEmbeddedRelationsRepository_LookupObjects(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	tuples := append(embeddedRBACTuples(),
+		createRelationship("rbac", "widget", "w2", "workspace", "rbac", "workspace", "parent", ""),
+		createRelationship("rbac", "widget", "w3", "workspace", "rbac", "workspace", "other", ""),
+	)
+	_, err := repo.CreateTuples(ctx, tuples, true, nil)
+	require.NoError(t, err)
+
+	widgetType := model.NewRepresentationTypeRequired(model.DeserializeResourceType("widget"), model.DeserializeReporterType("rbac"))
+	stream, err := repo.LookupObjects(ctx, widgetType, model.DeserializeRelation("view"),
+		createSubjectReference("rbac", "principal", "bob"), nil, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"w1": true, "w2": true}, collectObjectIds(t, stream))
+
+	page, err := repo.LookupObjects(ctx, widgetType, model.DeserializeRelation("view"),
+		createSubjectReference("rbac", "principal", "bob"), model.NewPagination(1, nil), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	first, err := page.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "w1", first.Object().ResourceId().Serialize())
+
+	continuation := first.ContinuationToken()
+	rest, err := repo.LookupObjects(ctx, widgetType, model.DeserializeRelation("view"),
+		createSubjectReference("rbac", "principal", "bob"), model.NewPagination(1, &continuation), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"w2": true}, collectObjectIds(t, rest))
+}
+
+func TestEmbeddedRelationsRepository_ReadTuples(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, []model.RelationsTuple{
+		createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "group", "admins", "member"),
+		createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "principal", "bob", ""),
+	}, true, nil)
+	require.NoError(t, err)
+
+	filter := model.NewTupleFilter().
+		WithReporterType(model.DeserializeReporterType("rbac")).
+		WithObjectType(model.DeserializeResourceType("role_binding")).
+		WithRelation(model.DeserializeRelation("subject"))
+	stream, err := repo.ReadTuples(ctx, filter, nil, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	items := readTuplesStreamToSlice(stream)
+	require.Len(t, items, 2)
+	assert.Equal(t, "subject", items[0].Relation().Serialize())
+	assert.Equal(t, "admins", items[0].Subject().Resource().ResourceId().Serialize())
+	require.NotNil(t, items[0].Subject().Relation())
+	assert.Equal(t, "member", items[0].Subject().Relation().Serialize())
+	assert.Nil(t, items[1].Subject().Relation())
+
+	_, err = repo.ReadTuples(ctx, model.NewTupleFilter().WithReporterType(model.DeserializeReporterType("rbac")), nil, nil)
+	assert.Error(t, err)
+}
+
+func TestEmbeddedRelationsRepository_Fencing(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestEmbeddedRelationsRepository(t)
+	lockId := model.DeserializeLockId("consumer/0")
+	tuple := createRelationship("rbac", "widget", "w1", "workspace", "rbac", "workspace", "ws", "")
+
+	first, err := repo.AcquireLock(ctx, lockId)
+	require.NoError(t, err)
+	fencing := model.NewFencingCheck(lockId, first.LockToken())
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, true, &fencing)
+	require.NoError(t, err)
+
+	_, err = repo.AcquireLock(ctx, lockId)
+	require.NoError(t, err)
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, true, &fencing)
+	assert.ErrorContains(t, err, "fencing token")
+	_, err = repo.DeleteTuples(ctx, testTupleFilterForSubject("widget", "w1", "workspace", "ws"), &fencing)
+	assert.ErrorContains(t, err, "fencing token")
+}
+
+func testTupleFilterForSubject(resourceType, resourceID, relation, subjectID string) model.TupleFilter {
+	return model.NewTupleFilter().
+		WithReporterType(model.DeserializeReporterType("rbac")).
+		WithObjectType(model.DeserializeResourceType(resourceType)).
+		WithObjectId(model.DeserializeLocalResourceId(resourceID)).
+		WithRelation(model.DeserializeRelation(relation)).
+		WithSubject(model.NewTupleSubjectFilter().WithSubjectId(model.DeserializeLocalResourceId(subjectID)))
+}
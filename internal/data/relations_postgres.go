@@ -0,0 +1,529 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	datamodel "github.com/project-kessel/inventory-api/internal/data/model"
+)
+
+// PostgresRelationsRepository implements RelationsRepository over tuples stored in the inventory
+// database. Permissions are computed in process by the same schema engine as
+// EmbeddedRelationsRepository, so deployments can run without SpiceDB while keeping its semantics.
+//
+// # Consistency
+//
+// Every request runs in one serializable transaction, so a check sees a single snapshot of the
+// tuples. On postgres, consistency tokens are transaction IDs: writes return the ID of the
+// transaction that committed them, and "at least as fresh" reads fail unless that transaction is
+// visible to the read's snapshot. Reads return the newest ID known to be settled in their snapshot.
+// Other databases (SQLite in tests) return empty tokens and every read is fully consistent.
+//
+// # Pagination
+//
+// ReadTuples pages through tuples in key order and LookupObjects/LookupSubjects page through their
+// sorted results. Continuation tokens are offsets, as in EmbeddedRelationsRepository.
+type PostgresRelationsRepository struct {
+	db                 *gorm.DB
+	transactionManager model.TransactionManager
+	schema             *zedSchema
+}
+
+var _ model.RelationsRepository = &PostgresRelationsRepository{}
+
+// NewPostgresRelationsRepository parses the given SpiceDB schema and creates a repository over the
+// relation_tuples table. The table is created by the inventory migrations.
+func NewPostgresRelationsRepository(db *gorm.DB, transactionManager model.TransactionManager, schema string) (*PostgresRelationsRepository, error) {
+	parsed, err := parseZedSchema(schema)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing relations schema: %w", err)
+	}
+	return &PostgresRelationsRepository{
+		db:                 db,
+		transactionManager: transactionManager,
+		schema:             parsed,
+	}, nil
+}
+
+// NewPostgresRelationsRepositoryFromFile creates a PostgresRelationsRepository from a schema file.
+func NewPostgresRelationsRepositoryFromFile(db *gorm.DB, transactionManager model.TransactionManager, schemaFile string) (*PostgresRelationsRepository, error) {
+	schema, err := readFile(schemaFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading relations schema file: %w", err)
+	}
+	return NewPostgresRelationsRepository(db, transactionManager, schema)
+}
+
+// postgresTuples reads tuples for the evaluator within one transaction. Tuplesets are cached
+// because evaluation revisits them, e.g. when several permissions share a relation.
+type postgresTuples struct {
+	tx       *gorm.DB
+	subjects map[zedTupleset][]zedSubject
+}
+
+func newPostgresTuples(tx *gorm.DB) *postgresTuples {
+	return &postgresTuples{tx: tx, subjects: map[zedTupleset][]zedSubject{}}
+}
+
+func (p *postgresTuples) readSubjects(tupleset zedTupleset) ([]zedSubject, error) {
+	if subjects, ok := p.subjects[tupleset]; ok {
+		return subjects, nil
+	}
+
+	var rows []datamodel.RelationTuple
+	err := p.tx.
+		Where(datamodel.ColumnRTResourceType+" = ?", tupleset.ResourceType).
+		Where(datamodel.ColumnRTResourceID+" = ?", tupleset.ResourceID).
+		Where(datamodel.ColumnRTRelation+" = ?", tupleset.Relation).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("error reading relationships: %w", err)
+	}
+
+	subjects := make([]zedSubject, 0, len(rows))
+	for _, row := range rows {
+		subjects = append(subjects, zedSubject{Type: row.SubjectType, ID: row.SubjectID, Relation: row.SubjectRelation})
+	}
+	subjects = sortedZedSubjects(subjects)
+	p.subjects[tupleset] = subjects
+	return subjects, nil
+}
+
+func (p *postgresTuples) readObjectIDs(objectType, subjectRelation string) ([]string, error) {
+	ids := map[string]bool{}
+
+	if subjectRelation == "" {
+		var resourceIDs []string
+		err := p.tx.Model(&datamodel.RelationTuple{}).
+			Where(datamodel.ColumnRTResourceType+" = ?", objectType).
+			Distinct().Pluck(datamodel.ColumnRTResourceID, &resourceIDs).Error
+		if err != nil {
+			return nil, fmt.Errorf("error reading relationships: %w", err)
+		}
+		for _, id := range resourceIDs {
+			ids[id] = true
+		}
+	}
+
+	query := p.tx.Model(&datamodel.RelationTuple{}).
+		Where(datamodel.ColumnRTSubjectType+" = ?", objectType).
+		Where(datamodel.ColumnRTSubjectID+" <> ?", "*")
+	if subjectRelation != "" {
+		query = query.Where(datamodel.ColumnRTSubjectRelation+" = ?", subjectRelation)
+	}
+	var subjectIDs []string
+	if err := query.Distinct().Pluck(datamodel.ColumnRTSubjectID, &subjectIDs).Error; err != nil {
+		return nil, fmt.Errorf("error reading relationships: %w", err)
+	}
+	for _, id := range subjectIDs {
+		ids[id] = true
+	}
+
+	return slices.Sorted(maps.Keys(ids)), nil
+}
+
+func (r *PostgresRelationsRepository) isPostgres() bool {
+	return r.db.Dialector.Name() == "postgres"
+}
+
+// writeToken returns the token for a write transaction: its transaction ID.
+func (r *PostgresRelationsRepository) writeToken(tx *gorm.DB) (model.ConsistencyToken, error) {
+	if !r.isPostgres() {
+		return model.MinimizeLatencyToken, nil
+	}
+	var txid string
+	if err := tx.Raw("SELECT pg_current_xact_id()::text").Scan(&txid).Error; err != nil {
+		return "", fmt.Errorf("error reading transaction id: %w", err)
+	}
+	return model.DeserializeConsistencyToken(txid), nil
+}
+
+// readToken returns the token for a read transaction. Every transaction ID below the snapshot's
+// xmin has either committed or aborted, so xmin-1 is the newest ID this snapshot fully reflects.
+func (r *PostgresRelationsRepository) readToken(tx *gorm.DB) (model.ConsistencyToken, error) {
+	if !r.isPostgres() {
+		return model.MinimizeLatencyToken, nil
+	}
+	var xmin string
+	if err := tx.Raw("SELECT pg_snapshot_xmin(pg_current_snapshot())::text").Scan(&xmin).Error; err != nil {
+		return "", fmt.Errorf("error reading snapshot: %w", err)
+	}
+	parsed, err := strconv.ParseUint(xmin, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("error parsing snapshot xmin %q: %w", xmin, err)
+	}
+	return model.DeserializeConsistencyToken(strconv.FormatUint(parsed-1, 10)), nil
+}
+
+// requireFresh fails unless the transaction that issued the requested token is visible to tx.
+func (r *PostgresRelationsRepository) requireFresh(tx *gorm.DB, consistency model.Consistency) error {
+	token := consistencyToSimpleToken(consistency)
+	if token == "" || !r.isPostgres() {
+		return nil
+	}
+	if _, err := strconv.ParseUint(token, 10, 64); err != nil {
+		return fmt.Errorf("invalid consistency token %q", token)
+	}
+	var visible bool
+	if err := tx.Raw("SELECT pg_visible_in_snapshot(?::xid8, pg_current_snapshot())", token).Scan(&visible).Error; err != nil {
+		return fmt.Errorf("error checking consistency token: %w", err)
+	}
+	if !visible {
+		return fmt.Errorf("relationships at consistency token %q are not yet visible", token)
+	}
+	return nil
+}
+
+// read runs fn over a fresh snapshot of the tuples and returns the snapshot's token.
+func (r *PostgresRelationsRepository) read(operationName string, consistency model.Consistency,
+	fn func(tuples *postgresTuples) error,
+) (model.ConsistencyToken, error) {
+	var token model.ConsistencyToken
+	err := r.transactionManager.HandleSerializableTransaction(operationName, r.db, func(tx *gorm.DB) error {
+		if err := r.requireFresh(tx, consistency); err != nil {
+			return err
+		}
+		var err error
+		if token, err = r.readToken(tx); err != nil {
+			return err
+		}
+		return fn(newPostgresTuples(tx))
+	})
+	return token, err
+}
+
+// write runs fn in a transaction and returns the transaction's token.
+func (r *PostgresRelationsRepository) write(operationName string, fn func(tx *gorm.DB) error) (model.ConsistencyToken, error) {
+	var token model.ConsistencyToken
+	err := r.transactionManager.HandleSerializableTransaction(operationName, r.db, func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		var err error
+		token, err = r.writeToken(tx)
+		return err
+	})
+	return token, err
+}
+
+// WritesTuples reports true: tuples are stored in the relation_tuples table.
+func (r *PostgresRelationsRepository) WritesTuples() bool {
+	return true
+}
+
+func (r *PostgresRelationsRepository) Health(ctx context.Context) (model.HealthResult, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return model.HealthResult{}, err
+	}
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return model.HealthResult{}, err
+	}
+	return model.NewHealthResult("OK", 200), nil
+}
+
+func (r *PostgresRelationsRepository) Check(_ context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	var allowed bool
+	token, err := r.read("Check", consistency, func(tuples *postgresTuples) error {
+		var err error
+		allowed, _, err = newZedEvaluator(r.schema, tuples, false).checkRelationship(rel)
+		return err
+	})
+	if err != nil {
+		return model.CheckResult{}, err
+	}
+	return model.NewCheckResult(allowed, token), nil
+}
+
+func (r *PostgresRelationsRepository) CheckForUpdate(ctx context.Context, rel model.Relationship,
+) (model.CheckResult, error) {
+	return r.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+}
+
+func (r *PostgresRelationsRepository) CheckBulk(_ context.Context, rels []model.Relationship, consistency model.Consistency,
+) (model.CheckBulkResult, error) {
+	var items []model.CheckBulkResultPair
+	token, err := r.read("CheckBulk", consistency, func(tuples *postgresTuples) error {
+		result, err := newZedEvaluator(r.schema, tuples, false).checkBulk(rels, "")
+		items = result.Pairs()
+		return err
+	})
+	if err != nil {
+		return model.CheckBulkResult{}, err
+	}
+	return model.NewCheckBulkResult(items, token), nil
+}
+
+func (r *PostgresRelationsRepository) CheckForUpdateBulk(ctx context.Context, rels []model.Relationship,
+) (model.CheckBulkResult, error) {
+	return r.CheckBulk(ctx, rels, model.NewConsistencyMinimizeLatency())
+}
+
+// CheckExplain performs a Check and returns the evaluation tree, as EmbeddedRelationsRepository does.
+func (r *PostgresRelationsRepository) CheckExplain(_ context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckExplainResult, error) {
+	var allowed bool
+	var trace *model.CheckTrace
+	token, err := r.read("CheckExplain", consistency, func(tuples *postgresTuples) error {
+		var err error
+		allowed, trace, err = newZedEvaluator(r.schema, tuples, true).checkRelationship(rel)
+		return err
+	})
+	if err != nil {
+		return model.CheckExplainResult{}, err
+	}
+	return model.NewCheckExplainResult(model.NewCheckResult(allowed, token), *trace), nil
+}
+
+func (r *PostgresRelationsRepository) LookupObjects(_ context.Context,
+	objectType model.RepresentationType,
+	relation model.Relation, subject model.SubjectReference,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupObjectsItem], error) {
+	var results []model.LookupObjectsItem
+	_, err := r.read("LookupObjects", consistency, func(tuples *postgresTuples) error {
+		var err error
+		results, err = newZedEvaluator(r.schema, tuples, false).lookupObjects(objectType, relation, subject, pagination)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &simpleLookupObjectsStream{results: results}, nil
+}
+
+func (r *PostgresRelationsRepository) LookupSubjects(_ context.Context,
+	object model.ResourceReference, relation model.Relation,
+	subjectType model.RepresentationType,
+	subjectRelation *model.Relation,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupSubjectsItem], error) {
+	var results []model.LookupSubjectsItem
+	_, err := r.read("LookupSubjects", consistency, func(tuples *postgresTuples) error {
+		var err error
+		results, err = newZedEvaluator(r.schema, tuples, false).lookupSubjects(object, relation, subjectType, subjectRelation, pagination)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &simpleLookupSubjectsStream{results: results}, nil
+}
+
+func (r *PostgresRelationsRepository) CreateTuples(_ context.Context, tuples []model.RelationsTuple, upsert bool, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	rows := make([]datamodel.RelationTuple, 0, len(tuples))
+	for _, tuple := range tuples {
+		tupleset, subject := zedTupleFromModel(tuple)
+		if err := r.schema.allowsSubject(tupleset.ResourceType, tupleset.Relation, subject.Type, subject.ID, subject.Relation); err != nil {
+			return model.TuplesResult{}, fmt.Errorf("error writing relationships: %w", err)
+		}
+		rows = append(rows, datamodel.RelationTuple{
+			ID:              uuid.New(),
+			ResourceType:    tupleset.ResourceType,
+			ResourceID:      tupleset.ResourceID,
+			Relation:        tupleset.Relation,
+			SubjectType:     subject.Type,
+			SubjectID:       subject.ID,
+			SubjectRelation: subject.Relation,
+		})
+	}
+
+	token, err := r.write("CreateTuples", func(tx *gorm.DB) error {
+		if err := r.checkFencing(tx, fencing); err != nil {
+			return err
+		}
+		if !upsert {
+			for _, row := range rows {
+				exists, err := postgresTupleExists(tx, row)
+				if err != nil {
+					return err
+				}
+				if exists {
+					return fmt.Errorf("error writing relationships: relationship %s:%s#%s@%s:%s already exists",
+						row.ResourceType, row.ResourceID, row.Relation, row.SubjectType, row.SubjectID)
+				}
+			}
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	if err != nil {
+		return model.TuplesResult{}, err
+	}
+	return model.NewTuplesResult(token), nil
+}
+
+func (r *PostgresRelationsRepository) DeleteTuples(_ context.Context, filter model.TupleFilter, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	relationshipFilter, err := tupleFilterToSpiceDBFilter(filter)
+	if err != nil {
+		return model.TuplesResult{}, fmt.Errorf("relations request validation: %w", err)
+	}
+
+	token, err := r.write("DeleteTuples", func(tx *gorm.DB) error {
+		if err := r.checkFencing(tx, fencing); err != nil {
+			return err
+		}
+		return postgresFilterQuery(tx, relationshipFilter).Delete(&datamodel.RelationTuple{}).Error
+	})
+	if err != nil {
+		return model.TuplesResult{}, err
+	}
+	return model.NewTuplesResult(token), nil
+}
+
+func (r *PostgresRelationsRepository) ReadTuples(_ context.Context, filter model.TupleFilter, pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.ReadTuplesItem], error) {
+	relationshipFilter, err := tupleFilterToSpiceDBFilter(filter)
+	if err != nil {
+		return nil, fmt.Errorf("relations request validation: %w", err)
+	}
+	offset, err := paginationOffset(pagination)
+	if err != nil {
+		return nil, fmt.Errorf("relations request validation: %w", err)
+	}
+
+	var rows []datamodel.RelationTuple
+	token, err := r.read("ReadTuples", consistency, func(tuples *postgresTuples) error {
+		query := postgresFilterQuery(tuples.tx, relationshipFilter).
+			Order(datamodel.ColumnRTResourceType).
+			Order(datamodel.ColumnRTResourceID).
+			Order(datamodel.ColumnRTRelation).
+			Order(datamodel.ColumnRTSubjectType).
+			Order(datamodel.ColumnRTSubjectID).
+			Order(datamodel.ColumnRTSubjectRelation).
+			Offset(offset)
+		if pagination != nil && pagination.Limit > 0 {
+			query = query.Limit(int(pagination.Limit))
+		}
+		return query.Find(&rows).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]model.ReadTuplesItem, 0, len(rows))
+	for i, row := range rows {
+		item, err := zedReadTuplesItem(
+			zedTupleset{ResourceType: row.ResourceType, ResourceID: row.ResourceID, Relation: row.Relation},
+			zedSubject{Type: row.SubjectType, ID: row.SubjectID, Relation: row.SubjectRelation},
+			continuationAfter(pagination, i), token)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, item)
+	}
+	return &simpleReadTuplesStream{results: results}, nil
+}
+
+// AcquireLock replaces the lock's fencing token, as the SpiceDB repository does.
+func (r *PostgresRelationsRepository) AcquireLock(_ context.Context, lockId model.LockId) (model.AcquireLockResult, error) {
+	token := uuid.New().String()
+	relation := addRelationPrefix(lockVersionRelation, relationPrefix)
+	if err := r.schema.allowsSubject(lockType, relation, lockVersionType, token, ""); err != nil {
+		return model.AcquireLockResult{}, fmt.Errorf("error writing relationships: %w", err)
+	}
+
+	_, err := r.write("AcquireLock", func(tx *gorm.DB) error {
+		err := tx.
+			Where(datamodel.ColumnRTResourceType+" = ?", lockType).
+			Where(datamodel.ColumnRTResourceID+" = ?", lockId.Serialize()).
+			Where(datamodel.ColumnRTRelation+" = ?", relation).
+			Delete(&datamodel.RelationTuple{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&datamodel.RelationTuple{
+			ID:           uuid.New(),
+			ResourceType: lockType,
+			ResourceID:   lockId.Serialize(),
+			Relation:     relation,
+			SubjectType:  lockVersionType,
+			SubjectID:    token,
+		}).Error
+	})
+	if err != nil {
+		return model.AcquireLockResult{}, err
+	}
+	return model.NewAcquireLockResult(model.DeserializeLockToken(token)), nil
+}
+
+// checkFencing fails unless the lock still holds the fencing token.
+func (r *PostgresRelationsRepository) checkFencing(tx *gorm.DB, fencing *model.FencingCheck) error {
+	if fencing == nil {
+		return nil
+	}
+	exists, err := postgresTupleExists(tx, datamodel.RelationTuple{
+		ResourceType: lockType,
+		ResourceID:   fencing.LockId().Serialize(),
+		Relation:     addRelationPrefix(lockVersionRelation, relationPrefix),
+		SubjectType:  lockVersionType,
+		SubjectID:    fencing.LockToken().Serialize(),
+	})
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("error writing relationships: fencing token for lock %q is no longer valid", fencing.LockId().Serialize())
+	}
+	return nil
+}
+
+func postgresTupleExists(tx *gorm.DB, row datamodel.RelationTuple) (bool, error) {
+	var existing datamodel.RelationTuple
+	err := tx.
+		Where(datamodel.ColumnRTResourceType+" = ?", row.ResourceType).
+		Where(datamodel.ColumnRTResourceID+" = ?", row.ResourceID).
+		Where(datamodel.ColumnRTRelation+" = ?", row.Relation).
+		Where(datamodel.ColumnRTSubjectType+" = ?", row.SubjectType).
+		Where(datamodel.ColumnRTSubjectID+" = ?", row.SubjectID).
+		Where(datamodel.ColumnRTSubjectRelation+" = ?", row.SubjectRelation).
+		Take(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading relationships: %w", err)
+	}
+	return true, nil
+}
+
+// postgresFilterQuery restricts a query to the tuples matching the filter, like zedMatchesFilter.
+func postgresFilterQuery(tx *gorm.DB, filter *v1.RelationshipFilter) *gorm.DB {
+	query := tx.Model(&datamodel.RelationTuple{})
+	if filter.GetResourceType() != "" {
+		query = query.Where(datamodel.ColumnRTResourceType+" = ?", filter.GetResourceType())
+	}
+	if filter.GetOptionalResourceId() != "" {
+		query = query.Where(datamodel.ColumnRTResourceID+" = ?", filter.GetOptionalResourceId())
+	}
+	if filter.GetOptionalRelation() != "" {
+		query = query.Where(datamodel.ColumnRTRelation+" = ?", filter.GetOptionalRelation())
+	}
+	if sf := filter.GetOptionalSubjectFilter(); sf != nil {
+		if sf.GetSubjectType() != "" {
+			query = query.Where(datamodel.ColumnRTSubjectType+" = ?", sf.GetSubjectType())
+		}
+		if sf.GetOptionalSubjectId() != "" {
+			query = query.Where(datamodel.ColumnRTSubjectID+" = ?", sf.GetOptionalSubjectId())
+		}
+		if sf.GetOptionalRelation() != nil {
+			query = query.Where(datamodel.ColumnRTSubjectRelation+" = ?", sf.GetOptionalRelation().GetRelation())
+		}
+	}
+	return query
+}
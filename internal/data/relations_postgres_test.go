@@ -0,0 +1,145 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+func newTestPostgresRelationsRepository(t *testing.T) *PostgresRelationsRepository {
+	t.Helper()
+	db := setupInMemoryDB(t)
+	tm := NewGormTransactionManager(metricscollector.NewFakeMetricsCollector(), 3)
+	repo, err := NewPostgresRelationsRepositoryFromFile(db, tm, "../../"+SpicedbSchemaBootstrapFile)
+	require.NoError(t, err)
+	return repo
+}
+
+func TestPostgresRelationsRepository_Check(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPostgresRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+
+	assert.True(t, embeddedCheck(t, repo, "workspace", "child", "view_widget", "bob"))
+	assert.True(t, embeddedCheck(t, repo, "widget", "w1", "view", "bob"))
+	assert.False(t, embeddedCheck(t, repo, "widget", "w1", "use", "bob"))
+	assert.False(t, embeddedCheck(t, repo, "widget", "w1", "view", "alice"))
+
+	_, err = repo.DeleteTuples(ctx, testTupleFilterForSubject("role_binding", "rb", "subject", "bob"), nil)
+	require.NoError(t, err)
+	assert.False(t, embeddedCheck(t, repo, "widget", "w1", "view", "bob"))
+}
+
+func TestPostgresRelationsRepository_CreateTuples(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPostgresRelationsRepository(t)
+
+	_, err := repo.CreateTuples(ctx, []model.RelationsTuple{
+		createRelationship("rbac", "widget", "w1", "workspace", "rbac", "principal", "bob", ""),
+	}, true, nil)
+	assert.ErrorContains(t, err, "is not allowed on rbac/widget#t_workspace")
+
+	tuple := createRelationship("rbac", "widget", "w1", "workspace", "rbac", "workspace", "ws", "")
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, false, nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, false, nil)
+	assert.ErrorContains(t, err, "already exists")
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, true, nil)
+	assert.NoError(t, err, "upserting an existing tuple is a no-op")
+}
+
+func TestPostgresRelationsRepository_CheckBulkAndExplain(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPostgresRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+
+	bob := createSubjectReference("rbac", "principal", "bob")
+	view := model.NewRelationship(createResourceReference("rbac", "widget", "w1"), model.DeserializeRelation("view"), bob)
+	result, err := repo.CheckBulk(ctx, []model.Relationship{
+		view,
+		model.NewRelationship(createResourceReference("hbi", "host", "h1"), model.DeserializeRelation("view"), bob),
+	}, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	require.Len(t, result.Pairs(), 2)
+	assert.True(t, result.Pairs()[0].Result().Allowed())
+	assert.Error(t, result.Pairs()[1].Result().Err())
+
+	explained, err := repo.CheckExplain(ctx, view, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.True(t, explained.Allowed())
+	assert.Len(t, explained.Trace().MatchedTuples(), 2)
+}
+
+func TestPostgresRelationsRepository_LookupObjects(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPostgresRelationsRepository(t)
+	tuples := append(embeddedRBACTuples(),
+		createRelationship("rbac", "widget", "w2", "workspace", "rbac", "workspace", "parent", ""),
+		createRelationship("rbac", "widget", "w3", "workspace", "rbac", "workspace", "other", ""),
+	)
+	_, err := repo.CreateTuples(ctx, tuples, true, nil)
+	require.NoError(t, err)
+
+	widgetType := model.NewRepresentationTypeRequired(model.DeserializeResourceType("widget"), model.DeserializeReporterType("rbac"))
+	stream, err := repo.LookupObjects(ctx, widgetType, model.DeserializeRelation("view"),
+		createSubjectReference("rbac", "principal", "bob"), nil, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"w1": true, "w2": true}, collectObjectIds(t, stream))
+}
+
+func TestPostgresRelationsRepository_ReadTuples(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPostgresRelationsRepository(t)
+	_, err := repo.CreateTuples(ctx, []model.RelationsTuple{
+		createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "principal", "bob", ""),
+		createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "group", "admins", "member"),
+	}, true, nil)
+	require.NoError(t, err)
+
+	filter := model.NewTupleFilter().
+		WithReporterType(model.DeserializeReporterType("rbac")).
+		WithObjectType(model.DeserializeResourceType("role_binding")).
+		WithRelation(model.DeserializeRelation("subject"))
+	page, err := repo.ReadTuples(ctx, filter, model.NewPagination(1, nil), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	first := readTuplesStreamToSlice(page)
+	require.Len(t, first, 1)
+	assert.Equal(t, "admins", first[0].Subject().Resource().ResourceId().Serialize())
+	require.NotNil(t, first[0].Subject().Relation())
+	assert.Equal(t, "member", first[0].Subject().Relation().Serialize())
+
+	continuation := first[0].ContinuationToken()
+	rest, err := repo.ReadTuples(ctx, filter, model.NewPagination(10, &continuation), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	second := readTuplesStreamToSlice(rest)
+	require.Len(t, second, 1)
+	assert.Equal(t, "bob", second[0].Subject().Resource().ResourceId().Serialize())
+	assert.Nil(t, second[0].Subject().Relation())
+}
+
+func TestPostgresRelationsRepository_Fencing(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestPostgresRelationsRepository(t)
+	lockId := model.DeserializeLockId("consumer/0")
+	tuple := createRelationship("rbac", "widget", "w1", "workspace", "rbac", "workspace", "ws", "")
+
+	first, err := repo.AcquireLock(ctx, lockId)
+	require.NoError(t, err)
+	fencing := model.NewFencingCheck(lockId, first.LockToken())
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, true, &fencing)
+	require.NoError(t, err)
+
+	_, err = repo.AcquireLock(ctx, lockId)
+	require.NoError(t, err)
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, true, &fencing)
+	assert.ErrorContains(t, err, "fencing token")
+	_, err = repo.DeleteTuples(ctx, testTupleFilterForSubject("widget", "w1", "workspace", "ws"), &fencing)
+	assert.ErrorContains(t, err, "fencing token")
+}
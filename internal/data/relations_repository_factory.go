@@ -7,11 +7,34 @@ import (
 	"github.com/go-kratos/kratos/v2/log"
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/config/relations"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+	"gorm.io/gorm"
 )
 
-func NewRelationsRepository(ctx context.Context, config relations.CompletedConfig, logger log.Logger) (model.RelationsRepository, error) {
+func NewRelationsRepository(ctx context.Context, config relations.CompletedConfig, db *gorm.DB, transactionManager model.TransactionManager,
+	metrics *metricscollector.MetricsCollector, logger log.Logger,
+) (model.RelationsRepository, error) {
+	if config.Authz != relations.Shadow {
+		return newRelationsRepositoryForImpl(ctx, config.Authz, config, db, transactionManager, logger)
+	}
+
+	primary, err := newRelationsRepositoryForImpl(ctx, config.Shadow.Primary, config, db, transactionManager, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating shadow primary: %w", err)
+	}
+	secondary, err := newRelationsRepositoryForImpl(ctx, config.Shadow.Secondary, config, db, transactionManager, logger)
+	if err != nil {
+		return nil, fmt.Errorf("error creating shadow secondary: %w", err)
+	}
+	return NewShadowRelationsRepository(primary, secondary, config.Shadow, metrics, log.NewHelper(logger)), nil
+}
+
+// newRelationsRepositoryForImpl creates the repository for one relations implementation.
+func newRelationsRepositoryForImpl(ctx context.Context, impl string, config relations.CompletedConfig, db *gorm.DB,
+	transactionManager model.TransactionManager, logger log.Logger,
+) (model.RelationsRepository, error) {
 	helper := log.NewHelper(logger)
-	switch config.Authz {
+	switch impl {
 	case relations.AllowAll:
 		return NewAllowAllRelationsRepository(helper), nil
 	case relations.Kessel:
@@ -22,7 +45,19 @@ func NewRelationsRepository(ctx context.Context, config relations.CompletedConfi
 			return nil, fmt.Errorf("error creating spicedb relations repository: %w", err)
 		}
 		return repo, nil
+	case relations.Embedded:
+		repo, err := NewEmbeddedRelationsRepositoryFromFile(config.Embedded.SchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating embedded relations repository: %w", err)
+		}
+		return repo, nil
+	case relations.Postgres:
+		repo, err := NewPostgresRelationsRepositoryFromFile(db, transactionManager, config.Postgres.SchemaFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating postgres relations repository: %w", err)
+		}
+		return repo, nil
 	default:
-		return nil, fmt.Errorf("unrecognized relations implementation: %s", config.Authz)
+		return nil, fmt.Errorf("unrecognized relations implementation: %s", impl)
 	}
 }
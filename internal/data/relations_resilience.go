@@ -0,0 +1,470 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/sony/gobreaker/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+const (
+	// hedgeLatencyWindow is the number of recent Check latencies the hedge delay is derived from.
+	hedgeLatencyWindow = 1000
+	// hedgeMinSamples is the number of Check latencies needed before Checks are hedged.
+	hedgeMinSamples = 20
+	// hedgeRecomputeInterval is the number of recorded latencies after which the hedge delay is
+	// recomputed, so that not every Check sorts the window.
+	hedgeRecomputeInterval = 50
+)
+
+// errRelationsCircuitOpen is returned instead of calling the backend while the circuit is open.
+var errRelationsCircuitOpen = status.Error(codes.Unavailable, "relations backend circuit breaker is open")
+
+// ResilientRelationsRepository protects callers from a slow or failing RelationsRepository backend.
+//
+// Idempotent reads (checks, lookups and ReadTuples) that fail with a transient error (Unavailable,
+// ResourceExhausted, Aborted, or DeadlineExceeded while the caller's context is still live) are
+// retried with jittered exponential backoff; the number of attempts is configurable per method.
+// Only the call that opens a lookup stream is retried, not reads from the stream. Tuple writes and
+// AcquireLock are never retried.
+//
+// Every call except Health goes through a single circuit breaker. Consecutive backend failures open
+// the circuit, after which calls fail with Unavailable without reaching the backend until the
+// breaker lets trial requests through again. Errors that describe the request rather than the
+// backend, such as InvalidArgument or a failed fencing check, don't count as failures.
+//
+// When hedging is enabled, a Check that hasn't answered within the configured percentile of recent
+// Check latencies is sent a second time, and whichever attempt answers first wins. When Check fails
+// because of the backend, it either returns the error (fail-closed) or answers allowed (fail-open).
+// Fail-open answers are marked with CheckResult.FailOpen so that they aren't cached or trusted as
+// decisions of the backend.
+type ResilientRelationsRepository struct {
+	repository model.RelationsRepository
+
+	config    resilience.CompletedConfig
+	breaker   *gobreaker.CircuitBreaker[any]
+	latencies *latencyWindow
+	metrics   *metricscollector.MetricsCollector
+	logger    *log.Helper
+}
+
+var _ model.RelationsRepository = &ResilientRelationsRepository{}
+
+// NewResilientRelationsRepository wraps repository with the retries, circuit breaker and hedging
+// configured by config.
+func NewResilientRelationsRepository(repository model.RelationsRepository, config resilience.CompletedConfig,
+	metrics *metricscollector.MetricsCollector, logger *log.Helper,
+) *ResilientRelationsRepository {
+	logger.Infof("Using relations resilience: retry-max-attempts=%d, breaker-failure-threshold=%d, hedge-check=%t, check-failure-mode=%s",
+		config.RetryMaxAttempts, config.BreakerFailures, config.HedgeCheck, config.CheckFailureMode)
+	r := &ResilientRelationsRepository{
+		repository: repository,
+		config:     config,
+		metrics:    metrics,
+		logger:     logger,
+	}
+	if config.HedgeCheck {
+		r.latencies = newLatencyWindow(config.HedgePercentile, config.HedgeMinDelay)
+	}
+	r.breaker = gobreaker.NewCircuitBreaker[any](gobreaker.Settings{
+		Name:        "relations-backend-breaker",
+		MaxRequests: config.BreakerHalfOpenMax,
+		Timeout:     config.BreakerOpenTimeout,
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= config.BreakerFailures
+		},
+		IsSuccessful: func(err error) bool {
+			return err == nil || !isRelationsBackendFailure(err)
+		},
+		// A cancelled call says nothing about the backend: the caller gave up or a hedged attempt lost.
+		IsExcluded: func(err error) bool {
+			return errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled
+		},
+		OnStateChange: func(name string, from gobreaker.State, to gobreaker.State) {
+			logger.Warnf("Circuit breaker %s changed from %s to %s", name, from, to)
+			r.recordBreakerState(to)
+		},
+	})
+	r.recordBreakerState(gobreaker.StateClosed)
+	return r
+}
+
+// FailClosed returns a view of the repository that shares its circuit breaker and hedging state but
+// returns the backend error from Check even when checks are configured to fail open. It is for
+// decisions that must never be granted because the backend is down, such as meta-authorization.
+func (r *ResilientRelationsRepository) FailClosed() *ResilientRelationsRepository {
+	closed := *r
+	closed.config = r.config.WithCheckFailureMode(resilience.FailClosed)
+	return &closed
+}
+
+// WritesTuples reports whether the wrapped repository stores tuples.
+func (r *ResilientRelationsRepository) WritesTuples() bool {
+	return model.WritesTuples(r.repository)
+}
+
+// Health reports the backend's own health, bypassing the circuit breaker.
+func (r *ResilientRelationsRepository) Health(ctx context.Context) (model.HealthResult, error) {
+	return r.repository.Health(ctx)
+}
+
+func (r *ResilientRelationsRepository) Check(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	result, err := resilientCall(ctx, r, "Check", func(ctx context.Context) (model.CheckResult, error) {
+		return r.hedgedCheck(ctx, rel, consistency)
+	})
+	if err != nil && r.failOpen(ctx, err) {
+		r.recordFailOpen("Check")
+		r.logger.Warnf("Relations backend failing, answering Check as allowed (fail-open): %v", err)
+		return model.NewFailOpenCheckResult(), nil
+	}
+	return result, err
+}
+
+func (r *ResilientRelationsRepository) CheckForUpdate(ctx context.Context, rel model.Relationship,
+) (model.CheckResult, error) {
+	return resilientCall(ctx, r, "CheckForUpdate", func(ctx context.Context) (model.CheckResult, error) {
+		return r.repository.CheckForUpdate(ctx, rel)
+	})
+}
+
+func (r *ResilientRelationsRepository) CheckBulk(ctx context.Context, rels []model.Relationship, consistency model.Consistency,
+) (model.CheckBulkResult, error) {
+	return resilientCall(ctx, r, "CheckBulk", func(ctx context.Context) (model.CheckBulkResult, error) {
+		return r.repository.CheckBulk(ctx, rels, consistency)
+	})
+}
+
+func (r *ResilientRelationsRepository) CheckForUpdateBulk(ctx context.Context, rels []model.Relationship,
+) (model.CheckBulkResult, error) {
+	return resilientCall(ctx, r, "CheckForUpdateBulk", func(ctx context.Context) (model.CheckBulkResult, error) {
+		return r.repository.CheckForUpdateBulk(ctx, rels)
+	})
+}
+
+func (r *ResilientRelationsRepository) CheckExplain(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckExplainResult, error) {
+	return resilientCall(ctx, r, "CheckExplain", func(ctx context.Context) (model.CheckExplainResult, error) {
+		return r.repository.CheckExplain(ctx, rel, consistency)
+	})
+}
+
+func (r *ResilientRelationsRepository) LookupObjects(ctx context.Context, objectType model.RepresentationType,
+	relation model.Relation, subject model.SubjectReference, pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupObjectsItem], error) {
+	return resilientCall(ctx, r, "LookupObjects", func(ctx context.Context) (model.ResultStream[model.LookupObjectsItem], error) {
+		return r.repository.LookupObjects(ctx, objectType, relation, subject, pagination, consistency)
+	})
+}
+
+func (r *ResilientRelationsRepository) LookupSubjects(ctx context.Context, object model.ResourceReference,
+	relation model.Relation, subjectType model.RepresentationType, subjectRelation *model.Relation,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupSubjectsItem], error) {
+	return resilientCall(ctx, r, "LookupSubjects", func(ctx context.Context) (model.ResultStream[model.LookupSubjectsItem], error) {
+		return r.repository.LookupSubjects(ctx, object, relation, subjectType, subjectRelation, pagination, consistency)
+	})
+}
+
+func (r *ResilientRelationsRepository) CreateTuples(ctx context.Context, tuples []model.RelationsTuple,
+	upsert bool, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	return resilientCall(ctx, r, "CreateTuples", func(ctx context.Context) (model.TuplesResult, error) {
+		return r.repository.CreateTuples(ctx, tuples, upsert, fencing)
+	})
+}
+
+func (r *ResilientRelationsRepository) DeleteTuples(ctx context.Context, filter model.TupleFilter,
+	fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	return resilientCall(ctx, r, "DeleteTuples", func(ctx context.Context) (model.TuplesResult, error) {
+		return r.repository.DeleteTuples(ctx, filter, fencing)
+	})
+}
+
+func (r *ResilientRelationsRepository) ReadTuples(ctx context.Context, filter model.TupleFilter,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.ReadTuplesItem], error) {
+	return resilientCall(ctx, r, "ReadTuples", func(ctx context.Context) (model.ResultStream[model.ReadTuplesItem], error) {
+		return r.repository.ReadTuples(ctx, filter, pagination, consistency)
+	})
+}
+
+func (r *ResilientRelationsRepository) AcquireLock(ctx context.Context, lockId model.LockId) (model.AcquireLockResult, error) {
+	return resilientCall(ctx, r, "AcquireLock", func(ctx context.Context) (model.AcquireLockResult, error) {
+		return r.repository.AcquireLock(ctx, lockId)
+	})
+}
+
+// resilientCall runs call through the circuit breaker, retrying transient errors up to the number
+// of attempts configured for method.
+func resilientCall[T any](ctx context.Context, r *ResilientRelationsRepository, method string,
+	call func(context.Context) (T, error),
+) (T, error) {
+	attempts := r.config.MaxAttempts(method)
+	backoff := r.config.RetryInitialBackoff
+	for attempt := 1; ; attempt++ {
+		result, err := breakerCall(ctx, r, method, call)
+		if err == nil || attempt >= attempts || !isRelationsTransientError(ctx, err) {
+			return result, err
+		}
+
+		r.recordRetry(method)
+		r.logger.Debugf("Retrying relations %s after transient error (attempt %d of %d): %v", method, attempt+1, attempts, err)
+		if sleepErr := sleepContext(ctx, jitter(backoff)); sleepErr != nil {
+			return result, err
+		}
+		backoff = min(backoff*2, r.config.RetryMaxBackoff)
+	}
+}
+
+// breakerCall runs call through the circuit breaker, translating rejections to errRelationsCircuitOpen.
+func breakerCall[T any](ctx context.Context, r *ResilientRelationsRepository, method string,
+	call func(context.Context) (T, error),
+) (T, error) {
+	result, err := r.breaker.Execute(func() (any, error) {
+		return call(ctx)
+	})
+	if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+		r.recordBreakerReject(method)
+		var zero T
+		return zero, errRelationsCircuitOpen
+	}
+	typed, _ := result.(T)
+	return typed, err
+}
+
+type checkAttempt struct {
+	result model.CheckResult
+	err    error
+	hedge  bool
+}
+
+// hedgedCheck sends a second Check if the first hasn't answered within the hedge delay, and
+// returns the first successful answer. Failures are only returned once no attempt is in flight.
+func (r *ResilientRelationsRepository) hedgedCheck(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	if r.latencies == nil {
+		return r.repository.Check(ctx, rel, consistency)
+	}
+	delay, ok := r.latencies.hedgeDelay()
+	if !ok {
+		return r.timedCheck(ctx, rel, consistency)
+	}
+
+	// Cancelling the context stops the losing attempt once an answer is returned.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	attempts := make(chan checkAttempt, 2)
+	send := func(hedge bool) {
+		go func() {
+			result, err := r.timedCheck(ctx, rel, consistency)
+			attempts <- checkAttempt{result: result, err: err, hedge: hedge}
+		}()
+	}
+
+	send(false)
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	inFlight, hedged := 1, false
+	for {
+		select {
+		case <-timer.C:
+			send(true)
+			inFlight++
+			hedged = true
+		case attempt := <-attempts:
+			inFlight--
+			if attempt.err != nil && inFlight > 0 {
+				continue
+			}
+			if hedged {
+				r.recordHedge("Check", attempt.hedge)
+			}
+			return attempt.result, attempt.err
+		}
+	}
+}
+
+// timedCheck calls Check and records the latency of successful calls for the hedge delay.
+func (r *ResilientRelationsRepository) timedCheck(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	start := time.Now()
+	result, err := r.repository.Check(ctx, rel, consistency)
+	if err == nil {
+		r.latencies.record(time.Since(start))
+	}
+	return result, err
+}
+
+// failOpen reports whether a failed Check should be answered as allowed.
+func (r *ResilientRelationsRepository) failOpen(ctx context.Context, err error) bool {
+	if r.config.CheckFailureMode != resilience.FailOpen || ctx.Err() != nil {
+		return false
+	}
+	return errors.Is(err, errRelationsCircuitOpen) || isRelationsBackendFailure(err)
+}
+
+func (r *ResilientRelationsRepository) recordBreakerState(state gobreaker.State) {
+	if r.metrics == nil || r.metrics.RelationsBreakerState == nil {
+		return
+	}
+	var value int64
+	switch state {
+	case gobreaker.StateClosed:
+		value = 0
+	case gobreaker.StateHalfOpen:
+		value = 1
+	case gobreaker.StateOpen:
+		value = 2
+	}
+	r.metrics.RelationsBreakerState.Record(context.Background(), value)
+}
+
+func (r *ResilientRelationsRepository) recordRetry(operation string) {
+	if r.metrics != nil && r.metrics.RelationsRetries != nil {
+		metricscollector.Incr(r.metrics.RelationsRetries, operation)
+	}
+}
+
+func (r *ResilientRelationsRepository) recordBreakerReject(operation string) {
+	if r.metrics != nil && r.metrics.RelationsBreakerRejects != nil {
+		metricscollector.Incr(r.metrics.RelationsBreakerRejects, operation)
+	}
+}
+
+func (r *ResilientRelationsRepository) recordHedge(operation string, hedgeWon bool) {
+	winner := "first"
+	if hedgeWon {
+		winner = "hedge"
+	}
+	if r.metrics != nil && r.metrics.RelationsHedgedRequests != nil {
+		metricscollector.Incr(r.metrics.RelationsHedgedRequests, operation, attribute.String("winner", winner))
+	}
+}
+
+func (r *ResilientRelationsRepository) recordFailOpen(operation string) {
+	if r.metrics != nil && r.metrics.RelationsFailOpens != nil {
+		metricscollector.Incr(r.metrics.RelationsFailOpens, operation)
+	}
+}
+
+// isRelationsBackendFailure reports whether err means the backend is unhealthy, as opposed to the
+// request being invalid or denied.
+func isRelationsBackendFailure(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRelationsTransientError reports whether retrying the call that failed with err may succeed.
+func isRelationsTransientError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, errRelationsCircuitOpen) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// jitter returns a random duration between half of d and d, so retries from many callers spread out.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + rand.N(d/2+1)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// latencyWindow keeps the most recent Check latencies and derives the hedge delay from them.
+type latencyWindow struct {
+	percentile float64
+	minDelay   time.Duration
+
+	mu       sync.Mutex
+	samples  []time.Duration
+	next     int
+	recorded int
+	delay    time.Duration
+	ready    bool
+}
+
+func newLatencyWindow(percentile float64, minDelay time.Duration) *latencyWindow {
+	return &latencyWindow{
+		percentile: percentile,
+		minDelay:   minDelay,
+		samples:    make([]time.Duration, 0, hedgeLatencyWindow),
+	}
+}
+
+func (w *latencyWindow) record(latency time.Duration) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.samples) < hedgeLatencyWindow {
+		w.samples = append(w.samples, latency)
+	} else {
+		w.samples[w.next] = latency
+	}
+	w.next = (w.next + 1) % hedgeLatencyWindow
+	w.recorded++
+
+	if len(w.samples) >= hedgeMinSamples && (!w.ready || w.recorded%hedgeRecomputeInterval == 0) {
+		sorted := slices.Clone(w.samples)
+		slices.Sort(sorted)
+		index := int(math.Ceil(w.percentile*float64(len(sorted)))) - 1
+		index = min(max(index, 0), len(sorted)-1)
+		w.delay = max(sorted[index], w.minDelay)
+		w.ready = true
+	}
+}
+
+// hedgeDelay returns how long to wait before hedging, or false until enough latencies are recorded.
+func (w *latencyWindow) hedgeDelay() (time.Duration, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.delay, w.ready
+}
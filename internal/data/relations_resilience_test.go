@@ -0,0 +1,293 @@
+package data
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/resilience"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+// failingRelationsRepository fails the first calls to Check, LookupObjects and CreateTuples with
+// the queued errors, and counts the calls that reach it.
+type failingRelationsRepository struct {
+	*SimpleRelationsRepository
+
+	mu          sync.Mutex
+	errs        []error
+	calls       map[string]int
+	blockFirst  bool
+	firstCalled chan struct{}
+}
+
+func newFailingRelationsRepository(errs ...error) *failingRelationsRepository {
+	return &failingRelationsRepository{
+		SimpleRelationsRepository: NewSimpleRelationsRepository(),
+		errs:                      errs,
+		calls:                     map[string]int{},
+		firstCalled:               make(chan struct{}),
+	}
+}
+
+func (r *failingRelationsRepository) next(method string) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls[method]++
+	if len(r.errs) == 0 {
+		return r.calls[method], nil
+	}
+	err := r.errs[0]
+	r.errs = r.errs[1:]
+	return r.calls[method], err
+}
+
+func (r *failingRelationsRepository) callCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls[method]
+}
+
+func (r *failingRelationsRepository) Check(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	call, err := r.next("Check")
+	if r.blockFirst && call == 1 {
+		// The first attempt hangs until it is cancelled, so only a hedged attempt can answer.
+		close(r.firstCalled)
+		<-ctx.Done()
+		return model.CheckResult{}, ctx.Err()
+	}
+	if err != nil {
+		return model.CheckResult{}, err
+	}
+	return r.SimpleRelationsRepository.Check(ctx, rel, consistency)
+}
+
+func (r *failingRelationsRepository) LookupObjects(ctx context.Context, objectType model.RepresentationType,
+	relation model.Relation, subject model.SubjectReference, pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupObjectsItem], error) {
+	if _, err := r.next("LookupObjects"); err != nil {
+		return nil, err
+	}
+	return r.SimpleRelationsRepository.LookupObjects(ctx, objectType, relation, subject, pagination, consistency)
+}
+
+func (r *failingRelationsRepository) CreateTuples(ctx context.Context, tuples []model.RelationsTuple,
+	upsert bool, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	if _, err := r.next("CreateTuples"); err != nil {
+		return model.TuplesResult{}, err
+	}
+	return r.SimpleRelationsRepository.CreateTuples(ctx, tuples, upsert, fencing)
+}
+
+func newTestResilientRelationsRepository(t *testing.T, inner model.RelationsRepository, configure func(*resilience.Options)) *ResilientRelationsRepository {
+	t.Helper()
+	options := resilience.NewOptions()
+	options.Enabled = true
+	options.RetryInitialBackoffMs = 1
+	options.RetryMaxBackoffMs = 2
+	if configure != nil {
+		configure(options)
+	}
+	require.Empty(t, options.Validate())
+	completed, errs := resilience.NewConfig(options).Complete()
+	require.Nil(t, errs)
+	return NewResilientRelationsRepository(inner, completed, metricscollector.NewFakeMetricsCollector(), log.NewHelper(log.DefaultLogger))
+}
+
+var errBackendUnavailable = status.Error(codes.Unavailable, "backend unavailable")
+
+func TestResilientRelationsRepository_RetriesTransientReadErrors(t *testing.T) {
+	inner := newFailingRelationsRepository(errBackendUnavailable, errBackendUnavailable)
+	inner.Grant("alice", "view", "hbi", "host", "host-1")
+	repo := newTestResilientRelationsRepository(t, inner, nil)
+
+	result, err := repo.Check(context.Background(), testRelationship("hbi", "host", "host-1", "view", "alice"), model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+	assert.Equal(t, 3, inner.callCount("Check"))
+	assert.Equal(t, 2, metricscollector.GetRelationsRetryCount())
+}
+
+func TestResilientRelationsRepository_GivesUpAfterMaxAttempts(t *testing.T) {
+	inner := newFailingRelationsRepository(errBackendUnavailable, errBackendUnavailable, errBackendUnavailable, errBackendUnavailable)
+	repo := newTestResilientRelationsRepository(t, inner, nil)
+
+	_, err := repo.Check(context.Background(), testRelationship("hbi", "host", "host-1", "view", "alice"), model.NewConsistencyMinimizeLatency())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, inner.callCount("Check"))
+}
+
+func TestResilientRelationsRepository_DoesNotRetry(t *testing.T) {
+	t.Run("request errors", func(t *testing.T) {
+		inner := newFailingRelationsRepository(status.Error(codes.InvalidArgument, "bad relation"))
+		repo := newTestResilientRelationsRepository(t, inner, nil)
+
+		_, err := repo.Check(context.Background(), testRelationship("hbi", "host", "host-1", "view", "alice"), model.NewConsistencyMinimizeLatency())
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, 1, inner.callCount("Check"))
+	})
+
+	t.Run("writes", func(t *testing.T) {
+		inner := newFailingRelationsRepository(errBackendUnavailable)
+		repo := newTestResilientRelationsRepository(t, inner, nil)
+
+		_, err := repo.CreateTuples(context.Background(), []model.RelationsTuple{testPrincipalTuple("hbi", "host", "host-1", "view", "alice")}, true, nil)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, inner.callCount("CreateTuples"))
+	})
+
+	t.Run("methods with a single attempt configured", func(t *testing.T) {
+		inner := newFailingRelationsRepository(errBackendUnavailable)
+		repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+			o.MethodMaxAttempts = map[string]int{"LookupObjects": 1}
+		})
+
+		objectType := model.NewRepresentationTypeRequired(model.DeserializeResourceType("host"), model.DeserializeReporterType("hbi"))
+		_, err := repo.LookupObjects(context.Background(), objectType, model.DeserializeRelation("view"), testSubjectRef("alice"), nil, model.NewConsistencyMinimizeLatency())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, 1, inner.callCount("LookupObjects"))
+	})
+}
+
+func TestResilientRelationsRepository_CircuitOpensAfterConsecutiveFailures(t *testing.T) {
+	inner := newFailingRelationsRepository(errBackendUnavailable, errBackendUnavailable, errBackendUnavailable)
+	repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+		o.RetryMaxAttempts = 1
+		o.BreakerFailureThreshold = 3
+	})
+	ctx := context.Background()
+	rel := testRelationship("hbi", "host", "host-1", "view", "alice")
+
+	for range 3 {
+		_, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+		assert.ErrorIs(t, err, errBackendUnavailable)
+	}
+
+	_, err := repo.Check(ctx, rel, model.NewConsistencyMinimizeLatency())
+	assert.ErrorIs(t, err, errRelationsCircuitOpen)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, inner.callCount("Check"))
+	assert.Equal(t, 1, metricscollector.GetRelationsBreakerRejectCount())
+
+	// Health bypasses the breaker so that it reports the backend's own state.
+	_, err = repo.Health(ctx)
+	assert.NoError(t, err)
+}
+
+func TestResilientRelationsRepository_RequestErrorsDoNotOpenCircuit(t *testing.T) {
+	invalid := status.Error(codes.InvalidArgument, "bad relation")
+	inner := newFailingRelationsRepository(invalid, invalid, invalid)
+	repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+		o.BreakerFailureThreshold = 2
+	})
+	rel := testRelationship("hbi", "host", "host-1", "view", "alice")
+
+	for range 3 {
+		_, err := repo.Check(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+	_, err := repo.Check(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+	assert.NoError(t, err)
+}
+
+func TestResilientRelationsRepository_CheckFailureMode(t *testing.T) {
+	rel := testRelationship("hbi", "host", "host-1", "view", "alice")
+
+	t.Run("fail-closed returns the backend error", func(t *testing.T) {
+		inner := newFailingRelationsRepository(errBackendUnavailable)
+		repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) { o.RetryMaxAttempts = 1 })
+
+		_, err := repo.Check(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("fail-open answers allowed", func(t *testing.T) {
+		inner := newFailingRelationsRepository(errBackendUnavailable)
+		repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+			o.RetryMaxAttempts = 1
+			o.CheckFailureMode = resilience.FailOpen
+		})
+
+		result, err := repo.Check(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+		require.NoError(t, err)
+		assert.True(t, result.Allowed())
+		assert.True(t, result.FailOpen(), "fail-open answers are marked as such")
+		assert.Equal(t, 1, metricscollector.GetRelationsFailOpenCount())
+	})
+
+	t.Run("the fail-closed view returns the backend error", func(t *testing.T) {
+		inner := newFailingRelationsRepository(errBackendUnavailable)
+		repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+			o.RetryMaxAttempts = 1
+			o.CheckFailureMode = resilience.FailOpen
+		})
+
+		_, err := repo.FailClosed().Check(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Equal(t, resilience.FailOpen, repo.config.CheckFailureMode, "the repository itself still fails open")
+	})
+
+	t.Run("fail-open still returns request errors", func(t *testing.T) {
+		inner := newFailingRelationsRepository(status.Error(codes.InvalidArgument, "bad relation"))
+		repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+			o.CheckFailureMode = resilience.FailOpen
+		})
+
+		_, err := repo.Check(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestResilientRelationsRepository_HedgesSlowChecks(t *testing.T) {
+	inner := newFailingRelationsRepository()
+	inner.blockFirst = true
+	inner.Grant("alice", "view", "hbi", "host", "host-1")
+	repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) {
+		o.HedgeCheck = true
+		o.HedgeMinDelayMs = 5
+	})
+	for range hedgeMinSamples {
+		repo.latencies.record(time.Millisecond)
+	}
+	delay, ok := repo.latencies.hedgeDelay()
+	require.True(t, ok)
+	assert.Equal(t, 5*time.Millisecond, delay)
+
+	result, err := repo.Check(context.Background(), testRelationship("hbi", "host", "host-1", "view", "alice"), model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+	<-inner.firstCalled
+	assert.Equal(t, 2, inner.callCount("Check"))
+	assert.Equal(t, 1, metricscollector.GetRelationsHedgedRequestCount())
+}
+
+func TestResilientRelationsRepository_DoesNotHedgeWithoutLatencies(t *testing.T) {
+	inner := newFailingRelationsRepository()
+	repo := newTestResilientRelationsRepository(t, inner, func(o *resilience.Options) { o.HedgeCheck = true })
+
+	_, err := repo.Check(context.Background(), testRelationship("hbi", "host", "host-1", "view", "alice"), model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.Equal(t, 1, inner.callCount("Check"))
+	assert.Equal(t, 0, metricscollector.GetRelationsHedgedRequestCount())
+}
+
+func TestLatencyWindow_HedgeDelayIsPercentile(t *testing.T) {
+	window := newLatencyWindow(0.9, 0)
+	for i := 1; i <= 100; i++ {
+		window.record(time.Duration(i) * time.Millisecond)
+	}
+
+	delay, ok := window.hedgeDelay()
+	require.True(t, ok)
+	assert.Equal(t, 90*time.Millisecond, delay)
+}
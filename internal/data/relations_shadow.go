@@ -0,0 +1,508 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+const (
+	shadowReasonDropped        = "dropped"
+	shadowReasonSecondaryError = "secondary_error"
+
+	// shadowLoggedIdsLimit caps how many differing IDs a lookup mismatch log lists.
+	shadowLoggedIdsLimit = 10
+)
+
+// ShadowRelationsRepository serves every request from a primary RelationsRepository and mirrors
+// it to a secondary one in the background, to compare two backends (e.g. relations-api and SpiceDB)
+// before switching between them. Callers only ever see the primary's answers and errors.
+//
+// Check, CheckForUpdate, CheckBulk and CheckForUpdateBulk are mirrored once the primary has
+// answered, and the decisions are compared. LookupObjects and LookupSubjects are mirrored once the
+// caller has read the primary's stream to the end, and the result sets are compared; paginated
+// lookups are not mirrored because continuation tokens are backend specific. Consistency tokens
+// are also backend specific, so at_least_as_fresh requests are mirrored as minimize_latency.
+//
+// Differences are counted in kessel_inventory_relations_shadow_mismatches and logged for a
+// sampled fraction of requests. Mirrored requests that fail, time out or are dropped because too
+// many are in flight are counted in kessel_inventory_relations_shadow_errors.
+//
+// When writes are mirrored, CreateTuples and DeleteTuples are replayed on the secondary in the
+// order they succeeded on the primary, as upserts and without fencing (locks only exist on the
+// primary). CheckExplain, ReadTuples, AcquireLock and Health are served by the primary alone.
+// Close stops mirroring once the queued writes have been replayed.
+type ShadowRelationsRepository struct {
+	primary   model.RelationsRepository
+	secondary model.RelationsRepository
+
+	mirrorWrites bool
+	timeout      time.Duration
+	metrics      *metricscollector.MetricsCollector
+	logger       *log.Helper
+	sample       func() bool
+
+	inFlight chan struct{}
+	pending  sync.WaitGroup
+
+	// writesMu guards writes against being closed while a write is queued.
+	writesMu sync.RWMutex
+	writes   chan func()
+	closed   bool
+	// replayed is closed once the replay goroutine has replayed every queued write.
+	replayed chan struct{}
+}
+
+var _ model.RelationsRepository = &ShadowRelationsRepository{}
+
+// NewShadowRelationsRepository serves requests from primary and mirrors them to secondary as
+// configured by config.
+func NewShadowRelationsRepository(primary, secondary model.RelationsRepository, config shadow.CompletedConfig,
+	metrics *metricscollector.MetricsCollector, logger *log.Helper,
+) *ShadowRelationsRepository {
+	logger.Infof("Using shadow relations: primary=%s, secondary=%s, mirror-writes=%t", config.Primary, config.Secondary, config.MirrorWrites)
+	s := &ShadowRelationsRepository{
+		primary:      primary,
+		secondary:    secondary,
+		mirrorWrites: config.MirrorWrites,
+		timeout:      config.Timeout,
+		metrics:      metrics,
+		logger:       logger,
+		sample:       func() bool { return rand.Float64() < config.LogSampleRate },
+		inFlight:     make(chan struct{}, config.MaxInFlight),
+	}
+	if config.MirrorWrites {
+		s.writes = make(chan func(), config.MaxInFlight)
+		s.replayed = make(chan struct{})
+		go s.replayWrites()
+	}
+	return s
+}
+
+// Wait blocks until every mirrored request started so far has finished.
+func (s *ShadowRelationsRepository) Wait() {
+	s.pending.Wait()
+}
+
+// Close replays the queued writes, stops the replay goroutine and waits for the mirrored requests
+// in flight. Writes that succeed on the primary afterwards are counted as dropped.
+func (s *ShadowRelationsRepository) Close() {
+	s.writesMu.Lock()
+	if s.closed {
+		s.writesMu.Unlock()
+		return
+	}
+	s.closed = true
+	if s.writes != nil {
+		close(s.writes)
+	}
+	s.writesMu.Unlock()
+
+	if s.replayed != nil {
+		<-s.replayed
+	}
+	s.pending.Wait()
+}
+
+// WritesTuples reports whether the primary stores tuples. The secondary only sees mirrored writes.
+func (s *ShadowRelationsRepository) WritesTuples() bool {
+	return model.WritesTuples(s.primary)
+}
+
+func (s *ShadowRelationsRepository) Health(ctx context.Context) (model.HealthResult, error) {
+	return s.primary.Health(ctx)
+}
+
+func (s *ShadowRelationsRepository) Check(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckResult, error) {
+	result, err := s.primary.Check(ctx, rel, consistency)
+	if err != nil {
+		return result, err
+	}
+	s.mirror(ctx, "Check", func(ctx context.Context) {
+		secondary, err := s.secondary.Check(ctx, rel, shadowConsistency(consistency))
+		s.compareCheck("Check", rel, result, secondary, err)
+	})
+	return result, nil
+}
+
+func (s *ShadowRelationsRepository) CheckForUpdate(ctx context.Context, rel model.Relationship,
+) (model.CheckResult, error) {
+	result, err := s.primary.CheckForUpdate(ctx, rel)
+	if err != nil {
+		return result, err
+	}
+	s.mirror(ctx, "CheckForUpdate", func(ctx context.Context) {
+		secondary, err := s.secondary.CheckForUpdate(ctx, rel)
+		s.compareCheck("CheckForUpdate", rel, result, secondary, err)
+	})
+	return result, nil
+}
+
+func (s *ShadowRelationsRepository) CheckBulk(ctx context.Context, rels []model.Relationship, consistency model.Consistency,
+) (model.CheckBulkResult, error) {
+	result, err := s.primary.CheckBulk(ctx, rels, consistency)
+	if err != nil {
+		return result, err
+	}
+	s.mirror(ctx, "CheckBulk", func(ctx context.Context) {
+		secondary, err := s.secondary.CheckBulk(ctx, rels, shadowConsistency(consistency))
+		s.compareCheckBulk("CheckBulk", result, secondary, err)
+	})
+	return result, nil
+}
+
+func (s *ShadowRelationsRepository) CheckForUpdateBulk(ctx context.Context, rels []model.Relationship,
+) (model.CheckBulkResult, error) {
+	result, err := s.primary.CheckForUpdateBulk(ctx, rels)
+	if err != nil {
+		return result, err
+	}
+	s.mirror(ctx, "CheckForUpdateBulk", func(ctx context.Context) {
+		secondary, err := s.secondary.CheckForUpdateBulk(ctx, rels)
+		s.compareCheckBulk("CheckForUpdateBulk", result, secondary, err)
+	})
+	return result, nil
+}
+
+func (s *ShadowRelationsRepository) CheckExplain(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckExplainResult, error) {
+	return s.primary.CheckExplain(ctx, rel, consistency)
+}
+
+func (s *ShadowRelationsRepository) LookupObjects(ctx context.Context,
+	objectType model.RepresentationType,
+	relation model.Relation, subject model.SubjectReference,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupObjectsItem], error) {
+	stream, err := s.primary.LookupObjects(ctx, objectType, relation, subject, pagination, consistency)
+	if err != nil || pagination != nil {
+		return stream, err
+	}
+	return &shadowRecordingStream[model.LookupObjectsItem]{
+		stream: stream,
+		key:    shadowLookupObjectsKey,
+		seen:   map[string]bool{},
+		onEOF: func(primary map[string]bool) {
+			s.mirror(ctx, "LookupObjects", func(ctx context.Context) {
+				secondary, err := s.secondary.LookupObjects(ctx, objectType, relation, subject, nil, shadowConsistency(consistency))
+				if err != nil {
+					s.recordError("LookupObjects", shadowReasonSecondaryError, err)
+					return
+				}
+				compareShadowLookup(s, "LookupObjects", primary, secondary, shadowLookupObjectsKey)
+			})
+		},
+	}, nil
+}
+
+func (s *ShadowRelationsRepository) LookupSubjects(ctx context.Context,
+	object model.ResourceReference, relation model.Relation,
+	subjectType model.RepresentationType,
+	subjectRelation *model.Relation,
+	pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.LookupSubjectsItem], error) {
+	stream, err := s.primary.LookupSubjects(ctx, object, relation, subjectType, subjectRelation, pagination, consistency)
+	if err != nil || pagination != nil {
+		return stream, err
+	}
+	return &shadowRecordingStream[model.LookupSubjectsItem]{
+		stream: stream,
+		key:    shadowLookupSubjectsKey,
+		seen:   map[string]bool{},
+		onEOF: func(primary map[string]bool) {
+			s.mirror(ctx, "LookupSubjects", func(ctx context.Context) {
+				secondary, err := s.secondary.LookupSubjects(ctx, object, relation, subjectType, subjectRelation, nil, shadowConsistency(consistency))
+				if err != nil {
+					s.recordError("LookupSubjects", shadowReasonSecondaryError, err)
+					return
+				}
+				compareShadowLookup(s, "LookupSubjects", primary, secondary, shadowLookupSubjectsKey)
+			})
+		},
+	}, nil
+}
+
+func (s *ShadowRelationsRepository) CreateTuples(ctx context.Context, tuples []model.RelationsTuple, upsert bool, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	result, err := s.primary.CreateTuples(ctx, tuples, upsert, fencing)
+	if err != nil {
+		return result, err
+	}
+	s.mirrorWrite(ctx, "CreateTuples", func(ctx context.Context) error {
+		_, err := s.secondary.CreateTuples(ctx, tuples, true, nil)
+		return err
+	})
+	return result, nil
+}
+
+func (s *ShadowRelationsRepository) DeleteTuples(ctx context.Context, filter model.TupleFilter, fencing *model.FencingCheck,
+) (model.TuplesResult, error) {
+	result, err := s.primary.DeleteTuples(ctx, filter, fencing)
+	if err != nil {
+		return result, err
+	}
+	s.mirrorWrite(ctx, "DeleteTuples", func(ctx context.Context) error {
+		_, err := s.secondary.DeleteTuples(ctx, filter, nil)
+		return err
+	})
+	return result, nil
+}
+
+func (s *ShadowRelationsRepository) ReadTuples(ctx context.Context, filter model.TupleFilter, pagination *model.Pagination, consistency model.Consistency,
+) (model.ResultStream[model.ReadTuplesItem], error) {
+	return s.primary.ReadTuples(ctx, filter, pagination, consistency)
+}
+
+func (s *ShadowRelationsRepository) AcquireLock(ctx context.Context, lockId model.LockId) (model.AcquireLockResult, error) {
+	return s.primary.AcquireLock(ctx, lockId)
+}
+
+// mirror runs fn in the background with the shadow timeout, unless too many mirrored requests
+// are already in flight. The request's cancellation does not stop the mirrored request.
+func (s *ShadowRelationsRepository) mirror(ctx context.Context, operation string, fn func(ctx context.Context)) {
+	select {
+	case s.inFlight <- struct{}{}:
+	default:
+		s.recordError(operation, shadowReasonDropped, nil)
+		return
+	}
+
+	s.pending.Add(1)
+	go func() {
+		defer s.pending.Done()
+		defer func() { <-s.inFlight }()
+
+		mirrorCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+		defer cancel()
+		fn(mirrorCtx)
+	}()
+}
+
+// mirrorWrite queues fn to be replayed on the secondary after every previously queued write.
+func (s *ShadowRelationsRepository) mirrorWrite(ctx context.Context, operation string, fn func(ctx context.Context) error) {
+	if !s.mirrorWrites {
+		return
+	}
+
+	s.pending.Add(1)
+	write := func() {
+		defer s.pending.Done()
+
+		mirrorCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.timeout)
+		defer cancel()
+		if err := fn(mirrorCtx); err != nil {
+			s.recordError(operation, shadowReasonSecondaryError, err)
+		}
+	}
+
+	s.writesMu.RLock()
+	defer s.writesMu.RUnlock()
+	if !s.closed {
+		select {
+		case s.writes <- write:
+			return
+		default:
+		}
+	}
+	s.pending.Done()
+	s.recordError(operation, shadowReasonDropped, nil)
+}
+
+func (s *ShadowRelationsRepository) replayWrites() {
+	defer close(s.replayed)
+	for write := range s.writes {
+		write()
+	}
+}
+
+func (s *ShadowRelationsRepository) compareCheck(operation string, rel model.Relationship, primary, secondary model.CheckResult, err error) {
+	if err != nil {
+		s.recordError(operation, shadowReasonSecondaryError, err)
+		return
+	}
+	if primary.Allowed() != secondary.Allowed() {
+		s.recordMismatch(operation,
+			"relationship", shadowRelationshipString(rel),
+			"primary", primary.Allowed(),
+			"secondary", secondary.Allowed(),
+		)
+	}
+}
+
+func (s *ShadowRelationsRepository) compareCheckBulk(operation string, primary, secondary model.CheckBulkResult, err error) {
+	if err != nil {
+		s.recordError(operation, shadowReasonSecondaryError, err)
+		return
+	}
+	if len(primary.Pairs()) != len(secondary.Pairs()) {
+		s.recordMismatch(operation,
+			"primary_results", len(primary.Pairs()),
+			"secondary_results", len(secondary.Pairs()),
+		)
+		return
+	}
+	for i, pair := range primary.Pairs() {
+		p, q := pair.Result(), secondary.Pairs()[i].Result()
+		if (p.Err() == nil) != (q.Err() == nil) || (p.Err() == nil && p.Allowed() != q.Allowed()) {
+			s.recordMismatch(operation,
+				"relationship", shadowRelationshipString(pair.Request()),
+				"primary", shadowBulkItemString(p),
+				"secondary", shadowBulkItemString(q),
+			)
+		}
+	}
+}
+
+func compareShadowSets(primary, secondary map[string]bool) (missing, extra []string) {
+	for key := range primary {
+		if !secondary[key] {
+			missing = append(missing, key)
+		}
+	}
+	for key := range secondary {
+		if !primary[key] {
+			extra = append(extra, key)
+		}
+	}
+	slices.Sort(missing)
+	slices.Sort(extra)
+	return missing, extra
+}
+
+// compareShadowLookup drains the secondary's lookup stream and compares its results with the
+// primary's. It is a function rather than a method because methods cannot be generic.
+func compareShadowLookup[T any](s *ShadowRelationsRepository, operation string, primary map[string]bool,
+	secondary model.ResultStream[T], key func(T) string,
+) {
+	seen, err := drainShadowStream(secondary, key)
+	if err != nil {
+		s.recordError(operation, shadowReasonSecondaryError, err)
+		return
+	}
+
+	missing, extra := compareShadowSets(primary, seen)
+	if len(missing) == 0 && len(extra) == 0 {
+		return
+	}
+	s.recordMismatch(operation,
+		"primary_results", len(primary),
+		"secondary_results", len(seen),
+		"missing_from_secondary", shadowTruncate(missing),
+		"extra_in_secondary", shadowTruncate(extra),
+	)
+}
+
+func drainShadowStream[T any](stream model.ResultStream[T], key func(T) string) (map[string]bool, error) {
+	seen := map[string]bool{}
+	for {
+		item, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return seen, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		seen[key(item)] = true
+	}
+}
+
+func (s *ShadowRelationsRepository) recordMismatch(operation string, keyvals ...any) {
+	if s.metrics != nil && s.metrics.RelationsShadowMismatches != nil {
+		metricscollector.Incr(s.metrics.RelationsShadowMismatches, operation)
+	}
+	if s.sample() {
+		s.logger.Warnw(append([]any{"msg", "relations shadow mismatch", "operation", operation}, keyvals...)...)
+	}
+}
+
+func (s *ShadowRelationsRepository) recordError(operation, reason string, err error) {
+	if s.metrics != nil && s.metrics.RelationsShadowErrors != nil {
+		metricscollector.Incr(s.metrics.RelationsShadowErrors, operation, attribute.String("reason", reason))
+	}
+	if s.sample() {
+		s.logger.Warnw("msg", "relations shadow request not compared", "operation", operation, "reason", reason, "error", err)
+	}
+}
+
+// shadowRecordingStream passes a primary lookup stream through to the caller, recording the key
+// of each item, and calls onEOF with the recorded keys once the stream is exhausted.
+type shadowRecordingStream[T any] struct {
+	stream model.ResultStream[T]
+	key    func(T) string
+	seen   map[string]bool
+	onEOF  func(map[string]bool)
+	done   bool
+}
+
+func (r *shadowRecordingStream[T]) Recv() (T, error) {
+	item, err := r.stream.Recv()
+	switch {
+	case err == nil:
+		r.seen[r.key(item)] = true
+	case errors.Is(err, io.EOF) && !r.done:
+		r.done = true
+		r.onEOF(r.seen)
+	}
+	return item, err
+}
+
+// shadowConsistency drops consistency tokens, which only the primary can interpret.
+func shadowConsistency(consistency model.Consistency) model.Consistency {
+	if model.ConsistencyAtLeastAsFreshToken(consistency) != nil {
+		return model.NewConsistencyMinimizeLatency()
+	}
+	return consistency
+}
+
+func shadowResourceKey(resource model.ResourceReference) string {
+	return resourceReferenceToSpiceDBType(resource) + ":" + resource.ResourceId().Serialize()
+}
+
+func shadowSubjectKey(subject model.SubjectReference) string {
+	key := shadowResourceKey(subject.Resource())
+	if relation := optionalRelationToString(subject.Relation()); relation != "" {
+		key += "#" + relation
+	}
+	return key
+}
+
+func shadowLookupObjectsKey(item model.LookupObjectsItem) string {
+	return shadowResourceKey(item.Object())
+}
+
+func shadowLookupSubjectsKey(item model.LookupSubjectsItem) string {
+	return shadowSubjectKey(item.Subject())
+}
+
+func shadowRelationshipString(rel model.Relationship) string {
+	return shadowResourceKey(rel.Object()) + "#" + rel.Relation().Serialize() + "@" + shadowSubjectKey(rel.Subject())
+}
+
+func shadowBulkItemString(item model.CheckBulkResultItem) string {
+	if item.Err() != nil {
+		return "error: " + item.Err().Error()
+	}
+	if item.Allowed() {
+		return "allowed"
+	}
+	return "denied"
+}
+
+func shadowTruncate(keys []string) []string {
+	if len(keys) > shadowLoggedIdsLimit {
+		return keys[:shadowLoggedIdsLimit]
+	}
+	return keys
+}
@@ -0,0 +1,183 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/relations/shadow"
+	"github.com/project-kessel/inventory-api/internal/metricscollector"
+)
+
+func newTestShadowRelationsRepository(t *testing.T, mirrorWrites bool) (*ShadowRelationsRepository, *EmbeddedRelationsRepository, *EmbeddedRelationsRepository) {
+	t.Helper()
+	primary := newTestEmbeddedRelationsRepository(t)
+	secondary := newTestEmbeddedRelationsRepository(t)
+	config, _ := shadow.NewConfig(&shadow.Options{
+		Primary:        "embedded",
+		Secondary:      "embedded",
+		MirrorWrites:   mirrorWrites,
+		LogSampleRate:  1,
+		TimeoutSeconds: 5,
+		MaxInFlight:    10,
+	}).Complete()
+	repo := NewShadowRelationsRepository(primary, secondary, config, metricscollector.NewFakeMetricsCollector(), log.NewHelper(log.DefaultLogger))
+	t.Cleanup(repo.Close)
+	return repo, primary, secondary
+}
+
+func TestShadowRelationsRepository_CheckMismatch(t *testing.T) {
+	ctx := context.Background()
+	repo, primary, secondary := newTestShadowRelationsRepository(t, false)
+	tuples := embeddedRBACTuples()
+	_, err := primary.CreateTuples(ctx, tuples, true, nil)
+	require.NoError(t, err)
+	_, err = secondary.CreateTuples(ctx, tuples[:len(tuples)-1], true, nil)
+	require.NoError(t, err)
+
+	assert.True(t, embeddedCheck(t, repo, "workspace", "parent", "view_widget", "bob"))
+	repo.Wait()
+	assert.Equal(t, 0, metricscollector.GetRelationsShadowMismatchCount(), "both backends allow the workspace check")
+
+	assert.True(t, embeddedCheck(t, repo, "widget", "w1", "view", "bob"), "callers get the primary's answer")
+	repo.Wait()
+	assert.Equal(t, 1, metricscollector.GetRelationsShadowMismatchCount())
+}
+
+func TestShadowRelationsRepository_CheckBulkMismatch(t *testing.T) {
+	ctx := context.Background()
+	repo, primary, secondary := newTestShadowRelationsRepository(t, false)
+	_, err := primary.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+	_, err = secondary.CreateTuples(ctx, embeddedRBACTuples()[:5], true, nil)
+	require.NoError(t, err)
+
+	bob := createSubjectReference("rbac", "principal", "bob")
+	result, err := repo.CheckBulk(ctx, []model.Relationship{
+		model.NewRelationship(createResourceReference("rbac", "workspace", "child"), model.DeserializeRelation("view_widget"), bob),
+		model.NewRelationship(createResourceReference("rbac", "widget", "w1"), model.DeserializeRelation("view"), bob),
+		model.NewRelationship(createResourceReference("rbac", "widget", "w1"), model.DeserializeRelation("use"), bob),
+	}, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.True(t, result.Pairs()[1].Result().Allowed())
+
+	repo.Wait()
+	assert.Equal(t, 1, metricscollector.GetRelationsShadowMismatchCount(), "only the widget view decision differs")
+}
+
+func TestShadowRelationsRepository_LookupObjectsComparedAfterStreamIsRead(t *testing.T) {
+	ctx := context.Background()
+	repo, primary, secondary := newTestShadowRelationsRepository(t, false)
+	tuples := append(embeddedRBACTuples(),
+		createRelationship("rbac", "widget", "w2", "workspace", "rbac", "workspace", "parent", ""))
+	_, err := primary.CreateTuples(ctx, tuples, true, nil)
+	require.NoError(t, err)
+	_, err = secondary.CreateTuples(ctx, tuples[:len(tuples)-1], true, nil)
+	require.NoError(t, err)
+
+	widgetType := model.NewRepresentationTypeRequired(model.DeserializeResourceType("widget"), model.DeserializeReporterType("rbac"))
+	stream, err := repo.LookupObjects(ctx, widgetType, model.DeserializeRelation("view"),
+		createSubjectReference("rbac", "principal", "bob"), nil, model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	repo.Wait()
+	assert.Equal(t, 0, metricscollector.GetRelationsShadowMismatchCount(), "nothing is mirrored until the stream is read")
+
+	assert.Equal(t, map[string]bool{"w1": true, "w2": true}, collectObjectIds(t, stream))
+	repo.Wait()
+	assert.Equal(t, 1, metricscollector.GetRelationsShadowMismatchCount())
+
+	page, err := repo.LookupObjects(ctx, widgetType, model.DeserializeRelation("view"),
+		createSubjectReference("rbac", "principal", "bob"), model.NewPagination(1, nil), model.NewConsistencyUnspecified())
+	require.NoError(t, err)
+	collectObjectIds(t, page)
+	repo.Wait()
+	assert.Equal(t, 1, metricscollector.GetRelationsShadowMismatchCount(), "paginated lookups are not mirrored")
+}
+
+func TestShadowRelationsRepository_MirrorsWritesInOrder(t *testing.T) {
+	ctx := context.Background()
+	repo, _, secondary := newTestShadowRelationsRepository(t, true)
+	tuple := createRelationship("rbac", "role_binding", "rb", "subject", "rbac", "principal", "bob", "")
+
+	_, err := repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, false, nil)
+	require.NoError(t, err)
+	_, err = repo.DeleteTuples(ctx, testTupleFilterForSubject("role_binding", "rb", "subject", "bob"), nil)
+	require.NoError(t, err)
+	_, err = repo.CreateTuples(ctx, []model.RelationsTuple{tuple}, false, nil)
+	require.NoError(t, err)
+	repo.Wait()
+
+	stream, err := secondary.ReadTuples(ctx, testTupleFilterForSubject("role_binding", "rb", "subject", "bob"), nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, readTuplesStreamToSlice(stream), 1)
+	assert.Equal(t, 0, metricscollector.GetRelationsShadowErrorCount())
+}
+
+func TestShadowRelationsRepository_CloseReplaysQueuedWrites(t *testing.T) {
+	repo, _, _ := newTestShadowRelationsRepository(t, true)
+	release := make(chan struct{})
+	var replayed []int
+
+	repo.mirrorWrite(context.Background(), "CreateTuples", func(context.Context) error {
+		<-release
+		replayed = append(replayed, 1)
+		return nil
+	})
+	repo.mirrorWrite(context.Background(), "CreateTuples", func(context.Context) error {
+		replayed = append(replayed, 2)
+		return nil
+	})
+	close(release)
+	repo.Close()
+	assert.Equal(t, []int{1, 2}, replayed, "queued writes are replayed before Close returns")
+
+	repo.mirrorWrite(context.Background(), "DeleteTuples", func(context.Context) error {
+		t.Error("writes after Close should have been dropped")
+		return nil
+	})
+	assert.Equal(t, 1, metricscollector.GetRelationsShadowErrorCount())
+	repo.Close()
+}
+
+func TestShadowRelationsRepository_WritesNotMirroredByDefault(t *testing.T) {
+	ctx := context.Background()
+	repo, _, secondary := newTestShadowRelationsRepository(t, false)
+
+	_, err := repo.CreateTuples(ctx, embeddedRBACTuples(), true, nil)
+	require.NoError(t, err)
+	repo.Wait()
+
+	assert.False(t, embeddedCheck(t, secondary, "widget", "w1", "view", "bob"))
+}
+
+func TestShadowRelationsRepository_DropsMirrorsBeyondMaxInFlight(t *testing.T) {
+	repo, _, _ := newTestShadowRelationsRepository(t, false)
+	repo.inFlight = make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	repo.mirror(context.Background(), "Check", func(context.Context) { <-release })
+	repo.mirror(context.Background(), "Check", func(context.Context) { t.Error("mirror should have been dropped") })
+	close(release)
+	repo.Wait()
+
+	assert.Equal(t, 1, metricscollector.GetRelationsShadowErrorCount())
+}
+
+func TestShadowRelationsRepository_MirrorOutlivesRequestContext(t *testing.T) {
+	repo, _, _ := newTestShadowRelationsRepository(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var mirrorErr error
+	repo.mirror(ctx, "Check", func(ctx context.Context) {
+		mirrorErr = ctx.Err()
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+	})
+	repo.Wait()
+	assert.NoError(t, mirrorErr)
+}
@@ -224,6 +224,11 @@ func (s *SimpleRelationsRepository) SetAcquireLockError(err error) {
 	s.acquireLockError = err
 }
 
+// WritesTuples reports true: tuples are stored in memory.
+func (s *SimpleRelationsRepository) WritesTuples() bool {
+	return true
+}
+
 func (s *SimpleRelationsRepository) Health(_ context.Context) (model.HealthResult, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -262,6 +267,18 @@ func (s *SimpleRelationsRepository) Check(_ context.Context, rel model.Relations
 	return model.NewCheckResult(allowed, resultToken), nil
 }
 
+// CheckExplain performs a Check and returns a best-effort trace. Only direct tuples are
+// supported, so the trace is a single relation step for the requested relationship.
+func (s *SimpleRelationsRepository) CheckExplain(ctx context.Context, rel model.Relationship, consistency model.Consistency,
+) (model.CheckExplainResult, error) {
+	result, err := s.Check(ctx, rel, consistency)
+	if err != nil {
+		return model.CheckExplainResult{}, err
+	}
+	trace := model.NewCheckTrace(rel, model.CheckTraceKindRelation, result.Allowed(), "", false, nil)
+	return model.NewCheckExplainResult(result, trace), nil
+}
+
 func (s *SimpleRelationsRepository) CheckForUpdate(_ context.Context, rel model.Relationship,
 ) (model.CheckResult, error) {
 	s.mu.RLock()
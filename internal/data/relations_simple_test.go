@@ -107,6 +107,25 @@ func TestSimpleRelationsRepository_CreateTuples_ThenCheck(t *testing.T) {
 	assert.True(t, result.Allowed())
 }
 
+func TestSimpleRelationsRepository_CheckExplain(t *testing.T) {
+	repo := NewSimpleRelationsRepository()
+	rel := testRelationship("hbi", "host", "resource-1", "view", "user-123")
+
+	result, err := repo.CheckExplain(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.False(t, result.Allowed())
+	assert.False(t, result.Trace().Allowed())
+	assert.Empty(t, result.Trace().MatchedTuples())
+
+	repo.Grant("user-123", "view", "hbi", "host", "resource-1")
+
+	result, err = repo.CheckExplain(context.Background(), rel, model.NewConsistencyMinimizeLatency())
+	require.NoError(t, err)
+	assert.True(t, result.Allowed())
+	assert.Equal(t, model.CheckTraceKindRelation, result.Trace().Kind())
+	assert.Equal(t, []model.Relationship{rel}, result.Trace().MatchedTuples())
+}
+
 func TestSimpleRelationsRepository_DeleteTuples(t *testing.T) {
 	repo := NewSimpleRelationsRepository()
 
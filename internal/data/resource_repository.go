@@ -27,6 +27,7 @@ type FindResourceByKeysResult struct {
 	LocalResourceID       string    `gorm:"column:local_resource_id"`
 	ReporterType          string    `gorm:"column:reporter_type"`
 	ReporterInstanceID    string    `gorm:"column:reporter_instance_id"`
+	OrgID                 string    `gorm:"column:org_id"`
 	APIHref               string    `gorm:"column:api_href"`
 	ConsoleHref           *string   `gorm:"column:console_href"`
 	ConsistencyToken      string    `gorm:"column:consistency_token"`
@@ -77,6 +78,7 @@ func (result FindResourceByKeysResult) ToSnapshots() (bizmodel.ResourceSnapshot,
 		ReporterType:       result.ReporterType,
 		ResourceType:       result.ResourceType,
 		ReporterInstanceID: result.ReporterInstanceID,
+		OrgID:              result.OrgID,
 	}
 
 	reporterResourceSnapshot := bizmodel.ReporterResourceSnapshot{
@@ -223,6 +225,11 @@ func (r *resourceRepository) buildReporterResourceKeyQuery(db *gorm.DB, key bizm
 		query = query.Where("rr.reporter_instance_id = ?", reporterInstanceId)
 	}
 
+	// Keys without an organization are not scoped, as when tenancy is disabled.
+	if orgId := key.OrgId().Serialize(); orgId != "" {
+		query = query.Where("rr.org_id = ?", orgId)
+	}
+
 	return query
 }
 
@@ -247,6 +254,7 @@ func (r *resourceRepository) FindResourceByKeys(tx *gorm.DB, key bizmodel.Report
 		rr2.local_resource_id,
 		rr2.reporter_type,
 		rr2.reporter_instance_id,
+		rr2.org_id,
 		rr2.api_href,
 		rr2.console_href
 	`).
@@ -372,6 +380,98 @@ func (r *resourceRepository) FindLatestRepresentations(tx *gorm.DB, key bizmodel
 	return rep, nil
 }
 
+// FindResourceSummaries returns the latest stored view of each live resource among localResourceIds.
+// When several reporter instances report the same resource, the most recently updated one wins.
+// Resources inventory has no live record of are omitted, as are resources of other organizations when
+// orgId is set.
+func (r *resourceRepository) FindResourceSummaries(tx *gorm.DB, orgId bizmodel.OrgId, reporterType bizmodel.ReporterType, resourceType bizmodel.ResourceType, localResourceIds []bizmodel.LocalResourceId) ([]bizmodel.ResourceSummary, error) {
+	if len(localResourceIds) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(localResourceIds))
+	for i, id := range localResourceIds {
+		ids[i] = id.Serialize()
+	}
+
+	var results []struct {
+		LocalResourceID string
+		APIHref         string
+		ConsoleHref     *string
+		Data            internal.JsonObject
+	}
+
+	db := r.getDBSession(tx)
+
+	query := db.Table("reporter_resources rr").
+		Select("rr.local_resource_id, rr.api_href, rr.console_href, cr.data").
+		Joins(`LEFT JOIN common_representations cr ON cr.resource_id = rr.resource_id
+		AND cr.version = (SELECT MAX(cr2.version) FROM common_representations cr2 WHERE cr2.resource_id = rr.resource_id)`).
+		Where("rr.reporter_type = ?", reporterType.Serialize()).
+		Where("rr.resource_type = ?", resourceType.Serialize()).
+		Where("rr.local_resource_id IN ?", ids).
+		Where("rr.tombstone = ?", false)
+	if orgId != "" {
+		query = query.Where("rr.org_id = ?", orgId.Serialize())
+	}
+	err := query.Order("rr.local_resource_id, rr.updated_at DESC").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resource summaries: %w", err)
+	}
+
+	summaries := make([]bizmodel.ResourceSummary, 0, len(results))
+	for i, row := range results {
+		if i > 0 && results[i-1].LocalResourceID == row.LocalResourceID {
+			continue
+		}
+		summaries = append(summaries, bizmodel.NewResourceSummary(
+			bizmodel.DeserializeLocalResourceId(row.LocalResourceID),
+			bizmodel.DeserializeApiHref(row.APIHref),
+			bizmodel.DeserializeConsoleHref(row.ConsoleHref),
+			bizmodel.DeserializeRepresentation(row.Data),
+		))
+	}
+	return summaries, nil
+}
+
+// FindResourceOrgIds returns the organizations of the live resources with the given local resource
+// IDs, keyed by local resource ID. IDs without a live resource are left out. Resources reported
+// while tenancy was disabled have an empty organization.
+func (r *resourceRepository) FindResourceOrgIds(tx *gorm.DB, reporterType bizmodel.ReporterType, resourceType bizmodel.ResourceType, localResourceIds []bizmodel.LocalResourceId) (map[bizmodel.LocalResourceId][]bizmodel.OrgId, error) {
+	if len(localResourceIds) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(localResourceIds))
+	for i, id := range localResourceIds {
+		ids[i] = id.Serialize()
+	}
+
+	var results []struct {
+		LocalResourceID string
+		OrgID           string
+	}
+	err := r.getDBSession(tx).Table("reporter_resources").
+		Distinct("local_resource_id", "org_id").
+		Where("reporter_type = ?", reporterType.Serialize()).
+		Where("resource_type = ?", resourceType.Serialize()).
+		Where("local_resource_id IN ?", ids).
+		Where("tombstone = ?", false).
+		Order("local_resource_id, org_id").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find resource organizations: %w", err)
+	}
+
+	orgIds := make(map[bizmodel.LocalResourceId][]bizmodel.OrgId, len(results))
+	for _, row := range results {
+		id := bizmodel.DeserializeLocalResourceId(row.LocalResourceID)
+		orgIds[id] = append(orgIds[id], bizmodel.DeserializeOrgId(row.OrgID))
+	}
+	return orgIds, nil
+}
+
 // HasTransactionIdBeenProcessed checks if a transaction ID exists in either the
 // reporter_representations or common_representations tables.
 // Returns true if the transaction has already been processed, false otherwise.
@@ -784,6 +784,171 @@ func TestFindResourceByKeys_TombstoneFilter(t *testing.T) {
 	}
 }
 
+func TestFindResourceSummaries(t *testing.T) {
+	implementations := []struct {
+		name string
+		repo func() (bizmodel.ResourceRepository, *gorm.DB)
+	}{
+		{
+			name: "Real Repository with GormTransactionManager",
+			repo: func() (bizmodel.ResourceRepository, *gorm.DB) {
+				db := setupInMemoryDB(t)
+				tm := NewGormTransactionManager(metricscollector.NewFakeMetricsCollector(), 3)
+				return NewResourceRepository(db, tm, noopOutboxPublisher()), db
+			},
+		},
+		{
+			name: "Fake Repository",
+			repo: func() (bizmodel.ResourceRepository, *gorm.DB) {
+				return NewFakeResourceRepository(), nil
+			},
+		},
+	}
+
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			repo, db := impl.repo()
+
+			live := createTestResourceWithLocalId(t, "live-resource")
+			require.NoError(t, repo.Save(db, live, bizmodel.OperationTypeCreated, bizmodel.NewTransactionId("test-tx-summary-live")))
+
+			deleted := createTestResourceWithLocalId(t, "deleted-resource")
+			require.NoError(t, repo.Save(db, deleted, bizmodel.OperationTypeCreated, bizmodel.NewTransactionId("test-tx-summary-deleted")))
+			deletedKey := createContractReporterResourceKey(t, "deleted-resource", "k8s_cluster", "ocm", "ocm-instance-1")
+			found, err := repo.FindResourceByKeys(db, deletedKey)
+			require.NoError(t, err)
+			require.NoError(t, found.Delete(deletedKey))
+			require.NoError(t, repo.Save(db, *found, bizmodel.OperationTypeDeleted, bizmodel.NewTransactionId("test-tx-summary-delete")))
+
+			ids := []bizmodel.LocalResourceId{
+				bizmodel.DeserializeLocalResourceId("live-resource"),
+				bizmodel.DeserializeLocalResourceId("deleted-resource"),
+				bizmodel.DeserializeLocalResourceId("missing-resource"),
+			}
+			summaries, err := repo.FindResourceSummaries(db, "", bizmodel.DeserializeReporterType("ocm"), bizmodel.DeserializeResourceType("k8s_cluster"), ids)
+			require.NoError(t, err)
+			require.Len(t, summaries, 1, "only live resources are returned")
+
+			summary := summaries[0]
+			assert.Equal(t, "live-resource", summary.LocalResourceId().String())
+			assert.Equal(t, "https://api.example.com/resource/123", summary.ApiHref().String())
+			require.NotNil(t, summary.ConsoleHref())
+			assert.Equal(t, "https://console.example.com/resource/123", summary.ConsoleHref().String())
+			workspaceID, ok := summary.Common().Lookup("workspace_id")
+			require.True(t, ok)
+			assert.Equal(t, "test-workspace", workspaceID)
+
+			summaries, err = repo.FindResourceSummaries(db, "", bizmodel.DeserializeReporterType("ocm"), bizmodel.DeserializeResourceType("host"), ids)
+			require.NoError(t, err)
+			assert.Empty(t, summaries, "resources of another type are not returned")
+		})
+	}
+}
+
+func TestResourceRepository_OrgScoping(t *testing.T) {
+	implementations := []struct {
+		name string
+		repo func() (bizmodel.ResourceRepository, *gorm.DB)
+	}{
+		{
+			name: "Real Repository with GormTransactionManager",
+			repo: func() (bizmodel.ResourceRepository, *gorm.DB) {
+				db := setupInMemoryDB(t)
+				tm := NewGormTransactionManager(metricscollector.NewFakeMetricsCollector(), 3)
+				return NewResourceRepository(db, tm, noopOutboxPublisher()), db
+			},
+		},
+		{
+			name: "Fake Repository",
+			repo: func() (bizmodel.ResourceRepository, *gorm.DB) {
+				return NewFakeResourceRepository(), nil
+			},
+		},
+	}
+
+	for _, impl := range implementations {
+		t.Run(impl.name, func(t *testing.T) {
+			repo, db := impl.repo()
+
+			// The same natural key is reported in two organizations.
+			orgA := createTestResourceWithLocalId(t, "shared-resource")
+			orgA.AssignOrgId("org-a")
+			require.NoError(t, repo.Save(db, orgA, bizmodel.OperationTypeCreated, newUniqueTxID("org-a")))
+			orgB := createTestResourceWithLocalId(t, "shared-resource")
+			orgB.AssignOrgId("org-b")
+			require.NoError(t, repo.Save(db, orgB, bizmodel.OperationTypeCreated, newUniqueTxID("org-b")))
+
+			key := createContractReporterResourceKey(t, "shared-resource", "k8s_cluster", "ocm", "ocm-instance-1")
+
+			found, err := repo.FindResourceByKeys(db, key.WithOrgId("org-a"))
+			require.NoError(t, err)
+			assert.Equal(t, orgA.ReporterResources()[0].Id(), found.ReporterResources()[0].Id())
+			assert.Equal(t, bizmodel.OrgId("org-a"), found.ReporterResources()[0].Key().OrgId())
+
+			found, err = repo.FindResourceByKeys(db, key.WithOrgId("org-b"))
+			require.NoError(t, err)
+			assert.Equal(t, orgB.ReporterResources()[0].Id(), found.ReporterResources()[0].Id())
+
+			_, err = repo.FindResourceByKeys(db, key.WithOrgId("org-c"))
+			assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+			ids := []bizmodel.LocalResourceId{bizmodel.DeserializeLocalResourceId("shared-resource")}
+			summaries, err := repo.FindResourceSummaries(db, "org-c", bizmodel.DeserializeReporterType("ocm"), bizmodel.DeserializeResourceType("k8s_cluster"), ids)
+			require.NoError(t, err)
+			assert.Empty(t, summaries)
+			summaries, err = repo.FindResourceSummaries(db, "org-a", bizmodel.DeserializeReporterType("ocm"), bizmodel.DeserializeResourceType("k8s_cluster"), ids)
+			require.NoError(t, err)
+			assert.Len(t, summaries, 1)
+
+			latest, err := repo.FindLatestRepresentations(db, key.WithOrgId("org-b"))
+			require.NoError(t, err)
+			assert.NotNil(t, latest)
+
+			missing := bizmodel.DeserializeLocalResourceId("missing-resource")
+			orgIds, err := repo.FindResourceOrgIds(db, bizmodel.DeserializeReporterType("ocm"), bizmodel.DeserializeResourceType("k8s_cluster"), append(ids, missing))
+			require.NoError(t, err)
+			assert.Equal(t, []bizmodel.OrgId{"org-a", "org-b"}, orgIds[ids[0]])
+			assert.Empty(t, orgIds[missing])
+		})
+	}
+}
+
+func TestResourceRepository_OrgIdMigrationUpgrade(t *testing.T) {
+	db := testutil.NewSQLiteTestDB(t, &gorm.Config{TranslateError: true})
+	require.NoError(t, MigrateTo(db, nil, "20261018140000"))
+	tm := NewGormTransactionManager(metricscollector.NewFakeMetricsCollector(), 3)
+	repo := NewResourceRepository(db, tm, noopOutboxPublisher())
+
+	// A resource reported before reporter resources had an organization.
+	legacy := createTestResourceWithLocalId(t, "legacy-resource")
+	require.NoError(t, repo.Save(db.Omit("org_id").Session(&gorm.Session{}), legacy, bizmodel.OperationTypeCreated, newUniqueTxID("legacy")))
+	require.NoError(t, Migrate(db, nil))
+
+	key := createContractReporterResourceKey(t, "legacy-resource", "k8s_cluster", "ocm", "ocm-instance-1")
+	found, err := repo.FindResourceByKeys(db, key)
+	require.NoError(t, err)
+	assert.Equal(t, bizmodel.OrgId(""), found.ReporterResources()[0].OrgId(), "existing rows have no organization")
+	_, err = repo.FindResourceByKeys(db, key.WithOrgId("org-a"))
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// An organization claims it instead of creating a duplicate.
+	require.True(t, found.ClaimOrgId(key.WithOrgId("org-a")))
+	apiHref, _ := bizmodel.NewApiHref("https://api.example.com/claimed")
+	consoleHref, _ := bizmodel.NewConsoleHref("https://console.example.com/claimed")
+	reporterData, _ := bizmodel.NewRepresentation(map[string]interface{}{"claimed": true})
+	require.NoError(t, found.Update(key.WithOrgId("org-a"), apiHref, &consoleHref, nil, &reporterData, nil, newUniqueTxID("claim")))
+	require.NoError(t, repo.Save(db, *found, bizmodel.OperationTypeUpdated, newUniqueTxID("claim")))
+
+	claimed, err := repo.FindResourceByKeys(db, key.WithOrgId("org-a"))
+	require.NoError(t, err)
+	assert.Equal(t, legacy.ReporterResources()[0].Id(), claimed.ReporterResources()[0].Id())
+	assert.False(t, claimed.ClaimOrgId(key.WithOrgId("org-b")), "claimed resources belong to their organization")
+
+	var count int64
+	require.NoError(t, db.Table("reporter_resources").Where("local_resource_id = ?", "legacy-resource").Count(&count).Error)
+	assert.Equal(t, int64(1), count)
+}
+
 func TestUniqueConstraint_ReporterResourceCompositeKey(t *testing.T) {
 	implementations := []struct {
 		name string
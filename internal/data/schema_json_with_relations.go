@@ -22,6 +22,11 @@ func (s JsonSchemaWithRelations) Validate(data interface{}) (bool, error) {
 	return validateJsonSchema(s.jsonSchema, data)
 }
 
+// Properties returns the top-level properties declared by the JSON schema.
+func (s JsonSchemaWithRelations) Properties() []string {
+	return jsonSchemaProperties(s.jsonSchema)
+}
+
 func (s JsonSchemaWithRelations) CalculateTuples(
 	currentRepresentation, previousRepresentation *model.Representations,
 	key model.ReporterResourceKey,
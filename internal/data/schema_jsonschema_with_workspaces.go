@@ -1,7 +1,9 @@
 package data
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/project-kessel/inventory-api/internal/biz/model"
@@ -26,6 +28,26 @@ func (jschema JsonSchemaWithWorkspaces) Validate(data interface{}) (bool, error)
 	return validateJsonSchema(jschema.jsonSchema, data)
 }
 
+// Properties returns the top-level properties declared by the JSON schema.
+func (jschema JsonSchemaWithWorkspaces) Properties() []string {
+	return jsonSchemaProperties(jschema.jsonSchema)
+}
+
+func jsonSchemaProperties(jsonSchema string) []string {
+	var document struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(jsonSchema), &document); err != nil {
+		return nil
+	}
+	properties := make([]string, 0, len(document.Properties))
+	for property := range document.Properties {
+		properties = append(properties, property)
+	}
+	sort.Strings(properties)
+	return properties
+}
+
 func validateJsonSchema(jsonSchema string, data interface{}) (bool, error) {
 	schemaLoader := gojsonschema.NewStringLoader(jsonSchema)
 	dataLoader := gojsonschema.NewGoLoader(data)
@@ -0,0 +1,80 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaPolicyConfig is the optional `policy` block of a resource or reporter config.yaml.
+// Unset fields inherit from the resource's policy (for a reporter) or the zero policy.
+type SchemaPolicyConfig struct {
+	RequireCommonOnCreate   *bool `yaml:"require_common_representation_on_create"`
+	RequireReporterOnCreate *bool `yaml:"require_reporter_representation_on_create"`
+	RejectUnknownProperties *bool `yaml:"reject_unknown_properties"`
+	MaxRepresentationBytes  *int  `yaml:"max_representation_bytes"`
+}
+
+var schemaPolicyKeys = map[string]bool{
+	"require_common_representation_on_create":   true,
+	"require_reporter_representation_on_create": true,
+	"reject_unknown_properties":                 true,
+	"max_representation_bytes":                  true,
+}
+
+// ParseSchemaPolicyConfig reads the `policy` block of config.yaml content. Unknown policy keys are
+// rejected so that a misspelled setting does not silently leave a policy unenforced.
+func ParseSchemaPolicyConfig(content []byte) (SchemaPolicyConfig, error) {
+	var config struct {
+		Policy yaml.Node `yaml:"policy"`
+	}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return SchemaPolicyConfig{}, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if config.Policy.IsZero() {
+		return SchemaPolicyConfig{}, nil
+	}
+	if config.Policy.Kind != yaml.MappingNode {
+		return SchemaPolicyConfig{}, fmt.Errorf("policy must be a mapping")
+	}
+
+	var unknown []string
+	for i := 0; i+1 < len(config.Policy.Content); i += 2 {
+		if key := config.Policy.Content[i].Value; !schemaPolicyKeys[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return SchemaPolicyConfig{}, fmt.Errorf("unknown policy settings: %s", strings.Join(unknown, ", "))
+	}
+
+	var policy SchemaPolicyConfig
+	if err := config.Policy.Decode(&policy); err != nil {
+		return SchemaPolicyConfig{}, fmt.Errorf("invalid policy: %w", err)
+	}
+	if policy.MaxRepresentationBytes != nil && *policy.MaxRepresentationBytes < 0 {
+		return SchemaPolicyConfig{}, fmt.Errorf("invalid policy: max_representation_bytes must not be negative")
+	}
+	return policy, nil
+}
+
+// Apply overrides base with the settings present in the config.
+func (c SchemaPolicyConfig) Apply(base model.SchemaPolicy) model.SchemaPolicy {
+	if c.RequireCommonOnCreate != nil {
+		base.RequireCommonOnCreate = *c.RequireCommonOnCreate
+	}
+	if c.RequireReporterOnCreate != nil {
+		base.RequireReporterOnCreate = *c.RequireReporterOnCreate
+	}
+	if c.RejectUnknownProperties != nil {
+		base.RejectUnknownProperties = *c.RejectUnknownProperties
+	}
+	if c.MaxRepresentationBytes != nil {
+		base.MaxRepresentationBytes = *c.MaxRepresentationBytes
+	}
+	return base
+}
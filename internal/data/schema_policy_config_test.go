@@ -0,0 +1,157 @@
+package data
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bizmodel "github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+func TestParseSchemaPolicyConfig(t *testing.T) {
+	t.Run("missing policy block", func(t *testing.T) {
+		config, err := ParseSchemaPolicyConfig([]byte("resource_type: host\nresource_reporters:\n  - hbi\n"))
+		require.NoError(t, err)
+		assert.Equal(t, bizmodel.SchemaPolicy{}, config.Apply(bizmodel.SchemaPolicy{}))
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		config, err := ParseSchemaPolicyConfig([]byte(`
+policy:
+  require_common_representation_on_create: true
+  require_reporter_representation_on_create: true
+  reject_unknown_properties: true
+  max_representation_bytes: 1024
+`))
+		require.NoError(t, err)
+		assert.Equal(t, bizmodel.SchemaPolicy{
+			RequireCommonOnCreate:   true,
+			RequireReporterOnCreate: true,
+			RejectUnknownProperties: true,
+			MaxRepresentationBytes:  1024,
+		}, config.Apply(bizmodel.SchemaPolicy{}))
+	})
+
+	t.Run("unset settings are inherited", func(t *testing.T) {
+		config, err := ParseSchemaPolicyConfig([]byte("policy:\n  reject_unknown_properties: false\n"))
+		require.NoError(t, err)
+		base := bizmodel.SchemaPolicy{RequireCommonOnCreate: true, RejectUnknownProperties: true}
+		assert.Equal(t, bizmodel.SchemaPolicy{RequireCommonOnCreate: true}, config.Apply(base))
+	})
+
+	t.Run("unknown settings are rejected", func(t *testing.T) {
+		_, err := ParseSchemaPolicyConfig([]byte("policy:\n  reject_unknown_property: true\n  max_bytes: 1\n"))
+		assert.ErrorContains(t, err, "unknown policy settings: max_bytes, reject_unknown_property")
+	})
+
+	t.Run("policy must be a mapping", func(t *testing.T) {
+		_, err := ParseSchemaPolicyConfig([]byte("policy: strict\n"))
+		assert.ErrorContains(t, err, "policy must be a mapping")
+	})
+
+	t.Run("negative size limit is rejected", func(t *testing.T) {
+		_, err := ParseSchemaPolicyConfig([]byte("policy:\n  max_representation_bytes: -1\n"))
+		assert.ErrorContains(t, err, "must not be negative")
+	})
+
+	t.Run("invalid value type", func(t *testing.T) {
+		_, err := ParseSchemaPolicyConfig([]byte("policy:\n  reject_unknown_properties: sometimes\n"))
+		assert.ErrorContains(t, err, "invalid policy")
+	})
+}
+
+func TestNewFromDir_SchemaPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDir := t.TempDir()
+	hostDir := filepath.Join(tmpDir, "host")
+	for _, reporter := range []string{"hbi", "satellite"} {
+		reporterDir := filepath.Join(hostDir, "reporters", reporter)
+		require.NoError(t, os.MkdirAll(reporterDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(reporterDir, "host.json"), []byte(`{"type": "object"}`), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "common_representation.json"), []byte(`{"type": "object"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "config.yaml"), []byte(`
+resource_type: host
+policy:
+  require_common_representation_on_create: true
+  max_representation_bytes: 2048
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "reporters", "hbi", "config.yaml"), []byte(`
+resource_type: host
+reporter_name: hbi
+policy:
+  reject_unknown_properties: true
+  max_representation_bytes: 4096
+`), 0644))
+
+	repo, err := NewInMemorySchemaRepositoryFromDir(ctx, tmpDir, DefaultSchemaFactory)
+	require.NoError(t, err)
+
+	rtHost, err := bizmodel.NewResourceType("host")
+	require.NoError(t, err)
+
+	hbi, err := repo.GetReporterSchema(ctx, rtHost, bizmodel.ReporterType("hbi"))
+	require.NoError(t, err)
+	assert.Equal(t, bizmodel.SchemaPolicy{
+		RequireCommonOnCreate:   true,
+		RejectUnknownProperties: true,
+		MaxRepresentationBytes:  4096,
+	}, hbi.Policy())
+
+	satellite, err := repo.GetReporterSchema(ctx, rtHost, bizmodel.ReporterType("satellite"))
+	require.NoError(t, err)
+	assert.Equal(t, bizmodel.SchemaPolicy{
+		RequireCommonOnCreate:  true,
+		MaxRepresentationBytes: 2048,
+	}, satellite.Policy())
+}
+
+func TestNewFromDir_InvalidSchemaPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	tmpDir := t.TempDir()
+	hostDir := filepath.Join(tmpDir, "host")
+	require.NoError(t, os.MkdirAll(filepath.Join(hostDir, "reporters", "hbi"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "common_representation.json"), []byte(`{"type": "object"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "reporters", "hbi", "host.json"), []byte(`{"type": "object"}`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(hostDir, "config.yaml"), []byte("policy:\n  strict: true\n"), 0644))
+
+	repo, err := NewInMemorySchemaRepositoryFromDir(ctx, tmpDir, DefaultSchemaFactory)
+	assert.Nil(t, repo)
+	assert.ErrorContains(t, err, "unknown policy settings: strict")
+}
+
+func TestNewFromJsonBytes_SchemaPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	content, err := json.Marshal(map[string]string{
+		"common:host":      `{"type": "object"}`,
+		"host:hbi":         `{"type": "object"}`,
+		"config:host":      base64.StdEncoding.EncodeToString([]byte("policy:\n  require_common_representation_on_create: true\n")),
+		"config:host:hbi":  base64.StdEncoding.EncodeToString([]byte("policy:\n  require_reporter_representation_on_create: true\n")),
+		"host:satellite":   `{"type": "object"}`,
+		"config:unrelated": base64.StdEncoding.EncodeToString([]byte("policy:\n  reject_unknown_properties: true\n")),
+	})
+	require.NoError(t, err)
+
+	repo, err := NewFromJsonBytes(ctx, content, DefaultSchemaFactory)
+	require.NoError(t, err)
+
+	rtHost, err := bizmodel.NewResourceType("host")
+	require.NoError(t, err)
+
+	hbi, err := repo.GetReporterSchema(ctx, rtHost, bizmodel.ReporterType("hbi"))
+	require.NoError(t, err)
+	assert.Equal(t, bizmodel.SchemaPolicy{RequireCommonOnCreate: true, RequireReporterOnCreate: true}, hbi.Policy())
+
+	satellite, err := repo.GetReporterSchema(ctx, rtHost, bizmodel.ReporterType("satellite"))
+	require.NoError(t, err)
+	assert.Equal(t, bizmodel.SchemaPolicy{RequireCommonOnCreate: true}, satellite.Policy())
+}
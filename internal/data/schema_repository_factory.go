@@ -0,0 +1,32 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+	"github.com/project-kessel/inventory-api/internal/config/schema"
+	inmemoryConfig "github.com/project-kessel/inventory-api/internal/config/schema/inmemory"
+)
+
+func NewSchemaRepository(ctx context.Context, c schema.CompletedConfig, logger *log.Helper) (model.SchemaRepository, error) {
+	switch c.Repository {
+	case schema.InMemoryRepository:
+		switch c.InMemory.Type {
+		case inmemoryConfig.EmptyRepository:
+			logger.Infof("Using empty in-memory schema repository")
+			return NewInMemorySchemaRepository(), nil
+		case inmemoryConfig.JSONRepository:
+			logger.Infof("Using json in-memory schema repository from path %q", c.InMemory.Path)
+			return NewInMemorySchemaRepositoryFromJsonFile(ctx, c.InMemory.Path, FeaturesAwareSchemaFactory)
+		case inmemoryConfig.DirRepository:
+			logger.Infof("Using dir in-memory schema repository from path %q", c.InMemory.Path)
+			return NewInMemorySchemaRepositoryFromDir(ctx, c.InMemory.Path, FeaturesAwareSchemaFactory)
+		default:
+			return nil, fmt.Errorf("invalid repository type: %s/%s", c.Repository, c.InMemory.Type)
+		}
+	}
+
+	return nil, fmt.Errorf("invalid repository type: %s", c.Repository)
+}
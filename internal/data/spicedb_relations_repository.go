@@ -170,6 +170,11 @@ func (s *SpiceDBRelationsRepository) Close() {
 	}
 }
 
+// WritesTuples reports true: tuples are written to SpiceDB.
+func (s *SpiceDBRelationsRepository) WritesTuples() bool {
+	return true
+}
+
 // Health checks the health of the SpiceDB backend.
 func (s *SpiceDBRelationsRepository) Health(ctx context.Context) (model.HealthResult, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
@@ -204,15 +209,52 @@ func (s *SpiceDBRelationsRepository) Health(ctx context.Context) (model.HealthRe
 
 // Check performs a single permission check.
 func (s *SpiceDBRelationsRepository) Check(ctx context.Context, rel model.Relationship, consistency model.Consistency) (model.CheckResult, error) {
-	if err := s.initialize(ctx); err != nil {
-		s.incrFailureCounter("Check")
+	checkResponse, err := s.checkPermission(ctx, "Check", rel, consistency, false)
+	if err != nil {
 		return model.CheckResult{}, err
 	}
 
+	allowed := checkResponse.Permissionship == v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
+	token := model.DeserializeConsistencyToken(checkResponse.GetCheckedAt().GetToken())
+	return model.NewCheckResult(allowed, token), nil
+}
+
+// CheckExplain performs a single permission check with SpiceDB debug tracing enabled and
+// converts the returned trace into a model.CheckTrace.
+func (s *SpiceDBRelationsRepository) CheckExplain(ctx context.Context, rel model.Relationship, consistency model.Consistency) (model.CheckExplainResult, error) {
+	checkResponse, err := s.checkPermission(ctx, "CheckExplain", rel, consistency, true)
+	if err != nil {
+		return model.CheckExplainResult{}, err
+	}
+
+	allowed := checkResponse.Permissionship == v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
+	token := model.DeserializeConsistencyToken(checkResponse.GetCheckedAt().GetToken())
+	result := model.NewCheckResult(allowed, token)
+
+	debug := checkResponse.GetDebugTrace()
+	if debug.GetCheck() == nil {
+		// Older SpiceDB versions may not return a trace; fall back to the bare result.
+		return model.NewCheckExplainResult(result, model.NewCheckTrace(rel, model.CheckTraceKindUnspecified, allowed, "", false, nil)), nil
+	}
+
+	trace, err := spiceDBCheckTraceToModel(debug.GetCheck(), spiceDBPermissionExpressions(debug.GetSchemaUsed()))
+	if err != nil {
+		return model.CheckExplainResult{}, fmt.Errorf("error converting SpiceDB check trace: %w", err)
+	}
+	return model.NewCheckExplainResult(result, trace), nil
+}
+
+// checkPermission issues a CheckPermission request for rel and records the outcome under method.
+func (s *SpiceDBRelationsRepository) checkPermission(ctx context.Context, method string, rel model.Relationship, consistency model.Consistency, withTracing bool) (*v1.CheckPermissionResponse, error) {
+	if err := s.initialize(ctx); err != nil {
+		s.incrFailureCounter(method)
+		return nil, err
+	}
+
 	obj := rel.Object()
 	subj := rel.Subject()
 
-	log.Infof("Check: on resourceType=%s, localResourceId=%s",
+	log.Infof("%s: on resourceType=%s, localResourceId=%s", method,
 		obj.ResourceType().Serialize(), obj.ResourceId().Serialize())
 
 	req := &v1.CheckPermissionRequest{
@@ -229,19 +271,17 @@ func (s *SpiceDBRelationsRepository) Check(ctx context.Context, rel model.Relati
 			},
 			OptionalRelation: optionalRelationToString(subj.Relation()),
 		},
+		WithTracing: withTracing,
 	}
 
 	checkResponse, err := s.client.CheckPermission(ctx, req)
 	if err != nil {
-		s.incrFailureCounter("Check")
-		return model.CheckResult{}, fmt.Errorf("error invoking CheckPermission in SpiceDB: %w", err)
+		s.incrFailureCounter(method)
+		return nil, fmt.Errorf("error invoking CheckPermission in SpiceDB: %w", err)
 	}
 
-	allowed := checkResponse.Permissionship == v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION
-	token := model.DeserializeConsistencyToken(checkResponse.GetCheckedAt().GetToken())
-
-	s.incrSuccessCounter("Check")
-	return model.NewCheckResult(allowed, token), nil
+	s.incrSuccessCounter(method)
+	return checkResponse, nil
 }
 
 // CheckForUpdate performs a strongly-consistent permission check.
@@ -718,6 +758,70 @@ func spiceDBTypeToResourceReference(spicedbType string, id string) (model.Resour
 	return model.NewResourceReference(resourceType, resourceId, reporter), nil
 }
 
+// spiceDBCheckTraceToModel converts a SpiceDB debug trace into a model.CheckTrace, annotating
+// permission steps with their expression from expressions (keyed by "type#permission").
+func spiceDBCheckTraceToModel(trace *v1.CheckDebugTrace, expressions map[string]string) (model.CheckTrace, error) {
+	resource, err := spiceDBTypeToResourceReference(trace.GetResource().GetObjectType(), trace.GetResource().GetObjectId())
+	if err != nil {
+		return model.CheckTrace{}, err
+	}
+	subjectObject := trace.GetSubject().GetObject()
+	subjectResource, err := spiceDBTypeToResourceReference(subjectObject.GetObjectType(), subjectObject.GetObjectId())
+	if err != nil {
+		return model.CheckTrace{}, err
+	}
+	var subjectRelation *model.Relation
+	if r := trace.GetSubject().GetOptionalRelation(); r != "" {
+		rel := model.DeserializeRelation(r)
+		subjectRelation = &rel
+	}
+	rel := model.NewRelationship(resource, model.DeserializeRelation(trace.GetPermission()),
+		model.NewSubjectReference(subjectResource, subjectRelation))
+
+	kind := model.CheckTraceKindUnspecified
+	var expression string
+	switch trace.GetPermissionType() {
+	case v1.CheckDebugTrace_PERMISSION_TYPE_RELATION:
+		kind = model.CheckTraceKindRelation
+	case v1.CheckDebugTrace_PERMISSION_TYPE_PERMISSION:
+		kind = model.CheckTraceKindPermission
+		expression = expressions[trace.GetResource().GetObjectType()+"#"+trace.GetPermission()]
+	}
+
+	var children []model.CheckTrace
+	for _, sub := range trace.GetSubProblems().GetTraces() {
+		child, err := spiceDBCheckTraceToModel(sub, expressions)
+		if err != nil {
+			return model.CheckTrace{}, err
+		}
+		children = append(children, child)
+	}
+
+	allowed := trace.GetResult() == v1.CheckDebugTrace_PERMISSIONSHIP_HAS_PERMISSION
+	return model.NewCheckTrace(rel, kind, allowed, expression, trace.GetWasCachedResult(), children), nil
+}
+
+// spiceDBPermissionExpressions extracts "type#permission" -> expression from a SpiceDB schema.
+// It is best-effort: only single-line permission definitions are recognised.
+func spiceDBPermissionExpressions(schema string) map[string]string {
+	expressions := map[string]string{}
+	var definition string
+	for _, line := range strings.Split(schema, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "definition" {
+			definition = strings.TrimSuffix(fields[1], "{")
+			continue
+		}
+		if len(fields) >= 4 && fields[0] == "permission" && fields[2] == "=" && definition != "" {
+			expressions[definition+"#"+fields[1]] = strings.Join(fields[3:], " ")
+		}
+	}
+	return expressions
+}
+
 func relationshipToCheckBulkItem(rel model.Relationship) *v1.CheckBulkPermissionsRequestItem {
 	obj := rel.Object()
 	subj := rel.Subject()
@@ -16,6 +16,7 @@ import (
 	"github.com/go-kratos/kratos/v2/middleware/tracing"
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -832,6 +833,126 @@ func TestSpiceDbRepository_CheckPermission(t *testing.T) {
 	assert.NotEmpty(t, resp2.ConsistencyToken())
 }
 
+func TestSpiceDbRepository_CheckExplain(t *testing.T) {
+	requireSpiceDBIntegration(t)
+
+	t.Parallel()
+
+	ctx := context.Background()
+	spiceDbRepo, err := container.CreateSpiceDbRepository()
+	if !assert.NoError(t, err) {
+		defer spiceDbRepo.Close()
+		return
+	}
+
+	tuples := []model.RelationsTuple{
+		createRelationship("rbac", "workspace", uniqueID(t, "test"), "user_grant", "rbac", "role_binding", uniqueID(t, "rb"), ""),
+		createRelationship("rbac", "role_binding", uniqueID(t, "rb"), "granted", "rbac", "role", uniqueID(t, "rl"), ""),
+		createRelationship("rbac", "role_binding", uniqueID(t, "rb"), "subject", "rbac", "principal", "bob", ""),
+		createRelationship("rbac", "role", uniqueID(t, "rl"), "view_widget", "rbac", "principal", "*", ""),
+	}
+
+	_, err = spiceDbRepo.CreateTuples(ctx, tuples, true, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	container.WaitForQuantizationInterval()
+
+	subject := createSubjectReference("rbac", "principal", "bob")
+	resource := createResourceReference("rbac", "workspace", uniqueID(t, "test"))
+	rel := model.NewRelationship(resource, model.DeserializeRelation("view_widget"), subject)
+
+	resp, err := spiceDbRepo.CheckExplain(ctx, rel, model.NewConsistencyUnspecified())
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.True(t, resp.Allowed())
+	assert.NotEmpty(t, resp.ConsistencyToken())
+
+	trace := resp.Trace()
+	assert.True(t, trace.Allowed())
+	assert.Equal(t, model.CheckTraceKindPermission, trace.Kind())
+	assert.Equal(t, "view_widget", trace.Relationship().Relation().String())
+	assert.NotEmpty(t, trace.Expression())
+	assert.NotEmpty(t, trace.MatchedTuples())
+}
+
+func TestSpiceDBCheckTraceToModel(t *testing.T) {
+	t.Parallel()
+
+	schema := `definition rbac/principal {}
+
+definition hbi/host {
+	permission view = t_workspace->inventory_host_view // host viewers
+	relation t_workspace: rbac/workspace
+}
+
+definition rbac/workspace {
+	permission inventory_host_view = t_parent->inventory_host_view + user_grant->inventory_host_view
+}`
+	expressions := spiceDBPermissionExpressions(schema)
+	assert.Equal(t, map[string]string{
+		"hbi/host#view":                      "t_workspace->inventory_host_view",
+		"rbac/workspace#inventory_host_view": "t_parent->inventory_host_view + user_grant->inventory_host_view",
+	}, expressions)
+
+	subject := &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "rbac/principal", ObjectId: "bob"}}
+	trace := &v1.CheckDebugTrace{
+		Resource:       &v1.ObjectReference{ObjectType: "hbi/host", ObjectId: "host-1"},
+		Permission:     "view",
+		PermissionType: v1.CheckDebugTrace_PERMISSION_TYPE_PERMISSION,
+		Subject:        subject,
+		Result:         v1.CheckDebugTrace_PERMISSIONSHIP_HAS_PERMISSION,
+		Resolution: &v1.CheckDebugTrace_SubProblems_{SubProblems: &v1.CheckDebugTrace_SubProblems{
+			Traces: []*v1.CheckDebugTrace{
+				{
+					Resource:       &v1.ObjectReference{ObjectType: "hbi/host", ObjectId: "host-1"},
+					Permission:     "t_workspace",
+					PermissionType: v1.CheckDebugTrace_PERMISSION_TYPE_RELATION,
+					Subject:        subject,
+					Result:         v1.CheckDebugTrace_PERMISSIONSHIP_NO_PERMISSION,
+					Resolution:     &v1.CheckDebugTrace_WasCachedResult{WasCachedResult: true},
+				},
+				{
+					Resource:       &v1.ObjectReference{ObjectType: "rbac/workspace", ObjectId: "ws-1"},
+					Permission:     "inventory_host_view",
+					PermissionType: v1.CheckDebugTrace_PERMISSION_TYPE_PERMISSION,
+					Subject:        subject,
+					Result:         v1.CheckDebugTrace_PERMISSIONSHIP_HAS_PERMISSION,
+				},
+			},
+		}},
+	}
+
+	result, err := spiceDBCheckTraceToModel(trace, expressions)
+	require.NoError(t, err)
+
+	assert.True(t, result.Allowed())
+	assert.Equal(t, model.CheckTraceKindPermission, result.Kind())
+	assert.Equal(t, "t_workspace->inventory_host_view", result.Expression())
+	assert.Equal(t, "hbi", result.Relationship().Object().Reporter().ReporterType().String())
+	assert.Equal(t, "bob", result.Relationship().Subject().Resource().ResourceId().String())
+	require.Len(t, result.Children(), 2)
+
+	relationStep := result.Children()[0]
+	assert.Equal(t, model.CheckTraceKindRelation, relationStep.Kind())
+	assert.False(t, relationStep.Allowed())
+	assert.True(t, relationStep.Cached())
+	assert.Empty(t, relationStep.Expression())
+
+	workspaceStep := result.Children()[1]
+	assert.Equal(t, "ws-1", workspaceStep.Relationship().Object().ResourceId().String())
+	assert.Equal(t, "t_parent->inventory_host_view + user_grant->inventory_host_view", workspaceStep.Expression())
+
+	_, err = spiceDBCheckTraceToModel(&v1.CheckDebugTrace{
+		Resource: &v1.ObjectReference{ObjectType: "a/b/c", ObjectId: "x"},
+		Subject:  subject,
+	}, expressions)
+	assert.Error(t, err)
+}
+
 func TestSpiceDbRepository_NewEnemyProblem_Success(t *testing.T) {
 	requireSpiceDBIntegration(t)
 
@@ -0,0 +1,430 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/project-kessel/inventory-api/internal/biz/model"
+)
+
+// zedTupleset identifies the subjects of one relation on one object. Types use the SpiceDB
+// "reporter/type" form and relations are stored with the same "t_" prefix the SpiceDB repository
+// writes, so that tuples line up with the relation names in the schema.
+type zedTupleset struct {
+	ResourceType string
+	ResourceID   string
+	Relation     string
+}
+
+type zedSubject struct {
+	Type     string
+	ID       string
+	Relation string
+}
+
+// zedTupleReader is the tuple storage a zedEvaluator reads from.
+type zedTupleReader interface {
+	// readSubjects returns the subjects of a tupleset in a stable order.
+	readSubjects(tupleset zedTupleset) ([]zedSubject, error)
+	// readObjectIDs returns the sorted IDs of every object of the given type that appears in a
+	// tuple, either as a resource or as a non-wildcard subject with the given subject relation.
+	readObjectIDs(objectType, subjectRelation string) ([]string, error)
+}
+
+func sortedZedSubjects(subjects []zedSubject) []zedSubject {
+	slices.SortFunc(subjects, func(a, b zedSubject) int {
+		return strings.Compare(a.Type+":"+a.ID+"#"+a.Relation, b.Type+":"+b.ID+"#"+b.Relation)
+	})
+	return subjects
+}
+
+func zedTupleFromModel(tuple model.RelationsTuple) (zedTupleset, zedSubject) {
+	return zedTupleset{
+		ResourceType: resourceReferenceToSpiceDBType(tuple.Object()),
+		ResourceID:   tuple.Object().ResourceId().Serialize(),
+		Relation:     addRelationPrefix(tuple.Relation().Serialize(), relationPrefix),
+	}, zedSubjectFromModel(tuple.Subject())
+}
+
+func zedSubjectFromModel(subject model.SubjectReference) zedSubject {
+	return zedSubject{
+		Type:     resourceReferenceToSpiceDBType(subject.Resource()),
+		ID:       subject.Resource().ResourceId().Serialize(),
+		Relation: optionalRelationToString(subject.Relation()),
+	}
+}
+
+// zedReadTuplesItem converts a stored tuple back into a ReadTuples result.
+func zedReadTuplesItem(tupleset zedTupleset, subject zedSubject, continuation model.ContinuationToken, token model.ConsistencyToken,
+) (model.ReadTuplesItem, error) {
+	object, err := spiceDBTypeToResourceReference(tupleset.ResourceType, tupleset.ResourceID)
+	if err != nil {
+		return model.ReadTuplesItem{}, err
+	}
+	subjectResource, err := spiceDBTypeToResourceReference(subject.Type, subject.ID)
+	if err != nil {
+		return model.ReadTuplesItem{}, err
+	}
+	var subjectRelation *model.Relation
+	if subject.Relation != "" {
+		r := model.DeserializeRelation(subject.Relation)
+		subjectRelation = &r
+	}
+	return model.NewReadTuplesItem(
+		object,
+		model.DeserializeRelation(stripRelationPrefix(tupleset.Relation, relationPrefix)),
+		model.NewSubjectReference(subjectResource, subjectRelation),
+		continuation,
+		token,
+	), nil
+}
+
+// zedEvaluator resolves checks against a parsed schema and one view of the tuples.
+// An evaluator is not safe for concurrent use; create one per request.
+type zedEvaluator struct {
+	schema  *zedSchema
+	tuples  zedTupleReader
+	explain bool
+	// visiting holds the checks on the current evaluation path. Revisiting one means the
+	// schema and tuples form a cycle, which fails the check with errZedCycle.
+	visiting map[zedCheckKey]bool
+}
+
+// errZedCycle fails checks that revisit a check on their own evaluation path, as SpiceDB fails
+// checks that exceed its maximum dispatch depth. Like SpiceDB, a union still allows when another
+// of its branches does.
+var errZedCycle = errors.New("max depth exceeded: the schema and tuples form a cycle")
+
+type zedCheckKey struct {
+	resourceType string
+	resourceID   string
+	name         string
+}
+
+func newZedEvaluator(schema *zedSchema, tuples zedTupleReader, explain bool) *zedEvaluator {
+	return &zedEvaluator{
+		schema:   schema,
+		tuples:   tuples,
+		explain:  explain,
+		visiting: map[zedCheckKey]bool{},
+	}
+}
+
+// requireRelationOrPermission fails if the type or the relation/permission is not in the schema.
+func (s *zedSchema) requireRelationOrPermission(resourceType, name string) error {
+	def, ok := s.definitions[resourceType]
+	if !ok {
+		return fmt.Errorf("object definition %q not found", resourceType)
+	}
+	if !def.hasRelationOrPermission(name) {
+		return fmt.Errorf("relation or permission %q not found on %q", name, resourceType)
+	}
+	return nil
+}
+
+// validateRelationship fails if the relationship's types or relation are not in the schema.
+func (z *zedEvaluator) validateRelationship(rel model.Relationship) error {
+	if err := z.schema.requireRelationOrPermission(resourceReferenceToSpiceDBType(rel.Object()), rel.Relation().Serialize()); err != nil {
+		return err
+	}
+	subjectType := resourceReferenceToSpiceDBType(rel.Subject().Resource())
+	if _, ok := z.schema.definitions[subjectType]; !ok {
+		return fmt.Errorf("subject definition %q not found", subjectType)
+	}
+	return nil
+}
+
+// checkRelationship checks a model relationship. The trace is nil unless the evaluator explains.
+func (z *zedEvaluator) checkRelationship(rel model.Relationship) (bool, *model.CheckTrace, error) {
+	if err := z.validateRelationship(rel); err != nil {
+		return false, nil, err
+	}
+	return z.check(resourceReferenceToSpiceDBType(rel.Object()), rel.Object().ResourceId().Serialize(),
+		rel.Relation().Serialize(), zedSubjectFromModel(rel.Subject()))
+}
+
+// checkBulk reports relationships that do not match the schema as per-item errors, as SpiceDB
+// does for CheckBulkPermissions. Failures reading tuples fail the whole call.
+func (z *zedEvaluator) checkBulk(rels []model.Relationship, token model.ConsistencyToken) (model.CheckBulkResult, error) {
+	pairs := make([]model.CheckBulkResultPair, len(rels))
+	for i, rel := range rels {
+		if err := z.validateRelationship(rel); err != nil {
+			pairs[i] = model.NewCheckBulkResultPair(rel, model.NewCheckBulkResultItem(false, err, int32(codes.FailedPrecondition)))
+			continue
+		}
+		allowed, _, err := z.checkRelationship(rel)
+		if err != nil {
+			return model.CheckBulkResult{}, err
+		}
+		pairs[i] = model.NewCheckBulkResultPair(rel, model.NewCheckBulkResultItem(allowed, nil, 0))
+	}
+	return model.NewCheckBulkResult(pairs, token), nil
+}
+
+// lookupObjects checks every object of the requested type that appears in a tuple.
+func (z *zedEvaluator) lookupObjects(objectType model.RepresentationType, relation model.Relation,
+	subject model.SubjectReference, pagination *model.Pagination) ([]model.LookupObjectsItem, error) {
+	resourceType := representationTypeToSpiceDBType(objectType)
+	if err := z.schema.requireRelationOrPermission(resourceType, relation.Serialize()); err != nil {
+		return nil, err
+	}
+	candidates, err := z.tuples.readObjectIDs(resourceType, "")
+	if err != nil {
+		return nil, err
+	}
+
+	sub := zedSubjectFromModel(subject)
+	var ids []string
+	for _, id := range candidates {
+		allowed, _, err := z.check(resourceType, id, relation.Serialize(), sub)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			ids = append(ids, id)
+		}
+	}
+
+	var results []model.LookupObjectsItem
+	for i, id := range paginate(ids, pagination) {
+		object, err := spiceDBTypeToResourceReference(resourceType, id)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.NewLookupObjectsItem(object, continuationAfter(pagination, i)))
+	}
+	return results, nil
+}
+
+// lookupSubjects returns concrete subjects only; wildcard subjects are excluded, matching the
+// SpiceDB repository.
+func (z *zedEvaluator) lookupSubjects(object model.ResourceReference, relation model.Relation,
+	subjectType model.RepresentationType, subjectRelation *model.Relation, pagination *model.Pagination,
+) ([]model.LookupSubjectsItem, error) {
+	resourceType := resourceReferenceToSpiceDBType(object)
+	if err := z.schema.requireRelationOrPermission(resourceType, relation.Serialize()); err != nil {
+		return nil, err
+	}
+	wantType := representationTypeToSpiceDBType(subjectType)
+	wantRelation := optionalRelationToString(subjectRelation)
+	candidates, err := z.tuples.readObjectIDs(wantType, wantRelation)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, id := range candidates {
+		sub := zedSubject{Type: wantType, ID: id, Relation: wantRelation}
+		allowed, _, err := z.check(resourceType, object.ResourceId().Serialize(), relation.Serialize(), sub)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			ids = append(ids, id)
+		}
+	}
+
+	var results []model.LookupSubjectsItem
+	for i, id := range paginate(ids, pagination) {
+		resource, err := spiceDBTypeToResourceReference(wantType, id)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, model.NewLookupSubjectsItem(
+			model.NewSubjectReference(resource, subjectRelation), continuationAfter(pagination, i)))
+	}
+	return results, nil
+}
+
+// check resolves a relation or permission on an object for a subject. Unions and intersections
+// short-circuit, so an explained trace only contains the steps that decided the result.
+func (z *zedEvaluator) check(resourceType, resourceID, name string, subject zedSubject) (bool, *model.CheckTrace, error) {
+	def, ok := z.schema.definitions[resourceType]
+	if !ok {
+		return false, nil, fmt.Errorf("object definition %q not found", resourceType)
+	}
+
+	key := zedCheckKey{resourceType: resourceType, resourceID: resourceID, name: name}
+	if z.visiting[key] {
+		return false, nil, fmt.Errorf("%w at %s:%s#%s", errZedCycle, resourceType, resourceID, name)
+	}
+	z.visiting[key] = true
+	defer delete(z.visiting, key)
+
+	var (
+		allowed    bool
+		children   []model.CheckTrace
+		err        error
+		kind       model.CheckTraceKind
+		expression string
+	)
+	switch {
+	case subject.Type == resourceType && subject.ID == resourceID && subject.Relation == name:
+		// A subject set trivially has its own relation.
+		allowed, kind = true, model.CheckTraceKindRelation
+	case def.relations[name] != nil:
+		kind = model.CheckTraceKindRelation
+		allowed, children, err = z.checkRelation(resourceType, resourceID, name, subject)
+	case def.permissions[name] != nil:
+		kind = model.CheckTraceKindPermission
+		expression = def.permissions[name].text
+		allowed, children, err = z.evaluate(def, resourceID, def.permissions[name].expression, subject)
+	default:
+		return false, nil, fmt.Errorf("relation or permission %q not found on %q", name, resourceType)
+	}
+	if err != nil || !z.explain {
+		return allowed, nil, err
+	}
+
+	trace, err := z.trace(resourceType, resourceID, name, subject, kind, allowed, expression, children)
+	if err != nil {
+		return false, nil, err
+	}
+	return allowed, &trace, nil
+}
+
+// checkRelation matches the subject against the relation's tuples: directly, through a wildcard,
+// or by recursing into subject sets.
+func (z *zedEvaluator) checkRelation(resourceType, resourceID, relation string, subject zedSubject,
+) (bool, []model.CheckTrace, error) {
+	tupleset := zedTupleset{ResourceType: resourceType, ResourceID: resourceID, Relation: relation}
+	subjects, err := z.tuples.readSubjects(tupleset)
+	if err != nil {
+		return false, nil, err
+	}
+	var children []model.CheckTrace
+	var cycleErr error
+	for _, candidate := range subjects {
+		if candidate == subject {
+			return true, children, nil
+		}
+		if candidate.ID == "*" && candidate.Relation == "" && candidate.Type == subject.Type && subject.Relation == "" {
+			return true, children, nil
+		}
+		if candidate.Relation == "" {
+			continue
+		}
+		allowed, trace, err := z.check(candidate.Type, candidate.ID, candidate.Relation, subject)
+		if errors.Is(err, errZedCycle) {
+			cycleErr = err
+			continue
+		}
+		if err != nil {
+			return false, nil, err
+		}
+		if trace != nil {
+			children = append(children, *trace)
+		}
+		if allowed {
+			return true, children, nil
+		}
+	}
+	if cycleErr != nil {
+		return false, nil, cycleErr
+	}
+	return false, children, nil
+}
+
+func (z *zedEvaluator) evaluate(def *zedDefinition, resourceID string, expr *zedExpression, subject zedSubject,
+) (bool, []model.CheckTrace, error) {
+	switch expr.kind {
+	case zedExpressionNil:
+		return false, nil, nil
+
+	case zedExpressionReference:
+		allowed, trace, err := z.check(def.name, resourceID, expr.name, subject)
+		if err != nil || trace == nil {
+			return allowed, nil, err
+		}
+		return allowed, []model.CheckTrace{*trace}, nil
+
+	case zedExpressionArrow:
+		tupleset := zedTupleset{ResourceType: def.name, ResourceID: resourceID, Relation: expr.name}
+		subjects, err := z.tuples.readSubjects(tupleset)
+		if err != nil {
+			return false, nil, err
+		}
+		var children []model.CheckTrace
+		var cycleErr error
+		for _, candidate := range subjects {
+			target, ok := z.schema.definitions[candidate.Type]
+			if !ok || candidate.ID == "*" || !target.hasRelationOrPermission(expr.target) {
+				continue
+			}
+			allowed, trace, err := z.check(candidate.Type, candidate.ID, expr.target, subject)
+			if errors.Is(err, errZedCycle) {
+				cycleErr = err
+				continue
+			}
+			if err != nil {
+				return false, nil, err
+			}
+			if trace != nil {
+				children = append(children, *trace)
+			}
+			if allowed {
+				return true, children, nil
+			}
+		}
+		if cycleErr != nil {
+			return false, nil, cycleErr
+		}
+		return false, children, nil
+
+	case zedExpressionUnion:
+		left, children, err := z.evaluate(def, resourceID, expr.left, subject)
+		cycleErr := err
+		if errors.Is(err, errZedCycle) {
+			err = nil
+		}
+		if err != nil || left {
+			return left, children, err
+		}
+		right, more, err := z.evaluate(def, resourceID, expr.right, subject)
+		if err == nil && !right && cycleErr != nil {
+			return false, nil, cycleErr
+		}
+		return right, append(children, more...), err
+
+	case zedExpressionIntersection:
+		left, children, err := z.evaluate(def, resourceID, expr.left, subject)
+		if err != nil || !left {
+			return false, children, err
+		}
+		right, more, err := z.evaluate(def, resourceID, expr.right, subject)
+		return right, append(children, more...), err
+
+	case zedExpressionExclusion:
+		left, children, err := z.evaluate(def, resourceID, expr.left, subject)
+		if err != nil || !left {
+			return false, children, err
+		}
+		right, more, err := z.evaluate(def, resourceID, expr.right, subject)
+		return !right, append(children, more...), err
+	}
+	return false, nil, fmt.Errorf("unsupported expression in %q", def.name)
+}
+
+func (z *zedEvaluator) trace(resourceType, resourceID, name string, subject zedSubject, kind model.CheckTraceKind,
+	allowed bool, expression string, children []model.CheckTrace) (model.CheckTrace, error) {
+	resource, err := spiceDBTypeToResourceReference(resourceType, resourceID)
+	if err != nil {
+		return model.CheckTrace{}, err
+	}
+	subjectResource, err := spiceDBTypeToResourceReference(subject.Type, subject.ID)
+	if err != nil {
+		return model.CheckTrace{}, err
+	}
+	var subjectRelation *model.Relation
+	if subject.Relation != "" {
+		r := model.DeserializeRelation(subject.Relation)
+		subjectRelation = &r
+	}
+	rel := model.NewRelationship(resource, model.DeserializeRelation(name),
+		model.NewSubjectReference(subjectResource, subjectRelation))
+	return model.NewCheckTrace(rel, kind, allowed, expression, false, children), nil
+}
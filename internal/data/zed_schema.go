@@ -0,0 +1,489 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// zedSchema is a parsed SpiceDB schema. Only the subset of the Zed language used by Kessel
+// schemas is supported: definitions, relations with direct, subject set (type#relation) and
+// wildcard (type:*) subject types, and permissions built from relation references, arrows
+// (relation->permission), nil, and the union (+), intersection (&) and exclusion (-) operators.
+// Caveats and functional arrows are rejected.
+type zedSchema struct {
+	definitions map[string]*zedDefinition
+}
+
+type zedDefinition struct {
+	name        string
+	relations   map[string][]zedAllowedType
+	permissions map[string]*zedPermission
+}
+
+// hasRelationOrPermission reports whether name is defined on the definition.
+func (d *zedDefinition) hasRelationOrPermission(name string) bool {
+	if _, ok := d.relations[name]; ok {
+		return true
+	}
+	_, ok := d.permissions[name]
+	return ok
+}
+
+// zedAllowedType is a subject type permitted on a relation.
+type zedAllowedType struct {
+	typ      string
+	relation string
+	wildcard bool
+}
+
+type zedPermission struct {
+	expression *zedExpression
+	// text is the permission expression as written in the schema, with whitespace normalized.
+	text string
+}
+
+type zedExpressionKind int
+
+const (
+	zedExpressionNil zedExpressionKind = iota
+	zedExpressionReference
+	zedExpressionArrow
+	zedExpressionUnion
+	zedExpressionIntersection
+	zedExpressionExclusion
+)
+
+// zedExpression is a node in a permission expression tree. References use name, arrows use
+// name (the tupleset relation) and target (the permission or relation on each subject), and
+// operators use left and right.
+type zedExpression struct {
+	kind   zedExpressionKind
+	name   string
+	target string
+	left   *zedExpression
+	right  *zedExpression
+}
+
+type zedTokenKind int
+
+const (
+	zedTokenEOF zedTokenKind = iota
+	zedTokenNewline
+	zedTokenIdentifier
+	zedTokenPunctuation
+)
+
+type zedToken struct {
+	kind  zedTokenKind
+	value string
+	start int
+	end   int
+	line  int
+}
+
+func tokenizeZed(source string) ([]zedToken, error) {
+	var tokens []zedToken
+	line := 1
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == '\n':
+			tokens = append(tokens, zedToken{kind: zedTokenNewline, value: "\n", start: i, end: i + 1, line: line})
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(source[i:], "//"):
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			line += strings.Count(source[i:i+2+end], "\n")
+			i += end + 4
+		case strings.HasPrefix(source[i:], "->"):
+			tokens = append(tokens, zedToken{kind: zedTokenPunctuation, value: "->", start: i, end: i + 2, line: line})
+			i += 2
+		case strings.ContainsRune("{}()=:|#*+&-;.,<>", rune(c)):
+			tokens = append(tokens, zedToken{kind: zedTokenPunctuation, value: string(c), start: i, end: i + 1, line: line})
+			i++
+		case isZedIdentifierChar(c):
+			start := i
+			for i < len(source) && isZedIdentifierChar(source[i]) {
+				i++
+			}
+			tokens = append(tokens, zedToken{kind: zedTokenIdentifier, value: source[start:i], start: start, end: i, line: line})
+		default:
+			return nil, fmt.Errorf("line %d: unexpected character %q", line, c)
+		}
+	}
+	return append(tokens, zedToken{kind: zedTokenEOF, start: len(source), end: len(source), line: line}), nil
+}
+
+func isZedIdentifierChar(c byte) bool {
+	return c == '_' || c == '/' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+type zedParser struct {
+	source string
+	tokens []zedToken
+	pos    int
+}
+
+// parseZedSchema parses and validates a SpiceDB schema.
+func parseZedSchema(source string) (*zedSchema, error) {
+	tokens, err := tokenizeZed(source)
+	if err != nil {
+		return nil, err
+	}
+	p := &zedParser{source: source, tokens: tokens}
+	schema := &zedSchema{definitions: map[string]*zedDefinition{}}
+
+	for {
+		p.skipNewlines()
+		tok := p.peek()
+		if tok.kind == zedTokenEOF {
+			break
+		}
+		if tok.kind != zedTokenIdentifier || tok.value != "definition" {
+			return nil, p.errorf(tok, "expected definition, found %q", tok.value)
+		}
+		def, err := p.parseDefinition()
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := schema.definitions[def.name]; exists {
+			return nil, fmt.Errorf("duplicate definition %q", def.name)
+		}
+		schema.definitions[def.name] = def
+	}
+
+	if err := schema.validate(); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func (p *zedParser) peek() zedToken {
+	return p.tokens[p.pos]
+}
+
+func (p *zedParser) next() zedToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != zedTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *zedParser) skipNewlines() {
+	for p.peek().kind == zedTokenNewline || p.peek().value == ";" {
+		p.pos++
+	}
+}
+
+func (p *zedParser) errorf(tok zedToken, format string, args ...any) error {
+	return fmt.Errorf("line %d: %s", tok.line, fmt.Sprintf(format, args...))
+}
+
+func (p *zedParser) expect(value string) (zedToken, error) {
+	tok := p.next()
+	if tok.value != value {
+		return tok, p.errorf(tok, "expected %q, found %q", value, tok.value)
+	}
+	return tok, nil
+}
+
+func (p *zedParser) expectIdentifier() (zedToken, error) {
+	tok := p.next()
+	if tok.kind != zedTokenIdentifier {
+		return tok, p.errorf(tok, "expected identifier, found %q", tok.value)
+	}
+	return tok, nil
+}
+
+func (p *zedParser) parseDefinition() (*zedDefinition, error) {
+	p.next()
+	name, err := p.expectIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	if strings.Count(name.value, "/") > 1 {
+		return nil, p.errorf(name, "invalid definition name %q", name.value)
+	}
+	if _, err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	def := &zedDefinition{
+		name:        name.value,
+		relations:   map[string][]zedAllowedType{},
+		permissions: map[string]*zedPermission{},
+	}
+	for {
+		p.skipNewlines()
+		tok := p.next()
+		switch {
+		case tok.value == "}":
+			return def, nil
+		case tok.kind == zedTokenIdentifier && tok.value == "relation":
+			relName, types, err := p.parseRelation()
+			if err != nil {
+				return nil, err
+			}
+			if def.hasRelationOrPermission(relName) {
+				return nil, p.errorf(tok, "duplicate relation or permission %q on %q", relName, def.name)
+			}
+			def.relations[relName] = types
+		case tok.kind == zedTokenIdentifier && tok.value == "permission":
+			permName, permission, err := p.parsePermission()
+			if err != nil {
+				return nil, err
+			}
+			if def.hasRelationOrPermission(permName) {
+				return nil, p.errorf(tok, "duplicate relation or permission %q on %q", permName, def.name)
+			}
+			def.permissions[permName] = permission
+		default:
+			return nil, p.errorf(tok, "expected relation, permission or '}' in definition %q, found %q", def.name, tok.value)
+		}
+	}
+}
+
+func (p *zedParser) parseRelation() (string, []zedAllowedType, error) {
+	name, err := p.expectIdentifier()
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := p.expect(":"); err != nil {
+		return "", nil, err
+	}
+
+	var types []zedAllowedType
+	for {
+		typ, err := p.expectIdentifier()
+		if err != nil {
+			return "", nil, err
+		}
+		allowed := zedAllowedType{typ: typ.value}
+		switch p.peek().value {
+		case "#":
+			p.next()
+			rel, err := p.expectIdentifier()
+			if err != nil {
+				return "", nil, err
+			}
+			allowed.relation = rel.value
+		case ":":
+			p.next()
+			if _, err := p.expect("*"); err != nil {
+				return "", nil, err
+			}
+			allowed.wildcard = true
+		}
+		if tok := p.peek(); tok.kind == zedTokenIdentifier && tok.value == "with" {
+			return "", nil, p.errorf(tok, "caveats are not supported")
+		}
+		types = append(types, allowed)
+
+		if p.peek().value != "|" {
+			break
+		}
+		p.next()
+		p.skipNewlines()
+	}
+	return name.value, types, nil
+}
+
+func (p *zedParser) parsePermission() (string, *zedPermission, error) {
+	name, err := p.expectIdentifier()
+	if err != nil {
+		return "", nil, err
+	}
+	if _, err := p.expect("="); err != nil {
+		return "", nil, err
+	}
+
+	start := p.peek().start
+	expr, err := p.parseUnion(0)
+	if err != nil {
+		return "", nil, err
+	}
+	end := p.tokens[p.pos-1].end
+
+	return name.value, &zedPermission{
+		expression: expr,
+		text:       strings.Join(strings.Fields(p.source[start:end]), " "),
+	}, nil
+}
+
+// Operator precedence follows SpiceDB: exclusion binds tighter than intersection, which binds
+// tighter than union. Newlines end an expression unless they follow an operator or appear
+// inside parentheses.
+
+func (p *zedParser) parseUnion(depth int) (*zedExpression, error) {
+	return p.parseBinary(depth, "+", zedExpressionUnion, p.parseIntersection)
+}
+
+func (p *zedParser) parseIntersection(depth int) (*zedExpression, error) {
+	return p.parseBinary(depth, "&", zedExpressionIntersection, p.parseExclusion)
+}
+
+func (p *zedParser) parseExclusion(depth int) (*zedExpression, error) {
+	return p.parseBinary(depth, "-", zedExpressionExclusion, p.parsePrimary)
+}
+
+func (p *zedParser) parseBinary(depth int, operator string, kind zedExpressionKind,
+	operand func(int) (*zedExpression, error)) (*zedExpression, error) {
+	left, err := operand(depth)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if depth > 0 {
+			p.skipNewlines()
+		}
+		if p.peek().value != operator {
+			return left, nil
+		}
+		p.next()
+		p.skipNewlines()
+		right, err := operand(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = &zedExpression{kind: kind, left: left, right: right}
+	}
+}
+
+func (p *zedParser) parsePrimary(depth int) (*zedExpression, error) {
+	tok := p.next()
+	if tok.value == "(" {
+		p.skipNewlines()
+		expr, err := p.parseUnion(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		p.skipNewlines()
+		if _, err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return expr, nil
+	}
+	if tok.kind != zedTokenIdentifier {
+		return nil, p.errorf(tok, "expected expression, found %q", tok.value)
+	}
+	if tok.value == "nil" {
+		return &zedExpression{kind: zedExpressionNil}, nil
+	}
+
+	switch p.peek().value {
+	case "->":
+		p.next()
+		target, err := p.expectIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		return &zedExpression{kind: zedExpressionArrow, name: tok.value, target: target.value}, nil
+	case ".":
+		return nil, p.errorf(p.peek(), "functional arrows are not supported")
+	}
+	return &zedExpression{kind: zedExpressionReference, name: tok.value}, nil
+}
+
+// validate checks that every type, relation and permission referenced by the schema exists.
+func (s *zedSchema) validate() error {
+	for _, defName := range s.definitionNames() {
+		def := s.definitions[defName]
+		for relName, types := range def.relations {
+			for _, allowed := range types {
+				target, ok := s.definitions[allowed.typ]
+				if !ok {
+					return fmt.Errorf("relation %s#%s references unknown type %q", def.name, relName, allowed.typ)
+				}
+				if allowed.relation != "" && !target.hasRelationOrPermission(allowed.relation) {
+					return fmt.Errorf("relation %s#%s references unknown relation %s#%s", def.name, relName, allowed.typ, allowed.relation)
+				}
+			}
+		}
+		for permName, permission := range def.permissions {
+			if err := s.validateExpression(def, permission.expression); err != nil {
+				return fmt.Errorf("permission %s#%s: %w", def.name, permName, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *zedSchema) validateExpression(def *zedDefinition, expr *zedExpression) error {
+	switch expr.kind {
+	case zedExpressionReference:
+		if !def.hasRelationOrPermission(expr.name) {
+			return fmt.Errorf("unknown relation or permission %q", expr.name)
+		}
+	case zedExpressionArrow:
+		types, ok := def.relations[expr.name]
+		if !ok {
+			return fmt.Errorf("arrow references unknown relation %q", expr.name)
+		}
+		found := false
+		for _, allowed := range types {
+			if s.definitions[allowed.typ].hasRelationOrPermission(expr.target) {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("arrow %s->%s does not resolve on any subject type", expr.name, expr.target)
+		}
+	case zedExpressionUnion, zedExpressionIntersection, zedExpressionExclusion:
+		if err := s.validateExpression(def, expr.left); err != nil {
+			return err
+		}
+		return s.validateExpression(def, expr.right)
+	}
+	return nil
+}
+
+func (s *zedSchema) definitionNames() []string {
+	names := make([]string, 0, len(s.definitions))
+	for name := range s.definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// allowsSubject reports whether a tuple with the given subject may be written to the relation.
+func (s *zedSchema) allowsSubject(resourceType, relation, subjectType, subjectID, subjectRelation string) error {
+	def, ok := s.definitions[resourceType]
+	if !ok {
+		return fmt.Errorf("object definition %q not found", resourceType)
+	}
+	types, ok := def.relations[relation]
+	if !ok {
+		return fmt.Errorf("relation %q not found on %q", relation, resourceType)
+	}
+	for _, allowed := range types {
+		if allowed.typ != subjectType {
+			continue
+		}
+		if subjectID == "*" {
+			if allowed.wildcard && subjectRelation == "" {
+				return nil
+			}
+			continue
+		}
+		if !allowed.wildcard && allowed.relation == subjectRelation {
+			return nil
+		}
+	}
+	subject := subjectType + ":" + subjectID
+	if subjectRelation != "" {
+		subject += "#" + subjectRelation
+	}
+	return fmt.Errorf("subject %s is not allowed on %s#%s", subject, resourceType, relation)
+}
@@ -0,0 +1,97 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseZedSchema(t *testing.T) {
+	schema, err := parseZedSchema(`
+/* widgets belong to workspaces */
+definition rbac/principal {}
+
+definition rbac/workspace {
+	relation t_member: rbac/principal | rbac/principal:*
+	relation t_banned: rbac/principal
+	permission view = t_member - t_banned + t_member &
+		t_member // trailing operators continue the expression
+	permission none = nil
+}
+
+definition rbac/widget {
+	relation t_workspace: rbac/workspace
+	permission view = (t_workspace->view)
+}
+`)
+	require.NoError(t, err)
+
+	workspace := schema.definitions["rbac/workspace"]
+	require.NotNil(t, workspace)
+	assert.Equal(t, []zedAllowedType{{typ: "rbac/principal"}, {typ: "rbac/principal", wildcard: true}}, workspace.relations["t_member"])
+	assert.Equal(t, "t_member - t_banned + t_member & t_member", workspace.permissions["view"].text)
+
+	// union binds loosest, then intersection, then exclusion
+	view := workspace.permissions["view"].expression
+	assert.Equal(t, zedExpressionUnion, view.kind)
+	assert.Equal(t, zedExpressionExclusion, view.left.kind)
+	assert.Equal(t, zedExpressionIntersection, view.right.kind)
+	assert.Equal(t, zedExpressionNil, workspace.permissions["none"].expression.kind)
+
+	arrow := schema.definitions["rbac/widget"].permissions["view"].expression
+	assert.Equal(t, &zedExpression{kind: zedExpressionArrow, name: "t_workspace", target: "view"}, arrow)
+}
+
+func TestParseZedSchema_DeploySchema(t *testing.T) {
+	schema, err := readFile("../../deploy/schema.zed")
+	require.NoError(t, err)
+	_, err = parseZedSchema(schema)
+	assert.NoError(t, err)
+}
+
+func TestParseZedSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{
+			name:   "unknown subject type",
+			schema: `definition a { relation t_x: b }`,
+			err:    `references unknown type "b"`,
+		},
+		{
+			name:   "unknown reference",
+			schema: `definition a { permission p = t_x }`,
+			err:    `unknown relation or permission "t_x"`,
+		},
+		{
+			name:   "arrow that never resolves",
+			schema: "definition b {}\ndefinition a {\nrelation t_x: b\npermission p = t_x->view\n}",
+			err:    "does not resolve on any subject type",
+		},
+		{
+			name:   "caveats",
+			schema: `definition b {} definition a { relation t_x: b with expiration }`,
+			err:    "caveats are not supported",
+		},
+		{
+			name:   "duplicate relation",
+			schema: "definition b {}\ndefinition a {\nrelation t_x: b\npermission t_x = nil\n}",
+			err:    `duplicate relation or permission "t_x"`,
+		},
+		{
+			name:   "unbalanced parentheses",
+			schema: "definition a {\npermission p = (nil\n}",
+			err:    `expected ")"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := parseZedSchema(test.schema)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
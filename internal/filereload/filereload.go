@@ -0,0 +1,97 @@
+// Package filereload keeps a value parsed from a file up to date with the file, so that files such
+// as key sets and policies can be changed without a restart.
+package filereload
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+// Reloader holds the value parsed from a file. It re-reads the file periodically and swaps in the
+// newly parsed value when the contents changed; a file that can't be read or parsed keeps the
+// previous value.
+type Reloader[T any] struct {
+	name    string
+	file    string
+	parse   func([]byte) (*T, error)
+	keyvals []interface{}
+
+	value    atomic.Pointer[T]
+	data     []byte
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New reads and parses file, failing if it can't, and re-reads it every interval unless interval
+// is zero. name describes the file in errors and logs, and keyvals are added to the logs.
+func New[T any](name, file string, interval time.Duration, parse func([]byte) (*T, error), keyvals ...interface{}) (*Reloader[T], error) {
+	r := &Reloader[T]{name: name, file: file, parse: parse, keyvals: keyvals, stop: make(chan struct{})}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	if interval > 0 {
+		go r.reloadEvery(interval)
+	}
+	return r, nil
+}
+
+// Load returns the value parsed from the file when it was last read successfully.
+func (r *Reloader[T]) Load() *T {
+	return r.value.Load()
+}
+
+// Stop ends periodic reloading.
+func (r *Reloader[T]) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+// Reload reads the file and swaps in the newly parsed value if it changed since the last read.
+// Reloads are not safe to run concurrently; New starts the only periodic one.
+func (r *Reloader[T]) Reload() (bool, error) {
+	data, err := os.ReadFile(r.file)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", r.name, err)
+	}
+	if r.value.Load() != nil && bytes.Equal(data, r.data) {
+		return false, nil
+	}
+	value, err := r.parse(data)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %s: %w", r.name, r.file, err)
+	}
+	r.data = data
+	r.value.Store(value)
+	return true, nil
+}
+
+func (r *Reloader[T]) reloadEvery(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				// Keep the previous value rather than failing; the file may be mid-rotation.
+				log.NewHelper(log.DefaultLogger).Warnw(append([]interface{}{
+					"msg", fmt.Sprintf("failed to reload %s, keeping the previous one", r.name),
+					"file", r.file,
+					"error", err,
+				}, r.keyvals...)...)
+			} else if reloaded {
+				log.NewHelper(log.DefaultLogger).Infow(append([]interface{}{
+					"msg", fmt.Sprintf("reloaded %s", r.name),
+					"file", r.file,
+				}, r.keyvals...)...)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package filereload
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseInt parses a file holding an integer and counts the parses.
+type parseInt struct {
+	calls int
+}
+
+func (p *parseInt) parse(data []byte) (*int, error) {
+	p.calls++
+	n, err := strconv.Atoi(string(data))
+	if err != nil {
+		return nil, errors.New("not a number")
+	}
+	return &n, nil
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestReloader_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "number")
+	writeFile(t, path, "1")
+	parser := &parseInt{}
+	r, err := New("number file", path, 0, parser.parse)
+	require.NoError(t, err)
+	defer r.Stop()
+	assert.Equal(t, 1, *r.Load())
+
+	reloaded, err := r.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Equal(t, 1, parser.calls, "an unchanged file isn't parsed again")
+
+	writeFile(t, path, "two")
+	_, err = r.Reload()
+	assert.EqualError(t, err, "invalid number file "+path+": not a number")
+	assert.Equal(t, 1, *r.Load(), "a file that can't be parsed keeps the previous value")
+
+	require.NoError(t, os.Remove(path))
+	_, err = r.Reload()
+	assert.ErrorContains(t, err, "failed to read number file")
+	assert.Equal(t, 1, *r.Load())
+
+	writeFile(t, path, "2")
+	reloaded, err = r.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, 2, *r.Load())
+}
+
+func TestReloader_ReloadsPeriodically(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "number")
+	writeFile(t, path, "1")
+	parser := &parseInt{}
+	r, err := New("number file", path, 10*time.Millisecond, parser.parse)
+	require.NoError(t, err)
+	defer r.Stop()
+
+	writeFile(t, path, "2")
+	assert.Eventually(t, func() bool { return *r.Load() == 2 }, time.Second, 10*time.Millisecond)
+	r.Stop()
+}
+
+func TestNew_FailsOnInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "number")
+	parser := &parseInt{}
+	_, err := New("number file", path, 0, parser.parse)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	writeFile(t, path, "one")
+	_, err = New("number file", path, 0, parser.parse)
+	assert.EqualError(t, err, "invalid number file "+path+": not a number")
+}
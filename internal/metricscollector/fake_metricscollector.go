@@ -18,6 +18,14 @@ type fakeMetricsState struct {
 	MsgProcessFailureCount       int
 	ConsumerErrorCount           int
 	KafkaErrorEventCount         int
+	RelationsCacheHitCount       int
+	RelationsCacheMissCount      int
+	RelationsShadowMismatchCount int
+	RelationsShadowErrorCount    int
+	RelationsRetryCount          int
+	RelationsHedgedRequestCount  int
+	RelationsBreakerRejectCount  int
+	RelationsFailOpenCount       int
 }
 
 var globalFakeState = &fakeMetricsState{}
@@ -25,15 +33,24 @@ var globalFakeState = &fakeMetricsState{}
 func NewFakeMetricsCollector() *MetricsCollector {
 	globalFakeState.Reset()
 	mc := &MetricsCollector{
-		SerializationFailures:    &fakeCounter{counterType: "serialization_failures"},
-		SerializationExhaustions: &fakeCounter{counterType: "serialization_exhaustions"},
-		OutboxEventWrites:        &fakeCounter{counterType: "outbox_event_writes"},
-		MsgsProcessed:            &fakeCounter{counterType: "msgs_processed"},
-		MsgProcessFailures:       &fakeCounter{counterType: "msg_process_failures"},
-		ConsumerErrors:           &fakeCounter{counterType: "consumer_errors"},
-		KafkaErrorEvents:         &fakeCounter{counterType: "kafka_error_events"},
-		ResourcesPerWorkspace:    &fakeHistogram{},
-		ResourceCount:            &fakeGauge{},
+		SerializationFailures:     &fakeCounter{counterType: "serialization_failures"},
+		SerializationExhaustions:  &fakeCounter{counterType: "serialization_exhaustions"},
+		OutboxEventWrites:         &fakeCounter{counterType: "outbox_event_writes"},
+		MsgsProcessed:             &fakeCounter{counterType: "msgs_processed"},
+		MsgProcessFailures:        &fakeCounter{counterType: "msg_process_failures"},
+		ConsumerErrors:            &fakeCounter{counterType: "consumer_errors"},
+		KafkaErrorEvents:          &fakeCounter{counterType: "kafka_error_events"},
+		RelationsCacheHits:        &fakeCounter{counterType: "relations_cache_hits"},
+		RelationsCacheMisses:      &fakeCounter{counterType: "relations_cache_misses"},
+		RelationsShadowMismatches: &fakeCounter{counterType: "relations_shadow_mismatches"},
+		RelationsShadowErrors:     &fakeCounter{counterType: "relations_shadow_errors"},
+		RelationsRetries:          &fakeCounter{counterType: "relations_retries"},
+		RelationsHedgedRequests:   &fakeCounter{counterType: "relations_hedged_requests"},
+		RelationsBreakerRejects:   &fakeCounter{counterType: "relations_circuit_breaker_rejections"},
+		RelationsBreakerState:     &fakeGauge{},
+		RelationsFailOpens:        &fakeCounter{counterType: "relations_fail_open_decisions"},
+		ResourcesPerWorkspace:     &fakeHistogram{},
+		ResourceCount:             &fakeGauge{},
 	}
 	return mc
 }
@@ -48,6 +65,14 @@ func (s *fakeMetricsState) Reset() {
 	s.MsgProcessFailureCount = 0
 	s.ConsumerErrorCount = 0
 	s.KafkaErrorEventCount = 0
+	s.RelationsCacheHitCount = 0
+	s.RelationsCacheMissCount = 0
+	s.RelationsShadowMismatchCount = 0
+	s.RelationsShadowErrorCount = 0
+	s.RelationsRetryCount = 0
+	s.RelationsHedgedRequestCount = 0
+	s.RelationsBreakerRejectCount = 0
+	s.RelationsFailOpenCount = 0
 }
 
 func GetSerializationFailureCount() int {
@@ -68,6 +93,54 @@ func GetOutboxEventWriteCount() int {
 	return globalFakeState.OutboxEventWriteCount
 }
 
+func GetRelationsCacheHitCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsCacheHitCount
+}
+
+func GetRelationsCacheMissCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsCacheMissCount
+}
+
+func GetRelationsShadowMismatchCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsShadowMismatchCount
+}
+
+func GetRelationsShadowErrorCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsShadowErrorCount
+}
+
+func GetRelationsRetryCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsRetryCount
+}
+
+func GetRelationsHedgedRequestCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsHedgedRequestCount
+}
+
+func GetRelationsBreakerRejectCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsBreakerRejectCount
+}
+
+func GetRelationsFailOpenCount() int {
+	globalFakeState.mu.Lock()
+	defer globalFakeState.mu.Unlock()
+	return globalFakeState.RelationsFailOpenCount
+}
+
 func incrementCounter(counterType string) {
 	globalFakeState.mu.Lock()
 	defer globalFakeState.mu.Unlock()
@@ -86,6 +159,22 @@ func incrementCounter(counterType string) {
 		globalFakeState.ConsumerErrorCount++
 	case "kafka_error_events":
 		globalFakeState.KafkaErrorEventCount++
+	case "relations_cache_hits":
+		globalFakeState.RelationsCacheHitCount++
+	case "relations_cache_misses":
+		globalFakeState.RelationsCacheMissCount++
+	case "relations_shadow_mismatches":
+		globalFakeState.RelationsShadowMismatchCount++
+	case "relations_shadow_errors":
+		globalFakeState.RelationsShadowErrorCount++
+	case "relations_retries":
+		globalFakeState.RelationsRetryCount++
+	case "relations_hedged_requests":
+		globalFakeState.RelationsHedgedRequestCount++
+	case "relations_circuit_breaker_rejections":
+		globalFakeState.RelationsBreakerRejectCount++
+	case "relations_fail_open_decisions":
+		globalFakeState.RelationsFailOpenCount++
 	}
 }
 
@@ -93,13 +93,22 @@ type MetricsCollector struct {
 	assignmentSize metric.Int64Gauge
 
 	// App Specific Metrics
-	MsgsProcessed            metric.Int64Counter
-	MsgProcessFailures       metric.Int64Counter
-	ConsumerErrors           metric.Int64Counter
-	KafkaErrorEvents         metric.Int64Counter
-	OutboxEventWrites        metric.Int64Counter
-	SerializationFailures    metric.Int64Counter
-	SerializationExhaustions metric.Int64Counter
+	MsgsProcessed             metric.Int64Counter
+	MsgProcessFailures        metric.Int64Counter
+	ConsumerErrors            metric.Int64Counter
+	KafkaErrorEvents          metric.Int64Counter
+	OutboxEventWrites         metric.Int64Counter
+	SerializationFailures     metric.Int64Counter
+	SerializationExhaustions  metric.Int64Counter
+	RelationsCacheHits        metric.Int64Counter
+	RelationsCacheMisses      metric.Int64Counter
+	RelationsShadowMismatches metric.Int64Counter
+	RelationsShadowErrors     metric.Int64Counter
+	RelationsRetries          metric.Int64Counter
+	RelationsHedgedRequests   metric.Int64Counter
+	RelationsBreakerRejects   metric.Int64Counter
+	RelationsBreakerState     metric.Int64Gauge
+	RelationsFailOpens        metric.Int64Counter
 
 	// Business Metrics
 	ResourcesPerWorkspace metric.Float64Histogram
@@ -183,6 +192,60 @@ func (m *MetricsCollector) New(meter metric.Meter) error {
 	if m.SerializationExhaustions, err = meter.Int64Counter(prefix + "serialization_exhaustions"); err != nil {
 		return err
 	}
+	if m.RelationsCacheHits, err = meter.Int64Counter(
+		prefix+"relations_cache_hits",
+		metric.WithDescription("Number of relationship checks answered from the decision cache"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsCacheMisses, err = meter.Int64Counter(
+		prefix+"relations_cache_misses",
+		metric.WithDescription("Number of cacheable relationship checks forwarded to the relations backend"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsShadowMismatches, err = meter.Int64Counter(
+		prefix+"relations_shadow_mismatches",
+		metric.WithDescription("Number of mirrored relations requests whose secondary answer differed from the primary"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsShadowErrors, err = meter.Int64Counter(
+		prefix+"relations_shadow_errors",
+		metric.WithDescription("Number of relations requests that could not be compared because mirroring failed or was skipped"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsRetries, err = meter.Int64Counter(
+		prefix+"relations_retries",
+		metric.WithDescription("Number of relations reads retried after a transient backend error"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsHedgedRequests, err = meter.Int64Counter(
+		prefix+"relations_hedged_requests",
+		metric.WithDescription("Number of hedged relationship checks sent because the first attempt was slow, labelled with the attempt that answered"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsBreakerRejects, err = meter.Int64Counter(
+		prefix+"relations_circuit_breaker_rejections",
+		metric.WithDescription("Number of relations requests rejected without calling the backend because the circuit was open"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsBreakerState, err = meter.Int64Gauge(
+		prefix+"relations_circuit_breaker_state",
+		metric.WithDescription("State of the relations backend circuit breaker: 0 closed, 1 half-open, 2 open"),
+	); err != nil {
+		return err
+	}
+	if m.RelationsFailOpens, err = meter.Int64Counter(
+		prefix+"relations_fail_open_decisions",
+		metric.WithDescription("Number of relationship checks answered as allowed because the relations backend was failing"),
+	); err != nil {
+		return err
+	}
 
 	// create business metrics
 	if m.ResourcesPerWorkspace, err = meter.Float64Histogram(
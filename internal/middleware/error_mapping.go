@@ -13,6 +13,7 @@ import (
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/tenancy"
 )
 
 // ErrorMapping returns a middleware that maps domain errors to gRPC status codes.
@@ -67,6 +68,10 @@ func MapError(err error) error {
 		return status.Error(codes.Unauthenticated, "self subject missing")
 	case errors.Is(err, metaauthorizer.ErrMetaAuthorizerUnavailable):
 		return status.Error(codes.Internal, "meta authorizer unavailable")
+	case errors.Is(err, metaauthorizer.ErrReporterNotBound):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, tenancy.ErrOrgRequired), errors.Is(err, tenancy.ErrCrossOrgDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
 	case errors.Is(err, metaauthorizer.ErrMetaAuthorizationDenied):
 		return status.Error(codes.PermissionDenied, "meta authorization denied")
 	// Domain errors (from model)
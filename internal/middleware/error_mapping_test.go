@@ -13,6 +13,7 @@ import (
 	"github.com/project-kessel/inventory-api/internal/biz/model"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/metaauthorizer"
 	"github.com/project-kessel/inventory-api/internal/biz/usecase/resources"
+	"github.com/project-kessel/inventory-api/internal/biz/usecase/tenancy"
 )
 
 func TestMapError(t *testing.T) {
@@ -47,6 +48,24 @@ func TestMapError(t *testing.T) {
 			expectedCode: codes.PermissionDenied,
 			expectedMsg:  "meta authorization denied",
 		},
+		{
+			name:         "ReporterBindingError maps to PermissionDenied with its reason",
+			err:          &metaauthorizer.ReporterBindingError{Reason: `client "acs" is not bound to reporter_type "hbi"`},
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  `client "acs" is not bound to reporter_type "hbi"`,
+		},
+		{
+			name:         "ErrOrgRequired maps to PermissionDenied",
+			err:          tenancy.ErrOrgRequired,
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "organization id required",
+		},
+		{
+			name:         "ErrCrossOrgDenied maps to PermissionDenied with its reason",
+			err:          fmt.Errorf("%w: caller may not act on organization %q", tenancy.ErrCrossOrgDenied, "42"),
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  `cross-organization access denied: caller may not act on organization "42"`,
+		},
 		// Domain errors (from model)
 		{
 			name:         "ErrResourceNotFound maps to NotFound",
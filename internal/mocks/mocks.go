@@ -71,6 +71,10 @@ func (m *MockRelationsRepository) ReadTuples(_ context.Context, _ model.TupleFil
 	panic("MockRelationsRepository.ReadTuples() is not supported - use SimpleRelationsRepository instead")
 }
 
+func (m *MockRelationsRepository) CheckExplain(_ context.Context, _ model.Relationship, _ model.Consistency) (model.CheckExplainResult, error) {
+	panic("MockRelationsRepository.CheckExplain() is not supported - use SimpleRelationsRepository instead")
+}
+
 func (m *MockRelationsRepository) CheckForUpdate(_ context.Context, _ model.Relationship) (model.CheckResult, error) {
 	panic("MockRelationsRepository.CheckForUpdate() is not supported - use SimpleRelationsRepository instead")
 }
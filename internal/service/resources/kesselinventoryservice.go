@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type InventoryService struct {
@@ -27,7 +28,7 @@ func NewKesselInventoryServiceV1beta2(c *resources.Usecase) *InventoryService {
 }
 
 func (c *InventoryService) ReportResource(ctx context.Context, r *pb.ReportResourceRequest) (*pb.ReportResourceResponse, error) {
-	cmd, err := toReportResourceCommand(r)
+	cmd, err := ToReportResourceCommand(r)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
 	}
@@ -77,6 +78,30 @@ func (s *InventoryService) Check(ctx context.Context, req *pb.CheckRequest) (*pb
 	return viewResponseFromAuthzRequestV1beta2(result.Allowed(), result.ConsistencyToken()), nil
 }
 
+func (s *InventoryService) CheckExplain(ctx context.Context, req *pb.CheckExplainRequest) (*pb.CheckExplainResponse, error) {
+	resourceRef, err := resourceReferenceFromProto(req.Object)
+	if err != nil {
+		log.Error("Failed to build resource reference: ", err)
+		return nil, err
+	}
+	subjectRef, err := subjectReferenceFromProto(req.GetSubject())
+	if err != nil {
+		log.Error("Failed to build subject reference: ", err)
+		return nil, err
+	}
+	relation, err := model.NewRelation(req.GetRelation())
+	if err != nil {
+		log.Error("Failed to build relation: ", err)
+		return nil, err
+	}
+	consistency := consistencyFromProto(req.GetConsistency())
+	result, err := s.Ctl.CheckExplain(ctx, relation, subjectRef, resourceRef, consistency)
+	if err != nil {
+		return nil, err
+	}
+	return checkExplainResponseFromResult(result), nil
+}
+
 func (s *InventoryService) CheckForUpdate(ctx context.Context, req *pb.CheckForUpdateRequest) (*pb.CheckForUpdateResponse, error) {
 	log.Info("CheckForUpdate using v1beta2 db")
 	resourceRef, err := resourceReferenceFromProto(req.Object)
@@ -171,6 +196,22 @@ func (s *InventoryService) CheckSelfBulk(ctx context.Context, req *pb.CheckSelfB
 	return fromCheckSelfBulkResult(resp, req), nil
 }
 
+func (s *InventoryService) GetConsistencyWatermark(ctx context.Context, req *pb.GetConsistencyWatermarkRequest) (*pb.GetConsistencyWatermarkResponse, error) {
+	var reporterType *model.ReporterType
+	if req.ReporterType != nil {
+		rt, err := model.NewReporterType(req.GetReporterType())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid reporter type: %v", err)
+		}
+		reporterType = &rt
+	}
+	watermark, err := s.Ctl.GetConsistencyWatermark(ctx, reporterType)
+	if err != nil {
+		return nil, err
+	}
+	return consistencyWatermarkResponseFromWatermark(watermark), nil
+}
+
 // resourceReferenceFromProto converts a v1beta2 ResourceReference to a model.ResourceReference.
 // Used by Relations-flow endpoints (Check, CheckForUpdate, CheckBulk, LookupSubjects, etc.).
 func resourceReferenceFromProto(resource *pb.ResourceReference) (model.ResourceReference, error) {
@@ -477,6 +518,10 @@ func (s *InventoryService) StreamedListObjects(
 		return err
 	}
 
+	if req.Inventory != nil {
+		return s.streamEnrichedObjects(ctx, lookupCmd, req.Inventory, stream)
+	}
+
 	clientStream, err := s.Ctl.LookupObjects(ctx, lookupCmd)
 	if err != nil {
 		return err
@@ -497,6 +542,88 @@ func (s *InventoryService) StreamedListObjects(
 	}
 }
 
+func (s *InventoryService) StreamedCheckBulk(stream pb.KesselInventoryService_StreamedCheckBulkServer) error {
+	ctx := stream.Context()
+
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	consistency := consistencyFromProto(first.GetConsistency())
+	log.Debugf("StreamedCheckBulk consistency: %s", model.ConsistencyTypeOf(consistency))
+
+	next := first
+	received, sent := 0, 0
+	token, err := s.Ctl.StreamedCheckBulk(ctx, resources.StreamedCheckBulkCommand{
+		Consistency: consistency,
+		Next: func() ([]resources.CheckBulkItem, error) {
+			req := next
+			next = nil
+			if req == nil {
+				var err error
+				if req, err = stream.Recv(); err != nil {
+					return nil, err
+				}
+			}
+			items, err := toStreamedCheckBulkItems(req, received)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+			}
+			received += len(items)
+			return items, nil
+		},
+		Send: func(pairs []resources.CheckBulkResultPair) error {
+			resp := fromStreamedCheckBulkPairs(pairs, sent)
+			sent += len(pairs)
+			return stream.Send(resp)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	final := &pb.StreamedCheckBulkResponse{}
+	if token != "" {
+		final.ConsistencyToken = &pb.ConsistencyToken{Token: token.Serialize()}
+	}
+	return stream.Send(final)
+}
+
+// streamEnrichedObjects serves a StreamedListObjects request that asked for inventory enrichment.
+func (s *InventoryService) streamEnrichedObjects(
+	ctx context.Context,
+	lookupCmd resources.LookupObjectsCommand,
+	enrichment *pb.InventoryEnrichment,
+	stream pb.KesselInventoryService_StreamedListObjectsServer,
+) error {
+	clientStream, err := s.Ctl.LookupObjectsEnriched(ctx, lookupCmd, lookupObjectsEnrichmentFromProto(enrichment))
+	if err != nil {
+		return err
+	}
+
+	for {
+		item, err := clientStream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err := ToEnrichedLookupObjectsResponse(item)
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
 func (s *InventoryService) StreamedListSubjects(
 	req *pb.StreamedListSubjectsRequest,
 	stream pb.KesselInventoryService_StreamedListSubjectsServer,
@@ -531,6 +658,43 @@ func (s *InventoryService) StreamedListSubjects(
 	}
 }
 
+// toStreamedCheckBulkItems converts the items of one StreamedCheckBulkRequest message.
+// offset is the number of items received earlier on the stream, so that error indexes
+// refer to the position within the whole stream.
+func toStreamedCheckBulkItems(req *pb.StreamedCheckBulkRequest, offset int) ([]resources.CheckBulkItem, error) {
+	items := make([]resources.CheckBulkItem, len(req.GetItems()))
+	for i, item := range req.GetItems() {
+		bulkItem, err := protoToCheckBulkItem(item, offset+i)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = bulkItem
+	}
+	return items, nil
+}
+
+// fromStreamedCheckBulkPairs converts a run of usecase results to a StreamedCheckBulkResponse.
+// offset is the stream position of the first pair, used in per-item error logs.
+func fromStreamedCheckBulkPairs(pairs []resources.CheckBulkResultPair, offset int) *pb.StreamedCheckBulkResponse {
+	out := make([]*pb.CheckBulkResponsePair, len(pairs))
+	for i, pair := range pairs {
+		allowed, errStatus := checkBulkResultItemToProtoFields(pair.Result, offset+i, "streamedcheckbulk")
+
+		p := &pb.CheckBulkResponsePair{Request: &pb.CheckBulkRequestItem{
+			Object:   resourceReferenceToProto(pair.Request.Resource),
+			Relation: pair.Request.Relation.String(),
+			Subject:  subjectReferenceToProto(pair.Request.Subject),
+		}}
+		if errStatus != nil {
+			p.Response = &pb.CheckBulkResponsePair_Error{Error: errStatus}
+		} else {
+			p.Response = &pb.CheckBulkResponsePair_Item{Item: &pb.CheckBulkResponseItem{Allowed: allowed}}
+		}
+		out[i] = p
+	}
+	return &pb.StreamedCheckBulkResponse{Pairs: out}
+}
+
 // ToLookupObjectsCommand converts a v1beta2 StreamedListObjectsRequest to a LookupObjectsCommand.
 func ToLookupObjectsCommand(request *pb.StreamedListObjectsRequest) (resources.LookupObjectsCommand, error) {
 	if request == nil {
@@ -582,6 +746,43 @@ func ToLookupObjectsResponse(item model.LookupObjectsItem) *pb.StreamedListObjec
 	return resp
 }
 
+// lookupObjectsEnrichmentFromProto converts a v1beta2 InventoryEnrichment to the usecase type.
+func lookupObjectsEnrichmentFromProto(enrichment *pb.InventoryEnrichment) resources.LookupObjectsEnrichment {
+	filters := make([]resources.RepresentationFilter, len(enrichment.GetFilters()))
+	for i, filter := range enrichment.GetFilters() {
+		filters[i] = resources.RepresentationFilter{
+			Path:   filter.GetPath(),
+			Equals: filter.GetEquals().AsInterface(),
+		}
+	}
+	return resources.LookupObjectsEnrichment{
+		CommonFields: enrichment.GetCommonFields(),
+		Filters:      filters,
+	}
+}
+
+// ToEnrichedLookupObjectsResponse converts an enriched lookup result to a StreamedListObjectsResponse.
+func ToEnrichedLookupObjectsResponse(item resources.EnrichedLookupObjectsItem) (*pb.StreamedListObjectsResponse, error) {
+	resp := ToLookupObjectsResponse(item.Item)
+	if item.Resource == nil {
+		return resp, nil
+	}
+
+	common, err := structpb.NewStruct(item.Resource.Common().Data())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode common representation: %v", err)
+	}
+	resp.Inventory = &pb.InventoryResourceData{
+		ApiHref: item.Resource.ApiHref().String(),
+		Common:  common,
+	}
+	if consoleHref := item.Resource.ConsoleHref(); consoleHref != nil {
+		href := consoleHref.String()
+		resp.Inventory.ConsoleHref = &href
+	}
+	return resp, nil
+}
+
 // ToLookupSubjectsCommand converts a v1beta2 LookupSubjectsRequest to a LookupSubjectsCommand.
 func ToLookupSubjectsCommand(request *pb.StreamedListSubjectsRequest) (resources.LookupSubjectsCommand, error) {
 	if request == nil {
@@ -690,6 +891,77 @@ func viewResponseFromAuthzRequestV1beta2(allowed bool, consistencyToken model.Co
 	return response
 }
 
+func checkExplainResponseFromResult(result model.CheckExplainResult) *pb.CheckExplainResponse {
+	response := &pb.CheckExplainResponse{
+		Allowed: allowedToProto(result.Allowed()),
+		Trace:   checkTraceToProto(result.Trace()),
+	}
+	if result.ConsistencyToken() != model.MinimizeLatencyToken {
+		response.ConsistencyToken = &pb.ConsistencyToken{Token: result.ConsistencyToken().Serialize()}
+	}
+	return response
+}
+
+func consistencyWatermarkResponseFromWatermark(watermark model.ConsistencyWatermark) *pb.GetConsistencyWatermarkResponse {
+	response := &pb.GetConsistencyWatermarkResponse{}
+	if !watermark.IsEmpty() {
+		response.ConsistencyToken = &pb.ConsistencyToken{Token: watermark.Token().Serialize()}
+		response.ReplicatedAt = timestamppb.New(watermark.ReplicatedAt())
+	}
+	return response
+}
+
+func checkTraceToProto(trace model.CheckTrace) *pb.CheckTrace {
+	rel := trace.Relationship()
+	out := &pb.CheckTrace{
+		Resource:   resourceReferenceToProto(rel.Object()),
+		Relation:   rel.Relation().String(),
+		Subject:    subjectReferenceToProto(rel.Subject()),
+		Result:     allowedToProto(trace.Allowed()),
+		Expression: trace.Expression(),
+		Cached:     trace.Cached(),
+	}
+	switch trace.Kind() {
+	case model.CheckTraceKindRelation:
+		out.Kind = pb.CheckTrace_KIND_RELATION
+	case model.CheckTraceKindPermission:
+		out.Kind = pb.CheckTrace_KIND_PERMISSION
+	}
+	for _, child := range trace.Children() {
+		out.SubTraces = append(out.SubTraces, checkTraceToProto(child))
+	}
+	return out
+}
+
+func resourceReferenceToProto(ref model.ResourceReference) *pb.ResourceReference {
+	out := &pb.ResourceReference{
+		ResourceId:   ref.ResourceId().String(),
+		ResourceType: ref.ResourceType().String(),
+	}
+	if ref.HasReporter() {
+		out.Reporter = &pb.ReporterReference{
+			Type: ref.Reporter().ReporterType().String(),
+		}
+	}
+	return out
+}
+
+func subjectReferenceToProto(sub model.SubjectReference) *pb.SubjectReference {
+	out := &pb.SubjectReference{Resource: resourceReferenceToProto(sub.Resource())}
+	if sub.HasRelation() {
+		rel := sub.Relation().String()
+		out.Relation = &rel
+	}
+	return out
+}
+
+func allowedToProto(allowed bool) pb.Allowed {
+	if allowed {
+		return pb.Allowed_ALLOWED_TRUE
+	}
+	return pb.Allowed_ALLOWED_FALSE
+}
+
 func updateResponseFromAuthzRequestV1beta2(allowed bool, consistencyToken model.ConsistencyToken) *pb.CheckForUpdateResponse {
 	response := &pb.CheckForUpdateResponse{}
 	if allowed {
@@ -711,9 +983,9 @@ func ResponseFromDeleteResource() *pb.DeleteResourceResponse {
 	return &pb.DeleteResourceResponse{}
 }
 
-// toReportResourceCommand converts a protobuf ReportResourceRequest to a domain ReportResourceCommand.
+// ToReportResourceCommand converts a protobuf ReportResourceRequest to a domain ReportResourceCommand.
 // This function handles all the conversion from presentation types to domain types.
-func toReportResourceCommand(r *pb.ReportResourceRequest) (resources.ReportResourceCommand, error) {
+func ToReportResourceCommand(r *pb.ReportResourceRequest) (resources.ReportResourceCommand, error) {
 	localResourceId, err := model.NewLocalResourceId(r.GetRepresentations().GetMetadata().GetLocalResourceId())
 	if err != nil {
 		return resources.ReportResourceCommand{}, fmt.Errorf("invalid local resource ID: %w", err)
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"slices"
 	"testing"
+	"time"
 
 	krlog "github.com/go-kratos/kratos/v2/log"
 	kratosTransport "github.com/go-kratos/kratos/v2/transport"
@@ -914,6 +915,191 @@ func TestInventoryService_Check_MetaAuthzDenied(t *testing.T) {
 	})
 }
 
+func TestInventoryService_CheckExplain_Allowed(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-123"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	protoReq := &pb.CheckExplainRequest{
+		Relation: "view",
+		Object: &pb.ResourceReference{
+			ResourceId:   "resource-abc",
+			ResourceType: "host",
+			Reporter:     &pb.ReporterReference{Type: "hbi"},
+		},
+		Subject: &pb.SubjectReference{
+			Resource: &pb.ResourceReference{
+				ResourceId:   "subject-456",
+				ResourceType: "principal",
+				Reporter:     &pb.ReporterReference{Type: "rbac"},
+			},
+		},
+	}
+
+	runServerTest(t, func(t *testing.T) (TestServerConfig, func(t *testing.T, tr *Transport)) {
+		simpleAuthz := data.NewSimpleRelationsRepository()
+		simpleAuthz.Grant("subject-456", "view", "hbi", "host", "resource-abc")
+		return TestServerConfig{
+				Usecase:       newTestUsecase(t, testUsecaseConfig{Relations: simpleAuthz}),
+				Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+			}, func(t *testing.T, tr *Transport) {
+				ctx := context.Background()
+				res := tr.Invoke(ctx, withBody(protoReq, CheckExplain, httpEndpoint("POST /api/kessel/v1beta2/checkexplain")))
+				resp := Extract(t, res, expectSuccess(func() *pb.CheckExplainResponse { return &pb.CheckExplainResponse{} }))
+				assert.Equal(t, pb.Allowed_ALLOWED_TRUE, resp.Allowed)
+				assert.NotEmpty(t, resp.ConsistencyToken.GetToken())
+				require.NotNil(t, resp.Trace)
+				assert.Equal(t, pb.Allowed_ALLOWED_TRUE, resp.Trace.Result)
+				assert.Equal(t, pb.CheckTrace_KIND_RELATION, resp.Trace.Kind)
+				assert.Equal(t, "view", resp.Trace.Relation)
+				assert.Equal(t, "resource-abc", resp.Trace.Resource.GetResourceId())
+				assert.Equal(t, "hbi", resp.Trace.Resource.GetReporter().GetType())
+				assert.Equal(t, "subject-456", resp.Trace.Subject.GetResource().GetResourceId())
+			}
+	})
+}
+
+func TestInventoryService_CheckExplain_MetaAuthzDenied(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-123"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	protoReq := &pb.CheckExplainRequest{
+		Relation: "view",
+		Object: &pb.ResourceReference{
+			ResourceId:   "resource-123",
+			ResourceType: "host",
+			Reporter:     &pb.ReporterReference{Type: "hbi"},
+		},
+		Subject: &pb.SubjectReference{
+			Resource: &pb.ResourceReference{
+				ResourceId:   "subject-456",
+				ResourceType: "principal",
+				Reporter:     &pb.ReporterReference{Type: "rbac"},
+			},
+		},
+	}
+
+	runServerTest(t, func(t *testing.T) (TestServerConfig, func(t *testing.T, tr *Transport)) {
+		uc := newTestUsecase(t, testUsecaseConfig{})
+		uc.ExplainMetaAuthorizer = &DenyingMetaAuthorizer{}
+		return TestServerConfig{
+				Usecase:       uc,
+				Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+			}, func(t *testing.T, tr *Transport) {
+				ctx := context.Background()
+				res := tr.Invoke(ctx, withBody(protoReq, CheckExplain, httpEndpoint("POST /api/kessel/v1beta2/checkexplain")))
+				Assert(t, res, requireError(codes.PermissionDenied))
+			}
+	})
+}
+
+func TestInventoryService_GetConsistencyWatermark(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-123"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+	replicatedAt := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		reporterType  *string
+		path          string
+		expectedToken string
+	}{
+		{"global", nil, "/api/kessel/v1beta2/consistencywatermark", "global-watermark"},
+		{"reporter type", proto.String("hbi"), "/api/kessel/v1beta2/consistencywatermark?reporter_type=hbi", "hbi-watermark"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runServerTest(t, func(t *testing.T) (TestServerConfig, func(t *testing.T, tr *Transport)) {
+				watermarks := data.NewFakeConsistencyWatermarkRepository()
+				require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{model.GlobalWatermarkScope}, "global-watermark", replicatedAt))
+				require.NoError(t, watermarks.Advance(nil, []model.WatermarkScope{model.NewReporterTypeWatermarkScope("hbi")}, "hbi-watermark", replicatedAt))
+				uc := newTestUsecase(t, testUsecaseConfig{})
+				uc.ConsistencyWatermarks = watermarks
+				return TestServerConfig{
+						Usecase:       uc,
+						Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+					}, func(t *testing.T, tr *Transport) {
+						ctx := context.Background()
+						protoReq := &pb.GetConsistencyWatermarkRequest{ReporterType: tt.reporterType}
+						res := tr.Invoke(ctx, withBody(protoReq, GetConsistencyWatermark, httpEndpoint("GET "+tt.path)))
+						resp := Extract(t, res, expectSuccess(func() *pb.GetConsistencyWatermarkResponse { return &pb.GetConsistencyWatermarkResponse{} }))
+						assert.Equal(t, tt.expectedToken, resp.GetConsistencyToken().GetToken())
+						assert.True(t, replicatedAt.Equal(resp.GetReplicatedAt().AsTime()))
+					}
+			})
+		})
+	}
+}
+
+func TestInventoryService_GetConsistencyWatermark_Empty(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-123"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	runServerTest(t, func(t *testing.T) (TestServerConfig, func(t *testing.T, tr *Transport)) {
+		uc := newTestUsecase(t, testUsecaseConfig{})
+		uc.ConsistencyWatermarks = data.NewFakeConsistencyWatermarkRepository()
+		return TestServerConfig{
+				Usecase:       uc,
+				Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+			}, func(t *testing.T, tr *Transport) {
+				ctx := context.Background()
+				res := tr.Invoke(ctx, withBody(&pb.GetConsistencyWatermarkRequest{}, GetConsistencyWatermark, httpEndpoint("GET /api/kessel/v1beta2/consistencywatermark")))
+				resp := Extract(t, res, expectSuccess(func() *pb.GetConsistencyWatermarkResponse { return &pb.GetConsistencyWatermarkResponse{} }))
+				assert.Nil(t, resp.GetConsistencyToken())
+				assert.Nil(t, resp.GetReplicatedAt())
+			}
+	})
+}
+
+func TestInventoryService_GetConsistencyWatermark_InvalidReporterType(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-123"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	runServerTest(t, func(t *testing.T) (TestServerConfig, func(t *testing.T, tr *Transport)) {
+		uc := newTestUsecase(t, testUsecaseConfig{})
+		uc.ConsistencyWatermarks = data.NewFakeConsistencyWatermarkRepository()
+		return TestServerConfig{
+				Usecase:       uc,
+				Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+			}, func(t *testing.T, tr *Transport) {
+				ctx := context.Background()
+				protoReq := &pb.GetConsistencyWatermarkRequest{ReporterType: proto.String(" ")}
+				res := tr.Invoke(ctx, withBody(protoReq, GetConsistencyWatermark, httpEndpoint("GET /api/kessel/v1beta2/consistencywatermark?reporter_type=%20")))
+				Assert(t, res, requireError(codes.InvalidArgument))
+			}
+	})
+}
+
+func TestInventoryService_GetConsistencyWatermark_MetaAuthzDenied(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-123"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	runServerTest(t, func(t *testing.T) (TestServerConfig, func(t *testing.T, tr *Transport)) {
+		uc := newTestUsecase(t, testUsecaseConfig{MetaAuthorizer: &DenyingMetaAuthorizer{}})
+		uc.ConsistencyWatermarks = data.NewFakeConsistencyWatermarkRepository()
+		return TestServerConfig{
+				Usecase:       uc,
+				Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+			}, func(t *testing.T, tr *Transport) {
+				ctx := context.Background()
+				res := tr.Invoke(ctx, withBody(&pb.GetConsistencyWatermarkRequest{}, GetConsistencyWatermark, httpEndpoint("GET /api/kessel/v1beta2/consistencywatermark")))
+				Assert(t, res, requireError(codes.PermissionDenied))
+			}
+	})
+}
+
 func TestInventoryService_CheckForUpdate_Allowed(t *testing.T) {
 	claims := &authnapi.Claims{
 		SubjectId: authnapi.SubjectId("user-123"),
@@ -1280,6 +1466,163 @@ func TestInventoryService_StreamedListObjects_StreamResults(t *testing.T) {
 	}
 }
 
+func TestInventoryService_StreamedListObjects_InventoryEnrichment(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-abc"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	simpleAuthz := data.NewSimpleRelationsRepository()
+	for _, id := range []string{"host-1", "host-2", "host-3"} {
+		simpleAuthz.Grant("subject-xyz", "view", "hbi", "host", id)
+	}
+
+	uc := newTestUsecase(t, testUsecaseConfig{Relations: simpleAuthz})
+	client := newTestServer(t, TestServerConfig{
+		Usecase:       uc,
+		Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+	})
+
+	_, err := client.ReportResource(context.Background(), makeReportReq("host", "hbi", "instance-001", "host-1", "web"))
+	require.NoError(t, err)
+	_, err = client.ReportResource(context.Background(), makeReportReq("host", "hbi", "instance-001", "host-2", "db"))
+	require.NoError(t, err)
+
+	reporterType := "hbi"
+	req := &pb.StreamedListObjectsRequest{
+		ObjectType: &pb.RepresentationType{
+			ReporterType: &reporterType,
+			ResourceType: "host",
+		},
+		Relation: "view",
+		Subject: &pb.SubjectReference{
+			Resource: &pb.ResourceReference{
+				ResourceId:   "subject-xyz",
+				ResourceType: "principal",
+				Reporter:     &pb.ReporterReference{Type: "rbac"},
+			},
+		},
+		Inventory: &pb.InventoryEnrichment{
+			CommonFields: []string{"hostname"},
+			Filters: []*pb.RepresentationFilter{
+				{Path: "hostname", Equals: structpb.NewStringValue("web")},
+			},
+		},
+	}
+
+	stream, err := client.StreamedListObjects(context.Background(), req)
+	require.NoError(t, err)
+
+	var responses []*pb.StreamedListObjectsResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		responses = append(responses, resp)
+	}
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, "host-1", responses[0].GetObject().GetResourceId())
+	require.NotNil(t, responses[0].GetInventory())
+	assert.Equal(t, "https://api.example.com/hosts/host-1", responses[0].GetInventory().GetApiHref())
+	assert.Equal(t, "web", responses[0].GetInventory().GetCommon().GetFields()["hostname"].GetStringValue())
+}
+
+func TestInventoryService_StreamedCheckBulk_StreamResults(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-abc"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	simpleAuthz := data.NewSimpleRelationsRepository()
+	simpleAuthz.Grant("subject-xyz", "view", "hbi", "host", "host-1")
+	simpleAuthz.Grant("subject-xyz", "view", "hbi", "host", "host-3")
+
+	uc := newTestUsecase(t, testUsecaseConfig{Relations: simpleAuthz})
+	client := newTestServer(t, TestServerConfig{
+		Usecase:       uc,
+		Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+	})
+
+	item := func(id string) *pb.CheckBulkRequestItem {
+		return &pb.CheckBulkRequestItem{
+			Object: &pb.ResourceReference{
+				ResourceId:   id,
+				ResourceType: "host",
+				Reporter:     &pb.ReporterReference{Type: "hbi"},
+			},
+			Relation: "view",
+			Subject: &pb.SubjectReference{
+				Resource: &pb.ResourceReference{
+					ResourceId:   "subject-xyz",
+					ResourceType: "principal",
+					Reporter:     &pb.ReporterReference{Type: "rbac"},
+				},
+			},
+		}
+	}
+
+	stream, err := client.StreamedCheckBulk(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.StreamedCheckBulkRequest{
+		Items:       []*pb.CheckBulkRequestItem{item("host-1"), item("host-2")},
+		Consistency: &pb.Consistency{Requirement: &pb.Consistency_MinimizeLatency{MinimizeLatency: true}},
+	}))
+	require.NoError(t, stream.Send(&pb.StreamedCheckBulkRequest{
+		Items: []*pb.CheckBulkRequestItem{item("host-3")},
+	}))
+	require.NoError(t, stream.CloseSend())
+
+	var ids []string
+	var allowed []pb.Allowed
+	var token *pb.ConsistencyToken
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		for _, pair := range resp.Pairs {
+			ids = append(ids, pair.GetRequest().GetObject().GetResourceId())
+			allowed = append(allowed, pair.GetItem().GetAllowed())
+		}
+		if resp.ConsistencyToken != nil {
+			assert.Empty(t, resp.Pairs, "token should only be set on the final message")
+			token = resp.ConsistencyToken
+		}
+	}
+
+	assert.Equal(t, []string{"host-1", "host-2", "host-3"}, ids)
+	assert.Equal(t, []pb.Allowed{pb.Allowed_ALLOWED_TRUE, pb.Allowed_ALLOWED_FALSE, pb.Allowed_ALLOWED_TRUE}, allowed)
+	require.NotNil(t, token)
+	assert.NotEmpty(t, token.Token)
+}
+
+func TestInventoryService_StreamedCheckBulk_ValidationRejectsEmptyItems(t *testing.T) {
+	claims := &authnapi.Claims{
+		SubjectId: authnapi.SubjectId("user-abc"),
+		AuthType:  authnapi.AuthTypeXRhIdentity,
+	}
+
+	uc := newTestUsecase(t, testUsecaseConfig{})
+	client := newTestServer(t, TestServerConfig{
+		Usecase:       uc,
+		Authenticator: &StubAuthenticator{Claims: claims, Decision: authnapi.Allow},
+	})
+
+	stream, err := client.StreamedCheckBulk(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&pb.StreamedCheckBulkRequest{}))
+
+	_, err = stream.Recv()
+	assert.Error(t, err)
+	grpcStatus, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, grpcStatus.Code())
+}
+
 // --- Update Path Tests ---
 
 func TestInventoryService_ReportResource_Update(t *testing.T) {
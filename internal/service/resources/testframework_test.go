@@ -206,6 +206,9 @@ var (
 	Check GRPCCall = func(ctx context.Context, c pb.KesselInventoryServiceClient, req proto.Message) (proto.Message, error) {
 		return c.Check(ctx, req.(*pb.CheckRequest))
 	}
+	CheckExplain GRPCCall = func(ctx context.Context, c pb.KesselInventoryServiceClient, req proto.Message) (proto.Message, error) {
+		return c.CheckExplain(ctx, req.(*pb.CheckExplainRequest))
+	}
 	CheckSelf GRPCCall = func(ctx context.Context, c pb.KesselInventoryServiceClient, req proto.Message) (proto.Message, error) {
 		return c.CheckSelf(ctx, req.(*pb.CheckSelfRequest))
 	}
@@ -227,6 +230,9 @@ var (
 	DeleteResource GRPCCall = func(ctx context.Context, c pb.KesselInventoryServiceClient, req proto.Message) (proto.Message, error) {
 		return c.DeleteResource(ctx, req.(*pb.DeleteResourceRequest))
 	}
+	GetConsistencyWatermark GRPCCall = func(ctx context.Context, c pb.KesselInventoryServiceClient, req proto.Message) (proto.Message, error) {
+		return c.GetConsistencyWatermark(ctx, req.(*pb.GetConsistencyWatermarkRequest))
+	}
 )
 
 // HTTPEndpoint is a parsed "METHOD /path" pair for the HTTP side of a [Request].